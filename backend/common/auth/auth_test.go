@@ -1,12 +1,24 @@
 package auth
 
 import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
 )
 
 type AuthTestSuite struct {
@@ -79,3 +91,206 @@ func (suite *AuthTestSuite) TestInvalidToken() {
 func TestAuthTestSuite(t *testing.T) {
 	suite.Run(t, new(AuthTestSuite))
 }
+
+func (suite *AuthTestSuite) TestTokenIDs() {
+	token, err := suite.tokenManager.GenerateSessionToken("user-123", "", "BIDDER", "session-1")
+	assert.NoError(suite.T(), err)
+	other, err := suite.tokenManager.GenerateSessionToken("user-123", "", "BIDDER", "session-1")
+	assert.NoError(suite.T(), err)
+
+	claims, err := suite.tokenManager.VerifyToken(token)
+	assert.NoError(suite.T(), err)
+	otherClaims, _ := suite.tokenManager.VerifyToken(other)
+	assert.NotEmpty(suite.T(), claims.ID)
+	assert.NotEqual(suite.T(), claims.ID, otherClaims.ID)
+	assert.Equal(suite.T(), "session-1", claims.SessionID)
+	assert.WithinDuration(suite.T(), time.Now().Add(AccessTokenTTL), claims.ExpiresAt.Time, time.Minute)
+}
+
+func (suite *AuthTestSuite) TestRevokedToken() {
+	denylist := NewDenylist()
+	suite.tokenManager.UseDenylist(denylist)
+
+	bySession, _ := suite.tokenManager.GenerateSessionToken("user-123", "", "BIDDER", "session-1")
+	byID, _ := suite.tokenManager.GenerateSessionToken("user-123", "", "BIDDER", "session-2")
+	kept, _ := suite.tokenManager.GenerateSessionToken("user-123", "", "BIDDER", "session-3")
+	claims, _ := suite.tokenManager.VerifyToken(byID)
+
+	event, _ := json.Marshal(TokensRevokedEvent{
+		UserID:     "user-123",
+		SessionIDs: []string{"session-1"},
+		TokenIDs:   []string{claims.ID},
+		ExpiresAt:  time.Now().Add(AccessTokenTTL),
+	})
+	h := &revocationHandler{denylist: denylist, log: logger.New(logger.Config{Level: "error", ServiceName: "test"})}
+	assert.NoError(suite.T(), h.HandleEvent(context.Background(), TopicTokensRevoked, nil, event))
+	// A bad payload is logged and skipped, not retried
+	assert.NoError(suite.T(), h.HandleEvent(context.Background(), TopicTokensRevoked, nil, []byte("not json")))
+
+	for _, token := range []string{bySession, byID} {
+		_, err := suite.tokenManager.VerifyToken(token)
+		assert.Equal(suite.T(), ErrRevokedToken, err)
+	}
+	_, err := suite.tokenManager.VerifyToken(kept)
+	assert.NoError(suite.T(), err)
+}
+
+func TestDenylist_Expiry(t *testing.T) {
+	denylist := NewDenylist()
+	denylist.Add("gone", time.Now().Add(-time.Second))
+	denylist.Add("live", time.Now().Add(time.Minute))
+
+	assert.False(t, denylist.Revoked("gone"))
+	assert.True(t, denylist.Revoked("", "live"))
+	assert.False(t, denylist.Revoked(""))
+}
+
+func newRSAKey(t *testing.T) *SigningKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signing, err := NewSigningKey(key)
+	require.NoError(t, err)
+	return signing
+}
+
+func newEd25519Key(t *testing.T) *SigningKey {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signing, err := NewSigningKey(key)
+	require.NoError(t, err)
+	return signing
+}
+
+func TestSigningTokenManager(t *testing.T) {
+	for name, key := range map[string]*SigningKey{"RS256": newRSAKey(t), "EdDSA": newEd25519Key(t)} {
+		t.Run(name, func(t *testing.T) {
+			tm, err := NewSigningTokenManager([]*SigningKey{key})
+			require.NoError(t, err)
+
+			token, err := tm.GenerateToken("user-123", "", "BIDDER")
+			require.NoError(t, err)
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &UserClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, name, parsed.Method.Alg())
+			assert.Equal(t, key.ID, parsed.Header["kid"])
+
+			claims, err := tm.VerifyToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, "user-123", claims.UserID)
+
+			// A token minted with the old shared secret is no good
+			forged, _ := NewTokenManager("secret").GenerateToken("user-123", "", "ADMIN")
+			_, err = tm.VerifyToken(forged)
+			assert.Equal(t, ErrInvalidToken, err)
+		})
+	}
+}
+
+func TestVerifyingTokenManager(t *testing.T) {
+	current, next := newRSAKey(t), newEd25519Key(t)
+	signer, err := NewSigningTokenManager([]*SigningKey{current, next})
+	require.NoError(t, err)
+
+	verifier := NewVerifyingTokenManager(staticKeys{current.ID: current.Public()})
+	_, err = verifier.GenerateToken("user-123", "", "BIDDER")
+	assert.Equal(t, ErrCannotSign, err)
+
+	token, _ := signer.GenerateToken("user-123", "", "BIDDER")
+	_, err = verifier.VerifyToken(token)
+	assert.NoError(t, err)
+
+	// Signed by a key the verifier doesn't know
+	rotated, err := NewSigningTokenManager([]*SigningKey{next, current})
+	require.NoError(t, err)
+	token, _ = rotated.GenerateToken("user-123", "", "BIDDER")
+	_, err = verifier.VerifyToken(token)
+	assert.Equal(t, ErrInvalidToken, err)
+
+	// A key claimed for another algorithm
+	hijack := jwt.NewWithClaims(jwt.SigningMethodHS256, UserClaims{UserID: "user-123"})
+	hijack.Header["kid"] = current.ID
+	forged, _ := hijack.SignedString([]byte("secret"))
+	_, err = verifier.VerifyToken(forged)
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestJWKS(t *testing.T) {
+	current, next := newRSAKey(t), newEd25519Key(t)
+	tm, err := NewSigningTokenManager([]*SigningKey{current, next})
+	require.NoError(t, err)
+
+	set := tm.JWKS()
+	require.Len(t, set.Keys, 2)
+	assert.Equal(t, current.ID, set.Keys[0].Kid)
+	for i, key := range []*SigningKey{current, next} {
+		public, err := set.Keys[i].PublicKey()
+		require.NoError(t, err)
+		assert.Equal(t, key.Public(), public)
+	}
+
+	assert.Empty(t, NewTokenManager("secret").JWKS().Keys)
+}
+
+func TestParseSigningKey(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(edKey)
+
+	for name, block := range map[string]*pem.Block{
+		"PKCS1": {Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)},
+		"PKCS8": {Type: "PRIVATE KEY", Bytes: pkcs8},
+	} {
+		t.Run(name, func(t *testing.T) {
+			key, err := ParseSigningKey(pem.EncodeToMemory(block))
+			require.NoError(t, err)
+			again, _ := ParseSigningKey(pem.EncodeToMemory(block))
+			assert.Equal(t, key.ID, again.ID, "the kid is derived from the key")
+		})
+	}
+
+	_, err := ParseSigningKey([]byte("not a key"))
+	assert.Error(t, err)
+}
+
+func TestJWKSClient(t *testing.T) {
+	current, next := newRSAKey(t), newEd25519Key(t)
+	signer, _ := NewSigningTokenManager([]*SigningKey{current})
+
+	var published atomic.Pointer[JWKSet]
+	publish := func(tm *TokenManager) {
+		set := tm.JWKS()
+		published.Store(&set)
+	}
+	publish(signer)
+
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		json.NewEncoder(w).Encode(published.Load())
+	}))
+	defer server.Close()
+
+	client := NewJWKSClient(server.URL, time.Hour, logger.New(logger.Config{Level: "error", ServiceName: "test"}))
+	require.NoError(t, client.Refresh(context.Background()))
+	verifier := NewVerifyingTokenManager(client)
+
+	token, _ := signer.GenerateToken("user-123", "", "BIDDER")
+	_, err := verifier.VerifyToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), fetches.Load())
+
+	// The auth service starts signing with a new key. Keys were fetched moments ago,
+	// so the verifier doesn't know it yet.
+	signer, _ = NewSigningTokenManager([]*SigningKey{next, current})
+	publish(signer)
+	token, _ = signer.GenerateToken("user-123", "", "BIDDER")
+	_, err = verifier.VerifyToken(token)
+	assert.Equal(t, ErrInvalidToken, err)
+	assert.Equal(t, int32(1), fetches.Load())
+
+	// Later, an unknown kid fetches the keys again
+	client.lastFetch = time.Now().Add(-jwksMinRefetch)
+	_, err = verifier.VerifyToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), fetches.Load())
+}
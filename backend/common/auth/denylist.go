@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/common/kafka"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"go.uber.org/zap"
+)
+
+// TopicTokensRevoked carries TokensRevokedEvent from the auth service to every service
+// that verifies tokens.
+const TopicTokensRevoked = "user.tokens_revoked"
+
+// TokensRevokedEvent revokes access tokens by jti, and every token of the sessions.
+type TokensRevokedEvent struct {
+	UserID     string   `json:"user_id"`
+	SessionIDs []string `json:"session_ids,omitempty"`
+	TokenIDs   []string `json:"token_ids,omitempty"`
+	// ExpiresAt is when the last of the tokens expires anyway; the revocation can be
+	// forgotten after that.
+	ExpiresAt time.Time `json:"expires_at"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Denylist reports revoked tokens.
+type Denylist interface {
+	// Revoked reports whether any of ids, a token's jti or session ID, has been
+	// revoked. Empty ids are ignored.
+	Revoked(ids ...string) bool
+}
+
+// MemoryDenylist is a Denylist kept in memory, usually fed by WatchRevocations.
+type MemoryDenylist struct {
+	mu    sync.RWMutex
+	until map[string]time.Time
+}
+
+func NewDenylist() *MemoryDenylist {
+	return &MemoryDenylist{until: make(map[string]time.Time)}
+}
+
+// Add revokes id until the given time, and forgets the revocations that have run out.
+func (d *MemoryDenylist) Add(id string, until time.Time) {
+	now := time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for other, t := range d.until {
+		if !t.After(now) {
+			delete(d.until, other)
+		}
+	}
+	if id != "" && until.After(now) && until.After(d.until[id]) {
+		d.until[id] = until
+	}
+}
+
+func (d *MemoryDenylist) Revoked(ids ...string) bool {
+	now := time.Now()
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		if until, ok := d.until[id]; ok && until.After(now) {
+			return true
+		}
+	}
+	return false
+}
+
+// revocationHandler feeds a MemoryDenylist from TopicTokensRevoked.
+type revocationHandler struct {
+	denylist *MemoryDenylist
+	log      logger.Logger
+}
+
+// HandleEvent adds the tokens and sessions of a TokensRevokedEvent.
+func (h *revocationHandler) HandleEvent(ctx context.Context, topic string, key, value []byte) error {
+	var event TokensRevokedEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		h.log.Error("Failed to unmarshal TokensRevokedEvent", zap.Error(err))
+		return nil // Don't retry on unmarshal error
+	}
+	for _, id := range event.SessionIDs {
+		h.denylist.Add(id, event.ExpiresAt)
+	}
+	for _, id := range event.TokenIDs {
+		h.denylist.Add(id, event.ExpiresAt)
+	}
+	return nil
+}
+
+// WatchRevocations feeds d from TopicTokensRevoked until ctx is done. Every process
+// must see every revocation, so the topic is read directly rather than through a
+// consumer group, starting AccessTokenTTL back: a revocation older than that only
+// names tokens that have expired anyway. The caller closes the returned consumer on
+// shutdown.
+func WatchRevocations(ctx context.Context, brokers []string, d *MemoryDenylist, log logger.Logger) *kafka.BroadcastConsumer {
+	consumer := kafka.NewBroadcastConsumer(brokers, TopicTokensRevoked, time.Now().Add(-AccessTokenTTL), log)
+	h := &revocationHandler{denylist: d, log: log}
+	consumer.Start(ctx, h.HandleEvent)
+	return consumer
+}
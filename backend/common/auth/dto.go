@@ -13,9 +13,21 @@ type RegisterRequest struct {
 	Role     string `json:"role"` // "SELLER" or "BIDDER"
 }
 
+// Tokens are what a login hands out: a short-lived access token, and an opaque
+// refresh token that can be exchanged once for new tokens.
+type Tokens struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"` // Seconds until Token expires
+}
+
 type AuthResponse struct {
-	Token string  `json:"token"`
-	User  UserDTO `json:"user"`
+	Tokens
+	User UserDTO `json:"user"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
 type UserDTO struct {
@@ -5,6 +5,8 @@ import "errors"
 var (
 	ErrInvalidToken = errors.New("invalid token")
 	ErrExpiredToken = errors.New("token has expired")
+	ErrRevokedToken = errors.New("token has been revoked")
+	ErrCannotSign   = errors.New("token manager can only verify tokens")
 	ErrNoToken      = errors.New("authorization token is missing")
 	ErrUnauthorized = errors.New("user is not authorized for this action")
 	ErrForbidden    = errors.New("user does not have the required permissions")
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	// How long a fetch of the JWKS may take.
+	jwksTimeout = 5 * time.Second
+
+	// A token signed with an unknown key fetches the JWKS again, at most this often,
+	// so that a key the auth service just started using is picked up at once.
+	jwksMinRefetch = 30 * time.Second
+)
+
+// JWKSClient is a KeySource that fetches the auth service's JWKS and caches it.
+type JWKSClient struct {
+	url      string
+	interval time.Duration
+	client   *http.Client
+	log      logger.Logger
+
+	mu   sync.RWMutex
+	keys staticKeys
+
+	// One fetch at a time; lastFetch is when the last one started.
+	fetchMu   sync.Mutex
+	lastFetch time.Time
+}
+
+func NewJWKSClient(url string, interval time.Duration, log logger.Logger) *JWKSClient {
+	return &JWKSClient{
+		url:      url,
+		interval: interval,
+		client:   &http.Client{Timeout: jwksTimeout},
+		log:      log,
+		keys:     staticKeys{},
+	}
+}
+
+// Start fetches the keys, then fetches them again every interval until ctx is done.
+// Failed fetches are logged; the keys fetched last stay in use meanwhile.
+func (c *JWKSClient) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := c.Refresh(ctx); err != nil {
+				c.log.Error("Failed to fetch JWKS", zap.Error(err), zap.String("url", c.url))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Refresh fetches the keys and replaces the cached ones with them, so that a key the
+// auth service no longer publishes stops verifying tokens.
+func (c *JWKSClient) Refresh(ctx context.Context) error {
+	c.fetchMu.Lock()
+	defer c.fetchMu.Unlock()
+	return c.fetch(ctx)
+}
+
+// fetch does the work of Refresh. The caller holds fetchMu.
+func (c *JWKSClient) fetch(ctx context.Context) error {
+	c.lastFetch = time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var set JWKSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return err
+	}
+	keys := make(staticKeys, len(set.Keys))
+	for _, jwk := range set.Keys {
+		key, err := jwk.PublicKey()
+		if err != nil {
+			// Skip what this version can't use, rather than lose every key
+			c.log.Warn("Skipping JWK", zap.Error(err))
+			continue
+		}
+		keys[key.ID] = key
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.mu.Unlock()
+	return nil
+}
+
+// PublicKey returns the cached key with the ID. A miss fetches the keys again,
+// unless they were fetched moments ago.
+func (c *JWKSClient) PublicKey(kid string) (*PublicKey, error) {
+	if key, err := c.cached(kid); err == nil {
+		return key, nil
+	}
+
+	c.fetchMu.Lock()
+	if time.Since(c.lastFetch) >= jwksMinRefetch {
+		ctx, cancel := context.WithTimeout(context.Background(), jwksTimeout)
+		if err := c.fetch(ctx); err != nil {
+			c.log.Error("Failed to fetch JWKS", zap.Error(err), zap.String("url", c.url))
+		}
+		cancel()
+	}
+	c.fetchMu.Unlock()
+	return c.cached(kid)
+}
+
+func (c *JWKSClient) cached(kid string) (*PublicKey, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.keys.PublicKey(kid)
+}
+
+// NewVerifier returns the TokenManager of a service that only verifies tokens: one
+// using the keys published at jwksURL, fetched again every interval until ctx is
+// done, or the secret shared with the auth service if jwksURL is empty.
+func NewVerifier(ctx context.Context, jwksURL string, interval time.Duration, secret string, log logger.Logger) *TokenManager {
+	if jwksURL == "" {
+		return NewTokenManager(secret)
+	}
+	keys := NewJWKSClient(jwksURL, interval, log)
+	keys.Start(ctx)
+	return NewVerifyingTokenManager(keys)
+}
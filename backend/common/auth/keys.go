@@ -0,0 +1,217 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrUnknownKey is returned for a token signed with a key the verifier doesn't know.
+var ErrUnknownKey = errors.New("token signed with an unknown key")
+
+// SigningKey is a private key the auth service signs tokens with: an RSA key, for
+// RS256, or an Ed25519 key, for EdDSA.
+type SigningKey struct {
+	ID  string // The kid header of the tokens it signs
+	Key crypto.Signer
+}
+
+// PublicKey verifies the tokens signed with the SigningKey of the same ID.
+type PublicKey struct {
+	ID        string
+	Algorithm string // "RS256" or "EdDSA"
+	Key       crypto.PublicKey
+}
+
+// KeySource looks up the keys that verify tokens.
+type KeySource interface {
+	// PublicKey returns the key with the ID, or ErrUnknownKey.
+	PublicKey(kid string) (*PublicKey, error)
+}
+
+// NewSigningKey wraps an RSA or Ed25519 private key. Its ID is the key's RFC 7638
+// thumbprint, so it stays the same wherever the key is loaded.
+func NewSigningKey(key crypto.Signer) (*SigningKey, error) {
+	jwk, err := (&PublicKey{Key: key.Public()}).JWK()
+	if err != nil {
+		return nil, err
+	}
+	return &SigningKey{ID: jwk.thumbprint(), Key: key}, nil
+}
+
+// LoadSigningKeys reads a PEM encoded private key from each of the files: PKCS #8,
+// or PKCS #1 for RSA.
+func LoadSigningKeys(paths []string) ([]*SigningKey, error) {
+	keys := make([]*SigningKey, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParseSigningKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ParseSigningKey parses a PEM encoded private key.
+func ParseSigningKey(data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		return NewSigningKey(key)
+	case ed25519.PrivateKey:
+		return NewSigningKey(key)
+	}
+	return nil, fmt.Errorf("unsupported key type %T", key)
+}
+
+// Public returns the key that verifies what k signs.
+func (k *SigningKey) Public() *PublicKey {
+	return &PublicKey{ID: k.ID, Algorithm: k.method().Alg(), Key: k.Key.Public()}
+}
+
+func (k *SigningKey) method() jwt.SigningMethod {
+	if _, ok := k.Key.(ed25519.PrivateKey); ok {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// JWK is a public key in the JSON Web Key format.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSet is the document the auth service serves at /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+func (k *PublicKey) JWK() (JWK, error) {
+	jwk := JWK{Kid: k.ID, Use: "sig", Alg: k.Algorithm}
+	switch key := k.Key.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(key)
+	default:
+		return JWK{}, fmt.Errorf("unsupported key type %T", key)
+	}
+	return jwk, nil
+}
+
+// PublicKey parses the key. Keys that aren't for signatures are rejected.
+func (jwk JWK) PublicKey() (*PublicKey, error) {
+	if jwk.Use != "" && jwk.Use != "sig" {
+		return nil, fmt.Errorf("key %q is not for signatures", jwk.Kid)
+	}
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("key %q: exponent too large", jwk.Kid)
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+		return &PublicKey{ID: jwk.Kid, Algorithm: jwt.SigningMethodRS256.Alg(), Key: key}, nil
+
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("key %q: unsupported curve %q", jwk.Kid, jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("key %q: bad Ed25519 key size", jwk.Kid)
+		}
+		return &PublicKey{ID: jwk.Kid, Algorithm: jwt.SigningMethodEdDSA.Alg(), Key: ed25519.PublicKey(x)}, nil
+	}
+	return nil, fmt.Errorf("key %q: unsupported key type %q", jwk.Kid, jwk.Kty)
+}
+
+// thumbprint is the RFC 7638 thumbprint of the key: the SHA-256 of its required
+// members, in lexical order.
+func (jwk JWK) thumbprint() string {
+	var members interface{}
+	if jwk.Kty == "RSA" {
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	} else {
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	}
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// staticKeys is a KeySource of keys known up front.
+type staticKeys map[string]*PublicKey
+
+func (s staticKeys) PublicKey(kid string) (*PublicKey, error) {
+	if key, ok := s[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
@@ -1,45 +1,130 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"sort"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// AccessTokenTTL is how long an access token is valid. Clients keep a session going
+// with a refresh token.
+const AccessTokenTTL = 15 * time.Minute
+
 type UserClaims struct {
 	UserID    string `json:"user_id"`
 	CompanyID string `json:"company_id"`
 	Role      string `json:"role"`
+	// SessionID names the login the token was issued for; revoking it revokes every
+	// token of the session.
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// TokenManager issues and verifies tokens. One made by NewTokenManager uses a secret
+// shared by every service (HS256). In production the auth service signs with private
+// keys, and the other services only verify, with the public keys it publishes.
 type TokenManager struct {
 	secretKey []byte
+
+	signing *SigningKey // Nil for a verifier
+	keys    KeySource   // Nil with a shared secret
+
+	denylist Denylist
 }
 
 func NewTokenManager(secret string) *TokenManager {
 	return &TokenManager{secretKey: []byte(secret)}
 }
 
+// NewSigningTokenManager signs tokens with the first of keys, and verifies tokens
+// signed with any of them. To rotate keys, first add the new key behind the current
+// one, so that verifiers learn of it; once they have, move it first; and after
+// AccessTokenTTL, drop the old key.
+func NewSigningTokenManager(keys []*SigningKey) (*TokenManager, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys")
+	}
+	public := make(staticKeys, len(keys))
+	for _, k := range keys {
+		public[k.ID] = k.Public()
+	}
+	return &TokenManager{signing: keys[0], keys: public}, nil
+}
+
+// NewVerifyingTokenManager verifies tokens signed with the keys ks holds. It can't
+// generate tokens.
+func NewVerifyingTokenManager(ks KeySource) *TokenManager {
+	return &TokenManager{keys: ks}
+}
+
+// JWKS returns the public keys of a signing TokenManager, for verifiers to fetch.
+func (tm *TokenManager) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	keys, ok := tm.keys.(staticKeys)
+	if !ok {
+		return set
+	}
+	// The signing key first, then the others in a stable order
+	ids := make([]string, 0, len(keys))
+	for id := range keys {
+		if id != tm.signing.ID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range append([]string{tm.signing.ID}, ids...) {
+		if jwk, err := keys[id].JWK(); err == nil {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+// UseDenylist makes VerifyToken reject the tokens d reports revoked.
+func (tm *TokenManager) UseDenylist(d Denylist) {
+	tm.denylist = d
+}
+
 func (tm *TokenManager) GenerateToken(userID, companyID, role string) (string, error) {
+	return tm.GenerateSessionToken(userID, companyID, role, "")
+}
+
+// GenerateSessionToken is GenerateToken for a token belonging to a session.
+func (tm *TokenManager) GenerateSessionToken(userID, companyID, role, sessionID string) (string, error) {
+	jti, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
 	claims := UserClaims{
 		UserID:    userID,
 		CompanyID: companyID,
 		Role:      role,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ID:        jti,
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 	}
+	if tm.signing != nil {
+		token := jwt.NewWithClaims(tm.signing.method(), claims)
+		token.Header["kid"] = tm.signing.ID
+		return token.SignedString(tm.signing.Key)
+	}
+	if tm.keys != nil {
+		return "", ErrCannotSign
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(tm.secretKey)
 }
 
 func (tm *TokenManager) VerifyToken(tokenString string) (*UserClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &UserClaims{}, func(t *jwt.Token) (interface{}, error) {
-		return tm.secretKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &UserClaims{}, tm.key)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
 			return nil, ErrExpiredToken
@@ -53,5 +138,37 @@ func (tm *TokenManager) VerifyToken(tokenString string) (*UserClaims, error) {
 	if !ok {
 		return nil, ErrInvalidToken
 	}
+	if tm.denylist != nil && tm.denylist.Revoked(claims.ID, claims.SessionID) {
+		return nil, ErrRevokedToken
+	}
 	return claims, nil
 }
+
+// key returns the key that verifies t. The algorithm must be the key's, so that a
+// public key is never taken for an HMAC secret.
+func (tm *TokenManager) key(t *jwt.Token) (interface{}, error) {
+	if tm.keys == nil {
+		if t.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, ErrInvalidToken
+		}
+		return tm.secretKey, nil
+	}
+
+	kid, _ := t.Header["kid"].(string)
+	key, err := tm.keys.PublicKey(kid)
+	if err != nil {
+		return nil, err
+	}
+	if t.Method.Alg() != key.Algorithm {
+		return nil, ErrInvalidToken
+	}
+	return key.Key, nil
+}
+
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"strings"
+	"time"
 )
 
 // Config holds all configuration for a microservice
@@ -31,7 +32,36 @@ type Config struct {
 	KafkaBrokers []string
 
 	// Auth configurations
-	JWTSecret string
+	JWTSecret           string        // Shared HS256 secret, used when the keys below aren't set
+	JWTSigningKeys      []string      // Auth service: PEM files of the private keys to sign tokens with, the first signing
+	JWKSURL             string        // Other services: where the auth service publishes its public keys
+	JWKSRefreshInterval time.Duration // How often to fetch the public keys again
+
+	// Background job configurations
+	SchedulerInterval   time.Duration
+	OutboxRelayInterval time.Duration
+
+	// Auction configurations
+	MaxAuctionExtensions int     // Cap on anti-sniping extensions per auction
+	BuyNowCutoff         float64 // Fraction of the buy-now price at which bidding disables buy-now
+
+	// Bidding configurations
+	BidRetractionCutoff time.Duration // Bidders cannot retract bids this close to an auction's end
+
+	// Email configurations
+	SMTPHost           string
+	SMTPPort           string
+	SMTPUsername       string // Empty disables SMTP authentication
+	SMTPPassword       string
+	SMTPFrom           string
+	EmailQueueInterval time.Duration
+
+	// Webhook configurations
+	WebhookDeliveryInterval time.Duration
+
+	// Notification hub configurations
+	HubBroker  string // "postgres" or "kafka" fans WebSocket pushes out to every instance; empty keeps them local
+	InstanceID string // Names this instance to the others; empty picks one from the hostname
 }
 
 // LoadConfig merges environment variables into the Config struct
@@ -57,6 +87,29 @@ func LoadConfig(serviceName string) *Config {
 
 		KafkaBrokers: strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
 
-		JWTSecret: getEnv("JWT_SECRET", "bidflow_default_secret_key_change_me"),
+		JWTSecret:           getEnv("JWT_SECRET", "bidflow_default_secret_key_change_me"),
+		JWTSigningKeys:      getEnvList("JWT_SIGNING_KEYS"),
+		JWKSURL:             getEnv("JWKS_URL", ""),
+		JWKSRefreshInterval: getEnvInterval("JWKS_REFRESH_INTERVAL", 5*time.Minute),
+
+		SchedulerInterval:   getEnvInterval("SCHEDULER_INTERVAL", 5*time.Second),
+		OutboxRelayInterval: getEnvInterval("OUTBOX_RELAY_INTERVAL", time.Second),
+
+		MaxAuctionExtensions: getEnvInt("AUCTION_MAX_EXTENSIONS", 10),
+		BuyNowCutoff:         getEnvFloat("BUY_NOW_CUTOFF", 0.5),
+
+		BidRetractionCutoff: getEnvDuration("BID_RETRACTION_CUTOFF", time.Hour),
+
+		SMTPHost:           getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:           getEnv("SMTP_PORT", "1025"),
+		SMTPUsername:       getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:       getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:           getEnv("SMTP_FROM", "BidFlow <no-reply@bidflow.local>"),
+		EmailQueueInterval: getEnvInterval("EMAIL_QUEUE_INTERVAL", 5*time.Second),
+
+		WebhookDeliveryInterval: getEnvInterval("WEBHOOK_DELIVERY_INTERVAL", 2*time.Second),
+
+		HubBroker:  getEnv("HUB_BROKER", ""),
+		InstanceID: getEnv("INSTANCE_ID", ""),
 	}
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -11,7 +12,7 @@ func TestLoadConfig(t *testing.T) {
 	// 1. Set temporary environment variables
 	os.Setenv("DB_HOST", "test-db-host")
 	os.Setenv("KAFKA_BROKERS", "kafka1:9092,kafka2:9092")
-	
+
 	defer os.Unsetenv("DB_HOST")
 	defer os.Unsetenv("KAFKA_BROKERS")
 
@@ -28,4 +29,69 @@ func TestGetEnvFallback(t *testing.T) {
 	// Should return default because KEY_NOT_EXIST is not set
 	val := getEnv("KEY_NOT_EXIST", "fallback_val")
 	assert.Equal(t, "fallback_val", val)
-}
\ No newline at end of file
+}
+
+func TestGetEnvDuration(t *testing.T) {
+	os.Setenv("TEST_INTERVAL", "250ms")
+	os.Setenv("TEST_BAD_INTERVAL", "soon")
+
+	defer os.Unsetenv("TEST_INTERVAL")
+	defer os.Unsetenv("TEST_BAD_INTERVAL")
+
+	assert.Equal(t, 250*time.Millisecond, getEnvDuration("TEST_INTERVAL", time.Second))
+	// Unparseable values fall back to the default
+	assert.Equal(t, time.Second, getEnvDuration("TEST_BAD_INTERVAL", time.Second))
+	assert.Equal(t, time.Second, getEnvDuration("KEY_NOT_EXIST", time.Second))
+}
+
+func TestGetEnvInterval(t *testing.T) {
+	os.Setenv("TEST_INTERVAL", "250ms")
+	os.Setenv("TEST_ZERO_INTERVAL", "0s")
+	os.Setenv("TEST_NEGATIVE_INTERVAL", "-1s")
+
+	defer os.Unsetenv("TEST_INTERVAL")
+	defer os.Unsetenv("TEST_ZERO_INTERVAL")
+	defer os.Unsetenv("TEST_NEGATIVE_INTERVAL")
+
+	assert.Equal(t, 250*time.Millisecond, getEnvInterval("TEST_INTERVAL", time.Second))
+	// A ticker can't run with these, so they fall back to the default
+	assert.Equal(t, time.Second, getEnvInterval("TEST_ZERO_INTERVAL", time.Second))
+	assert.Equal(t, time.Second, getEnvInterval("TEST_NEGATIVE_INTERVAL", time.Second))
+}
+func TestGetEnvInt(t *testing.T) {
+	os.Setenv("TEST_LIMIT", "3")
+	os.Setenv("TEST_BAD_LIMIT", "three")
+
+	defer os.Unsetenv("TEST_LIMIT")
+	defer os.Unsetenv("TEST_BAD_LIMIT")
+
+	assert.Equal(t, 3, getEnvInt("TEST_LIMIT", 10))
+	// Unparseable values fall back to the default
+	assert.Equal(t, 10, getEnvInt("TEST_BAD_LIMIT", 10))
+	assert.Equal(t, 10, getEnvInt("KEY_NOT_EXIST", 10))
+}
+
+func TestGetEnvFloat(t *testing.T) {
+	os.Setenv("TEST_FRACTION", "0.8")
+	os.Setenv("TEST_BAD_FRACTION", "most")
+
+	defer os.Unsetenv("TEST_FRACTION")
+	defer os.Unsetenv("TEST_BAD_FRACTION")
+
+	assert.Equal(t, 0.8, getEnvFloat("TEST_FRACTION", 0.5))
+	// Unparseable values fall back to the default
+	assert.Equal(t, 0.5, getEnvFloat("TEST_BAD_FRACTION", 0.5))
+	assert.Equal(t, 0.5, getEnvFloat("KEY_NOT_EXIST", 0.5))
+}
+
+func TestGetEnvList(t *testing.T) {
+	os.Setenv("TEST_LIST", "a.pem, b.pem,,")
+	os.Setenv("TEST_EMPTY_LIST", "")
+
+	defer os.Unsetenv("TEST_LIST")
+	defer os.Unsetenv("TEST_EMPTY_LIST")
+
+	assert.Equal(t, []string{"a.pem", "b.pem"}, getEnvList("TEST_LIST"))
+	assert.Nil(t, getEnvList("TEST_EMPTY_LIST"))
+	assert.Nil(t, getEnvList("KEY_NOT_EXIST"))
+}
@@ -2,11 +2,14 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
-// InitEnv loads the .env file if it exists. 
+// InitEnv loads the .env file if it exists.
 // In Production (K8s/Azure), the .env file won't exist, and that's okay.
 func InitEnv() {
 	_ = godotenv.Load() // Ignore error if .env is missing (expected in K8s)
@@ -18,4 +21,55 @@ func getEnv(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// getEnvDuration reads a duration (e.g. "5s", "1m") from the environment or returns a default value
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+// getEnvInterval is getEnvDuration for the period of a ticker, which must be positive:
+// zero or negative values return the default value too
+func getEnvInterval(key string, defaultValue time.Duration) time.Duration {
+	if d := getEnvDuration(key, defaultValue); d > 0 {
+		return d
+	}
+	return defaultValue
+}
+
+// getEnvInt reads an integer from the environment or returns a default value
+func getEnvInt(key string, defaultValue int) int {
+	if value, exists := os.LookupEnv(key); exists {
+		if n, err := strconv.Atoi(value); err == nil {
+			return n
+		}
+	}
+	return defaultValue
+}
+
+// getEnvFloat reads a float from the environment or returns a default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value, exists := os.LookupEnv(key); exists {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return defaultValue
+}
+
+// getEnvList reads a comma-separated list from the environment, or returns nil if
+// it is unset or empty
+func getEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithinTx_Commit(t *testing.T) {
+	db, sm, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sm.ExpectBegin()
+	sm.ExpectExec("INSERT INTO a").WillReturnResult(sqlmock.NewResult(1, 1))
+	sm.ExpectExec("INSERT INTO b").WillReturnResult(sqlmock.NewResult(1, 1))
+	sm.ExpectCommit()
+
+	err = NewTransactor(db).WithinTx(context.Background(), func(ctx context.Context) error {
+		if _, err := Conn(ctx, db).ExecContext(ctx, "INSERT INTO a VALUES (1)"); err != nil {
+			return err
+		}
+		// Nested units of work join the outer transaction.
+		return NewTransactor(db).WithinTx(ctx, func(ctx context.Context) error {
+			_, err := Conn(ctx, db).ExecContext(ctx, "INSERT INTO b VALUES (1)")
+			return err
+		})
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, sm.ExpectationsWereMet())
+}
+
+func TestWithinTx_RollbackOnError(t *testing.T) {
+	db, sm, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sm.ExpectBegin()
+	sm.ExpectExec("INSERT INTO a").WillReturnResult(sqlmock.NewResult(1, 1))
+	sm.ExpectRollback()
+
+	boom := errors.New("boom")
+	err = NewTransactor(db).WithinTx(context.Background(), func(ctx context.Context) error {
+		if _, err := Conn(ctx, db).ExecContext(ctx, "INSERT INTO a VALUES (1)"); err != nil {
+			return err
+		}
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+	assert.NoError(t, sm.ExpectationsWereMet())
+}
+
+func TestConn_WithoutTx(t *testing.T) {
+	db, _, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	assert.Equal(t, DBTX(db), Conn(context.Background(), db))
+}
@@ -0,0 +1,173 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"go.uber.org/zap"
+)
+
+// How long to wait before looking up the partitions of a topic that doesn't exist yet,
+// and the longest wait after failing to fetch from a partition.
+const broadcastRetryInterval = 5 * time.Second
+
+// BroadcastConsumer reads every partition of a topic directly, without a consumer
+// group, so each process sees every message and leaves no group behind when it stops.
+// Nothing is committed: a restarted process reads again from the time it is given.
+type BroadcastConsumer struct {
+	brokers []string
+	topic   string
+	since   time.Time
+	logger  logger.Logger
+
+	mu      sync.Mutex
+	readers []*kafka.Reader
+	closed  bool
+}
+
+// NewBroadcastConsumer returns a consumer of topic that starts at the first message
+// published at or after since.
+func NewBroadcastConsumer(brokers []string, topic string, since time.Time, log logger.Logger) *BroadcastConsumer {
+	return &BroadcastConsumer{
+		brokers: brokers,
+		topic:   topic,
+		since:   since,
+		logger:  log,
+	}
+}
+
+// Start looks up the partitions of the topic, waiting for it to be created if need
+// be, and reads each of them until ctx is done or the consumer is closed. Partitions
+// added later are not read.
+func (c *BroadcastConsumer) Start(ctx context.Context, handler Handler) {
+	go func() {
+		partitions, err := c.partitions(ctx)
+		if err != nil {
+			return
+		}
+		for _, p := range partitions {
+			r := kafka.NewReader(kafka.ReaderConfig{
+				Brokers:   c.brokers,
+				Topic:     c.topic,
+				Partition: p.ID,
+				MaxWait:   100 * time.Millisecond,
+			})
+			if !c.add(r) {
+				r.Close()
+				return
+			}
+			go c.read(ctx, r, handler)
+		}
+	}()
+}
+
+func (c *BroadcastConsumer) read(ctx context.Context, r *kafka.Reader, handler Handler) {
+	if err := r.SetOffsetAt(ctx, c.since); err != nil {
+		// The reader starts at the beginning of the partition instead
+		c.logger.Error("failed to seek partition", zap.String("topic", c.topic), zap.Int("partition", r.Config().Partition), zap.Error(err))
+	}
+
+	failures := 0
+	for {
+		m, err := r.ReadMessage(ctx)
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				c.logger.Info("kafka reader closed", zap.String("topic", c.topic))
+				return
+			}
+			failures++
+			c.logger.Error("failed to fetch message", zap.String("topic", c.topic), zap.Int("failures", failures), zap.Error(err))
+
+			// Don't spin while the broker is unreachable
+			select {
+			case <-ctx.Done():
+				c.logger.Info("kafka reader closed", zap.String("topic", c.topic))
+				return
+			case <-time.After(broadcastBackoff(failures)):
+			}
+			continue
+		}
+		failures = 0
+
+		if err := handler(ctx, m.Topic, m.Key, m.Value); err != nil {
+			c.logger.Error("failed to handle message", zap.Error(err))
+		}
+	}
+}
+
+// broadcastBackoff doubles from 100ms per failed fetch in a row, capped at
+// broadcastRetryInterval.
+func broadcastBackoff(failures int) time.Duration {
+	d := 100 * time.Millisecond
+	for i := 1; i < failures && d < broadcastRetryInterval; i++ {
+		d *= 2
+	}
+	if d > broadcastRetryInterval {
+		d = broadcastRetryInterval
+	}
+	return d
+}
+
+// partitions returns the partitions of the topic, retrying until there are some or
+// ctx is done.
+func (c *BroadcastConsumer) partitions(ctx context.Context) ([]kafka.Partition, error) {
+	for {
+		partitions, err := c.lookupPartitions(ctx)
+		if err == nil && len(partitions) > 0 {
+			return partitions, nil
+		}
+		c.logger.Warn("waiting for kafka topic", zap.String("topic", c.topic), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(broadcastRetryInterval):
+		}
+	}
+}
+
+func (c *BroadcastConsumer) lookupPartitions(ctx context.Context) ([]kafka.Partition, error) {
+	var lastErr error
+	for _, broker := range c.brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		partitions, err := conn.ReadPartitions(c.topic)
+		conn.Close()
+		if err == nil {
+			return partitions, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// add keeps r to be closed by Close. It reports false if the consumer is already closed.
+func (c *BroadcastConsumer) add(r *kafka.Reader) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return false
+	}
+	c.readers = append(c.readers, r)
+	return true
+}
+
+func (c *BroadcastConsumer) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	var errs []error
+	for _, r := range c.readers {
+		errs = append(errs, r.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,14 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcastBackoff(t *testing.T) {
+	assert.Equal(t, 100*time.Millisecond, broadcastBackoff(1))
+	assert.Equal(t, 400*time.Millisecond, broadcastBackoff(3))
+	assert.Equal(t, broadcastRetryInterval, broadcastBackoff(20))
+}
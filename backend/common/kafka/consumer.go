@@ -3,6 +3,7 @@ package kafka
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
@@ -31,6 +32,24 @@ func NewConsumer(brokers []string, topics []string, groupID string, log logger.L
 	}
 }
 
+// NewTailConsumer is NewConsumer for a group that only wants what is published from
+// now on: when the group is new it starts at the end of the topics rather than the
+// beginning.
+func NewTailConsumer(brokers []string, topics []string, groupID string, log logger.Logger) *Consumer {
+	r := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     brokers,
+		GroupTopics: topics,
+		GroupID:     groupID,
+		StartOffset: kafka.LastOffset,
+		MaxWait:     100 * time.Millisecond,
+	})
+
+	return &Consumer{
+		reader: r,
+		logger: log,
+	}
+}
+
 func (c *Consumer) Start(ctx context.Context, handler Handler) {
 	go func() {
 		for {
@@ -0,0 +1,223 @@
+package kafka
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/common/database"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	outboxBatchSize  = 100
+	outboxMaxBackoff = 5 * time.Minute
+
+	// After this many failed attempts, about an hour of retries, a message is marked
+	// failed and no longer sent.
+	outboxMaxAttempts = 20
+)
+
+// Publisher is satisfied by both Producer and Outbox, so event producers can
+// be wired to either.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, key string, message interface{}) error
+}
+
+// Outbox stores events in the outbox table instead of sending them to Kafka.
+// Called inside database.Transactor.WithinTx, the event is committed or rolled
+// back together with the domain rows; OutboxRelay delivers it afterwards.
+type Outbox struct {
+	db *sql.DB
+}
+
+func NewOutbox(db *sql.DB) *Outbox {
+	return &Outbox{db: db}
+}
+
+func (o *Outbox) Publish(ctx context.Context, topic string, key string, message interface{}) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO outbox (topic, message_key, payload, created_at) VALUES ($1, $2, $3, $4)`
+	_, err = database.Conn(ctx, o.db).ExecContext(ctx, query, topic, key, payload, time.Now())
+	return err
+}
+
+type outboxMessage struct {
+	id       int64
+	topic    string
+	key      string
+	payload  []byte
+	attempts int
+}
+
+// OutboxRelay drains the outbox table into Kafka. Delivery is at-least-once:
+// a message is marked published only after the broker acknowledged it.
+// Messages sharing a key are sent in insertion order; a failed message holds
+// back the rest of its key until a retry succeeds, or until it has failed
+// outboxMaxAttempts times and is marked failed, which releases the key.
+type OutboxRelay struct {
+	db        *sql.DB
+	publisher Publisher
+	interval  time.Duration
+	logger    logger.Logger
+}
+
+func NewOutboxRelay(db *sql.DB, publisher Publisher, interval time.Duration, log logger.Logger) *OutboxRelay {
+	return &OutboxRelay{
+		db:        db,
+		publisher: publisher,
+		interval:  interval,
+		logger:    log,
+	}
+}
+
+func (r *OutboxRelay) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				r.logger.Info("outbox relay stopped")
+				return
+			case now := <-ticker.C:
+				if _, err := r.Drain(ctx, now); err != nil {
+					r.logger.Error("failed to drain outbox", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Drain publishes one batch of pending messages and returns how many were
+// sent. An advisory lock keeps concurrent replicas from draining (and
+// reordering) the same table at once. It is held by the session rather than a
+// transaction: each message is marked in its own statement as soon as the
+// broker accepted it, so an error later in the batch can't undo the marks of
+// messages already sent.
+func (r *OutboxRelay) Drain(ctx context.Context, now time.Time) (int, error) {
+	conn, err := r.db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock(hashtext('outbox_relay'))`).Scan(&locked); err != nil {
+		return 0, err
+	}
+	if !locked {
+		return 0, nil
+	}
+	defer func() {
+		// Unlock even if ctx was cancelled, or the pooled session would keep the lock
+		if _, err := conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock(hashtext('outbox_relay'))`); err != nil {
+			r.logger.Error("failed to release outbox relay lock", zap.Error(err))
+		}
+	}()
+
+	messages, err := r.pending(ctx, conn, now)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	blocked := make(map[string]bool)
+	for _, m := range messages {
+		if blocked[m.key] {
+			continue
+		}
+
+		if err := r.publisher.Publish(ctx, m.topic, m.key, json.RawMessage(m.payload)); err != nil {
+			blocked[m.key] = true
+			if err := r.recordFailure(ctx, conn, m, err, now); err != nil {
+				return published, err
+			}
+			continue
+		}
+
+		if _, err := conn.ExecContext(ctx, `UPDATE outbox SET published_at = $1 WHERE id = $2`, now, m.id); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
+
+// recordFailure schedules the next attempt at m, or marks it failed once it
+// has used up outboxMaxAttempts.
+func (r *OutboxRelay) recordFailure(ctx context.Context, conn *sql.Conn, m outboxMessage, publishErr error, now time.Time) error {
+	attempts := m.attempts + 1
+	if attempts < outboxMaxAttempts {
+		r.logger.Warn("outbox publish failed, will retry",
+			zap.Int64("id", m.id),
+			zap.String("topic", m.topic),
+			zap.Int("attempts", attempts),
+			zap.Error(publishErr),
+		)
+		query := `UPDATE outbox SET attempts = $1, last_error = $2, next_attempt_at = $3 WHERE id = $4`
+		_, err := conn.ExecContext(ctx, query, attempts, publishErr.Error(), now.Add(outboxBackoff(attempts)), m.id)
+		return err
+	}
+
+	r.logger.Error("outbox publish failed, giving up",
+		zap.Int64("id", m.id),
+		zap.String("topic", m.topic),
+		zap.String("key", m.key),
+		zap.Int("attempts", attempts),
+		zap.Error(publishErr),
+	)
+	query := `UPDATE outbox SET attempts = $1, last_error = $2, failed_at = $3 WHERE id = $4`
+	_, err := conn.ExecContext(ctx, query, attempts, publishErr.Error(), now, m.id)
+	return err
+}
+
+// pending returns the messages due at now, oldest first. A message waiting for
+// a retry holds back the later messages of its key, so they are left out too.
+func (r *OutboxRelay) pending(ctx context.Context, conn *sql.Conn, now time.Time) ([]outboxMessage, error) {
+	query := `SELECT id, topic, message_key, payload, attempts
+			  FROM outbox o
+			  WHERE published_at IS NULL AND failed_at IS NULL AND next_attempt_at <= $2
+			  AND NOT EXISTS (
+				  SELECT 1 FROM outbox w
+				  WHERE w.message_key = o.message_key AND w.id < o.id
+				  AND w.published_at IS NULL AND w.failed_at IS NULL AND w.next_attempt_at > $2
+			  )
+			  ORDER BY id LIMIT $1`
+	rows, err := conn.QueryContext(ctx, query, outboxBatchSize, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []outboxMessage
+	for rows.Next() {
+		var m outboxMessage
+		if err := rows.Scan(&m.id, &m.topic, &m.key, &m.payload, &m.attempts); err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, rows.Err()
+}
+
+// outboxBackoff doubles from one second per failed attempt, capped at
+// outboxMaxBackoff.
+func outboxBackoff(attempts int) time.Duration {
+	d := time.Second
+	for i := 1; i < attempts && d < outboxMaxBackoff; i++ {
+		d *= 2
+	}
+	if d > outboxMaxBackoff {
+		d = outboxMaxBackoff
+	}
+	return d
+}
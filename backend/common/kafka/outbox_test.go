@@ -0,0 +1,186 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/database"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+)
+
+type MockPublisher struct {
+	mock.Mock
+}
+
+func (m *MockPublisher) Publish(ctx context.Context, topic string, key string, message interface{}) error {
+	payload, _ := json.Marshal(message)
+	args := m.Called(ctx, topic, key, string(payload))
+	return args.Error(0)
+}
+
+func newTestLogger() logger.Logger {
+	return logger.New(logger.Config{Level: "error", ServiceName: "test"})
+}
+
+func outboxRows() *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "topic", "message_key", "payload", "attempts"}).
+		AddRow(1, "bid.placed", "auction-1", []byte(`{"n":1}`), 0).
+		AddRow(2, "bid.placed", "auction-2", []byte(`{"n":2}`), 0).
+		AddRow(3, "bid.placed", "auction-1", []byte(`{"n":3}`), 0)
+}
+
+func expectLock(sm sqlmock.Sqlmock, locked bool) {
+	sm.ExpectQuery("SELECT pg_try_advisory_lock").WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(locked))
+}
+
+func expectUnlock(sm sqlmock.Sqlmock) {
+	sm.ExpectExec("SELECT pg_advisory_unlock").WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func TestOutbox_PublishJoinsTransaction(t *testing.T) {
+	db, sm, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	sm.ExpectBegin()
+	sm.ExpectExec("INSERT INTO outbox").
+		WithArgs("bid.placed", "auction-1", []byte(`{"amount":10}`), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	sm.ExpectCommit()
+
+	outbox := NewOutbox(db)
+	err = database.NewTransactor(db).WithinTx(context.Background(), func(ctx context.Context) error {
+		return outbox.Publish(ctx, "bid.placed", "auction-1", map[string]float64{"amount": 10})
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, sm.ExpectationsWereMet())
+}
+
+func TestOutboxRelay_DrainPublishesInOrder(t *testing.T) {
+	db, sm, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	pub := new(MockPublisher)
+	pub.On("Publish", mock.Anything, "bid.placed", "auction-1", `{"n":1}`).Return(nil).Once()
+	pub.On("Publish", mock.Anything, "bid.placed", "auction-2", `{"n":2}`).Return(nil).Once()
+	pub.On("Publish", mock.Anything, "bid.placed", "auction-1", `{"n":3}`).Return(nil).Once()
+
+	expectLock(sm, true)
+	sm.ExpectQuery("SELECT id, topic, message_key, payload, attempts").WithArgs(outboxBatchSize, now).WillReturnRows(outboxRows())
+	for _, id := range []int64{1, 2, 3} {
+		sm.ExpectExec("UPDATE outbox SET published_at").WithArgs(now, id).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	expectUnlock(sm)
+
+	relay := NewOutboxRelay(db, pub, time.Second, newTestLogger())
+	n, err := relay.Drain(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	pub.AssertExpectations(t)
+	assert.NoError(t, sm.ExpectationsWereMet())
+}
+
+func TestOutboxRelay_FailureHoldsBackSameKey(t *testing.T) {
+	db, sm, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	pub := new(MockPublisher)
+	pub.On("Publish", mock.Anything, "bid.placed", "auction-1", `{"n":1}`).Return(errors.New("broker down")).Once()
+	pub.On("Publish", mock.Anything, "bid.placed", "auction-2", `{"n":2}`).Return(nil).Once()
+
+	expectLock(sm, true)
+	sm.ExpectQuery("SELECT id, topic, message_key, payload, attempts").WithArgs(outboxBatchSize, now).WillReturnRows(outboxRows())
+	sm.ExpectExec("UPDATE outbox SET attempts").
+		WithArgs(1, "broker down", now.Add(time.Second), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sm.ExpectExec("UPDATE outbox SET published_at").WithArgs(now, int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	expectUnlock(sm)
+
+	relay := NewOutboxRelay(db, pub, time.Second, newTestLogger())
+	n, err := relay.Drain(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	pub.AssertExpectations(t) // message 3 (auction-1) must not be sent ahead of message 1
+	assert.NoError(t, sm.ExpectationsWereMet())
+}
+
+func TestOutboxRelay_GivesUpAfterMaxAttempts(t *testing.T) {
+	db, sm, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	pub := new(MockPublisher)
+	pub.On("Publish", mock.Anything, "bid.placed", "auction-1", `{"n":1}`).Return(errors.New("message too large")).Once()
+
+	expectLock(sm, true)
+	sm.ExpectQuery("SELECT id, topic, message_key, payload, attempts").WithArgs(outboxBatchSize, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "topic", "message_key", "payload", "attempts"}).
+			AddRow(1, "bid.placed", "auction-1", []byte(`{"n":1}`), outboxMaxAttempts-1))
+	sm.ExpectExec("UPDATE outbox SET attempts = \\$1, last_error = \\$2, failed_at = \\$3").
+		WithArgs(outboxMaxAttempts, "message too large", now, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectUnlock(sm)
+
+	relay := NewOutboxRelay(db, pub, time.Second, newTestLogger())
+	n, err := relay.Drain(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	pub.AssertExpectations(t)
+	assert.NoError(t, sm.ExpectationsWereMet())
+}
+
+func TestOutboxRelay_KeepsMarksOnLaterError(t *testing.T) {
+	db, sm, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	pub := new(MockPublisher)
+	pub.On("Publish", mock.Anything, "bid.placed", "auction-1", `{"n":1}`).Return(nil).Once()
+	pub.On("Publish", mock.Anything, "bid.placed", "auction-2", `{"n":2}`).Return(nil).Once()
+
+	// Each mark stands on its own; there is no transaction for the failure to roll back
+	expectLock(sm, true)
+	sm.ExpectQuery("SELECT id, topic, message_key, payload, attempts").WithArgs(outboxBatchSize, now).WillReturnRows(outboxRows())
+	sm.ExpectExec("UPDATE outbox SET published_at").WithArgs(now, int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	sm.ExpectExec("UPDATE outbox SET published_at").WithArgs(now, int64(2)).WillReturnError(errors.New("connection reset"))
+	expectUnlock(sm)
+
+	relay := NewOutboxRelay(db, pub, time.Second, newTestLogger())
+	n, err := relay.Drain(context.Background(), now)
+	assert.Error(t, err)
+	assert.Equal(t, 1, n)
+	pub.AssertExpectations(t)
+	assert.NoError(t, sm.ExpectationsWereMet())
+}
+
+func TestOutboxRelay_SkipsWhenLockHeld(t *testing.T) {
+	db, sm, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	expectLock(sm, false)
+
+	relay := NewOutboxRelay(db, new(MockPublisher), time.Second, newTestLogger())
+	n, err := relay.Drain(context.Background(), time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.NoError(t, sm.ExpectationsWereMet())
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, outboxBackoff(1))
+	assert.Equal(t, 4*time.Second, outboxBackoff(3))
+	assert.Equal(t, outboxMaxBackoff, outboxBackoff(20))
+}
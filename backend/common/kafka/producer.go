@@ -18,7 +18,7 @@ type Producer struct {
 func NewProducer(brokers []string, log logger.Logger) *Producer {
 	w := &kafka.Writer{
 		Addr:         kafka.TCP(brokers...),
-		Balancer:     &kafka.LeastBytes{},
+		Balancer:     &kafka.Hash{}, // keyed messages stay on one partition, preserving their order
 		BatchTimeout: 10 * time.Millisecond,
 	}
 
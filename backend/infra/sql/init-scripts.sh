@@ -11,12 +11,15 @@ EOSQL
 
 echo "Initializing auth_db..."
 psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "auth_db" -f /docker-entrypoint-initdb.d/schemas/user_init.sql
+psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "auth_db" -f /docker-entrypoint-initdb.d/schemas/outbox_init.sql
 
 echo "Initializing auction_db..."
 psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "auction_db" -f /docker-entrypoint-initdb.d/schemas/auction_init.sql
+psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "auction_db" -f /docker-entrypoint-initdb.d/schemas/outbox_init.sql
 
 echo "Initializing bidding_db..."
 psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "bidding_db" -f /docker-entrypoint-initdb.d/schemas/bidding_init.sql
+psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "bidding_db" -f /docker-entrypoint-initdb.d/schemas/outbox_init.sql
 
 echo "Initializing notification_db..."
 psql -v ON_ERROR_STOP=1 --username "$POSTGRES_USER" --dbname "notification_db" -f /docker-entrypoint-initdb.d/schemas/notification_init.sql
@@ -5,11 +5,27 @@ CREATE TABLE IF NOT EXISTS auctions (
     description TEXT,
     start_price DECIMAL(10, 2) NOT NULL,
     current_price DECIMAL(10, 2) NOT NULL,
-    status VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL, -- PENDING, ACTIVE, CLOSED, CANCELLED, RESERVE_NOT_MET
     start_time TIMESTAMP WITH TIME ZONE NOT NULL,
     end_time TIMESTAMP WITH TIME ZONE NOT NULL,
     category VARCHAR(100),
     image_url TEXT,
+    winner_id VARCHAR(36),
+    winning_bid_id VARCHAR(36),
+    min_increment JSONB, -- tier table; NULL means a one-cent minimum raise
+    reserve_price DECIMAL(10, 2), -- hidden from bidders; NULL means no reserve
+    extension_window INTEGER NOT NULL DEFAULT 0, -- seconds before end_time in which a bid extends the auction; 0 disables
+    extension_duration INTEGER NOT NULL DEFAULT 0, -- seconds added to end_time per extension
+    max_extensions INTEGER NOT NULL DEFAULT 0,
+    extension_count INTEGER NOT NULL DEFAULT 0,
+    buy_now_price DECIMAL(10, 2), -- NULL means no buy-now option
+    auction_type VARCHAR(20) NOT NULL DEFAULT 'ENGLISH', -- ENGLISH, SEALED_FIRST_PRICE, SEALED_SECOND_PRICE, REVERSE, DUTCH
+    clock_step DECIMAL(10, 2) NOT NULL DEFAULT 0, -- DUTCH only: price drop per clock_interval
+    clock_interval INTEGER NOT NULL DEFAULT 0, -- DUTCH only: seconds between price drops
+    floor_price DECIMAL(10, 2) NOT NULL DEFAULT 0, -- DUTCH only: the clock never drops below it
+    quantity INTEGER NOT NULL DEFAULT 1, -- identical units on sale
+    lot_pricing VARCHAR(20), -- multi-lot only: UNIFORM or DISCRIMINATORY
+    winners JSONB, -- multi-lot only: [{bid_id, bidder_id, units, price}] once closed
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
 );
@@ -17,3 +33,11 @@ CREATE TABLE IF NOT EXISTS auctions (
 CREATE INDEX idx_auctions_status ON auctions(status);
 CREATE INDEX idx_auctions_category ON auctions(category);
 CREATE INDEX idx_auctions_seller_id ON auctions(seller_id);
+
+-- Bidders who placed their one bid on a sealed auction. The amounts live in the bidding service.
+CREATE TABLE IF NOT EXISTS sealed_bidders (
+    auction_id VARCHAR(36) NOT NULL REFERENCES auctions(id),
+    bidder_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (auction_id, bidder_id)
+);
@@ -3,8 +3,47 @@ CREATE TABLE IF NOT EXISTS bids (
     auction_id VARCHAR(36) NOT NULL,
     bidder_id VARCHAR(36) NOT NULL,
     amount DECIMAL(10, 2) NOT NULL,
-    timestamp TIMESTAMP NOT NULL
+    timestamp TIMESTAMP NOT NULL,
+    is_proxy BOOLEAN NOT NULL DEFAULT FALSE,
+    sealed BOOLEAN NOT NULL DEFAULT FALSE, -- amount is hidden until the auction closes
+    quantity INTEGER NOT NULL DEFAULT 1, -- units bid for, at amount each
+    retracted BOOLEAN NOT NULL DEFAULT FALSE -- withdrawn; never leads or wins, see bid_retractions
 );
 
 CREATE INDEX idx_bids_auction_id ON bids(auction_id);
 CREATE INDEX idx_bids_bidder_id ON bids(bidder_id);
+
+-- Hidden proxy-bidding ceilings, one per bidder per auction. Never exposed through the bids API.
+CREATE TABLE IF NOT EXISTS proxy_bids (
+    auction_id VARCHAR(36) NOT NULL,
+    bidder_id VARCHAR(36) NOT NULL,
+    max_amount DECIMAL(10, 2) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (auction_id, bidder_id)
+);
+
+-- Companies the auth service has verified, fed by company.verified events.
+-- Only their members may bid on reverse auctions.
+CREATE TABLE IF NOT EXISTS verified_companies (
+    company_id VARCHAR(36) PRIMARY KEY,
+    verified_at TIMESTAMP NOT NULL
+);
+
+-- Audit trail of withdrawn bids. The bid row stays, flagged retracted; leader_id and
+-- leading_amount are the live bid that led the auction afterwards.
+CREATE TABLE IF NOT EXISTS bid_retractions (
+    bid_id VARCHAR(36) PRIMARY KEY REFERENCES bids(id),
+    auction_id VARCHAR(36) NOT NULL,
+    bidder_id VARCHAR(36) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    retracted_by VARCHAR(36) NOT NULL,
+    cancelled BOOLEAN NOT NULL DEFAULT FALSE, -- by the seller or an admin rather than the bidder
+    reason TEXT NOT NULL DEFAULT '',
+    was_leading BOOLEAN NOT NULL DEFAULT FALSE,
+    leader_id VARCHAR(36) NOT NULL DEFAULT '',
+    leading_amount DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    retracted_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_bid_retractions_auction_id ON bid_retractions(auction_id);
@@ -6,7 +6,124 @@ CREATE TABLE IF NOT EXISTS notifications (
     message TEXT NOT NULL,
     resource_id VARCHAR(36),
     is_read BOOLEAN DEFAULT FALSE,
-    created_at TIMESTAMP NOT NULL
+    created_at TIMESTAMP NOT NULL,
+    -- Position in the user's stream of pushed notifications, for resuming WebSockets
+    seq BIGINT NOT NULL DEFAULT 0
 );
 
 CREATE INDEX idx_notifications_user_id ON notifications(user_id);
+-- Keyset pagination of a user's notifications, newest first
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications(user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_seq ON notifications(user_id, seq);
+
+-- The last sequence number handed out to each user's notifications
+CREATE TABLE IF NOT EXISTS notification_sequences (
+    user_id VARCHAR(36) PRIMARY KEY,
+    seq BIGINT NOT NULL
+);
+
+-- Who has bid on each auction, projected from bid.placed, so everyone can be told the outcome
+CREATE TABLE IF NOT EXISTS auction_participants (
+    auction_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    first_bid_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (auction_id, user_id)
+);
+
+-- Which channels each type of notification goes out on, per user; types missing from
+-- channels use the service defaults
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id VARCHAR(36) PRIMARY KEY,
+    channels JSONB NOT NULL DEFAULT '{}',
+    quiet_start VARCHAR(5),
+    quiet_end VARCHAR(5),
+    time_zone VARCHAR(64),
+    digest VARCHAR(20) NOT NULL DEFAULT 'IMMEDIATE',
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Users' email addresses, projected from user.registered
+CREATE TABLE IF NOT EXISTS user_contacts (
+    user_id VARCHAR(36) PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Notifications waiting to go out by email, with their delivery status
+CREATE TABLE IF NOT EXISTS email_queue (
+    id BIGSERIAL PRIMARY KEY,
+    recipient VARCHAR(255) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    notification_id VARCHAR(36) NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    resource_id VARCHAR(36),
+    notified_at TIMESTAMP NOT NULL,
+    digest VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    send_after TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_queue_pending ON email_queue(send_after) WHERE status = 'PENDING';
+
+-- Outbound webhooks, owned by a user or a company
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id VARCHAR(36) PRIMARY KEY,
+    owner_type VARCHAR(10) NOT NULL,
+    owner_id VARCHAR(36) NOT NULL,
+    url TEXT NOT NULL,
+    event_types TEXT[] NOT NULL,
+    secret VARCHAR(100) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    consecutive_failures INT NOT NULL DEFAULT 0,
+    disabled_at TIMESTAMP WITH TIME ZONE,
+    created_by VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_owner ON webhook_subscriptions(owner_type, owner_id);
+
+-- Every delivery of an event to a webhook, pending or done; doubles as the delivery log
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id VARCHAR(36) PRIMARY KEY,
+    subscription_id VARCHAR(36) NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    response_status INT,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    replay_of VARCHAR(36),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    delivered_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
+
+-- Company members, projected from company.verified, so company webhooks get their members' events
+CREATE TABLE IF NOT EXISTS company_members (
+    company_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    PRIMARY KEY (company_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_company_members_user ON company_members(user_id);
+
+-- Which notification-service instances hold WebSocket connections of which users,
+-- refreshed by each instance while it runs
+CREATE TABLE IF NOT EXISTS hub_presence (
+    user_id VARCHAR(36) NOT NULL,
+    instance_id VARCHAR(100) NOT NULL,
+    seen_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (user_id, instance_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_hub_presence_instance ON hub_presence(instance_id);
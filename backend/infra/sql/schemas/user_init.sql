@@ -28,4 +28,19 @@ CREATE TABLE IF NOT EXISTS users (
     two_factor_secret TEXT,            -- TOTP Secret
     created_at TIMESTAMPTZ DEFAULT NOW(),
     updated_at TIMESTAMPTZ DEFAULT NOW()
-);
\ No newline at end of file
+);
+-- 3. Refresh Tokens Table
+-- Stored by the SHA-256 of the opaque token. A refresh uses the token up and adds
+-- its successor to the same family; all tokens of a login share a family.
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    family_id UUID NOT NULL,
+    token_hash CHAR(64) UNIQUE NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    used_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family ON refresh_tokens(family_id);
@@ -21,21 +21,106 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type BidRejectionReason int32
+
+const (
+	BidRejectionReason_BID_REJECTION_REASON_UNSPECIFIED BidRejectionReason = 0 // The bid was accepted
+	BidRejectionReason_AUCTION_NOT_FOUND                BidRejectionReason = 1
+	BidRejectionReason_AUCTION_NOT_ACTIVE               BidRejectionReason = 2
+	BidRejectionReason_AUCTION_ENDED                    BidRejectionReason = 3
+	BidRejectionReason_BID_TOO_LOW                      BidRejectionReason = 4
+	BidRejectionReason_BUY_NOW_UNAVAILABLE              BidRejectionReason = 5 // No buy-now price, or bidding passed the cutoff
+	BidRejectionReason_ALREADY_BID                      BidRejectionReason = 6 // The bidder already placed their one bid on a sealed auction
+	BidRejectionReason_BID_TOO_HIGH                     BidRejectionReason = 7 // Reverse auctions: the bid does not undercut the current price by the minimum decrement
+	BidRejectionReason_WRONG_AUCTION_TYPE               BidRejectionReason = 8 // Bids on a Dutch auction, or clock acceptances on any other
+	BidRejectionReason_INVALID_QUANTITY                 BidRejectionReason = 9 // More units than the auction offers
+)
+
+// Enum value maps for BidRejectionReason.
+var (
+	BidRejectionReason_name = map[int32]string{
+		0: "BID_REJECTION_REASON_UNSPECIFIED",
+		1: "AUCTION_NOT_FOUND",
+		2: "AUCTION_NOT_ACTIVE",
+		3: "AUCTION_ENDED",
+		4: "BID_TOO_LOW",
+		5: "BUY_NOW_UNAVAILABLE",
+		6: "ALREADY_BID",
+		7: "BID_TOO_HIGH",
+		8: "WRONG_AUCTION_TYPE",
+		9: "INVALID_QUANTITY",
+	}
+	BidRejectionReason_value = map[string]int32{
+		"BID_REJECTION_REASON_UNSPECIFIED": 0,
+		"AUCTION_NOT_FOUND":                1,
+		"AUCTION_NOT_ACTIVE":               2,
+		"AUCTION_ENDED":                    3,
+		"BID_TOO_LOW":                      4,
+		"BUY_NOW_UNAVAILABLE":              5,
+		"ALREADY_BID":                      6,
+		"BID_TOO_HIGH":                     7,
+		"WRONG_AUCTION_TYPE":               8,
+		"INVALID_QUANTITY":                 9,
+	}
+)
+
+func (x BidRejectionReason) Enum() *BidRejectionReason {
+	p := new(BidRejectionReason)
+	*p = x
+	return p
+}
+
+func (x BidRejectionReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BidRejectionReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_auction_proto_enumTypes[0].Descriptor()
+}
+
+func (BidRejectionReason) Type() protoreflect.EnumType {
+	return &file_auction_proto_enumTypes[0]
+}
+
+func (x BidRejectionReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BidRejectionReason.Descriptor instead.
+func (BidRejectionReason) EnumDescriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{0}
+}
+
 type Auction struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	SellerId      string                 `protobuf:"bytes,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
-	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	StartPrice    float64                `protobuf:"fixed64,5,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
-	CurrentPrice  float64                `protobuf:"fixed64,6,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
-	Status        string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"` // ACTIVE, CLOSED, PENDING, CANCELLED
-	StartTime     int64                  `protobuf:"varint,8,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       int64                  `protobuf:"varint,9,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	Category      string                 `protobuf:"bytes,10,opt,name=category,proto3" json:"category,omitempty"`
-	ImageUrl      string                 `protobuf:"bytes,11,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SellerId          string                 `protobuf:"bytes,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	Title             string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description       string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	StartPrice        float64                `protobuf:"fixed64,5,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
+	CurrentPrice      float64                `protobuf:"fixed64,6,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
+	Status            string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"` // ACTIVE, CLOSED, PENDING, CANCELLED, RESERVE_NOT_MET
+	StartTime         int64                  `protobuf:"varint,8,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime           int64                  `protobuf:"varint,9,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Category          string                 `protobuf:"bytes,10,opt,name=category,proto3" json:"category,omitempty"`
+	ImageUrl          string                 `protobuf:"bytes,11,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	WinnerId          string                 `protobuf:"bytes,12,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"` // Set once the auction is closed with a winning bid
+	WinningBidId      string                 `protobuf:"bytes,13,opt,name=winning_bid_id,json=winningBidId,proto3" json:"winning_bid_id,omitempty"`
+	MinIncrement      []*IncrementTier       `protobuf:"bytes,14,rep,name=min_increment,json=minIncrement,proto3" json:"min_increment,omitempty"`                 // Empty means a one-cent minimum raise
+	ExtensionWindow   int64                  `protobuf:"varint,15,opt,name=extension_window,json=extensionWindow,proto3" json:"extension_window,omitempty"`       // Seconds before end_time in which a bid extends the auction; 0 disables
+	ExtensionDuration int64                  `protobuf:"varint,16,opt,name=extension_duration,json=extensionDuration,proto3" json:"extension_duration,omitempty"` // Seconds added to end_time per extension
+	MaxExtensions     int32                  `protobuf:"varint,17,opt,name=max_extensions,json=maxExtensions,proto3" json:"max_extensions,omitempty"`
+	ExtensionCount    int32                  `protobuf:"varint,18,opt,name=extension_count,json=extensionCount,proto3" json:"extension_count,omitempty"`
+	BuyNowPrice       float64                `protobuf:"fixed64,19,opt,name=buy_now_price,json=buyNowPrice,proto3" json:"buy_now_price,omitempty"` // 0 when the auction has no buy-now option
+	AuctionType       string                 `protobuf:"bytes,20,opt,name=auction_type,json=auctionType,proto3" json:"auction_type,omitempty"`     // ENGLISH, SEALED_FIRST_PRICE, SEALED_SECOND_PRICE, REVERSE or DUTCH
+	ClockStep         float64                `protobuf:"fixed64,21,opt,name=clock_step,json=clockStep,proto3" json:"clock_step,omitempty"`         // Dutch auctions: the price drops by clock_step every clock_interval seconds
+	ClockInterval     int64                  `protobuf:"varint,22,opt,name=clock_interval,json=clockInterval,proto3" json:"clock_interval,omitempty"`
+	FloorPrice        float64                `protobuf:"fixed64,23,opt,name=floor_price,json=floorPrice,proto3" json:"floor_price,omitempty"` // Dutch auctions: the clock stops here
+	Quantity          int32                  `protobuf:"varint,24,opt,name=quantity,proto3" json:"quantity,omitempty"`                        // Identical units on sale; 1 for a single item
+	LotPricing        string                 `protobuf:"bytes,25,opt,name=lot_pricing,json=lotPricing,proto3" json:"lot_pricing,omitempty"`   // Multi-lot auctions: UNIFORM or DISCRIMINATORY
+	Winners           []*LotWinner           `protobuf:"bytes,26,rep,name=winners,proto3" json:"winners,omitempty"`                           // Multi-lot auctions: set once closed, best bid first
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *Auction) Reset() {
@@ -145,128 +230,137 @@ func (x *Auction) GetImageUrl() string {
 	return ""
 }
 
-type CreateAuctionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	SellerId      string                 `protobuf:"bytes,1,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	StartPrice    float64                `protobuf:"fixed64,4,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
-	StartTime     int64                  `protobuf:"varint,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       int64                  `protobuf:"varint,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	Category      string                 `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
-	ImageUrl      string                 `protobuf:"bytes,8,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Auction) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
 }
 
-func (x *CreateAuctionRequest) Reset() {
-	*x = CreateAuctionRequest{}
-	mi := &file_auction_proto_msgTypes[1]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Auction) GetWinningBidId() string {
+	if x != nil {
+		return x.WinningBidId
+	}
+	return ""
 }
 
-func (x *CreateAuctionRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Auction) GetMinIncrement() []*IncrementTier {
+	if x != nil {
+		return x.MinIncrement
+	}
+	return nil
 }
 
-func (*CreateAuctionRequest) ProtoMessage() {}
+func (x *Auction) GetExtensionWindow() int64 {
+	if x != nil {
+		return x.ExtensionWindow
+	}
+	return 0
+}
 
-func (x *CreateAuctionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[1]
+func (x *Auction) GetExtensionDuration() int64 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ExtensionDuration
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use CreateAuctionRequest.ProtoReflect.Descriptor instead.
-func (*CreateAuctionRequest) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{1}
+func (x *Auction) GetMaxExtensions() int32 {
+	if x != nil {
+		return x.MaxExtensions
+	}
+	return 0
 }
 
-func (x *CreateAuctionRequest) GetSellerId() string {
+func (x *Auction) GetExtensionCount() int32 {
 	if x != nil {
-		return x.SellerId
+		return x.ExtensionCount
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateAuctionRequest) GetTitle() string {
+func (x *Auction) GetBuyNowPrice() float64 {
 	if x != nil {
-		return x.Title
+		return x.BuyNowPrice
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateAuctionRequest) GetDescription() string {
+func (x *Auction) GetAuctionType() string {
 	if x != nil {
-		return x.Description
+		return x.AuctionType
 	}
 	return ""
 }
 
-func (x *CreateAuctionRequest) GetStartPrice() float64 {
+func (x *Auction) GetClockStep() float64 {
 	if x != nil {
-		return x.StartPrice
+		return x.ClockStep
 	}
 	return 0
 }
 
-func (x *CreateAuctionRequest) GetStartTime() int64 {
+func (x *Auction) GetClockInterval() int64 {
 	if x != nil {
-		return x.StartTime
+		return x.ClockInterval
 	}
 	return 0
 }
 
-func (x *CreateAuctionRequest) GetEndTime() int64 {
+func (x *Auction) GetFloorPrice() float64 {
 	if x != nil {
-		return x.EndTime
+		return x.FloorPrice
 	}
 	return 0
 }
 
-func (x *CreateAuctionRequest) GetCategory() string {
+func (x *Auction) GetQuantity() int32 {
 	if x != nil {
-		return x.Category
+		return x.Quantity
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateAuctionRequest) GetImageUrl() string {
+func (x *Auction) GetLotPricing() string {
 	if x != nil {
-		return x.ImageUrl
+		return x.LotPricing
 	}
 	return ""
 }
 
-type CreateAuctionResponse struct {
+func (x *Auction) GetWinners() []*LotWinner {
+	if x != nil {
+		return x.Winners
+	}
+	return nil
+}
+
+// LotWinner is a bid that won units of a multi-lot auction.
+type LotWinner struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Auction       *Auction               `protobuf:"bytes,1,opt,name=auction,proto3" json:"auction,omitempty"`
+	BidId         string                 `protobuf:"bytes,1,opt,name=bid_id,json=bidId,proto3" json:"bid_id,omitempty"`
+	BidderId      string                 `protobuf:"bytes,2,opt,name=bidder_id,json=bidderId,proto3" json:"bidder_id,omitempty"`
+	Units         int32                  `protobuf:"varint,3,opt,name=units,proto3" json:"units,omitempty"`
+	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"` // Per unit
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateAuctionResponse) Reset() {
-	*x = CreateAuctionResponse{}
-	mi := &file_auction_proto_msgTypes[2]
+func (x *LotWinner) Reset() {
+	*x = LotWinner{}
+	mi := &file_auction_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateAuctionResponse) String() string {
+func (x *LotWinner) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateAuctionResponse) ProtoMessage() {}
+func (*LotWinner) ProtoMessage() {}
 
-func (x *CreateAuctionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[2]
+func (x *LotWinner) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -277,40 +371,65 @@ func (x *CreateAuctionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateAuctionResponse.ProtoReflect.Descriptor instead.
-func (*CreateAuctionResponse) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use LotWinner.ProtoReflect.Descriptor instead.
+func (*LotWinner) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *CreateAuctionResponse) GetAuction() *Auction {
+func (x *LotWinner) GetBidId() string {
 	if x != nil {
-		return x.Auction
+		return x.BidId
 	}
-	return nil
+	return ""
 }
 
-type GetAuctionRequest struct {
+func (x *LotWinner) GetBidderId() string {
+	if x != nil {
+		return x.BidderId
+	}
+	return ""
+}
+
+func (x *LotWinner) GetUnits() int32 {
+	if x != nil {
+		return x.Units
+	}
+	return 0
+}
+
+func (x *LotWinner) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+// IncrementTier sets the minimum raise for prices from min_price up to the next tier.
+// Exactly one of amount (fixed) and percent (of the current price) is set.
+type IncrementTier struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MinPrice      float64                `protobuf:"fixed64,1,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Percent       float64                `protobuf:"fixed64,3,opt,name=percent,proto3" json:"percent,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAuctionRequest) Reset() {
-	*x = GetAuctionRequest{}
-	mi := &file_auction_proto_msgTypes[3]
+func (x *IncrementTier) Reset() {
+	*x = IncrementTier{}
+	mi := &file_auction_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAuctionRequest) String() string {
+func (x *IncrementTier) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAuctionRequest) ProtoMessage() {}
+func (*IncrementTier) ProtoMessage() {}
 
-func (x *GetAuctionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[3]
+func (x *IncrementTier) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -321,40 +440,73 @@ func (x *GetAuctionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAuctionRequest.ProtoReflect.Descriptor instead.
-func (*GetAuctionRequest) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use IncrementTier.ProtoReflect.Descriptor instead.
+func (*IncrementTier) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetAuctionRequest) GetId() string {
+func (x *IncrementTier) GetMinPrice() float64 {
 	if x != nil {
-		return x.Id
+		return x.MinPrice
 	}
-	return ""
+	return 0
 }
 
-type GetAuctionResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Auction       *Auction               `protobuf:"bytes,1,opt,name=auction,proto3" json:"auction,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *IncrementTier) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
 }
 
-func (x *GetAuctionResponse) Reset() {
-	*x = GetAuctionResponse{}
-	mi := &file_auction_proto_msgTypes[4]
+func (x *IncrementTier) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+type CreateAuctionRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SellerId          string                 `protobuf:"bytes,1,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	Title             string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description       string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	StartPrice        float64                `protobuf:"fixed64,4,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
+	StartTime         int64                  `protobuf:"varint,5,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime           int64                  `protobuf:"varint,6,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Category          string                 `protobuf:"bytes,7,opt,name=category,proto3" json:"category,omitempty"`
+	ImageUrl          string                 `protobuf:"bytes,8,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	MinIncrement      []*IncrementTier       `protobuf:"bytes,9,rep,name=min_increment,json=minIncrement,proto3" json:"min_increment,omitempty"`            // Optional; tiers ordered by min_price, starting at 0
+	ReservePrice      float64                `protobuf:"fixed64,10,opt,name=reserve_price,json=reservePrice,proto3" json:"reserve_price,omitempty"`         // Optional; hidden from bidders
+	ExtensionWindow   int64                  `protobuf:"varint,11,opt,name=extension_window,json=extensionWindow,proto3" json:"extension_window,omitempty"` // Optional; seconds, set together with extension_duration
+	ExtensionDuration int64                  `protobuf:"varint,12,opt,name=extension_duration,json=extensionDuration,proto3" json:"extension_duration,omitempty"`
+	MaxExtensions     int32                  `protobuf:"varint,13,opt,name=max_extensions,json=maxExtensions,proto3" json:"max_extensions,omitempty"` // Optional; defaults to the service cap
+	BuyNowPrice       float64                `protobuf:"fixed64,14,opt,name=buy_now_price,json=buyNowPrice,proto3" json:"buy_now_price,omitempty"`    // Optional; must exceed the start price and any reserve
+	AuctionType       string                 `protobuf:"bytes,15,opt,name=auction_type,json=auctionType,proto3" json:"auction_type,omitempty"`        // Optional; defaults to ENGLISH
+	ClockStep         float64                `protobuf:"fixed64,16,opt,name=clock_step,json=clockStep,proto3" json:"clock_step,omitempty"`            // Required by DUTCH auctions
+	ClockInterval     int64                  `protobuf:"varint,17,opt,name=clock_interval,json=clockInterval,proto3" json:"clock_interval,omitempty"` // Required by DUTCH auctions; seconds
+	FloorPrice        float64                `protobuf:"fixed64,18,opt,name=floor_price,json=floorPrice,proto3" json:"floor_price,omitempty"`         // Optional for DUTCH auctions; below the start price
+	Quantity          int32                  `protobuf:"varint,19,opt,name=quantity,proto3" json:"quantity,omitempty"`                                // Optional; defaults to 1. Above 1 only for ENGLISH auctions
+	LotPricing        string                 `protobuf:"bytes,20,opt,name=lot_pricing,json=lotPricing,proto3" json:"lot_pricing,omitempty"`           // Optional for multi-lot auctions; defaults to UNIFORM
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateAuctionRequest) Reset() {
+	*x = CreateAuctionRequest{}
+	mi := &file_auction_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAuctionResponse) String() string {
+func (x *CreateAuctionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAuctionResponse) ProtoMessage() {}
+func (*CreateAuctionRequest) ProtoMessage() {}
 
-func (x *GetAuctionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[4]
+func (x *CreateAuctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,44 +517,827 @@ func (x *GetAuctionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAuctionResponse.ProtoReflect.Descriptor instead.
-func (*GetAuctionResponse) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use CreateAuctionRequest.ProtoReflect.Descriptor instead.
+func (*CreateAuctionRequest) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetAuctionResponse) GetAuction() *Auction {
+func (x *CreateAuctionRequest) GetSellerId() string {
 	if x != nil {
-		return x.Auction
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *CreateAuctionRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateAuctionRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateAuctionRequest) GetStartPrice() float64 {
+	if x != nil {
+		return x.StartPrice
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetStartTime() int64 {
+	if x != nil {
+		return x.StartTime
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetEndTime() int64 {
+	if x != nil {
+		return x.EndTime
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CreateAuctionRequest) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *CreateAuctionRequest) GetMinIncrement() []*IncrementTier {
+	if x != nil {
+		return x.MinIncrement
 	}
 	return nil
 }
 
-type ListAuctionsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                     // Optional filter
-	SellerId      string                 `protobuf:"bytes,4,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"` // Optional filter
-	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`                 // Optional filter
+func (x *CreateAuctionRequest) GetReservePrice() float64 {
+	if x != nil {
+		return x.ReservePrice
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetExtensionWindow() int64 {
+	if x != nil {
+		return x.ExtensionWindow
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetExtensionDuration() int64 {
+	if x != nil {
+		return x.ExtensionDuration
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetMaxExtensions() int32 {
+	if x != nil {
+		return x.MaxExtensions
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetBuyNowPrice() float64 {
+	if x != nil {
+		return x.BuyNowPrice
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetAuctionType() string {
+	if x != nil {
+		return x.AuctionType
+	}
+	return ""
+}
+
+func (x *CreateAuctionRequest) GetClockStep() float64 {
+	if x != nil {
+		return x.ClockStep
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetClockInterval() int64 {
+	if x != nil {
+		return x.ClockInterval
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetFloorPrice() float64 {
+	if x != nil {
+		return x.FloorPrice
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *CreateAuctionRequest) GetLotPricing() string {
+	if x != nil {
+		return x.LotPricing
+	}
+	return ""
+}
+
+type CreateAuctionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auction       *Auction               `protobuf:"bytes,1,opt,name=auction,proto3" json:"auction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAuctionResponse) Reset() {
+	*x = CreateAuctionResponse{}
+	mi := &file_auction_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAuctionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAuctionResponse) ProtoMessage() {}
+
+func (x *CreateAuctionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAuctionResponse.ProtoReflect.Descriptor instead.
+func (*CreateAuctionResponse) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateAuctionResponse) GetAuction() *Auction {
+	if x != nil {
+		return x.Auction
+	}
+	return nil
+}
+
+type GetAuctionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuctionRequest) Reset() {
+	*x = GetAuctionRequest{}
+	mi := &file_auction_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuctionRequest) ProtoMessage() {}
+
+func (x *GetAuctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuctionRequest.ProtoReflect.Descriptor instead.
+func (*GetAuctionRequest) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAuctionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetAuctionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auction       *Auction               `protobuf:"bytes,1,opt,name=auction,proto3" json:"auction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuctionResponse) Reset() {
+	*x = GetAuctionResponse{}
+	mi := &file_auction_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuctionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuctionResponse) ProtoMessage() {}
+
+func (x *GetAuctionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuctionResponse.ProtoReflect.Descriptor instead.
+func (*GetAuctionResponse) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetAuctionResponse) GetAuction() *Auction {
+	if x != nil {
+		return x.Auction
+	}
+	return nil
+}
+
+type ListAuctionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                     // Optional filter
+	SellerId      string                 `protobuf:"bytes,4,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"` // Optional filter
+	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`                 // Optional filter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuctionsRequest) Reset() {
+	*x = ListAuctionsRequest{}
+	mi := &file_auction_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuctionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuctionsRequest) ProtoMessage() {}
+
+func (x *ListAuctionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuctionsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuctionsRequest) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListAuctionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAuctionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListAuctionsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListAuctionsRequest) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *ListAuctionsRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type ListAuctionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auctions      []*Auction             `protobuf:"bytes,1,rep,name=auctions,proto3" json:"auctions,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuctionsResponse) Reset() {
+	*x = ListAuctionsResponse{}
+	mi := &file_auction_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuctionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuctionsResponse) ProtoMessage() {}
+
+func (x *ListAuctionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuctionsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuctionsResponse) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListAuctionsResponse) GetAuctions() []*Auction {
+	if x != nil {
+		return x.Auctions
+	}
+	return nil
+}
+
+func (x *ListAuctionsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type UpdateAuctionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAuctionRequest) Reset() {
+	*x = UpdateAuctionRequest{}
+	mi := &file_auction_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAuctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAuctionRequest) ProtoMessage() {}
+
+func (x *UpdateAuctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAuctionRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAuctionRequest) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateAuctionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateAuctionRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateAuctionRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UpdateAuctionRequest) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+type UpdateAuctionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Auction       *Auction               `protobuf:"bytes,1,opt,name=auction,proto3" json:"auction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAuctionResponse) Reset() {
+	*x = UpdateAuctionResponse{}
+	mi := &file_auction_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAuctionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAuctionResponse) ProtoMessage() {}
+
+func (x *UpdateAuctionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAuctionResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAuctionResponse) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateAuctionResponse) GetAuction() *Auction {
+	if x != nil {
+		return x.Auction
+	}
+	return nil
+}
+
+type CloseAuctionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseAuctionRequest) Reset() {
+	*x = CloseAuctionRequest{}
+	mi := &file_auction_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseAuctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseAuctionRequest) ProtoMessage() {}
+
+func (x *CloseAuctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseAuctionRequest.ProtoReflect.Descriptor instead.
+func (*CloseAuctionRequest) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CloseAuctionRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CloseAuctionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseAuctionResponse) Reset() {
+	*x = CloseAuctionResponse{}
+	mi := &file_auction_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseAuctionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseAuctionResponse) ProtoMessage() {}
+
+func (x *CloseAuctionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseAuctionResponse.ProtoReflect.Descriptor instead.
+func (*CloseAuctionResponse) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CloseAuctionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CloseAuctionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type AcceptBidRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"` // Per unit
+	BidderId      string                 `protobuf:"bytes,3,opt,name=bidder_id,json=bidderId,proto3" json:"bidder_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"` // Units wanted; 0 means 1
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptBidRequest) Reset() {
+	*x = AcceptBidRequest{}
+	mi := &file_auction_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptBidRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptBidRequest) ProtoMessage() {}
+
+func (x *AcceptBidRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptBidRequest.ProtoReflect.Descriptor instead.
+func (*AcceptBidRequest) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AcceptBidRequest) GetAuctionId() string {
+	if x != nil {
+		return x.AuctionId
+	}
+	return ""
+}
+
+func (x *AcceptBidRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AcceptBidRequest) GetBidderId() string {
+	if x != nil {
+		return x.BidderId
+	}
+	return ""
+}
+
+func (x *AcceptBidRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type AcceptBidResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	CurrentPrice  float64                `protobuf:"fixed64,2,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"` // The new price if accepted, otherwise the price that beat the bid
+	Reason        BidRejectionReason     `protobuf:"varint,3,opt,name=reason,proto3,enum=proto.auction.BidRejectionReason" json:"reason,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	MinNextBid    float64                `protobuf:"fixed64,5,opt,name=min_next_bid,json=minNextBid,proto3" json:"min_next_bid,omitempty"` // Lowest amount the auction will accept next
+	Sealed        bool                   `protobuf:"varint,6,opt,name=sealed,proto3" json:"sealed,omitempty"`                              // The auction is sealed-bid: the price did not move and the amount must stay hidden until close
+	MaxNextBid    float64                `protobuf:"fixed64,7,opt,name=max_next_bid,json=maxNextBid,proto3" json:"max_next_bid,omitempty"` // Reverse auctions: highest amount the auction will accept next (min_next_bid is 0)
+	Reverse       bool                   `protobuf:"varint,8,opt,name=reverse,proto3" json:"reverse,omitempty"`                            // The auction is a reverse auction, where bids go down
+	MultiLot      bool                   `protobuf:"varint,9,opt,name=multi_lot,json=multiLot,proto3" json:"multi_lot,omitempty"`          // The auction sells several units: the price did not move and units are allocated from the bids
+	SellerId      string                 `protobuf:"bytes,10,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`          // Set when accepted, so the seller can be told about the bid
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptBidResponse) Reset() {
+	*x = AcceptBidResponse{}
+	mi := &file_auction_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptBidResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptBidResponse) ProtoMessage() {}
+
+func (x *AcceptBidResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptBidResponse.ProtoReflect.Descriptor instead.
+func (*AcceptBidResponse) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *AcceptBidResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *AcceptBidResponse) GetCurrentPrice() float64 {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *AcceptBidResponse) GetReason() BidRejectionReason {
+	if x != nil {
+		return x.Reason
+	}
+	return BidRejectionReason_BID_REJECTION_REASON_UNSPECIFIED
+}
+
+func (x *AcceptBidResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AcceptBidResponse) GetMinNextBid() float64 {
+	if x != nil {
+		return x.MinNextBid
+	}
+	return 0
+}
+
+func (x *AcceptBidResponse) GetSealed() bool {
+	if x != nil {
+		return x.Sealed
+	}
+	return false
+}
+
+func (x *AcceptBidResponse) GetMaxNextBid() float64 {
+	if x != nil {
+		return x.MaxNextBid
+	}
+	return 0
+}
+
+func (x *AcceptBidResponse) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+func (x *AcceptBidResponse) GetMultiLot() bool {
+	if x != nil {
+		return x.MultiLot
+	}
+	return false
+}
+
+func (x *AcceptBidResponse) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+type AcceptBuyNowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	BuyerId       string                 `protobuf:"bytes,2,opt,name=buyer_id,json=buyerId,proto3" json:"buyer_id,omitempty"`
+	BidId         string                 `protobuf:"bytes,3,opt,name=bid_id,json=bidId,proto3" json:"bid_id,omitempty"` // Recorded as the winning bid
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAuctionsRequest) Reset() {
-	*x = ListAuctionsRequest{}
-	mi := &file_auction_proto_msgTypes[5]
+func (x *AcceptBuyNowRequest) Reset() {
+	*x = AcceptBuyNowRequest{}
+	mi := &file_auction_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAuctionsRequest) String() string {
+func (x *AcceptBuyNowRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAuctionsRequest) ProtoMessage() {}
+func (*AcceptBuyNowRequest) ProtoMessage() {}
 
-func (x *ListAuctionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[5]
+func (x *AcceptBuyNowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -413,69 +1348,57 @@ func (x *ListAuctionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAuctionsRequest.ProtoReflect.Descriptor instead.
-func (*ListAuctionsRequest) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *ListAuctionsRequest) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
-}
-
-func (x *ListAuctionsRequest) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
+// Deprecated: Use AcceptBuyNowRequest.ProtoReflect.Descriptor instead.
+func (*AcceptBuyNowRequest) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListAuctionsRequest) GetStatus() string {
+func (x *AcceptBuyNowRequest) GetAuctionId() string {
 	if x != nil {
-		return x.Status
+		return x.AuctionId
 	}
 	return ""
 }
 
-func (x *ListAuctionsRequest) GetSellerId() string {
+func (x *AcceptBuyNowRequest) GetBuyerId() string {
 	if x != nil {
-		return x.SellerId
+		return x.BuyerId
 	}
 	return ""
 }
 
-func (x *ListAuctionsRequest) GetCategory() string {
+func (x *AcceptBuyNowRequest) GetBidId() string {
 	if x != nil {
-		return x.Category
+		return x.BidId
 	}
 	return ""
 }
 
-type ListAuctionsResponse struct {
+type AcceptBuyNowResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Auctions      []*Auction             `protobuf:"bytes,1,rep,name=auctions,proto3" json:"auctions,omitempty"`
-	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"` // The buy-now price paid
+	Reason        BidRejectionReason     `protobuf:"varint,3,opt,name=reason,proto3,enum=proto.auction.BidRejectionReason" json:"reason,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAuctionsResponse) Reset() {
-	*x = ListAuctionsResponse{}
-	mi := &file_auction_proto_msgTypes[6]
+func (x *AcceptBuyNowResponse) Reset() {
+	*x = AcceptBuyNowResponse{}
+	mi := &file_auction_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAuctionsResponse) String() string {
+func (x *AcceptBuyNowResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAuctionsResponse) ProtoMessage() {}
+func (*AcceptBuyNowResponse) ProtoMessage() {}
 
-func (x *ListAuctionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[6]
+func (x *AcceptBuyNowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -486,50 +1409,63 @@ func (x *ListAuctionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAuctionsResponse.ProtoReflect.Descriptor instead.
-func (*ListAuctionsResponse) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use AcceptBuyNowResponse.ProtoReflect.Descriptor instead.
+func (*AcceptBuyNowResponse) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ListAuctionsResponse) GetAuctions() []*Auction {
+func (x *AcceptBuyNowResponse) GetAccepted() bool {
 	if x != nil {
-		return x.Auctions
+		return x.Accepted
 	}
-	return nil
+	return false
 }
 
-func (x *ListAuctionsResponse) GetTotalCount() int64 {
+func (x *AcceptBuyNowResponse) GetPrice() float64 {
 	if x != nil {
-		return x.TotalCount
+		return x.Price
 	}
 	return 0
 }
 
-type UpdateAuctionRequest struct {
+func (x *AcceptBuyNowResponse) GetReason() BidRejectionReason {
+	if x != nil {
+		return x.Reason
+	}
+	return BidRejectionReason_BID_REJECTION_REASON_UNSPECIFIED
+}
+
+func (x *AcceptBuyNowResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type AcceptClockPriceRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	ImageUrl      string                 `protobuf:"bytes,4,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	BuyerId       string                 `protobuf:"bytes,2,opt,name=buyer_id,json=buyerId,proto3" json:"buyer_id,omitempty"`
+	BidId         string                 `protobuf:"bytes,3,opt,name=bid_id,json=bidId,proto3" json:"bid_id,omitempty"` // Recorded as the winning bid
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAuctionRequest) Reset() {
-	*x = UpdateAuctionRequest{}
-	mi := &file_auction_proto_msgTypes[7]
+func (x *AcceptClockPriceRequest) Reset() {
+	*x = AcceptClockPriceRequest{}
+	mi := &file_auction_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAuctionRequest) String() string {
+func (x *AcceptClockPriceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAuctionRequest) ProtoMessage() {}
+func (*AcceptClockPriceRequest) ProtoMessage() {}
 
-func (x *UpdateAuctionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[7]
+func (x *AcceptClockPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -540,61 +1476,57 @@ func (x *UpdateAuctionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAuctionRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAuctionRequest) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *UpdateAuctionRequest) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use AcceptClockPriceRequest.ProtoReflect.Descriptor instead.
+func (*AcceptClockPriceRequest) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *UpdateAuctionRequest) GetTitle() string {
+func (x *AcceptClockPriceRequest) GetAuctionId() string {
 	if x != nil {
-		return x.Title
+		return x.AuctionId
 	}
 	return ""
 }
 
-func (x *UpdateAuctionRequest) GetDescription() string {
+func (x *AcceptClockPriceRequest) GetBuyerId() string {
 	if x != nil {
-		return x.Description
+		return x.BuyerId
 	}
 	return ""
 }
 
-func (x *UpdateAuctionRequest) GetImageUrl() string {
+func (x *AcceptClockPriceRequest) GetBidId() string {
 	if x != nil {
-		return x.ImageUrl
+		return x.BidId
 	}
 	return ""
 }
 
-type UpdateAuctionResponse struct {
+type AcceptClockPriceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Auction       *Auction               `protobuf:"bytes,1,opt,name=auction,proto3" json:"auction,omitempty"`
+	Accepted      bool                   `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"` // The clock price paid
+	Reason        BidRejectionReason     `protobuf:"varint,3,opt,name=reason,proto3,enum=proto.auction.BidRejectionReason" json:"reason,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAuctionResponse) Reset() {
-	*x = UpdateAuctionResponse{}
-	mi := &file_auction_proto_msgTypes[8]
+func (x *AcceptClockPriceResponse) Reset() {
+	*x = AcceptClockPriceResponse{}
+	mi := &file_auction_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAuctionResponse) String() string {
+func (x *AcceptClockPriceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAuctionResponse) ProtoMessage() {}
+func (*AcceptClockPriceResponse) ProtoMessage() {}
 
-func (x *UpdateAuctionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[8]
+func (x *AcceptClockPriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -605,40 +1537,62 @@ func (x *UpdateAuctionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAuctionResponse.ProtoReflect.Descriptor instead.
-func (*UpdateAuctionResponse) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use AcceptClockPriceResponse.ProtoReflect.Descriptor instead.
+func (*AcceptClockPriceResponse) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *UpdateAuctionResponse) GetAuction() *Auction {
+func (x *AcceptClockPriceResponse) GetAccepted() bool {
 	if x != nil {
-		return x.Auction
+		return x.Accepted
 	}
-	return nil
+	return false
 }
 
-type CloseAuctionRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AcceptClockPriceResponse) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
 }
 
-func (x *CloseAuctionRequest) Reset() {
-	*x = CloseAuctionRequest{}
-	mi := &file_auction_proto_msgTypes[9]
+func (x *AcceptClockPriceResponse) GetReason() BidRejectionReason {
+	if x != nil {
+		return x.Reason
+	}
+	return BidRejectionReason_BID_REJECTION_REASON_UNSPECIFIED
+}
+
+func (x *AcceptClockPriceResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RestorePriceRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId       string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	RetractedAmount float64                `protobuf:"fixed64,2,opt,name=retracted_amount,json=retractedAmount,proto3" json:"retracted_amount,omitempty"` // Amount of the bid that was withdrawn
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RestorePriceRequest) Reset() {
+	*x = RestorePriceRequest{}
+	mi := &file_auction_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CloseAuctionRequest) String() string {
+func (x *RestorePriceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CloseAuctionRequest) ProtoMessage() {}
+func (*RestorePriceRequest) ProtoMessage() {}
 
-func (x *CloseAuctionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[9]
+func (x *RestorePriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -649,41 +1603,48 @@ func (x *CloseAuctionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CloseAuctionRequest.ProtoReflect.Descriptor instead.
-func (*CloseAuctionRequest) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use RestorePriceRequest.ProtoReflect.Descriptor instead.
+func (*RestorePriceRequest) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *CloseAuctionRequest) GetId() string {
+func (x *RestorePriceRequest) GetAuctionId() string {
 	if x != nil {
-		return x.Id
+		return x.AuctionId
 	}
 	return ""
 }
 
-type CloseAuctionResponse struct {
+func (x *RestorePriceRequest) GetRetractedAmount() float64 {
+	if x != nil {
+		return x.RetractedAmount
+	}
+	return 0
+}
+
+type RestorePriceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Restored      bool                   `protobuf:"varint,1,opt,name=restored,proto3" json:"restored,omitempty"` // Whether the price moved
+	CurrentPrice  float64                `protobuf:"fixed64,2,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CloseAuctionResponse) Reset() {
-	*x = CloseAuctionResponse{}
-	mi := &file_auction_proto_msgTypes[10]
+func (x *RestorePriceResponse) Reset() {
+	*x = RestorePriceResponse{}
+	mi := &file_auction_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CloseAuctionResponse) String() string {
+func (x *RestorePriceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CloseAuctionResponse) ProtoMessage() {}
+func (*RestorePriceResponse) ProtoMessage() {}
 
-func (x *CloseAuctionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[10]
+func (x *RestorePriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -694,48 +1655,48 @@ func (x *CloseAuctionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CloseAuctionResponse.ProtoReflect.Descriptor instead.
-func (*CloseAuctionResponse) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use RestorePriceResponse.ProtoReflect.Descriptor instead.
+func (*RestorePriceResponse) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *CloseAuctionResponse) GetSuccess() bool {
+func (x *RestorePriceResponse) GetRestored() bool {
 	if x != nil {
-		return x.Success
+		return x.Restored
 	}
 	return false
 }
 
-func (x *CloseAuctionResponse) GetMessage() string {
+func (x *RestorePriceResponse) GetCurrentPrice() float64 {
 	if x != nil {
-		return x.Message
+		return x.CurrentPrice
 	}
-	return ""
+	return 0
 }
 
-type UpdateAuctionPriceRequest struct {
+type ReleaseSealedBidRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	BidderId      string                 `protobuf:"bytes,2,opt,name=bidder_id,json=bidderId,proto3" json:"bidder_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAuctionPriceRequest) Reset() {
-	*x = UpdateAuctionPriceRequest{}
-	mi := &file_auction_proto_msgTypes[11]
+func (x *ReleaseSealedBidRequest) Reset() {
+	*x = ReleaseSealedBidRequest{}
+	mi := &file_auction_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAuctionPriceRequest) String() string {
+func (x *ReleaseSealedBidRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAuctionPriceRequest) ProtoMessage() {}
+func (*ReleaseSealedBidRequest) ProtoMessage() {}
 
-func (x *UpdateAuctionPriceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[11]
+func (x *ReleaseSealedBidRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -746,48 +1707,47 @@ func (x *UpdateAuctionPriceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAuctionPriceRequest.ProtoReflect.Descriptor instead.
-func (*UpdateAuctionPriceRequest) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ReleaseSealedBidRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseSealedBidRequest) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *UpdateAuctionPriceRequest) GetAuctionId() string {
+func (x *ReleaseSealedBidRequest) GetAuctionId() string {
 	if x != nil {
 		return x.AuctionId
 	}
 	return ""
 }
 
-func (x *UpdateAuctionPriceRequest) GetAmount() float64 {
+func (x *ReleaseSealedBidRequest) GetBidderId() string {
 	if x != nil {
-		return x.Amount
+		return x.BidderId
 	}
-	return 0
+	return ""
 }
 
-type UpdateAuctionPriceResponse struct {
+type ReleaseSealedBidResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Released      bool                   `protobuf:"varint,1,opt,name=released,proto3" json:"released,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateAuctionPriceResponse) Reset() {
-	*x = UpdateAuctionPriceResponse{}
-	mi := &file_auction_proto_msgTypes[12]
+func (x *ReleaseSealedBidResponse) Reset() {
+	*x = ReleaseSealedBidResponse{}
+	mi := &file_auction_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateAuctionPriceResponse) String() string {
+func (x *ReleaseSealedBidResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateAuctionPriceResponse) ProtoMessage() {}
+func (*ReleaseSealedBidResponse) ProtoMessage() {}
 
-func (x *UpdateAuctionPriceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[12]
+func (x *ReleaseSealedBidResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auction_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -798,25 +1758,18 @@ func (x *UpdateAuctionPriceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateAuctionPriceResponse.ProtoReflect.Descriptor instead.
-func (*UpdateAuctionPriceResponse) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ReleaseSealedBidResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseSealedBidResponse) Descriptor() ([]byte, []int) {
+	return file_auction_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *UpdateAuctionPriceResponse) GetSuccess() bool {
+func (x *ReleaseSealedBidResponse) GetReleased() bool {
 	if x != nil {
-		return x.Success
+		return x.Released
 	}
 	return false
 }
 
-func (x *UpdateAuctionPriceResponse) GetMessage() string {
-	if x != nil {
-		return x.Message
-	}
-	return ""
-}
-
 // Existing messages
 type BidRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -829,7 +1782,7 @@ type BidRequest struct {
 
 func (x *BidRequest) Reset() {
 	*x = BidRequest{}
-	mi := &file_auction_proto_msgTypes[13]
+	mi := &file_auction_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -841,7 +1794,7 @@ func (x *BidRequest) String() string {
 func (*BidRequest) ProtoMessage() {}
 
 func (x *BidRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[13]
+	mi := &file_auction_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -854,7 +1807,7 @@ func (x *BidRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BidRequest.ProtoReflect.Descriptor instead.
 func (*BidRequest) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{13}
+	return file_auction_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *BidRequest) GetAuctionId() string {
@@ -889,7 +1842,7 @@ type BidResponse struct {
 
 func (x *BidResponse) Reset() {
 	*x = BidResponse{}
-	mi := &file_auction_proto_msgTypes[14]
+	mi := &file_auction_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -901,7 +1854,7 @@ func (x *BidResponse) String() string {
 func (*BidResponse) ProtoMessage() {}
 
 func (x *BidResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[14]
+	mi := &file_auction_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -914,7 +1867,7 @@ func (x *BidResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BidResponse.ProtoReflect.Descriptor instead.
 func (*BidResponse) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{14}
+	return file_auction_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *BidResponse) GetIsValid() bool {
@@ -947,7 +1900,7 @@ type StatusRequest struct {
 
 func (x *StatusRequest) Reset() {
 	*x = StatusRequest{}
-	mi := &file_auction_proto_msgTypes[15]
+	mi := &file_auction_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -959,7 +1912,7 @@ func (x *StatusRequest) String() string {
 func (*StatusRequest) ProtoMessage() {}
 
 func (x *StatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[15]
+	mi := &file_auction_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -972,7 +1925,7 @@ func (x *StatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
 func (*StatusRequest) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{15}
+	return file_auction_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *StatusRequest) GetAuctionId() string {
@@ -983,19 +1936,26 @@ func (x *StatusRequest) GetAuctionId() string {
 }
 
 type StatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	CurrentPrice  float64                `protobuf:"fixed64,3,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	EndTimeUnix   int64                  `protobuf:"varint,5,opt,name=end_time_unix,json=endTimeUnix,proto3" json:"end_time_unix,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId       string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	Title           string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	CurrentPrice    float64                `protobuf:"fixed64,3,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
+	Status          string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	EndTimeUnix     int64                  `protobuf:"varint,5,opt,name=end_time_unix,json=endTimeUnix,proto3" json:"end_time_unix,omitempty"`
+	HasReserve      bool                   `protobuf:"varint,6,opt,name=has_reserve,json=hasReserve,proto3" json:"has_reserve,omitempty"`
+	ReserveMet      bool                   `protobuf:"varint,7,opt,name=reserve_met,json=reserveMet,proto3" json:"reserve_met,omitempty"` // Whether the current price has reached the reserve; the amount itself is never exposed
+	BuyNowAvailable bool                   `protobuf:"varint,8,opt,name=buy_now_available,json=buyNowAvailable,proto3" json:"buy_now_available,omitempty"`
+	AuctionType     string                 `protobuf:"bytes,9,opt,name=auction_type,json=auctionType,proto3" json:"auction_type,omitempty"`
+	NextTickUnix    int64                  `protobuf:"varint,10,opt,name=next_tick_unix,json=nextTickUnix,proto3" json:"next_tick_unix,omitempty"` // Dutch auctions: when current_price, the clock price, next drops; 0 once it stops
+	Quantity        int32                  `protobuf:"varint,11,opt,name=quantity,proto3" json:"quantity,omitempty"`                               // Units on sale; above 1 for multi-lot auctions
+	SellerId        string                 `protobuf:"bytes,12,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *StatusResponse) Reset() {
 	*x = StatusResponse{}
-	mi := &file_auction_proto_msgTypes[16]
+	mi := &file_auction_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1007,7 +1967,7 @@ func (x *StatusResponse) String() string {
 func (*StatusResponse) ProtoMessage() {}
 
 func (x *StatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auction_proto_msgTypes[16]
+	mi := &file_auction_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1020,7 +1980,7 @@ func (x *StatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
 func (*StatusResponse) Descriptor() ([]byte, []int) {
-	return file_auction_proto_rawDescGZIP(), []int{16}
+	return file_auction_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *StatusResponse) GetAuctionId() string {
@@ -1058,11 +2018,60 @@ func (x *StatusResponse) GetEndTimeUnix() int64 {
 	return 0
 }
 
+func (x *StatusResponse) GetHasReserve() bool {
+	if x != nil {
+		return x.HasReserve
+	}
+	return false
+}
+
+func (x *StatusResponse) GetReserveMet() bool {
+	if x != nil {
+		return x.ReserveMet
+	}
+	return false
+}
+
+func (x *StatusResponse) GetBuyNowAvailable() bool {
+	if x != nil {
+		return x.BuyNowAvailable
+	}
+	return false
+}
+
+func (x *StatusResponse) GetAuctionType() string {
+	if x != nil {
+		return x.AuctionType
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetNextTickUnix() int64 {
+	if x != nil {
+		return x.NextTickUnix
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
 var File_auction_proto protoreflect.FileDescriptor
 
 const file_auction_proto_rawDesc = "" +
 	"\n" +
-	"\rauction.proto\x12\rproto.auction\"\xbf\x02\n" +
+	"\rauction.proto\x12\rproto.auction\"\x8e\a\n" +
 	"\aAuction\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tseller_id\x18\x02 \x01(\tR\bsellerId\x12\x14\n" +
@@ -1077,7 +2086,34 @@ const file_auction_proto_rawDesc = "" +
 	"\bend_time\x18\t \x01(\x03R\aendTime\x12\x1a\n" +
 	"\bcategory\x18\n" +
 	" \x01(\tR\bcategory\x12\x1b\n" +
-	"\timage_url\x18\v \x01(\tR\bimageUrl\"\xff\x01\n" +
+	"\timage_url\x18\v \x01(\tR\bimageUrl\x12\x1b\n" +
+	"\twinner_id\x18\f \x01(\tR\bwinnerId\x12$\n" +
+	"\x0ewinning_bid_id\x18\r \x01(\tR\fwinningBidId\x12A\n" +
+	"\rmin_increment\x18\x0e \x03(\v2\x1c.proto.auction.IncrementTierR\fminIncrement\x12)\n" +
+	"\x10extension_window\x18\x0f \x01(\x03R\x0fextensionWindow\x12-\n" +
+	"\x12extension_duration\x18\x10 \x01(\x03R\x11extensionDuration\x12%\n" +
+	"\x0emax_extensions\x18\x11 \x01(\x05R\rmaxExtensions\x12'\n" +
+	"\x0fextension_count\x18\x12 \x01(\x05R\x0eextensionCount\x12\"\n" +
+	"\rbuy_now_price\x18\x13 \x01(\x01R\vbuyNowPrice\x12!\n" +
+	"\fauction_type\x18\x14 \x01(\tR\vauctionType\x12\x1d\n" +
+	"\n" +
+	"clock_step\x18\x15 \x01(\x01R\tclockStep\x12%\n" +
+	"\x0eclock_interval\x18\x16 \x01(\x03R\rclockInterval\x12\x1f\n" +
+	"\vfloor_price\x18\x17 \x01(\x01R\n" +
+	"floorPrice\x12\x1a\n" +
+	"\bquantity\x18\x18 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vlot_pricing\x18\x19 \x01(\tR\n" +
+	"lotPricing\x122\n" +
+	"\awinners\x18\x1a \x03(\v2\x18.proto.auction.LotWinnerR\awinners\"k\n" +
+	"\tLotWinner\x12\x15\n" +
+	"\x06bid_id\x18\x01 \x01(\tR\x05bidId\x12\x1b\n" +
+	"\tbidder_id\x18\x02 \x01(\tR\bbidderId\x12\x14\n" +
+	"\x05units\x18\x03 \x01(\x05R\x05units\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\"^\n" +
+	"\rIncrementTier\x12\x1b\n" +
+	"\tmin_price\x18\x01 \x01(\x01R\bminPrice\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x18\n" +
+	"\apercent\x18\x03 \x01(\x01R\apercent\"\xd3\x05\n" +
 	"\x14CreateAuctionRequest\x12\x1b\n" +
 	"\tseller_id\x18\x01 \x01(\tR\bsellerId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -1088,7 +2124,23 @@ const file_auction_proto_rawDesc = "" +
 	"start_time\x18\x05 \x01(\x03R\tstartTime\x12\x19\n" +
 	"\bend_time\x18\x06 \x01(\x03R\aendTime\x12\x1a\n" +
 	"\bcategory\x18\a \x01(\tR\bcategory\x12\x1b\n" +
-	"\timage_url\x18\b \x01(\tR\bimageUrl\"I\n" +
+	"\timage_url\x18\b \x01(\tR\bimageUrl\x12A\n" +
+	"\rmin_increment\x18\t \x03(\v2\x1c.proto.auction.IncrementTierR\fminIncrement\x12#\n" +
+	"\rreserve_price\x18\n" +
+	" \x01(\x01R\freservePrice\x12)\n" +
+	"\x10extension_window\x18\v \x01(\x03R\x0fextensionWindow\x12-\n" +
+	"\x12extension_duration\x18\f \x01(\x03R\x11extensionDuration\x12%\n" +
+	"\x0emax_extensions\x18\r \x01(\x05R\rmaxExtensions\x12\"\n" +
+	"\rbuy_now_price\x18\x0e \x01(\x01R\vbuyNowPrice\x12!\n" +
+	"\fauction_type\x18\x0f \x01(\tR\vauctionType\x12\x1d\n" +
+	"\n" +
+	"clock_step\x18\x10 \x01(\x01R\tclockStep\x12%\n" +
+	"\x0eclock_interval\x18\x11 \x01(\x03R\rclockInterval\x12\x1f\n" +
+	"\vfloor_price\x18\x12 \x01(\x01R\n" +
+	"floorPrice\x12\x1a\n" +
+	"\bquantity\x18\x13 \x01(\x05R\bquantity\x12\x1f\n" +
+	"\vlot_pricing\x18\x14 \x01(\tR\n" +
+	"lotPricing\"I\n" +
 	"\x15CreateAuctionResponse\x120\n" +
 	"\aauction\x18\x01 \x01(\v2\x16.proto.auction.AuctionR\aauction\"#\n" +
 	"\x11GetAuctionRequest\x12\x0e\n" +
@@ -1116,14 +2168,60 @@ const file_auction_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"J\n" +
 	"\x14CloseAuctionResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"R\n" +
-	"\x19UpdateAuctionPriceRequest\x12\x1d\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x82\x01\n" +
+	"\x10AcceptBidRequest\x12\x1d\n" +
 	"\n" +
 	"auction_id\x18\x01 \x01(\tR\tauctionId\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x01R\x06amount\"P\n" +
-	"\x1aUpdateAuctionPriceResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"`\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1b\n" +
+	"\tbidder_id\x18\x03 \x01(\tR\bbidderId\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\"\xd9\x02\n" +
+	"\x11AcceptBidResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12#\n" +
+	"\rcurrent_price\x18\x02 \x01(\x01R\fcurrentPrice\x129\n" +
+	"\x06reason\x18\x03 \x01(\x0e2!.proto.auction.BidRejectionReasonR\x06reason\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12 \n" +
+	"\fmin_next_bid\x18\x05 \x01(\x01R\n" +
+	"minNextBid\x12\x16\n" +
+	"\x06sealed\x18\x06 \x01(\bR\x06sealed\x12 \n" +
+	"\fmax_next_bid\x18\a \x01(\x01R\n" +
+	"maxNextBid\x12\x18\n" +
+	"\areverse\x18\b \x01(\bR\areverse\x12\x1b\n" +
+	"\tmulti_lot\x18\t \x01(\bR\bmultiLot\x12\x1b\n" +
+	"\tseller_id\x18\n" +
+	" \x01(\tR\bsellerId\"f\n" +
+	"\x13AcceptBuyNowRequest\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\x12\x19\n" +
+	"\bbuyer_id\x18\x02 \x01(\tR\abuyerId\x12\x15\n" +
+	"\x06bid_id\x18\x03 \x01(\tR\x05bidId\"\x9d\x01\n" +
+	"\x14AcceptBuyNowResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x129\n" +
+	"\x06reason\x18\x03 \x01(\x0e2!.proto.auction.BidRejectionReasonR\x06reason\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"j\n" +
+	"\x17AcceptClockPriceRequest\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\x12\x19\n" +
+	"\bbuyer_id\x18\x02 \x01(\tR\abuyerId\x12\x15\n" +
+	"\x06bid_id\x18\x03 \x01(\tR\x05bidId\"\xa1\x01\n" +
+	"\x18AcceptClockPriceResponse\x12\x1a\n" +
+	"\baccepted\x18\x01 \x01(\bR\baccepted\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x129\n" +
+	"\x06reason\x18\x03 \x01(\x0e2!.proto.auction.BidRejectionReasonR\x06reason\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"_\n" +
+	"\x13RestorePriceRequest\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\x12)\n" +
+	"\x10retracted_amount\x18\x02 \x01(\x01R\x0fretractedAmount\"W\n" +
+	"\x14RestorePriceResponse\x12\x1a\n" +
+	"\brestored\x18\x01 \x01(\bR\brestored\x12#\n" +
+	"\rcurrent_price\x18\x02 \x01(\x01R\fcurrentPrice\"U\n" +
+	"\x17ReleaseSealedBidRequest\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\x12\x1b\n" +
+	"\tbidder_id\x18\x02 \x01(\tR\bbidderId\"6\n" +
+	"\x18ReleaseSealedBidResponse\x12\x1a\n" +
+	"\breleased\x18\x01 \x01(\bR\breleased\"`\n" +
 	"\n" +
 	"BidRequest\x12\x1d\n" +
 	"\n" +
@@ -1136,14 +2234,35 @@ const file_auction_proto_rawDesc = "" +
 	"\amessage\x18\x03 \x01(\tR\amessage\".\n" +
 	"\rStatusRequest\x12\x1d\n" +
 	"\n" +
-	"auction_id\x18\x01 \x01(\tR\tauctionId\"\xa6\x01\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\"\x96\x03\n" +
 	"\x0eStatusResponse\x12\x1d\n" +
 	"\n" +
 	"auction_id\x18\x01 \x01(\tR\tauctionId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12#\n" +
 	"\rcurrent_price\x18\x03 \x01(\x01R\fcurrentPrice\x12\x16\n" +
 	"\x06status\x18\x04 \x01(\tR\x06status\x12\"\n" +
-	"\rend_time_unix\x18\x05 \x01(\x03R\vendTimeUnix2\xcf\x05\n" +
+	"\rend_time_unix\x18\x05 \x01(\x03R\vendTimeUnix\x12\x1f\n" +
+	"\vhas_reserve\x18\x06 \x01(\bR\n" +
+	"hasReserve\x12\x1f\n" +
+	"\vreserve_met\x18\a \x01(\bR\n" +
+	"reserveMet\x12*\n" +
+	"\x11buy_now_available\x18\b \x01(\bR\x0fbuyNowAvailable\x12!\n" +
+	"\fauction_type\x18\t \x01(\tR\vauctionType\x12$\n" +
+	"\x0enext_tick_unix\x18\n" +
+	" \x01(\x03R\fnextTickUnix\x12\x1a\n" +
+	"\bquantity\x18\v \x01(\x05R\bquantity\x12\x1b\n" +
+	"\tseller_id\x18\f \x01(\tR\bsellerId*\xf7\x01\n" +
+	"\x12BidRejectionReason\x12$\n" +
+	" BID_REJECTION_REASON_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11AUCTION_NOT_FOUND\x10\x01\x12\x16\n" +
+	"\x12AUCTION_NOT_ACTIVE\x10\x02\x12\x11\n" +
+	"\rAUCTION_ENDED\x10\x03\x12\x0f\n" +
+	"\vBID_TOO_LOW\x10\x04\x12\x17\n" +
+	"\x13BUY_NOW_UNAVAILABLE\x10\x05\x12\x0f\n" +
+	"\vALREADY_BID\x10\x06\x12\x10\n" +
+	"\fBID_TOO_HIGH\x10\a\x12\x16\n" +
+	"\x12WRONG_AUCTION_TYPE\x10\b\x12\x14\n" +
+	"\x10INVALID_QUANTITY\x10\t2\xb0\b\n" +
 	"\x0eAuctionService\x12D\n" +
 	"\vValidateBid\x12\x19.proto.auction.BidRequest\x1a\x1a.proto.auction.BidResponse\x12O\n" +
 	"\x10GetAuctionStatus\x12\x1c.proto.auction.StatusRequest\x1a\x1d.proto.auction.StatusResponse\x12Z\n" +
@@ -1152,8 +2271,12 @@ const file_auction_proto_rawDesc = "" +
 	"GetAuction\x12 .proto.auction.GetAuctionRequest\x1a!.proto.auction.GetAuctionResponse\x12W\n" +
 	"\fListAuctions\x12\".proto.auction.ListAuctionsRequest\x1a#.proto.auction.ListAuctionsResponse\x12Z\n" +
 	"\rUpdateAuction\x12#.proto.auction.UpdateAuctionRequest\x1a$.proto.auction.UpdateAuctionResponse\x12W\n" +
-	"\fCloseAuction\x12\".proto.auction.CloseAuctionRequest\x1a#.proto.auction.CloseAuctionResponse\x12i\n" +
-	"\x12UpdateAuctionPrice\x12(.proto.auction.UpdateAuctionPriceRequest\x1a).proto.auction.UpdateAuctionPriceResponseB8Z6github.com/temesgen-abebayehu/bidflow/backend/proto/pbb\x06proto3"
+	"\fCloseAuction\x12\".proto.auction.CloseAuctionRequest\x1a#.proto.auction.CloseAuctionResponse\x12N\n" +
+	"\tAcceptBid\x12\x1f.proto.auction.AcceptBidRequest\x1a .proto.auction.AcceptBidResponse\x12W\n" +
+	"\fAcceptBuyNow\x12\".proto.auction.AcceptBuyNowRequest\x1a#.proto.auction.AcceptBuyNowResponse\x12c\n" +
+	"\x10AcceptClockPrice\x12&.proto.auction.AcceptClockPriceRequest\x1a'.proto.auction.AcceptClockPriceResponse\x12W\n" +
+	"\fRestorePrice\x12\".proto.auction.RestorePriceRequest\x1a#.proto.auction.RestorePriceResponse\x12c\n" +
+	"\x10ReleaseSealedBid\x12&.proto.auction.ReleaseSealedBidRequest\x1a'.proto.auction.ReleaseSealedBidResponseB8Z6github.com/temesgen-abebayehu/bidflow/backend/proto/pbb\x06proto3"
 
 var (
 	file_auction_proto_rawDescOnce sync.Once
@@ -1167,52 +2290,78 @@ func file_auction_proto_rawDescGZIP() []byte {
 	return file_auction_proto_rawDescData
 }
 
-var file_auction_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_auction_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_auction_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_auction_proto_goTypes = []any{
-	(*Auction)(nil),                    // 0: proto.auction.Auction
-	(*CreateAuctionRequest)(nil),       // 1: proto.auction.CreateAuctionRequest
-	(*CreateAuctionResponse)(nil),      // 2: proto.auction.CreateAuctionResponse
-	(*GetAuctionRequest)(nil),          // 3: proto.auction.GetAuctionRequest
-	(*GetAuctionResponse)(nil),         // 4: proto.auction.GetAuctionResponse
-	(*ListAuctionsRequest)(nil),        // 5: proto.auction.ListAuctionsRequest
-	(*ListAuctionsResponse)(nil),       // 6: proto.auction.ListAuctionsResponse
-	(*UpdateAuctionRequest)(nil),       // 7: proto.auction.UpdateAuctionRequest
-	(*UpdateAuctionResponse)(nil),      // 8: proto.auction.UpdateAuctionResponse
-	(*CloseAuctionRequest)(nil),        // 9: proto.auction.CloseAuctionRequest
-	(*CloseAuctionResponse)(nil),       // 10: proto.auction.CloseAuctionResponse
-	(*UpdateAuctionPriceRequest)(nil),  // 11: proto.auction.UpdateAuctionPriceRequest
-	(*UpdateAuctionPriceResponse)(nil), // 12: proto.auction.UpdateAuctionPriceResponse
-	(*BidRequest)(nil),                 // 13: proto.auction.BidRequest
-	(*BidResponse)(nil),                // 14: proto.auction.BidResponse
-	(*StatusRequest)(nil),              // 15: proto.auction.StatusRequest
-	(*StatusResponse)(nil),             // 16: proto.auction.StatusResponse
+	(BidRejectionReason)(0),          // 0: proto.auction.BidRejectionReason
+	(*Auction)(nil),                  // 1: proto.auction.Auction
+	(*LotWinner)(nil),                // 2: proto.auction.LotWinner
+	(*IncrementTier)(nil),            // 3: proto.auction.IncrementTier
+	(*CreateAuctionRequest)(nil),     // 4: proto.auction.CreateAuctionRequest
+	(*CreateAuctionResponse)(nil),    // 5: proto.auction.CreateAuctionResponse
+	(*GetAuctionRequest)(nil),        // 6: proto.auction.GetAuctionRequest
+	(*GetAuctionResponse)(nil),       // 7: proto.auction.GetAuctionResponse
+	(*ListAuctionsRequest)(nil),      // 8: proto.auction.ListAuctionsRequest
+	(*ListAuctionsResponse)(nil),     // 9: proto.auction.ListAuctionsResponse
+	(*UpdateAuctionRequest)(nil),     // 10: proto.auction.UpdateAuctionRequest
+	(*UpdateAuctionResponse)(nil),    // 11: proto.auction.UpdateAuctionResponse
+	(*CloseAuctionRequest)(nil),      // 12: proto.auction.CloseAuctionRequest
+	(*CloseAuctionResponse)(nil),     // 13: proto.auction.CloseAuctionResponse
+	(*AcceptBidRequest)(nil),         // 14: proto.auction.AcceptBidRequest
+	(*AcceptBidResponse)(nil),        // 15: proto.auction.AcceptBidResponse
+	(*AcceptBuyNowRequest)(nil),      // 16: proto.auction.AcceptBuyNowRequest
+	(*AcceptBuyNowResponse)(nil),     // 17: proto.auction.AcceptBuyNowResponse
+	(*AcceptClockPriceRequest)(nil),  // 18: proto.auction.AcceptClockPriceRequest
+	(*AcceptClockPriceResponse)(nil), // 19: proto.auction.AcceptClockPriceResponse
+	(*RestorePriceRequest)(nil),      // 20: proto.auction.RestorePriceRequest
+	(*RestorePriceResponse)(nil),     // 21: proto.auction.RestorePriceResponse
+	(*ReleaseSealedBidRequest)(nil),  // 22: proto.auction.ReleaseSealedBidRequest
+	(*ReleaseSealedBidResponse)(nil), // 23: proto.auction.ReleaseSealedBidResponse
+	(*BidRequest)(nil),               // 24: proto.auction.BidRequest
+	(*BidResponse)(nil),              // 25: proto.auction.BidResponse
+	(*StatusRequest)(nil),            // 26: proto.auction.StatusRequest
+	(*StatusResponse)(nil),           // 27: proto.auction.StatusResponse
 }
 var file_auction_proto_depIdxs = []int32{
-	0,  // 0: proto.auction.CreateAuctionResponse.auction:type_name -> proto.auction.Auction
-	0,  // 1: proto.auction.GetAuctionResponse.auction:type_name -> proto.auction.Auction
-	0,  // 2: proto.auction.ListAuctionsResponse.auctions:type_name -> proto.auction.Auction
-	0,  // 3: proto.auction.UpdateAuctionResponse.auction:type_name -> proto.auction.Auction
-	13, // 4: proto.auction.AuctionService.ValidateBid:input_type -> proto.auction.BidRequest
-	15, // 5: proto.auction.AuctionService.GetAuctionStatus:input_type -> proto.auction.StatusRequest
-	1,  // 6: proto.auction.AuctionService.CreateAuction:input_type -> proto.auction.CreateAuctionRequest
-	3,  // 7: proto.auction.AuctionService.GetAuction:input_type -> proto.auction.GetAuctionRequest
-	5,  // 8: proto.auction.AuctionService.ListAuctions:input_type -> proto.auction.ListAuctionsRequest
-	7,  // 9: proto.auction.AuctionService.UpdateAuction:input_type -> proto.auction.UpdateAuctionRequest
-	9,  // 10: proto.auction.AuctionService.CloseAuction:input_type -> proto.auction.CloseAuctionRequest
-	11, // 11: proto.auction.AuctionService.UpdateAuctionPrice:input_type -> proto.auction.UpdateAuctionPriceRequest
-	14, // 12: proto.auction.AuctionService.ValidateBid:output_type -> proto.auction.BidResponse
-	16, // 13: proto.auction.AuctionService.GetAuctionStatus:output_type -> proto.auction.StatusResponse
-	2,  // 14: proto.auction.AuctionService.CreateAuction:output_type -> proto.auction.CreateAuctionResponse
-	4,  // 15: proto.auction.AuctionService.GetAuction:output_type -> proto.auction.GetAuctionResponse
-	6,  // 16: proto.auction.AuctionService.ListAuctions:output_type -> proto.auction.ListAuctionsResponse
-	8,  // 17: proto.auction.AuctionService.UpdateAuction:output_type -> proto.auction.UpdateAuctionResponse
-	10, // 18: proto.auction.AuctionService.CloseAuction:output_type -> proto.auction.CloseAuctionResponse
-	12, // 19: proto.auction.AuctionService.UpdateAuctionPrice:output_type -> proto.auction.UpdateAuctionPriceResponse
-	12, // [12:20] is the sub-list for method output_type
-	4,  // [4:12] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	3,  // 0: proto.auction.Auction.min_increment:type_name -> proto.auction.IncrementTier
+	2,  // 1: proto.auction.Auction.winners:type_name -> proto.auction.LotWinner
+	3,  // 2: proto.auction.CreateAuctionRequest.min_increment:type_name -> proto.auction.IncrementTier
+	1,  // 3: proto.auction.CreateAuctionResponse.auction:type_name -> proto.auction.Auction
+	1,  // 4: proto.auction.GetAuctionResponse.auction:type_name -> proto.auction.Auction
+	1,  // 5: proto.auction.ListAuctionsResponse.auctions:type_name -> proto.auction.Auction
+	1,  // 6: proto.auction.UpdateAuctionResponse.auction:type_name -> proto.auction.Auction
+	0,  // 7: proto.auction.AcceptBidResponse.reason:type_name -> proto.auction.BidRejectionReason
+	0,  // 8: proto.auction.AcceptBuyNowResponse.reason:type_name -> proto.auction.BidRejectionReason
+	0,  // 9: proto.auction.AcceptClockPriceResponse.reason:type_name -> proto.auction.BidRejectionReason
+	24, // 10: proto.auction.AuctionService.ValidateBid:input_type -> proto.auction.BidRequest
+	26, // 11: proto.auction.AuctionService.GetAuctionStatus:input_type -> proto.auction.StatusRequest
+	4,  // 12: proto.auction.AuctionService.CreateAuction:input_type -> proto.auction.CreateAuctionRequest
+	6,  // 13: proto.auction.AuctionService.GetAuction:input_type -> proto.auction.GetAuctionRequest
+	8,  // 14: proto.auction.AuctionService.ListAuctions:input_type -> proto.auction.ListAuctionsRequest
+	10, // 15: proto.auction.AuctionService.UpdateAuction:input_type -> proto.auction.UpdateAuctionRequest
+	12, // 16: proto.auction.AuctionService.CloseAuction:input_type -> proto.auction.CloseAuctionRequest
+	14, // 17: proto.auction.AuctionService.AcceptBid:input_type -> proto.auction.AcceptBidRequest
+	16, // 18: proto.auction.AuctionService.AcceptBuyNow:input_type -> proto.auction.AcceptBuyNowRequest
+	18, // 19: proto.auction.AuctionService.AcceptClockPrice:input_type -> proto.auction.AcceptClockPriceRequest
+	20, // 20: proto.auction.AuctionService.RestorePrice:input_type -> proto.auction.RestorePriceRequest
+	22, // 21: proto.auction.AuctionService.ReleaseSealedBid:input_type -> proto.auction.ReleaseSealedBidRequest
+	25, // 22: proto.auction.AuctionService.ValidateBid:output_type -> proto.auction.BidResponse
+	27, // 23: proto.auction.AuctionService.GetAuctionStatus:output_type -> proto.auction.StatusResponse
+	5,  // 24: proto.auction.AuctionService.CreateAuction:output_type -> proto.auction.CreateAuctionResponse
+	7,  // 25: proto.auction.AuctionService.GetAuction:output_type -> proto.auction.GetAuctionResponse
+	9,  // 26: proto.auction.AuctionService.ListAuctions:output_type -> proto.auction.ListAuctionsResponse
+	11, // 27: proto.auction.AuctionService.UpdateAuction:output_type -> proto.auction.UpdateAuctionResponse
+	13, // 28: proto.auction.AuctionService.CloseAuction:output_type -> proto.auction.CloseAuctionResponse
+	15, // 29: proto.auction.AuctionService.AcceptBid:output_type -> proto.auction.AcceptBidResponse
+	17, // 30: proto.auction.AuctionService.AcceptBuyNow:output_type -> proto.auction.AcceptBuyNowResponse
+	19, // 31: proto.auction.AuctionService.AcceptClockPrice:output_type -> proto.auction.AcceptClockPriceResponse
+	21, // 32: proto.auction.AuctionService.RestorePrice:output_type -> proto.auction.RestorePriceResponse
+	23, // 33: proto.auction.AuctionService.ReleaseSealedBid:output_type -> proto.auction.ReleaseSealedBidResponse
+	22, // [22:34] is the sub-list for method output_type
+	10, // [10:22] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_auction_proto_init() }
@@ -1225,13 +2374,14 @@ func file_auction_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auction_proto_rawDesc), len(file_auction_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   17,
+			NumEnums:      1,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_auction_proto_goTypes,
 		DependencyIndexes: file_auction_proto_depIdxs,
+		EnumInfos:         file_auction_proto_enumTypes,
 		MessageInfos:      file_auction_proto_msgTypes,
 	}.Build()
 	File_auction_proto = out.File
@@ -19,14 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuctionService_ValidateBid_FullMethodName        = "/proto.auction.AuctionService/ValidateBid"
-	AuctionService_GetAuctionStatus_FullMethodName   = "/proto.auction.AuctionService/GetAuctionStatus"
-	AuctionService_CreateAuction_FullMethodName      = "/proto.auction.AuctionService/CreateAuction"
-	AuctionService_GetAuction_FullMethodName         = "/proto.auction.AuctionService/GetAuction"
-	AuctionService_ListAuctions_FullMethodName       = "/proto.auction.AuctionService/ListAuctions"
-	AuctionService_UpdateAuction_FullMethodName      = "/proto.auction.AuctionService/UpdateAuction"
-	AuctionService_CloseAuction_FullMethodName       = "/proto.auction.AuctionService/CloseAuction"
-	AuctionService_UpdateAuctionPrice_FullMethodName = "/proto.auction.AuctionService/UpdateAuctionPrice"
+	AuctionService_ValidateBid_FullMethodName      = "/proto.auction.AuctionService/ValidateBid"
+	AuctionService_GetAuctionStatus_FullMethodName = "/proto.auction.AuctionService/GetAuctionStatus"
+	AuctionService_CreateAuction_FullMethodName    = "/proto.auction.AuctionService/CreateAuction"
+	AuctionService_GetAuction_FullMethodName       = "/proto.auction.AuctionService/GetAuction"
+	AuctionService_ListAuctions_FullMethodName     = "/proto.auction.AuctionService/ListAuctions"
+	AuctionService_UpdateAuction_FullMethodName    = "/proto.auction.AuctionService/UpdateAuction"
+	AuctionService_CloseAuction_FullMethodName     = "/proto.auction.AuctionService/CloseAuction"
+	AuctionService_AcceptBid_FullMethodName        = "/proto.auction.AuctionService/AcceptBid"
+	AuctionService_AcceptBuyNow_FullMethodName     = "/proto.auction.AuctionService/AcceptBuyNow"
+	AuctionService_AcceptClockPrice_FullMethodName = "/proto.auction.AuctionService/AcceptClockPrice"
+	AuctionService_RestorePrice_FullMethodName     = "/proto.auction.AuctionService/RestorePrice"
+	AuctionService_ReleaseSealedBid_FullMethodName = "/proto.auction.AuctionService/ReleaseSealedBid"
 )
 
 // AuctionServiceClient is the client API for AuctionService service.
@@ -85,9 +89,53 @@ type AuctionServiceClient interface {
 	// @return CloseAuctionResponse The final state of the closed auction.
 	CloseAuction(ctx context.Context, in *CloseAuctionRequest, opts ...grpc.CallOption) (*CloseAuctionResponse, error)
 	// *
-	// Updates the current price of an auction.
-	// This is typically called by the Bidding Service after a successful bid.
-	UpdateAuctionPrice(ctx context.Context, in *UpdateAuctionPriceRequest, opts ...grpc.CallOption) (*UpdateAuctionPriceResponse, error)
+	// Atomically validates a bid and raises the current price to its amount.
+	// The price only moves if the auction is active, has not ended and the amount is
+	// higher than the current price at the moment of the update, so concurrent bids
+	// can never overwrite a higher price with a lower one.
+	// Sealed-bid auctions instead accept one bid per bidder of at least the start
+	// price and leave the price untouched until close. Reverse auctions lower the
+	// price instead, to amounts at least one decrement under it. Multi-lot auctions
+	// accept any bid of at least the start price for up to their quantity of units;
+	// the units are allocated to the highest bids at close.
+	//
+	// @param AcceptBidRequest The bid to apply.
+	// @return AcceptBidResponse The new price, or the reason the bid was rejected.
+	AcceptBid(ctx context.Context, in *AcceptBidRequest, opts ...grpc.CallOption) (*AcceptBidResponse, error)
+	// *
+	// Atomically closes an auction at its buy-now price with the buyer as winner.
+	// Only one buyer can succeed; buy-now is unavailable once the current price has
+	// reached the service's cutoff fraction of the buy-now price.
+	//
+	// @param AcceptBuyNowRequest The buyer and the id of the bid recorded for the purchase.
+	// @return AcceptBuyNowResponse The price paid, or the reason the purchase was rejected.
+	AcceptBuyNow(ctx context.Context, in *AcceptBuyNowRequest, opts ...grpc.CallOption) (*AcceptBuyNowResponse, error)
+	// *
+	// Atomically closes a Dutch auction at its current clock price with the buyer as
+	// winner. The first acceptance wins; later ones find the auction closed.
+	//
+	// @param AcceptClockPriceRequest The buyer and the id of the bid recorded for the purchase.
+	// @return AcceptClockPriceResponse The clock price paid, or the reason the acceptance was rejected.
+	AcceptClockPrice(ctx context.Context, in *AcceptClockPriceRequest, opts ...grpc.CallOption) (*AcceptClockPriceResponse, error)
+	// *
+	// Recomputes the current price after a bid was retracted or cancelled.
+	// If the withdrawn bid set the price, the price falls back to the leading live bid
+	// reported by the Bidding Service's GetHighestBid (the lowest bid in a reverse
+	// auction), or to the start price if none is left. The update is a compare-and-set
+	// on retracted_amount, so a bid that raised the price in the meantime is left alone.
+	// Sealed-bid and multi-lot auctions have no running price and are never changed.
+	//
+	// @param RestorePriceRequest The auction and the amount of the withdrawn bid.
+	// @return RestorePriceResponse The current price, and whether it moved.
+	RestorePrice(ctx context.Context, in *RestorePriceRequest, opts ...grpc.CallOption) (*RestorePriceResponse, error)
+	// *
+	// Forgets that a bidder bid on a sealed auction, so they can bid again. The Bidding
+	// Service calls it when it could not record a sealed bid that AcceptBid accepted.
+	// Only an auction that is still active is changed.
+	//
+	// @param ReleaseSealedBidRequest The auction and the bidder.
+	// @return ReleaseSealedBidResponse Whether the bidder was released.
+	ReleaseSealedBid(ctx context.Context, in *ReleaseSealedBidRequest, opts ...grpc.CallOption) (*ReleaseSealedBidResponse, error)
 }
 
 type auctionServiceClient struct {
@@ -168,10 +216,50 @@ func (c *auctionServiceClient) CloseAuction(ctx context.Context, in *CloseAuctio
 	return out, nil
 }
 
-func (c *auctionServiceClient) UpdateAuctionPrice(ctx context.Context, in *UpdateAuctionPriceRequest, opts ...grpc.CallOption) (*UpdateAuctionPriceResponse, error) {
+func (c *auctionServiceClient) AcceptBid(ctx context.Context, in *AcceptBidRequest, opts ...grpc.CallOption) (*AcceptBidResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceptBidResponse)
+	err := c.cc.Invoke(ctx, AuctionService_AcceptBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) AcceptBuyNow(ctx context.Context, in *AcceptBuyNowRequest, opts ...grpc.CallOption) (*AcceptBuyNowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceptBuyNowResponse)
+	err := c.cc.Invoke(ctx, AuctionService_AcceptBuyNow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) AcceptClockPrice(ctx context.Context, in *AcceptClockPriceRequest, opts ...grpc.CallOption) (*AcceptClockPriceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(UpdateAuctionPriceResponse)
-	err := c.cc.Invoke(ctx, AuctionService_UpdateAuctionPrice_FullMethodName, in, out, cOpts...)
+	out := new(AcceptClockPriceResponse)
+	err := c.cc.Invoke(ctx, AuctionService_AcceptClockPrice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) RestorePrice(ctx context.Context, in *RestorePriceRequest, opts ...grpc.CallOption) (*RestorePriceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestorePriceResponse)
+	err := c.cc.Invoke(ctx, AuctionService_RestorePrice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *auctionServiceClient) ReleaseSealedBid(ctx context.Context, in *ReleaseSealedBidRequest, opts ...grpc.CallOption) (*ReleaseSealedBidResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseSealedBidResponse)
+	err := c.cc.Invoke(ctx, AuctionService_ReleaseSealedBid_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -234,9 +322,53 @@ type AuctionServiceServer interface {
 	// @return CloseAuctionResponse The final state of the closed auction.
 	CloseAuction(context.Context, *CloseAuctionRequest) (*CloseAuctionResponse, error)
 	// *
-	// Updates the current price of an auction.
-	// This is typically called by the Bidding Service after a successful bid.
-	UpdateAuctionPrice(context.Context, *UpdateAuctionPriceRequest) (*UpdateAuctionPriceResponse, error)
+	// Atomically validates a bid and raises the current price to its amount.
+	// The price only moves if the auction is active, has not ended and the amount is
+	// higher than the current price at the moment of the update, so concurrent bids
+	// can never overwrite a higher price with a lower one.
+	// Sealed-bid auctions instead accept one bid per bidder of at least the start
+	// price and leave the price untouched until close. Reverse auctions lower the
+	// price instead, to amounts at least one decrement under it. Multi-lot auctions
+	// accept any bid of at least the start price for up to their quantity of units;
+	// the units are allocated to the highest bids at close.
+	//
+	// @param AcceptBidRequest The bid to apply.
+	// @return AcceptBidResponse The new price, or the reason the bid was rejected.
+	AcceptBid(context.Context, *AcceptBidRequest) (*AcceptBidResponse, error)
+	// *
+	// Atomically closes an auction at its buy-now price with the buyer as winner.
+	// Only one buyer can succeed; buy-now is unavailable once the current price has
+	// reached the service's cutoff fraction of the buy-now price.
+	//
+	// @param AcceptBuyNowRequest The buyer and the id of the bid recorded for the purchase.
+	// @return AcceptBuyNowResponse The price paid, or the reason the purchase was rejected.
+	AcceptBuyNow(context.Context, *AcceptBuyNowRequest) (*AcceptBuyNowResponse, error)
+	// *
+	// Atomically closes a Dutch auction at its current clock price with the buyer as
+	// winner. The first acceptance wins; later ones find the auction closed.
+	//
+	// @param AcceptClockPriceRequest The buyer and the id of the bid recorded for the purchase.
+	// @return AcceptClockPriceResponse The clock price paid, or the reason the acceptance was rejected.
+	AcceptClockPrice(context.Context, *AcceptClockPriceRequest) (*AcceptClockPriceResponse, error)
+	// *
+	// Recomputes the current price after a bid was retracted or cancelled.
+	// If the withdrawn bid set the price, the price falls back to the leading live bid
+	// reported by the Bidding Service's GetHighestBid (the lowest bid in a reverse
+	// auction), or to the start price if none is left. The update is a compare-and-set
+	// on retracted_amount, so a bid that raised the price in the meantime is left alone.
+	// Sealed-bid and multi-lot auctions have no running price and are never changed.
+	//
+	// @param RestorePriceRequest The auction and the amount of the withdrawn bid.
+	// @return RestorePriceResponse The current price, and whether it moved.
+	RestorePrice(context.Context, *RestorePriceRequest) (*RestorePriceResponse, error)
+	// *
+	// Forgets that a bidder bid on a sealed auction, so they can bid again. The Bidding
+	// Service calls it when it could not record a sealed bid that AcceptBid accepted.
+	// Only an auction that is still active is changed.
+	//
+	// @param ReleaseSealedBidRequest The auction and the bidder.
+	// @return ReleaseSealedBidResponse Whether the bidder was released.
+	ReleaseSealedBid(context.Context, *ReleaseSealedBidRequest) (*ReleaseSealedBidResponse, error)
 	mustEmbedUnimplementedAuctionServiceServer()
 }
 
@@ -268,8 +400,20 @@ func (UnimplementedAuctionServiceServer) UpdateAuction(context.Context, *UpdateA
 func (UnimplementedAuctionServiceServer) CloseAuction(context.Context, *CloseAuctionRequest) (*CloseAuctionResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CloseAuction not implemented")
 }
-func (UnimplementedAuctionServiceServer) UpdateAuctionPrice(context.Context, *UpdateAuctionPriceRequest) (*UpdateAuctionPriceResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method UpdateAuctionPrice not implemented")
+func (UnimplementedAuctionServiceServer) AcceptBid(context.Context, *AcceptBidRequest) (*AcceptBidResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcceptBid not implemented")
+}
+func (UnimplementedAuctionServiceServer) AcceptBuyNow(context.Context, *AcceptBuyNowRequest) (*AcceptBuyNowResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcceptBuyNow not implemented")
+}
+func (UnimplementedAuctionServiceServer) AcceptClockPrice(context.Context, *AcceptClockPriceRequest) (*AcceptClockPriceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcceptClockPrice not implemented")
+}
+func (UnimplementedAuctionServiceServer) RestorePrice(context.Context, *RestorePriceRequest) (*RestorePriceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestorePrice not implemented")
+}
+func (UnimplementedAuctionServiceServer) ReleaseSealedBid(context.Context, *ReleaseSealedBidRequest) (*ReleaseSealedBidResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleaseSealedBid not implemented")
 }
 func (UnimplementedAuctionServiceServer) mustEmbedUnimplementedAuctionServiceServer() {}
 func (UnimplementedAuctionServiceServer) testEmbeddedByValue()                        {}
@@ -418,20 +562,92 @@ func _AuctionService_CloseAuction_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuctionService_UpdateAuctionPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateAuctionPriceRequest)
+func _AuctionService_AcceptBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptBidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).AcceptBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_AcceptBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).AcceptBid(ctx, req.(*AcceptBidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_AcceptBuyNow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptBuyNowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).AcceptBuyNow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_AcceptBuyNow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).AcceptBuyNow(ctx, req.(*AcceptBuyNowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_AcceptClockPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptClockPriceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuctionServiceServer).UpdateAuctionPrice(ctx, in)
+		return srv.(AuctionServiceServer).AcceptClockPrice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuctionService_UpdateAuctionPrice_FullMethodName,
+		FullMethod: AuctionService_AcceptClockPrice_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuctionServiceServer).UpdateAuctionPrice(ctx, req.(*UpdateAuctionPriceRequest))
+		return srv.(AuctionServiceServer).AcceptClockPrice(ctx, req.(*AcceptClockPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_RestorePrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestorePriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).RestorePrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_RestorePrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).RestorePrice(ctx, req.(*RestorePriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuctionService_ReleaseSealedBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseSealedBidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuctionServiceServer).ReleaseSealedBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuctionService_ReleaseSealedBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuctionServiceServer).ReleaseSealedBid(ctx, req.(*ReleaseSealedBidRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -472,8 +688,24 @@ var AuctionService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _AuctionService_CloseAuction_Handler,
 		},
 		{
-			MethodName: "UpdateAuctionPrice",
-			Handler:    _AuctionService_UpdateAuctionPrice_Handler,
+			MethodName: "AcceptBid",
+			Handler:    _AuctionService_AcceptBid_Handler,
+		},
+		{
+			MethodName: "AcceptBuyNow",
+			Handler:    _AuctionService_AcceptBuyNow_Handler,
+		},
+		{
+			MethodName: "AcceptClockPrice",
+			Handler:    _AuctionService_AcceptClockPrice_Handler,
+		},
+		{
+			MethodName: "RestorePrice",
+			Handler:    _AuctionService_RestorePrice_Handler,
+		},
+		{
+			MethodName: "ReleaseSealedBid",
+			Handler:    _AuctionService_ReleaseSealedBid_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
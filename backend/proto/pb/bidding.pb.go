@@ -23,10 +23,17 @@ const (
 )
 
 type PlaceBidRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
-	BidderId      string                 `protobuf:"bytes,2,opt,name=bidder_id,json=bidderId,proto3" json:"bidder_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	BidderId  string                 `protobuf:"bytes,2,opt,name=bidder_id,json=bidderId,proto3" json:"bidder_id,omitempty"`
+	Amount    float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// Optional hidden ceiling. When set, the service bids on the bidder's behalf
+	// up to this amount whenever they are outbid.
+	MaxAmount float64 `protobuf:"fixed64,4,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	// The bidder's company; reverse auctions only take bids from verified companies.
+	CompanyId string `protobuf:"bytes,5,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	// Units wanted in a multi-lot auction, at amount each; 0 means 1.
+	Quantity      int32 `protobuf:"varint,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,6 +89,27 @@ func (x *PlaceBidRequest) GetAmount() float64 {
 	return 0
 }
 
+func (x *PlaceBidRequest) GetMaxAmount() float64 {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return 0
+}
+
+func (x *PlaceBidRequest) GetCompanyId() string {
+	if x != nil {
+		return x.CompanyId
+	}
+	return ""
+}
+
+func (x *PlaceBidRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
 type PlaceBidResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Bid           *Bid                   `protobuf:"bytes,1,opt,name=bid,proto3" json:"bid,omitempty"`
@@ -126,6 +154,102 @@ func (x *PlaceBidResponse) GetBid() *Bid {
 	return nil
 }
 
+type BuyNowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	BuyerId       string                 `protobuf:"bytes,2,opt,name=buyer_id,json=buyerId,proto3" json:"buyer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuyNowRequest) Reset() {
+	*x = BuyNowRequest{}
+	mi := &file_bidding_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyNowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyNowRequest) ProtoMessage() {}
+
+func (x *BuyNowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyNowRequest.ProtoReflect.Descriptor instead.
+func (*BuyNowRequest) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BuyNowRequest) GetAuctionId() string {
+	if x != nil {
+		return x.AuctionId
+	}
+	return ""
+}
+
+func (x *BuyNowRequest) GetBuyerId() string {
+	if x != nil {
+		return x.BuyerId
+	}
+	return ""
+}
+
+type BuyNowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bid           *Bid                   `protobuf:"bytes,1,opt,name=bid,proto3" json:"bid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuyNowResponse) Reset() {
+	*x = BuyNowResponse{}
+	mi := &file_bidding_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuyNowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuyNowResponse) ProtoMessage() {}
+
+func (x *BuyNowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuyNowResponse.ProtoReflect.Descriptor instead.
+func (*BuyNowResponse) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BuyNowResponse) GetBid() *Bid {
+	if x != nil {
+		return x.Bid
+	}
+	return nil
+}
+
 type GetBidsByAuctionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
@@ -135,7 +259,7 @@ type GetBidsByAuctionRequest struct {
 
 func (x *GetBidsByAuctionRequest) Reset() {
 	*x = GetBidsByAuctionRequest{}
-	mi := &file_bidding_proto_msgTypes[2]
+	mi := &file_bidding_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -147,7 +271,7 @@ func (x *GetBidsByAuctionRequest) String() string {
 func (*GetBidsByAuctionRequest) ProtoMessage() {}
 
 func (x *GetBidsByAuctionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bidding_proto_msgTypes[2]
+	mi := &file_bidding_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -160,7 +284,7 @@ func (x *GetBidsByAuctionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBidsByAuctionRequest.ProtoReflect.Descriptor instead.
 func (*GetBidsByAuctionRequest) Descriptor() ([]byte, []int) {
-	return file_bidding_proto_rawDescGZIP(), []int{2}
+	return file_bidding_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetBidsByAuctionRequest) GetAuctionId() string {
@@ -179,7 +303,7 @@ type GetBidsByAuctionResponse struct {
 
 func (x *GetBidsByAuctionResponse) Reset() {
 	*x = GetBidsByAuctionResponse{}
-	mi := &file_bidding_proto_msgTypes[3]
+	mi := &file_bidding_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -191,7 +315,7 @@ func (x *GetBidsByAuctionResponse) String() string {
 func (*GetBidsByAuctionResponse) ProtoMessage() {}
 
 func (x *GetBidsByAuctionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bidding_proto_msgTypes[3]
+	mi := &file_bidding_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -204,7 +328,7 @@ func (x *GetBidsByAuctionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBidsByAuctionResponse.ProtoReflect.Descriptor instead.
 func (*GetBidsByAuctionResponse) Descriptor() ([]byte, []int) {
-	return file_bidding_proto_rawDescGZIP(), []int{3}
+	return file_bidding_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetBidsByAuctionResponse) GetBids() []*Bid {
@@ -214,32 +338,28 @@ func (x *GetBidsByAuctionResponse) GetBids() []*Bid {
 	return nil
 }
 
-type Bid struct {
+type GetHighestBidRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	AuctionId     string                 `protobuf:"bytes,2,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
-	BidderId      string                 `protobuf:"bytes,3,opt,name=bidder_id,json=bidderId,proto3" json:"bidder_id,omitempty"`
-	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Bid) Reset() {
-	*x = Bid{}
-	mi := &file_bidding_proto_msgTypes[4]
+func (x *GetHighestBidRequest) Reset() {
+	*x = GetHighestBidRequest{}
+	mi := &file_bidding_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Bid) String() string {
+func (x *GetHighestBidRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Bid) ProtoMessage() {}
+func (*GetHighestBidRequest) ProtoMessage() {}
 
-func (x *Bid) ProtoReflect() protoreflect.Message {
-	mi := &file_bidding_proto_msgTypes[4]
+func (x *GetHighestBidRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -250,73 +370,789 @@ func (x *Bid) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Bid.ProtoReflect.Descriptor instead.
-func (*Bid) Descriptor() ([]byte, []int) {
-	return file_bidding_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetHighestBidRequest.ProtoReflect.Descriptor instead.
+func (*GetHighestBidRequest) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *Bid) GetId() string {
+func (x *GetHighestBidRequest) GetAuctionId() string {
 	if x != nil {
-		return x.Id
+		return x.AuctionId
 	}
 	return ""
 }
 
-func (x *Bid) GetAuctionId() string {
+type GetHighestBidResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bid           *Bid                   `protobuf:"bytes,1,opt,name=bid,proto3" json:"bid,omitempty"`
+	Found         bool                   `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"` // false when the auction has no bids
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHighestBidResponse) Reset() {
+	*x = GetHighestBidResponse{}
+	mi := &file_bidding_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHighestBidResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHighestBidResponse) ProtoMessage() {}
+
+func (x *GetHighestBidResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHighestBidResponse.ProtoReflect.Descriptor instead.
+func (*GetHighestBidResponse) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetHighestBidResponse) GetBid() *Bid {
+	if x != nil {
+		return x.Bid
+	}
+	return nil
+}
+
+func (x *GetHighestBidResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+type AcceptPriceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	BidderId      string                 `protobuf:"bytes,2,opt,name=bidder_id,json=bidderId,proto3" json:"bidder_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptPriceRequest) Reset() {
+	*x = AcceptPriceRequest{}
+	mi := &file_bidding_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptPriceRequest) ProtoMessage() {}
+
+func (x *AcceptPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptPriceRequest.ProtoReflect.Descriptor instead.
+func (*AcceptPriceRequest) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AcceptPriceRequest) GetAuctionId() string {
 	if x != nil {
 		return x.AuctionId
 	}
 	return ""
 }
 
-func (x *Bid) GetBidderId() string {
+func (x *AcceptPriceRequest) GetBidderId() string {
 	if x != nil {
 		return x.BidderId
 	}
 	return ""
 }
 
-func (x *Bid) GetAmount() float64 {
+type AcceptPriceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bid           *Bid                   `protobuf:"bytes,1,opt,name=bid,proto3" json:"bid,omitempty"` // The winning bid, at the clock price
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptPriceResponse) Reset() {
+	*x = AcceptPriceResponse{}
+	mi := &file_bidding_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptPriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptPriceResponse) ProtoMessage() {}
+
+func (x *AcceptPriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[9]
 	if x != nil {
-		return x.Amount
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *Bid) GetTimestamp() *timestamppb.Timestamp {
+// Deprecated: Use AcceptPriceResponse.ProtoReflect.Descriptor instead.
+func (*AcceptPriceResponse) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *AcceptPriceResponse) GetBid() *Bid {
 	if x != nil {
-		return x.Timestamp
+		return x.Bid
 	}
 	return nil
 }
 
-var File_bidding_proto protoreflect.FileDescriptor
+type GetAllocationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"` // Units on sale
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_bidding_proto_rawDesc = "" +
-	"\n" +
-	"\rbidding.proto\x12\rproto.bidding\x1a\x1fgoogle/protobuf/timestamp.proto\"e\n" +
-	"\x0fPlaceBidRequest\x12\x1d\n" +
-	"\n" +
-	"auction_id\x18\x01 \x01(\tR\tauctionId\x12\x1b\n" +
-	"\tbidder_id\x18\x02 \x01(\tR\bbidderId\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x01R\x06amount\"8\n" +
-	"\x10PlaceBidResponse\x12$\n" +
-	"\x03bid\x18\x01 \x01(\v2\x12.proto.bidding.BidR\x03bid\"8\n" +
-	"\x17GetBidsByAuctionRequest\x12\x1d\n" +
-	"\n" +
-	"auction_id\x18\x01 \x01(\tR\tauctionId\"B\n" +
-	"\x18GetBidsByAuctionResponse\x12&\n" +
-	"\x04bids\x18\x01 \x03(\v2\x12.proto.bidding.BidR\x04bids\"\xa3\x01\n" +
-	"\x03Bid\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
-	"\n" +
-	"auction_id\x18\x02 \x01(\tR\tauctionId\x12\x1b\n" +
-	"\tbidder_id\x18\x03 \x01(\tR\bbidderId\x12\x16\n" +
-	"\x06amount\x18\x04 \x01(\x01R\x06amount\x128\n" +
-	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp2\xc2\x01\n" +
-	"\x0eBiddingService\x12K\n" +
-	"\bPlaceBid\x12\x1e.proto.bidding.PlaceBidRequest\x1a\x1f.proto.bidding.PlaceBidResponse\x12c\n" +
-	"\x10GetBidsByAuction\x12&.proto.bidding.GetBidsByAuctionRequest\x1a'.proto.bidding.GetBidsByAuctionResponseB8Z6github.com/temesgen-abebayehu/bidflow/backend/proto/pbb\x06proto3"
+func (x *GetAllocationRequest) Reset() {
+	*x = GetAllocationRequest{}
+	mi := &file_bidding_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllocationRequest) ProtoMessage() {}
+
+func (x *GetAllocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllocationRequest.ProtoReflect.Descriptor instead.
+func (*GetAllocationRequest) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAllocationRequest) GetAuctionId() string {
+	if x != nil {
+		return x.AuctionId
+	}
+	return ""
+}
+
+func (x *GetAllocationRequest) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type GetAllocationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bids          []*Bid                 `protobuf:"bytes,1,rep,name=bids,proto3" json:"bids,omitempty"` // The winning bids, best first, with the units each won in allocated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAllocationResponse) Reset() {
+	*x = GetAllocationResponse{}
+	mi := &file_bidding_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAllocationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllocationResponse) ProtoMessage() {}
+
+func (x *GetAllocationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllocationResponse.ProtoReflect.Descriptor instead.
+func (*GetAllocationResponse) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetAllocationResponse) GetBids() []*Bid {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+type RetractBidRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BidId         string                 `protobuf:"bytes,1,opt,name=bid_id,json=bidId,proto3" json:"bid_id,omitempty"`
+	BidderId      string                 `protobuf:"bytes,2,opt,name=bidder_id,json=bidderId,proto3" json:"bidder_id,omitempty"` // Must be the bid's bidder
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetractBidRequest) Reset() {
+	*x = RetractBidRequest{}
+	mi := &file_bidding_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetractBidRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetractBidRequest) ProtoMessage() {}
+
+func (x *RetractBidRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetractBidRequest.ProtoReflect.Descriptor instead.
+func (*RetractBidRequest) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RetractBidRequest) GetBidId() string {
+	if x != nil {
+		return x.BidId
+	}
+	return ""
+}
+
+func (x *RetractBidRequest) GetBidderId() string {
+	if x != nil {
+		return x.BidderId
+	}
+	return ""
+}
+
+func (x *RetractBidRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RetractBidResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bid           *Bid                   `protobuf:"bytes,1,opt,name=bid,proto3" json:"bid,omitempty"` // The retracted bid
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetractBidResponse) Reset() {
+	*x = RetractBidResponse{}
+	mi := &file_bidding_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetractBidResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetractBidResponse) ProtoMessage() {}
+
+func (x *RetractBidResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetractBidResponse.ProtoReflect.Descriptor instead.
+func (*RetractBidResponse) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RetractBidResponse) GetBid() *Bid {
+	if x != nil {
+		return x.Bid
+	}
+	return nil
+}
+
+type CancelBidRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BidId         string                 `protobuf:"bytes,1,opt,name=bid_id,json=bidId,proto3" json:"bid_id,omitempty"`
+	ActorId       string                 `protobuf:"bytes,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // The auction's seller, or an admin
+	Admin         bool                   `protobuf:"varint,3,opt,name=admin,proto3" json:"admin,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // Required
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBidRequest) Reset() {
+	*x = CancelBidRequest{}
+	mi := &file_bidding_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBidRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBidRequest) ProtoMessage() {}
+
+func (x *CancelBidRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBidRequest.ProtoReflect.Descriptor instead.
+func (*CancelBidRequest) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CancelBidRequest) GetBidId() string {
+	if x != nil {
+		return x.BidId
+	}
+	return ""
+}
+
+func (x *CancelBidRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *CancelBidRequest) GetAdmin() bool {
+	if x != nil {
+		return x.Admin
+	}
+	return false
+}
+
+func (x *CancelBidRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CancelBidResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bid           *Bid                   `protobuf:"bytes,1,opt,name=bid,proto3" json:"bid,omitempty"` // The cancelled bid
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelBidResponse) Reset() {
+	*x = CancelBidResponse{}
+	mi := &file_bidding_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelBidResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelBidResponse) ProtoMessage() {}
+
+func (x *CancelBidResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelBidResponse.ProtoReflect.Descriptor instead.
+func (*CancelBidResponse) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelBidResponse) GetBid() *Bid {
+	if x != nil {
+		return x.Bid
+	}
+	return nil
+}
+
+type GetTopBidsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuctionId     string                 `protobuf:"bytes,1,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	LowestFirst   bool                   `protobuf:"varint,3,opt,name=lowest_first,json=lowestFirst,proto3" json:"lowest_first,omitempty"` // Reverse auctions, where the lowest bid leads
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopBidsRequest) Reset() {
+	*x = GetTopBidsRequest{}
+	mi := &file_bidding_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopBidsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopBidsRequest) ProtoMessage() {}
+
+func (x *GetTopBidsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopBidsRequest.ProtoReflect.Descriptor instead.
+func (*GetTopBidsRequest) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetTopBidsRequest) GetAuctionId() string {
+	if x != nil {
+		return x.AuctionId
+	}
+	return ""
+}
+
+func (x *GetTopBidsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetTopBidsRequest) GetLowestFirst() bool {
+	if x != nil {
+		return x.LowestFirst
+	}
+	return false
+}
+
+type GetTopBidsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bids          []*Bid                 `protobuf:"bytes,1,rep,name=bids,proto3" json:"bids,omitempty"` // Best first; equal amounts by time placed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTopBidsResponse) Reset() {
+	*x = GetTopBidsResponse{}
+	mi := &file_bidding_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTopBidsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTopBidsResponse) ProtoMessage() {}
+
+func (x *GetTopBidsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTopBidsResponse.ProtoReflect.Descriptor instead.
+func (*GetTopBidsResponse) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetTopBidsResponse) GetBids() []*Bid {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+type Bid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AuctionId     string                 `protobuf:"bytes,2,opt,name=auction_id,json=auctionId,proto3" json:"auction_id,omitempty"`
+	BidderId      string                 `protobuf:"bytes,3,opt,name=bidder_id,json=bidderId,proto3" json:"bidder_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	IsProxy       bool                   `protobuf:"varint,6,opt,name=is_proxy,json=isProxy,proto3" json:"is_proxy,omitempty"` // Placed automatically from the bidder's max_amount
+	Sealed        bool                   `protobuf:"varint,7,opt,name=sealed,proto3" json:"sealed,omitempty"`                  // Placed on a sealed-bid auction; amount is 0 while the auction is open
+	Quantity      int32                  `protobuf:"varint,8,opt,name=quantity,proto3" json:"quantity,omitempty"`              // Units bid for, at amount each
+	Allocated     int32                  `protobuf:"varint,9,opt,name=allocated,proto3" json:"allocated,omitempty"`            // Multi-lot auctions: units the bid currently wins
+	Retracted     bool                   `protobuf:"varint,10,opt,name=retracted,proto3" json:"retracted,omitempty"`           // Withdrawn by the bidder or cancelled by the seller; never wins and does not set the price
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bid) Reset() {
+	*x = Bid{}
+	mi := &file_bidding_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bid) ProtoMessage() {}
+
+func (x *Bid) ProtoReflect() protoreflect.Message {
+	mi := &file_bidding_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bid.ProtoReflect.Descriptor instead.
+func (*Bid) Descriptor() ([]byte, []int) {
+	return file_bidding_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Bid) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Bid) GetAuctionId() string {
+	if x != nil {
+		return x.AuctionId
+	}
+	return ""
+}
+
+func (x *Bid) GetBidderId() string {
+	if x != nil {
+		return x.BidderId
+	}
+	return ""
+}
+
+func (x *Bid) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Bid) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Bid) GetIsProxy() bool {
+	if x != nil {
+		return x.IsProxy
+	}
+	return false
+}
+
+func (x *Bid) GetSealed() bool {
+	if x != nil {
+		return x.Sealed
+	}
+	return false
+}
+
+func (x *Bid) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Bid) GetAllocated() int32 {
+	if x != nil {
+		return x.Allocated
+	}
+	return 0
+}
+
+func (x *Bid) GetRetracted() bool {
+	if x != nil {
+		return x.Retracted
+	}
+	return false
+}
+
+var File_bidding_proto protoreflect.FileDescriptor
+
+const file_bidding_proto_rawDesc = "" +
+	"\n" +
+	"\rbidding.proto\x12\rproto.bidding\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbf\x01\n" +
+	"\x0fPlaceBidRequest\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\x12\x1b\n" +
+	"\tbidder_id\x18\x02 \x01(\tR\bbidderId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1d\n" +
+	"\n" +
+	"max_amount\x18\x04 \x01(\x01R\tmaxAmount\x12\x1d\n" +
+	"\n" +
+	"company_id\x18\x05 \x01(\tR\tcompanyId\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x05R\bquantity\"8\n" +
+	"\x10PlaceBidResponse\x12$\n" +
+	"\x03bid\x18\x01 \x01(\v2\x12.proto.bidding.BidR\x03bid\"I\n" +
+	"\rBuyNowRequest\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\x12\x19\n" +
+	"\bbuyer_id\x18\x02 \x01(\tR\abuyerId\"6\n" +
+	"\x0eBuyNowResponse\x12$\n" +
+	"\x03bid\x18\x01 \x01(\v2\x12.proto.bidding.BidR\x03bid\"8\n" +
+	"\x17GetBidsByAuctionRequest\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\"B\n" +
+	"\x18GetBidsByAuctionResponse\x12&\n" +
+	"\x04bids\x18\x01 \x03(\v2\x12.proto.bidding.BidR\x04bids\"5\n" +
+	"\x14GetHighestBidRequest\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\"S\n" +
+	"\x15GetHighestBidResponse\x12$\n" +
+	"\x03bid\x18\x01 \x01(\v2\x12.proto.bidding.BidR\x03bid\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\"P\n" +
+	"\x12AcceptPriceRequest\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\x12\x1b\n" +
+	"\tbidder_id\x18\x02 \x01(\tR\bbidderId\";\n" +
+	"\x13AcceptPriceResponse\x12$\n" +
+	"\x03bid\x18\x01 \x01(\v2\x12.proto.bidding.BidR\x03bid\"Q\n" +
+	"\x14GetAllocationRequest\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\"?\n" +
+	"\x15GetAllocationResponse\x12&\n" +
+	"\x04bids\x18\x01 \x03(\v2\x12.proto.bidding.BidR\x04bids\"_\n" +
+	"\x11RetractBidRequest\x12\x15\n" +
+	"\x06bid_id\x18\x01 \x01(\tR\x05bidId\x12\x1b\n" +
+	"\tbidder_id\x18\x02 \x01(\tR\bbidderId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\":\n" +
+	"\x12RetractBidResponse\x12$\n" +
+	"\x03bid\x18\x01 \x01(\v2\x12.proto.bidding.BidR\x03bid\"r\n" +
+	"\x10CancelBidRequest\x12\x15\n" +
+	"\x06bid_id\x18\x01 \x01(\tR\x05bidId\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\tR\aactorId\x12\x14\n" +
+	"\x05admin\x18\x03 \x01(\bR\x05admin\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"9\n" +
+	"\x11CancelBidResponse\x12$\n" +
+	"\x03bid\x18\x01 \x01(\v2\x12.proto.bidding.BidR\x03bid\"k\n" +
+	"\x11GetTopBidsRequest\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x01 \x01(\tR\tauctionId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12!\n" +
+	"\flowest_first\x18\x03 \x01(\bR\vlowestFirst\"<\n" +
+	"\x12GetTopBidsResponse\x12&\n" +
+	"\x04bids\x18\x01 \x03(\v2\x12.proto.bidding.BidR\x04bids\"\xae\x02\n" +
+	"\x03Bid\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"auction_id\x18\x02 \x01(\tR\tauctionId\x12\x1b\n" +
+	"\tbidder_id\x18\x03 \x01(\tR\bbidderId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x19\n" +
+	"\bis_proxy\x18\x06 \x01(\bR\aisProxy\x12\x16\n" +
+	"\x06sealed\x18\a \x01(\bR\x06sealed\x12\x1a\n" +
+	"\bquantity\x18\b \x01(\x05R\bquantity\x12\x1c\n" +
+	"\tallocated\x18\t \x01(\x05R\tallocated\x12\x1c\n" +
+	"\tretracted\x18\n" +
+	" \x01(\bR\tretracted2\x8d\x06\n" +
+	"\x0eBiddingService\x12K\n" +
+	"\bPlaceBid\x12\x1e.proto.bidding.PlaceBidRequest\x1a\x1f.proto.bidding.PlaceBidResponse\x12c\n" +
+	"\x10GetBidsByAuction\x12&.proto.bidding.GetBidsByAuctionRequest\x1a'.proto.bidding.GetBidsByAuctionResponse\x12Z\n" +
+	"\rGetHighestBid\x12#.proto.bidding.GetHighestBidRequest\x1a$.proto.bidding.GetHighestBidResponse\x12Q\n" +
+	"\n" +
+	"GetTopBids\x12 .proto.bidding.GetTopBidsRequest\x1a!.proto.bidding.GetTopBidsResponse\x12E\n" +
+	"\x06BuyNow\x12\x1c.proto.bidding.BuyNowRequest\x1a\x1d.proto.bidding.BuyNowResponse\x12T\n" +
+	"\vAcceptPrice\x12!.proto.bidding.AcceptPriceRequest\x1a\".proto.bidding.AcceptPriceResponse\x12Z\n" +
+	"\rGetAllocation\x12#.proto.bidding.GetAllocationRequest\x1a$.proto.bidding.GetAllocationResponse\x12Q\n" +
+	"\n" +
+	"RetractBid\x12 .proto.bidding.RetractBidRequest\x1a!.proto.bidding.RetractBidResponse\x12N\n" +
+	"\tCancelBid\x12\x1f.proto.bidding.CancelBidRequest\x1a .proto.bidding.CancelBidResponseB8Z6github.com/temesgen-abebayehu/bidflow/backend/proto/pbb\x06proto3"
 
 var (
 	file_bidding_proto_rawDescOnce sync.Once
@@ -330,28 +1166,63 @@ func file_bidding_proto_rawDescGZIP() []byte {
 	return file_bidding_proto_rawDescData
 }
 
-var file_bidding_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_bidding_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_bidding_proto_goTypes = []any{
 	(*PlaceBidRequest)(nil),          // 0: proto.bidding.PlaceBidRequest
 	(*PlaceBidResponse)(nil),         // 1: proto.bidding.PlaceBidResponse
-	(*GetBidsByAuctionRequest)(nil),  // 2: proto.bidding.GetBidsByAuctionRequest
-	(*GetBidsByAuctionResponse)(nil), // 3: proto.bidding.GetBidsByAuctionResponse
-	(*Bid)(nil),                      // 4: proto.bidding.Bid
-	(*timestamppb.Timestamp)(nil),    // 5: google.protobuf.Timestamp
+	(*BuyNowRequest)(nil),            // 2: proto.bidding.BuyNowRequest
+	(*BuyNowResponse)(nil),           // 3: proto.bidding.BuyNowResponse
+	(*GetBidsByAuctionRequest)(nil),  // 4: proto.bidding.GetBidsByAuctionRequest
+	(*GetBidsByAuctionResponse)(nil), // 5: proto.bidding.GetBidsByAuctionResponse
+	(*GetHighestBidRequest)(nil),     // 6: proto.bidding.GetHighestBidRequest
+	(*GetHighestBidResponse)(nil),    // 7: proto.bidding.GetHighestBidResponse
+	(*AcceptPriceRequest)(nil),       // 8: proto.bidding.AcceptPriceRequest
+	(*AcceptPriceResponse)(nil),      // 9: proto.bidding.AcceptPriceResponse
+	(*GetAllocationRequest)(nil),     // 10: proto.bidding.GetAllocationRequest
+	(*GetAllocationResponse)(nil),    // 11: proto.bidding.GetAllocationResponse
+	(*RetractBidRequest)(nil),        // 12: proto.bidding.RetractBidRequest
+	(*RetractBidResponse)(nil),       // 13: proto.bidding.RetractBidResponse
+	(*CancelBidRequest)(nil),         // 14: proto.bidding.CancelBidRequest
+	(*CancelBidResponse)(nil),        // 15: proto.bidding.CancelBidResponse
+	(*GetTopBidsRequest)(nil),        // 16: proto.bidding.GetTopBidsRequest
+	(*GetTopBidsResponse)(nil),       // 17: proto.bidding.GetTopBidsResponse
+	(*Bid)(nil),                      // 18: proto.bidding.Bid
+	(*timestamppb.Timestamp)(nil),    // 19: google.protobuf.Timestamp
 }
 var file_bidding_proto_depIdxs = []int32{
-	4, // 0: proto.bidding.PlaceBidResponse.bid:type_name -> proto.bidding.Bid
-	4, // 1: proto.bidding.GetBidsByAuctionResponse.bids:type_name -> proto.bidding.Bid
-	5, // 2: proto.bidding.Bid.timestamp:type_name -> google.protobuf.Timestamp
-	0, // 3: proto.bidding.BiddingService.PlaceBid:input_type -> proto.bidding.PlaceBidRequest
-	2, // 4: proto.bidding.BiddingService.GetBidsByAuction:input_type -> proto.bidding.GetBidsByAuctionRequest
-	1, // 5: proto.bidding.BiddingService.PlaceBid:output_type -> proto.bidding.PlaceBidResponse
-	3, // 6: proto.bidding.BiddingService.GetBidsByAuction:output_type -> proto.bidding.GetBidsByAuctionResponse
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	18, // 0: proto.bidding.PlaceBidResponse.bid:type_name -> proto.bidding.Bid
+	18, // 1: proto.bidding.BuyNowResponse.bid:type_name -> proto.bidding.Bid
+	18, // 2: proto.bidding.GetBidsByAuctionResponse.bids:type_name -> proto.bidding.Bid
+	18, // 3: proto.bidding.GetHighestBidResponse.bid:type_name -> proto.bidding.Bid
+	18, // 4: proto.bidding.AcceptPriceResponse.bid:type_name -> proto.bidding.Bid
+	18, // 5: proto.bidding.GetAllocationResponse.bids:type_name -> proto.bidding.Bid
+	18, // 6: proto.bidding.RetractBidResponse.bid:type_name -> proto.bidding.Bid
+	18, // 7: proto.bidding.CancelBidResponse.bid:type_name -> proto.bidding.Bid
+	18, // 8: proto.bidding.GetTopBidsResponse.bids:type_name -> proto.bidding.Bid
+	19, // 9: proto.bidding.Bid.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 10: proto.bidding.BiddingService.PlaceBid:input_type -> proto.bidding.PlaceBidRequest
+	4,  // 11: proto.bidding.BiddingService.GetBidsByAuction:input_type -> proto.bidding.GetBidsByAuctionRequest
+	6,  // 12: proto.bidding.BiddingService.GetHighestBid:input_type -> proto.bidding.GetHighestBidRequest
+	16, // 13: proto.bidding.BiddingService.GetTopBids:input_type -> proto.bidding.GetTopBidsRequest
+	2,  // 14: proto.bidding.BiddingService.BuyNow:input_type -> proto.bidding.BuyNowRequest
+	8,  // 15: proto.bidding.BiddingService.AcceptPrice:input_type -> proto.bidding.AcceptPriceRequest
+	10, // 16: proto.bidding.BiddingService.GetAllocation:input_type -> proto.bidding.GetAllocationRequest
+	12, // 17: proto.bidding.BiddingService.RetractBid:input_type -> proto.bidding.RetractBidRequest
+	14, // 18: proto.bidding.BiddingService.CancelBid:input_type -> proto.bidding.CancelBidRequest
+	1,  // 19: proto.bidding.BiddingService.PlaceBid:output_type -> proto.bidding.PlaceBidResponse
+	5,  // 20: proto.bidding.BiddingService.GetBidsByAuction:output_type -> proto.bidding.GetBidsByAuctionResponse
+	7,  // 21: proto.bidding.BiddingService.GetHighestBid:output_type -> proto.bidding.GetHighestBidResponse
+	17, // 22: proto.bidding.BiddingService.GetTopBids:output_type -> proto.bidding.GetTopBidsResponse
+	3,  // 23: proto.bidding.BiddingService.BuyNow:output_type -> proto.bidding.BuyNowResponse
+	9,  // 24: proto.bidding.BiddingService.AcceptPrice:output_type -> proto.bidding.AcceptPriceResponse
+	11, // 25: proto.bidding.BiddingService.GetAllocation:output_type -> proto.bidding.GetAllocationResponse
+	13, // 26: proto.bidding.BiddingService.RetractBid:output_type -> proto.bidding.RetractBidResponse
+	15, // 27: proto.bidding.BiddingService.CancelBid:output_type -> proto.bidding.CancelBidResponse
+	19, // [19:28] is the sub-list for method output_type
+	10, // [10:19] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_bidding_proto_init() }
@@ -365,7 +1236,7 @@ func file_bidding_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bidding_proto_rawDesc), len(file_bidding_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,6 +21,13 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	BiddingService_PlaceBid_FullMethodName         = "/proto.bidding.BiddingService/PlaceBid"
 	BiddingService_GetBidsByAuction_FullMethodName = "/proto.bidding.BiddingService/GetBidsByAuction"
+	BiddingService_GetHighestBid_FullMethodName    = "/proto.bidding.BiddingService/GetHighestBid"
+	BiddingService_GetTopBids_FullMethodName       = "/proto.bidding.BiddingService/GetTopBids"
+	BiddingService_BuyNow_FullMethodName           = "/proto.bidding.BiddingService/BuyNow"
+	BiddingService_AcceptPrice_FullMethodName      = "/proto.bidding.BiddingService/AcceptPrice"
+	BiddingService_GetAllocation_FullMethodName    = "/proto.bidding.BiddingService/GetAllocation"
+	BiddingService_RetractBid_FullMethodName       = "/proto.bidding.BiddingService/RetractBid"
+	BiddingService_CancelBid_FullMethodName        = "/proto.bidding.BiddingService/CancelBid"
 )
 
 // BiddingServiceClient is the client API for BiddingService service.
@@ -33,6 +40,20 @@ type BiddingServiceClient interface {
 	PlaceBid(ctx context.Context, in *PlaceBidRequest, opts ...grpc.CallOption) (*PlaceBidResponse, error)
 	// Retrieves all bids associated with a specific auction.
 	GetBidsByAuction(ctx context.Context, in *GetBidsByAuctionRequest, opts ...grpc.CallOption) (*GetBidsByAuctionResponse, error)
+	// Retrieves the highest bid on an auction. Used by the Auction Service to determine the winner at close.
+	GetHighestBid(ctx context.Context, in *GetHighestBidRequest, opts ...grpc.CallOption) (*GetHighestBidResponse, error)
+	// Retrieves the leading bids on an auction, best first. Used by the Auction Service to settle sealed-bid and reverse auctions.
+	GetTopBids(ctx context.Context, in *GetTopBidsRequest, opts ...grpc.CallOption) (*GetTopBidsResponse, error)
+	// Buys the auction at its buy-now price, recording a bid and closing the auction with the buyer as winner.
+	BuyNow(ctx context.Context, in *BuyNowRequest, opts ...grpc.CallOption) (*BuyNowResponse, error)
+	// Accepts a Dutch auction's current clock price, recording a bid and closing the auction with the bidder as winner.
+	AcceptPrice(ctx context.Context, in *AcceptPriceRequest, opts ...grpc.CallOption) (*AcceptPriceResponse, error)
+	// Allocates a multi-lot auction's units to its highest standing bids. Used by the Auction Service to settle multi-lot auctions.
+	GetAllocation(ctx context.Context, in *GetAllocationRequest, opts ...grpc.CallOption) (*GetAllocationResponse, error)
+	// Withdraws a bid on behalf of its bidder, subject to the retraction policy, and restores the auction price.
+	RetractBid(ctx context.Context, in *RetractBidRequest, opts ...grpc.CallOption) (*RetractBidResponse, error)
+	// Cancels a bid on behalf of the auction's seller or an admin, and restores the auction price.
+	CancelBid(ctx context.Context, in *CancelBidRequest, opts ...grpc.CallOption) (*CancelBidResponse, error)
 }
 
 type biddingServiceClient struct {
@@ -63,6 +84,76 @@ func (c *biddingServiceClient) GetBidsByAuction(ctx context.Context, in *GetBids
 	return out, nil
 }
 
+func (c *biddingServiceClient) GetHighestBid(ctx context.Context, in *GetHighestBidRequest, opts ...grpc.CallOption) (*GetHighestBidResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHighestBidResponse)
+	err := c.cc.Invoke(ctx, BiddingService_GetHighestBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biddingServiceClient) GetTopBids(ctx context.Context, in *GetTopBidsRequest, opts ...grpc.CallOption) (*GetTopBidsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTopBidsResponse)
+	err := c.cc.Invoke(ctx, BiddingService_GetTopBids_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biddingServiceClient) BuyNow(ctx context.Context, in *BuyNowRequest, opts ...grpc.CallOption) (*BuyNowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BuyNowResponse)
+	err := c.cc.Invoke(ctx, BiddingService_BuyNow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biddingServiceClient) AcceptPrice(ctx context.Context, in *AcceptPriceRequest, opts ...grpc.CallOption) (*AcceptPriceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceptPriceResponse)
+	err := c.cc.Invoke(ctx, BiddingService_AcceptPrice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biddingServiceClient) GetAllocation(ctx context.Context, in *GetAllocationRequest, opts ...grpc.CallOption) (*GetAllocationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAllocationResponse)
+	err := c.cc.Invoke(ctx, BiddingService_GetAllocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biddingServiceClient) RetractBid(ctx context.Context, in *RetractBidRequest, opts ...grpc.CallOption) (*RetractBidResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetractBidResponse)
+	err := c.cc.Invoke(ctx, BiddingService_RetractBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *biddingServiceClient) CancelBid(ctx context.Context, in *CancelBidRequest, opts ...grpc.CallOption) (*CancelBidResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelBidResponse)
+	err := c.cc.Invoke(ctx, BiddingService_CancelBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BiddingServiceServer is the server API for BiddingService service.
 // All implementations must embed UnimplementedBiddingServiceServer
 // for forward compatibility.
@@ -73,6 +164,20 @@ type BiddingServiceServer interface {
 	PlaceBid(context.Context, *PlaceBidRequest) (*PlaceBidResponse, error)
 	// Retrieves all bids associated with a specific auction.
 	GetBidsByAuction(context.Context, *GetBidsByAuctionRequest) (*GetBidsByAuctionResponse, error)
+	// Retrieves the highest bid on an auction. Used by the Auction Service to determine the winner at close.
+	GetHighestBid(context.Context, *GetHighestBidRequest) (*GetHighestBidResponse, error)
+	// Retrieves the leading bids on an auction, best first. Used by the Auction Service to settle sealed-bid and reverse auctions.
+	GetTopBids(context.Context, *GetTopBidsRequest) (*GetTopBidsResponse, error)
+	// Buys the auction at its buy-now price, recording a bid and closing the auction with the buyer as winner.
+	BuyNow(context.Context, *BuyNowRequest) (*BuyNowResponse, error)
+	// Accepts a Dutch auction's current clock price, recording a bid and closing the auction with the bidder as winner.
+	AcceptPrice(context.Context, *AcceptPriceRequest) (*AcceptPriceResponse, error)
+	// Allocates a multi-lot auction's units to its highest standing bids. Used by the Auction Service to settle multi-lot auctions.
+	GetAllocation(context.Context, *GetAllocationRequest) (*GetAllocationResponse, error)
+	// Withdraws a bid on behalf of its bidder, subject to the retraction policy, and restores the auction price.
+	RetractBid(context.Context, *RetractBidRequest) (*RetractBidResponse, error)
+	// Cancels a bid on behalf of the auction's seller or an admin, and restores the auction price.
+	CancelBid(context.Context, *CancelBidRequest) (*CancelBidResponse, error)
 	mustEmbedUnimplementedBiddingServiceServer()
 }
 
@@ -89,6 +194,27 @@ func (UnimplementedBiddingServiceServer) PlaceBid(context.Context, *PlaceBidRequ
 func (UnimplementedBiddingServiceServer) GetBidsByAuction(context.Context, *GetBidsByAuctionRequest) (*GetBidsByAuctionResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetBidsByAuction not implemented")
 }
+func (UnimplementedBiddingServiceServer) GetHighestBid(context.Context, *GetHighestBidRequest) (*GetHighestBidResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHighestBid not implemented")
+}
+func (UnimplementedBiddingServiceServer) GetTopBids(context.Context, *GetTopBidsRequest) (*GetTopBidsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTopBids not implemented")
+}
+func (UnimplementedBiddingServiceServer) BuyNow(context.Context, *BuyNowRequest) (*BuyNowResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BuyNow not implemented")
+}
+func (UnimplementedBiddingServiceServer) AcceptPrice(context.Context, *AcceptPriceRequest) (*AcceptPriceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AcceptPrice not implemented")
+}
+func (UnimplementedBiddingServiceServer) GetAllocation(context.Context, *GetAllocationRequest) (*GetAllocationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAllocation not implemented")
+}
+func (UnimplementedBiddingServiceServer) RetractBid(context.Context, *RetractBidRequest) (*RetractBidResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RetractBid not implemented")
+}
+func (UnimplementedBiddingServiceServer) CancelBid(context.Context, *CancelBidRequest) (*CancelBidResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelBid not implemented")
+}
 func (UnimplementedBiddingServiceServer) mustEmbedUnimplementedBiddingServiceServer() {}
 func (UnimplementedBiddingServiceServer) testEmbeddedByValue()                        {}
 
@@ -146,6 +272,132 @@ func _BiddingService_GetBidsByAuction_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BiddingService_GetHighestBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHighestBidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiddingServiceServer).GetHighestBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BiddingService_GetHighestBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiddingServiceServer).GetHighestBid(ctx, req.(*GetHighestBidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BiddingService_GetTopBids_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopBidsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiddingServiceServer).GetTopBids(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BiddingService_GetTopBids_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiddingServiceServer).GetTopBids(ctx, req.(*GetTopBidsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BiddingService_BuyNow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BuyNowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiddingServiceServer).BuyNow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BiddingService_BuyNow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiddingServiceServer).BuyNow(ctx, req.(*BuyNowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BiddingService_AcceptPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptPriceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiddingServiceServer).AcceptPrice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BiddingService_AcceptPrice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiddingServiceServer).AcceptPrice(ctx, req.(*AcceptPriceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BiddingService_GetAllocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllocationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiddingServiceServer).GetAllocation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BiddingService_GetAllocation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiddingServiceServer).GetAllocation(ctx, req.(*GetAllocationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BiddingService_RetractBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetractBidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiddingServiceServer).RetractBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BiddingService_RetractBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiddingServiceServer).RetractBid(ctx, req.(*RetractBidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BiddingService_CancelBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BiddingServiceServer).CancelBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BiddingService_CancelBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BiddingServiceServer).CancelBid(ctx, req.(*CancelBidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BiddingService_ServiceDesc is the grpc.ServiceDesc for BiddingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,6 +413,34 @@ var BiddingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBidsByAuction",
 			Handler:    _BiddingService_GetBidsByAuction_Handler,
 		},
+		{
+			MethodName: "GetHighestBid",
+			Handler:    _BiddingService_GetHighestBid_Handler,
+		},
+		{
+			MethodName: "GetTopBids",
+			Handler:    _BiddingService_GetTopBids_Handler,
+		},
+		{
+			MethodName: "BuyNow",
+			Handler:    _BiddingService_BuyNow_Handler,
+		},
+		{
+			MethodName: "AcceptPrice",
+			Handler:    _BiddingService_AcceptPrice_Handler,
+		},
+		{
+			MethodName: "GetAllocation",
+			Handler:    _BiddingService_GetAllocation_Handler,
+		},
+		{
+			MethodName: "RetractBid",
+			Handler:    _BiddingService_RetractBid_Handler,
+		},
+		{
+			MethodName: "CancelBid",
+			Handler:    _BiddingService_CancelBid_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "bidding.proto",
@@ -1,19 +1,36 @@
 package main
 
 import (
-	"log"
+	"context"
 
+	"github.com/temesgen-abebayehu/bidflow/backend/common/auth"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/config"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/api-gateway/routes"
+	"go.uber.org/zap"
 )
 
 func main() {
 	cfg := config.LoadConfig("api-gateway")
+	log := logger.New(logger.Config{
+		Level:       "info",
+		Development: cfg.Env == "development",
+		ServiceName: cfg.ServiceName,
+	})
 
-	r := routes.SetupRouter(cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	tm := auth.NewVerifier(ctx, cfg.JWKSURL, cfg.JWKSRefreshInterval, cfg.JWTSecret, log)
+	// Turn away revoked tokens at the edge too, as the auth service revokes them
+	denylist := auth.NewDenylist()
+	tm.UseDenylist(denylist)
+	revocations := auth.WatchRevocations(ctx, cfg.KafkaBrokers, denylist, log)
+	defer revocations.Close()
 
-	log.Printf("API Gateway starting on port %s", cfg.HTTPPort)
+	r := routes.SetupRouter(cfg, tm)
+
+	log.Info("API Gateway starting on port " + cfg.HTTPPort)
 	if err := r.Run(":" + cfg.HTTPPort); err != nil {
-		log.Fatalf("Failed to run server: %v", err)
+		log.Fatal("Failed to run server", zap.Error(err))
 	}
 }
@@ -4,12 +4,14 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/auth"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/config"
 )
 
-func SetupRouter(cfg *config.Config) *gin.Engine {
+func SetupRouter(cfg *config.Config, tm *auth.TokenManager) *gin.Engine {
 	r := gin.Default()
 
 	r.GET("/health", func(c *gin.Context) {
@@ -38,21 +40,73 @@ func SetupRouter(cfg *config.Config) *gin.Engine {
 		}
 	}
 
+	// Public keys that verify the tokens the Auth Service issues
+	r.GET("/.well-known/jwks.json", proxy(cfg.AuthServiceURL))
+
 	api := r.Group("/api/v1")
 	{
-		// Auth Service (handles both auth and users)
+		// Auth Service (handles both auth and users). Login, refreshing and logging
+		// out may come with an expired token, so /auth checks its own.
 		api.Any("/auth/*any", proxy(cfg.AuthServiceURL))
-		api.Any("/users/*any", proxy(cfg.AuthServiceURL))
+		api.Any("/users/*any", verifyBearer(tm, nil), proxy(cfg.AuthServiceURL))
 
 		// Auction Service
-		api.Any("/auctions/*any", proxy(cfg.AuctionServiceURL))
+		api.Any("/auctions/*any", verifyBearer(tm, isRead), proxy(cfg.AuctionServiceURL))
 
 		// Bidding Service
-		api.Any("/bids/*any", proxy(cfg.BiddingServiceURL))
+		api.Any("/bids/*any", verifyBearer(tm, isRead), proxy(cfg.BiddingServiceURL))
 
-		// Notification Service
-		api.Any("/notifications/*any", proxy(cfg.NotificationServiceURL))
+		// Notification Service. The WebSocket carries its token in the query.
+		api.Any("/notifications/*any", verifyBearer(tm, func(req *http.Request) bool {
+			return req.URL.Path == "/api/v1/notifications/ws"
+		}), proxy(cfg.NotificationServiceURL))
 	}
 
 	return r
 }
+
+// isRead reports whether req only reads, which auctions and bids allow without a login.
+func isRead(req *http.Request) bool {
+	return req.Method == http.MethodGet || req.Method == http.MethodHead
+}
+
+// verifyBearer turns away a request that needs a login unless its bearer token
+// verifies and has not been revoked, before it reaches a service. A request for which
+// public reports true goes through either way; a bearer token that doesn't verify is
+// dropped from it, so a client with a stale token still gets the public answer.
+func verifyBearer(tm *auth.TokenManager, public func(*http.Request) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if public != nil && public(c.Request) {
+			if !validBearer(tm, c.GetHeader("Authorization")) {
+				c.Request.Header.Del("Authorization")
+			}
+			c.Next()
+			return
+		}
+
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
+			return
+		}
+		if _, ok := strings.CutPrefix(header, "Bearer "); !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token format"})
+			return
+		}
+		if !validBearer(tm, header) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func validBearer(tm *auth.TokenManager, header string) bool {
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return false
+	}
+	_, err := tm.VerifyToken(token)
+	return err == nil
+}
@@ -18,8 +18,15 @@ const (
 	AuctionStatusClosed    AuctionStatus = "CLOSED"
 	AuctionStatusPending   AuctionStatus = "PENDING"
 	AuctionStatusCancelled AuctionStatus = "CANCELLED"
+	// AuctionStatusReserveNotMet is a closed auction whose top bid stayed under the reserve price.
+	AuctionStatusReserveNotMet AuctionStatus = "RESERVE_NOT_MET"
 )
 
+// IsFinal reports whether the auction can no longer change.
+func (s AuctionStatus) IsFinal() bool {
+	return s == AuctionStatusClosed || s == AuctionStatusCancelled || s == AuctionStatusReserveNotMet
+}
+
 type Auction struct {
 	ID           string        `json:"id" gorm:"primaryKey"`
 	SellerID     string        `json:"seller_id"`
@@ -32,8 +39,86 @@ type Auction struct {
 	EndTime      time.Time     `json:"end_time"`
 	Category     string        `json:"category"`
 	ImageURL     string        `json:"image_url"`
-	CreatedAt    time.Time     `json:"created_at"`
-	UpdatedAt    time.Time     `json:"updated_at"`
+	WinnerID     string        `json:"winner_id,omitempty"` // Set when the auction closes with a winning bid
+	WinningBidID string        `json:"winning_bid_id,omitempty"`
+	MinIncrement IncrementRule `json:"min_increment,omitempty"`
+	ReservePrice float64       `json:"-"` // Hidden from bidders; see ReserveMet
+	// A bid accepted less than ExtensionWindow before EndTime pushes EndTime back by
+	// ExtensionDuration, at most MaxExtensions times. A zero window disables extensions.
+	ExtensionWindow   Seconds `json:"extension_window,omitempty"`
+	ExtensionDuration Seconds `json:"extension_duration,omitempty"`
+	MaxExtensions     int     `json:"max_extensions,omitempty"`
+	ExtensionCount    int     `json:"extension_count"`
+	BuyNowPrice       float64 `json:"buy_now_price,omitempty"` // Zero means no buy-now option
+	// AuctionType is the format; in sealed formats CurrentPrice stays at StartPrice until close,
+	// in reverse auctions it only goes down. Dutch auctions store StartPrice until accepted
+	// and are read at their ClockPrice.
+	AuctionType AuctionType `json:"auction_type"`
+	// Dutch auctions only: the clock drops the price by ClockStep every ClockInterval,
+	// stopping at FloorPrice.
+	ClockStep     float64 `json:"clock_step,omitempty"`
+	ClockInterval Seconds `json:"clock_interval,omitempty"`
+	FloorPrice    float64 `json:"floor_price,omitempty"`
+	// Quantity identical units are on sale. Multi-lot auctions allocate them to the highest
+	// bids at close, priced by LotPricing, and record the result in Winners rather than
+	// WinnerID; CurrentPrice stays at StartPrice until then and becomes the clearing price.
+	Quantity   int        `json:"quantity"`
+	LotPricing LotPricing `json:"lot_pricing,omitempty"`
+	Winners    LotWinners `json:"winners,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// Seconds is a duration exchanged as whole seconds, like the Unix timestamps of the API.
+type Seconds int64
+
+func (s Seconds) Duration() time.Duration {
+	return time.Duration(s) * time.Second
+}
+
+// HasReserve reports whether the seller set a reserve price.
+func (a *Auction) HasReserve() bool {
+	return a.ReservePrice > 0
+}
+
+// ReserveMet reports whether the current price has reached the reserve. Auctions
+// without a reserve always meet it.
+func (a *Auction) ReserveMet() bool {
+	return a.CurrentPrice >= a.ReservePrice
+}
+
+// BuyNowAvailable reports whether the auction can still be bought outright: it has a
+// buy-now price and bidding has not reached cutoff (a fraction) of it.
+func (a *Auction) BuyNowAvailable(cutoff float64) bool {
+	return a.BuyNowPrice > 0 && a.CurrentPrice < a.BuyNowPrice*cutoff
+}
+
+// ExtensionFor returns the end time a bid accepted at now moves the auction to, and
+// false if the bid falls outside the extension window or the cap has been reached.
+func (a *Auction) ExtensionFor(now time.Time) (time.Time, bool) {
+	if a.ExtensionWindow <= 0 || a.ExtensionDuration <= 0 || a.ExtensionCount >= a.MaxExtensions {
+		return time.Time{}, false
+	}
+	if a.EndTime.Sub(now) > a.ExtensionWindow.Duration() {
+		return time.Time{}, false
+	}
+	return a.EndTime.Add(a.ExtensionDuration.Duration()), true
+}
+
+// AuctionOptions are the optional bidding rules of a new auction.
+type AuctionOptions struct {
+	MinIncrement      IncrementRule
+	ReservePrice      float64
+	ExtensionWindow   Seconds
+	ExtensionDuration Seconds
+	MaxExtensions     int // Zero falls back to the service default
+	BuyNowPrice       float64
+	AuctionType       AuctionType // Empty means ENGLISH
+	ClockStep         float64     // DUTCH only
+	ClockInterval     Seconds     // DUTCH only
+	FloorPrice        float64     // DUTCH only
+	Quantity          int         // Zero means 1
+	LotPricing        LotPricing  // Multi-lot only; empty means UNIFORM
 }
 
 type AuctionRepository interface {
@@ -42,20 +127,133 @@ type AuctionRepository interface {
 	Update(ctx context.Context, auction *Auction) error
 	Delete(ctx context.Context, id string) error
 	List(ctx context.Context, page, limit int, status AuctionStatus, category string) ([]Auction, int64, error)
+	// ActivateDue moves up to limit PENDING auctions whose start time has passed to ACTIVE
+	// and returns them. Rows locked by another replica are skipped.
+	ActivateDue(ctx context.Context, now time.Time, limit int) ([]Auction, error)
+	// ListExpired returns up to limit ACTIVE auctions whose end time has passed.
+	ListExpired(ctx context.Context, now time.Time, limit int) ([]Auction, error)
+	// LockOpen locks the auction's row until the transaction ends, so no bid can be
+	// accepted meanwhile, if it is still open and its end time is still auction.EndTime.
+	// It reports whether it is.
+	LockOpen(ctx context.Context, auction *Auction) (bool, error)
+	// Close persists the auction's final status together with its winner and final price, but
+	// only if it is still open and its end time is still auction.EndTime, i.e. no late bid
+	// extended it since it was read. It reports whether this call performed the transition.
+	Close(ctx context.Context, auction *Auction) (bool, error)
+	// RaisePrice sets the current price to amount in a single conditional update that only
+	// matches an ACTIVE, unexpired auction whose price is still expectedPrice. It reports
+	// whether the price was raised (or, in a reverse auction, lowered); false means the
+	// auction closed or another bid moved the price first.
+	RaisePrice(ctx context.Context, auctionID string, expectedPrice, amount float64, now time.Time) (bool, error)
+	// Extend moves an ACTIVE auction's end time from endTime to newEndTime and counts the
+	// extension, unless the end time already moved or the extension cap has been reached.
+	Extend(ctx context.Context, auctionID string, endTime, newEndTime time.Time) (bool, error)
+	// BuyNow closes an ACTIVE, unexpired auction whose price is still expectedPrice at its
+	// buy-now price, with auction.WinnerID and auction.WinningBidID as the winner. It reports
+	// whether this call closed the auction; false means a bid or another buyer got there first.
+	BuyNow(ctx context.Context, auction *Auction, expectedPrice float64, now time.Time) (bool, error)
+	// AddSealedBidder records that bidderID placed their one bid on an ACTIVE, unexpired
+	// sealed auction. It reports false if the bidder already bid or the auction closed.
+	AddSealedBidder(ctx context.Context, auctionID, bidderID string, now time.Time) (bool, error)
+	// HasSealedBidder reports whether bidderID already bid on the sealed auction.
+	HasSealedBidder(ctx context.Context, auctionID, bidderID string) (bool, error)
+	// RemoveSealedBidder undoes AddSealedBidder while the auction is still ACTIVE. It
+	// reports whether the bidder was removed.
+	RemoveSealedBidder(ctx context.Context, auctionID, bidderID string) (bool, error)
+	// ClaimClock closes an ACTIVE, unexpired Dutch auction at price, with auction.WinnerID
+	// and auction.WinningBidID as the winner. It reports whether this call closed the
+	// auction; false means another buyer accepted first or the auction ended.
+	ClaimClock(ctx context.Context, auction *Auction, price float64, now time.Time) (bool, error)
+}
+
+// BidRejectionReason explains why AcceptBid turned a bid down.
+type BidRejectionReason string
+
+const (
+	BidRejectionNone              BidRejectionReason = ""
+	BidRejectionAuctionNotFound   BidRejectionReason = "AUCTION_NOT_FOUND"
+	BidRejectionNotActive         BidRejectionReason = "AUCTION_NOT_ACTIVE"
+	BidRejectionEnded             BidRejectionReason = "AUCTION_ENDED"
+	BidRejectionTooLow            BidRejectionReason = "BID_TOO_LOW"
+	BidRejectionTooHigh           BidRejectionReason = "BID_TOO_HIGH" // reverse auctions
+	BidRejectionBuyNowUnavailable BidRejectionReason = "BUY_NOW_UNAVAILABLE"
+	BidRejectionAlreadyBid        BidRejectionReason = "ALREADY_BID"
+	BidRejectionWrongType         BidRejectionReason = "WRONG_AUCTION_TYPE" // bids on Dutch auctions, clock acceptances elsewhere
+	BidRejectionInvalidQuantity   BidRejectionReason = "INVALID_QUANTITY"
+)
+
+// BidDecision is the outcome of AcceptBid.
+type BidDecision struct {
+	Accepted     bool
+	CurrentPrice float64
+	MinNextBid   float64 // lowest amount the auction will accept next; zero if the auction was not found
+	MaxNextBid   float64 // highest amount a reverse auction will accept next; zero for other types
+	Reason       BidRejectionReason
+	Message      string
+	Sealed       bool   // the auction is sealed-bid, so the accepted amount must not be disclosed
+	Reverse      bool   // the auction is a reverse auction, where bids go down
+	MultiLot     bool   // the auction sells several units, allocated from the bids at close
+	SellerID     string // set once the bid is accepted, so the bidding service can tell the seller
+}
+
+// WinningBid is a top bid on an auction as reported by the bidding service.
+type WinningBid struct {
+	BidID    string
+	BidderID string
+	Amount   float64
+	Units    int // GetAllocation only: units allocated to the bid
+}
+
+type BiddingClient interface {
+	// GetHighestBid returns nil if the auction received no bids.
+	GetHighestBid(ctx context.Context, auctionID string) (*WinningBid, error)
+	// GetTopBids returns up to limit of the highest bids, highest first and equal
+	// amounts by time placed.
+	GetTopBids(ctx context.Context, auctionID string, limit int) ([]WinningBid, error)
+	// GetLowestBid returns the lowest bid, which leads a reverse auction, or nil if
+	// the auction received no bids.
+	GetLowestBid(ctx context.Context, auctionID string) (*WinningBid, error)
+	// GetAllocation allocates quantity units to the highest standing bids and returns the
+	// bids that won any, best first.
+	GetAllocation(ctx context.Context, auctionID string, quantity int) ([]WinningBid, error)
+}
+
+// Transactor runs fn in one database transaction. Repository calls and
+// EventProducer calls made with the ctx passed to fn are committed together.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type EventProducer interface {
 	PublishAuctionCreated(ctx context.Context, auction *Auction) error
 	PublishAuctionUpdated(ctx context.Context, auction *Auction) error
-	PublishAuctionClosed(ctx context.Context, auction *Auction, winnerID string) error
+	// PublishAuctionClosed announces the closed auction with AllWinners.
+	PublishAuctionClosed(ctx context.Context, auction *Auction) error
+	PublishAuctionExtended(ctx context.Context, auction *Auction, previousEndTime time.Time) error
 }
 
 type AuctionService interface {
-	CreateAuction(ctx context.Context, sellerID, title, description string, startPrice float64, startTime, endTime time.Time, category, imageURL string) (*Auction, error)
+	CreateAuction(ctx context.Context, sellerID, title, description string, startPrice float64, startTime, endTime time.Time, category, imageURL string, opts AuctionOptions) (*Auction, error)
 	GetAuction(ctx context.Context, id string) (*Auction, error)
 	ListAuctions(ctx context.Context, page, limit int, status string, category string) ([]Auction, int64, error)
 	UpdateAuction(ctx context.Context, id string, title, description, imageURL string) (*Auction, error)
 	CloseAuction(ctx context.Context, id string) error
-	ValidateBid(ctx context.Context, auctionID string, amount float64) (bool, string, error)
-	UpdateCurrentPrice(ctx context.Context, auctionID string, amount float64) error
+	ValidateBid(ctx context.Context, auctionID, bidderID string, amount float64) (bool, string, error)
+	// AcceptBid accepts a bid of amount per unit for quantity units; only multi-lot
+	// auctions take more than one.
+	AcceptBid(ctx context.Context, auctionID, bidderID string, amount float64, quantity int) (*BidDecision, error)
+	// AcceptBuyNow closes the auction at its buy-now price with buyerID as winner and bidID
+	// as the winning bid. The decision's CurrentPrice is the price paid.
+	AcceptBuyNow(ctx context.Context, auctionID, buyerID, bidID string) (*BidDecision, error)
+	// BuyNowAvailable reports whether auction can still be bought at its buy-now price.
+	BuyNowAvailable(auction *Auction) bool
+	// AcceptClockPrice closes a Dutch auction at its current clock price with buyerID as
+	// winner and bidID as the winning bid. The decision's CurrentPrice is the price paid.
+	AcceptClockPrice(ctx context.Context, auctionID, buyerID, bidID string) (*BidDecision, error)
+	// RestorePrice recomputes the current price after a bid of retractedAmount was
+	// withdrawn. It returns the current price and whether it moved.
+	RestorePrice(ctx context.Context, auctionID string, retractedAmount float64) (float64, bool, error)
+	// ReleaseSealedBid lets bidderID bid on the sealed auction again after the bid it
+	// accepted could not be recorded. It reports whether the bidder was released.
+	ReleaseSealedBid(ctx context.Context, auctionID, bidderID string) (bool, error)
 }
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// ClockPrice is a Dutch auction's price at now: StartPrice until the first tick, then
+// ClockStep lower after every full ClockInterval since StartTime, never below FloorPrice.
+// It is a pure function of the auction, so every replica reads the same price.
+func (a *Auction) ClockPrice(now time.Time) float64 {
+	return math.Max(roundCents(a.StartPrice-float64(a.clockTicks(now))*a.ClockStep), a.FloorPrice)
+}
+
+// NextTick returns when the clock price next drops, and false once the price has
+// reached the floor or the next drop would come at or after EndTime.
+func (a *Auction) NextTick(now time.Time) (time.Time, bool) {
+	if a.ClockInterval <= 0 || a.ClockPrice(now) <= a.FloorPrice {
+		return time.Time{}, false
+	}
+	next := a.StartTime.Add(time.Duration(a.clockTicks(now)+1) * a.ClockInterval.Duration())
+	if !next.Before(a.EndTime) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+// clockTicks is the number of full intervals between StartTime and now.
+func (a *Auction) clockTicks(now time.Time) int64 {
+	if a.ClockInterval <= 0 || now.Before(a.StartTime) {
+		return 0
+	}
+	return int64(now.Sub(a.StartTime) / a.ClockInterval.Duration())
+}
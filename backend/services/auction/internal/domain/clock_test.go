@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAuction_ClockPrice(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	auction := Auction{
+		AuctionType:   AuctionTypeDutch,
+		StartPrice:    100,
+		ClockStep:     7.5,
+		ClockInterval: 60,
+		FloorPrice:    70,
+		StartTime:     start,
+		EndTime:       start.Add(time.Hour),
+	}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		wantPrice float64
+		wantNext  time.Time // zero once the clock has stopped
+	}{
+		{"Before Start", start.Add(-time.Minute), 100, start.Add(time.Minute)},
+		{"At Start", start, 100, start.Add(time.Minute)},
+		{"Mid Interval", start.Add(90 * time.Second), 92.5, start.Add(2 * time.Minute)},
+		{"On A Tick", start.Add(2 * time.Minute), 85, start.Add(3 * time.Minute)},
+		{"Last Step Above Floor", start.Add(4 * time.Minute), 70, time.Time{}},
+		{"Held At Floor", start.Add(30 * time.Minute), 70, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := auction.ClockPrice(tt.now); got != tt.wantPrice {
+				t.Errorf("ClockPrice() = %.2f, want %.2f", got, tt.wantPrice)
+			}
+			next, ok := auction.NextTick(tt.now)
+			if ok != !tt.wantNext.IsZero() || !next.Equal(tt.wantNext) {
+				t.Errorf("NextTick() = %v, %v; want %v", next, ok, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestAuction_NextTick_StopsAtEndTime(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	auction := Auction{StartPrice: 100, ClockStep: 1, ClockInterval: 60, StartTime: start, EndTime: start.Add(2 * time.Minute)}
+
+	if _, ok := auction.NextTick(start.Add(90 * time.Second)); ok {
+		t.Error("expected no tick at the end time")
+	}
+}
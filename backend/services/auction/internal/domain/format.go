@@ -0,0 +1,87 @@
+package domain
+
+import "errors"
+
+var ErrInvalidAuctionType = errors.New("invalid auction type")
+
+// AuctionType is the auction format, which decides how bids are taken and how the winner pays.
+type AuctionType string
+
+const (
+	// AuctionTypeEnglish is an open ascending auction: every bid must beat the current
+	// price and the highest bidder pays their bid.
+	AuctionTypeEnglish AuctionType = "ENGLISH"
+	// AuctionTypeSealedFirstPrice takes one hidden bid per bidder; the highest bidder
+	// pays their bid.
+	AuctionTypeSealedFirstPrice AuctionType = "SEALED_FIRST_PRICE"
+	// AuctionTypeSealedSecondPrice (Vickrey) takes one hidden bid per bidder; the highest
+	// bidder pays the runner-up's bid.
+	AuctionTypeSealedSecondPrice AuctionType = "SEALED_SECOND_PRICE"
+	// AuctionTypeReverse is a procurement auction: the creator is the buyer, StartPrice
+	// is their ceiling, and verified companies bid the price down. The lowest bid wins.
+	AuctionTypeReverse AuctionType = "REVERSE"
+	// AuctionTypeDutch is a descending clock auction: the price starts at StartPrice and
+	// drops by ClockStep every ClockInterval down to FloorPrice. The first bidder to
+	// accept the clock price wins and pays it.
+	AuctionTypeDutch AuctionType = "DUTCH"
+)
+
+// ParseAuctionType returns the type named by s; an empty s is an English auction.
+func ParseAuctionType(s string) (AuctionType, error) {
+	switch t := AuctionType(s); t {
+	case "":
+		return AuctionTypeEnglish, nil
+	case AuctionTypeEnglish, AuctionTypeSealedFirstPrice, AuctionTypeSealedSecondPrice, AuctionTypeReverse, AuctionTypeDutch:
+		return t, nil
+	}
+	return "", ErrInvalidAuctionType
+}
+
+// IsSealed reports whether bids stay hidden until the auction closes.
+func (t AuctionType) IsSealed() bool {
+	return t == AuctionTypeSealedFirstPrice || t == AuctionTypeSealedSecondPrice
+}
+
+// IsReverse reports whether bids must go down rather than up.
+func (t AuctionType) IsReverse() bool {
+	return t == AuctionTypeReverse
+}
+
+// IsDutch reports whether the auction is won by accepting a falling clock price
+// rather than by bidding.
+func (t AuctionType) IsDutch() bool {
+	return t == AuctionTypeDutch
+}
+
+// SettlementBids is how many of the top bids Settle needs to price the auction.
+func (t AuctionType) SettlementBids() int {
+	if t == AuctionTypeSealedSecondPrice {
+		return 2
+	}
+	return 1
+}
+
+// Settle picks the winner from top, the auction's leading bids ordered best first (the
+// highest, or the lowest in a reverse auction; ties already broken by time), and the
+// price they pay. A second-price winner pays the runner-up's bid, or the start price
+// without one, and never less than a reserve their own bid meets; so the price reaches
+// the reserve exactly when the winning bid does, and ReserveMet holds for the settled
+// price. Settle returns nil if there are no bids.
+func (a *Auction) Settle(top []WinningBid) (*WinningBid, float64) {
+	if len(top) == 0 {
+		return nil, 0
+	}
+	winner := top[0]
+	if a.AuctionType != AuctionTypeSealedSecondPrice {
+		return &winner, winner.Amount
+	}
+
+	price := a.StartPrice
+	if len(top) > 1 && top[1].Amount > price {
+		price = top[1].Amount
+	}
+	if winner.Amount >= a.ReservePrice && a.ReservePrice > price {
+		price = a.ReservePrice
+	}
+	return &winner, price
+}
@@ -0,0 +1,71 @@
+package domain
+
+import "testing"
+
+func TestParseAuctionType(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    AuctionType
+		wantErr bool
+	}{
+		{"", AuctionTypeEnglish, false},
+		{"ENGLISH", AuctionTypeEnglish, false},
+		{"SEALED_FIRST_PRICE", AuctionTypeSealedFirstPrice, false},
+		{"SEALED_SECOND_PRICE", AuctionTypeSealedSecondPrice, false},
+		{"REVERSE", AuctionTypeReverse, false},
+		{"DUTCH", AuctionTypeDutch, false},
+		{"JAPANESE", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAuctionType(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseAuctionType(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestAuction_Settle(t *testing.T) {
+	top := []WinningBid{
+		{BidID: "b1", BidderID: "u1", Amount: 150},
+		{BidID: "b2", BidderID: "u2", Amount: 120},
+	}
+
+	tests := []struct {
+		name      string
+		auction   Auction
+		bids      []WinningBid
+		wantBid   string
+		wantPrice float64
+	}{
+		{"No Bids", Auction{AuctionType: AuctionTypeSealedSecondPrice, StartPrice: 100}, nil, "", 0},
+		{"English", Auction{AuctionType: AuctionTypeEnglish, StartPrice: 100}, top[:1], "b1", 150},
+		{"First Price", Auction{AuctionType: AuctionTypeSealedFirstPrice, StartPrice: 100}, top, "b1", 150},
+		{"Second Price", Auction{AuctionType: AuctionTypeSealedSecondPrice, StartPrice: 100}, top, "b1", 120},
+		{"Second Price Single Bid", Auction{AuctionType: AuctionTypeSealedSecondPrice, StartPrice: 100}, top[:1], "b1", 100},
+		{"Second Price Raised To Reserve", Auction{AuctionType: AuctionTypeSealedSecondPrice, StartPrice: 100, ReservePrice: 140}, top, "b1", 140},
+		{"Second Price Reserve Not Met", Auction{AuctionType: AuctionTypeSealedSecondPrice, StartPrice: 100, ReservePrice: 200}, top, "b1", 120},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			winner, price := tt.auction.Settle(tt.bids)
+			if tt.wantBid == "" {
+				if winner != nil {
+					t.Fatalf("expected no winner, got %+v", winner)
+				}
+				return
+			}
+			if winner == nil || winner.BidID != tt.wantBid {
+				t.Fatalf("expected winning bid %s, got %+v", tt.wantBid, winner)
+			}
+			if price != tt.wantPrice {
+				t.Errorf("expected price %.2f, got %.2f", tt.wantPrice, price)
+			}
+			tt.auction.CurrentPrice = price
+			if met := tt.auction.ReserveMet(); met != (winner.Amount >= tt.auction.ReservePrice) {
+				t.Errorf("ReserveMet() = %v for a winning bid of %.2f against a reserve of %.2f", met, winner.Amount, tt.auction.ReservePrice)
+			}
+		})
+	}
+}
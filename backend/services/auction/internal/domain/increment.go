@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// MinBidStep is the smallest raise any auction accepts: one cent.
+const MinBidStep = 0.01
+
+var ErrInvalidIncrement = errors.New("invalid minimum increment")
+
+// IncrementTier sets the minimum raise for prices from MinPrice up to the next
+// tier. Exactly one of Amount (fixed) and Percent (of the current price) is set.
+type IncrementTier struct {
+	MinPrice float64 `json:"min_price"`
+	Amount   float64 `json:"amount,omitempty"`
+	Percent  float64 `json:"percent,omitempty"`
+}
+
+// IncrementRule is an auction's minimum bid increment: a tier table ordered by
+// MinPrice whose first tier starts at zero. A fixed increment is a single tier.
+// An empty rule only requires a raise of MinBidStep.
+type IncrementRule []IncrementTier
+
+// FixedIncrement is a rule that always requires the same raise.
+func FixedIncrement(amount float64) IncrementRule {
+	return IncrementRule{{MinPrice: 0, Amount: amount}}
+}
+
+func (r IncrementRule) Validate() error {
+	for i, t := range r {
+		if i == 0 && t.MinPrice != 0 {
+			return fmt.Errorf("%w: first tier must start at 0", ErrInvalidIncrement)
+		}
+		if i > 0 && t.MinPrice <= r[i-1].MinPrice {
+			return fmt.Errorf("%w: tiers must be in ascending min_price order", ErrInvalidIncrement)
+		}
+		if (t.Amount > 0) == (t.Percent > 0) || t.Amount < 0 || t.Percent < 0 {
+			return fmt.Errorf("%w: each tier needs either a positive amount or a positive percent", ErrInvalidIncrement)
+		}
+	}
+	return nil
+}
+
+// At returns the minimum raise over price, never less than MinBidStep.
+func (r IncrementRule) At(price float64) float64 {
+	step := 0.0
+	for _, t := range r {
+		if price < t.MinPrice {
+			break
+		}
+		step = t.Amount
+		if t.Percent > 0 {
+			step = price * t.Percent / 100
+		}
+	}
+	return math.Max(roundCents(step), MinBidStep)
+}
+
+// MinNextBid is the lowest bid accepted while the auction stands at price.
+func (r IncrementRule) MinNextBid(price float64) float64 {
+	return roundCents(price + r.At(price))
+}
+
+// MaxNextBid is the highest bid accepted while a reverse auction stands at price: the
+// rule then sets the minimum decrement instead of the minimum raise.
+func (r IncrementRule) MaxNextBid(price float64) float64 {
+	return math.Max(roundCents(price-r.At(price)), 0)
+}
+
+// UnmarshalJSON accepts either a tier table or a plain number as shorthand for
+// a fixed increment.
+func (r *IncrementRule) UnmarshalJSON(data []byte) error {
+	var amount float64
+	if err := json.Unmarshal(data, &amount); err == nil {
+		if amount == 0 {
+			*r = nil
+			return nil
+		}
+		*r = FixedIncrement(amount)
+		return nil
+	}
+
+	var tiers []IncrementTier
+	if err := json.Unmarshal(data, &tiers); err != nil {
+		return err
+	}
+	*r = tiers
+	return nil
+}
+
+// Value stores the rule as JSONB; an empty rule is stored as NULL.
+func (r IncrementRule) Value() (driver.Value, error) {
+	if len(r) == 0 {
+		return nil, nil
+	}
+	return json.Marshal([]IncrementTier(r))
+}
+
+func (r *IncrementRule) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*r = nil
+		return nil
+	case []byte:
+		return r.UnmarshalJSON(v)
+	case string:
+		return r.UnmarshalJSON([]byte(v))
+	default:
+		return fmt.Errorf("cannot scan %T into IncrementRule", src)
+	}
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
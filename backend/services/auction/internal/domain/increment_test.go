@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestIncrementRule_MinNextBid(t *testing.T) {
+	tiered := IncrementRule{
+		{MinPrice: 0, Amount: 0.5},
+		{MinPrice: 100, Amount: 5},
+		{MinPrice: 1000, Percent: 2.5},
+	}
+
+	tests := []struct {
+		name  string
+		rule  IncrementRule
+		price float64
+		want  float64
+	}{
+		{"Empty Rule", nil, 10, 10.01},
+		{"Fixed", FixedIncrement(2), 10, 12},
+		{"First Tier", tiered, 99.99, 100.49},
+		{"Tier Boundary", tiered, 100, 105},
+		{"Percent Tier", tiered, 2000, 2050},
+		{"Percent Rounds To Cents", IncrementRule{{MinPrice: 0, Percent: 1}}, 12.34, 12.46},
+		{"Never Below One Cent", IncrementRule{{MinPrice: 0, Percent: 0.01}}, 1, 1.01},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.MinNextBid(tt.price); got != tt.want {
+				t.Errorf("MinNextBid(%.2f) = %.2f, want %.2f", tt.price, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncrementRule_MaxNextBid(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  IncrementRule
+		price float64
+		want  float64
+	}{
+		{"Empty Rule", nil, 10, 9.99},
+		{"Fixed", FixedIncrement(2), 10, 8},
+		{"Percent", IncrementRule{{MinPrice: 0, Percent: 1}}, 12.34, 12.22},
+		{"Never Below Zero", FixedIncrement(5), 3, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.MaxNextBid(tt.price); got != tt.want {
+				t.Errorf("MaxNextBid(%.2f) = %.2f, want %.2f", tt.price, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIncrementRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    IncrementRule
+		wantErr bool
+	}{
+		{"Empty", nil, false},
+		{"Fixed", FixedIncrement(1), false},
+		{"Tiers", IncrementRule{{MinPrice: 0, Amount: 1}, {MinPrice: 100, Percent: 5}}, false},
+		{"First Tier Not Zero", IncrementRule{{MinPrice: 10, Amount: 1}}, true},
+		{"Unordered", IncrementRule{{MinPrice: 0, Amount: 1}, {MinPrice: 100, Amount: 2}, {MinPrice: 50, Amount: 3}}, true},
+		{"Amount And Percent", IncrementRule{{MinPrice: 0, Amount: 1, Percent: 5}}, true},
+		{"Neither", IncrementRule{{MinPrice: 0}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidIncrement) {
+				t.Errorf("expected ErrInvalidIncrement, got %v", err)
+			}
+		})
+	}
+}
+
+func TestIncrementRule_UnmarshalJSON(t *testing.T) {
+	var fixed IncrementRule
+	if err := json.Unmarshal([]byte(`2.5`), &fixed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fixed) != 1 || fixed[0].Amount != 2.5 {
+		t.Errorf("expected a single fixed tier of 2.5, got %+v", fixed)
+	}
+
+	var tiers IncrementRule
+	if err := json.Unmarshal([]byte(`[{"min_price":0,"amount":1},{"min_price":100,"percent":5}]`), &tiers); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tiers) != 2 || tiers[1].Percent != 5 {
+		t.Errorf("expected two tiers, got %+v", tiers)
+	}
+
+	if err := json.Unmarshal([]byte(`"five"`), &tiers); err == nil {
+		t.Error("expected error for a string increment, got nil")
+	}
+}
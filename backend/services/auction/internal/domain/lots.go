@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidLotPricing = errors.New("invalid lot pricing")
+
+// LotPricing decides what the winners of a multi-lot auction pay per unit.
+type LotPricing string
+
+const (
+	// LotPricingUniform charges every winner the lowest winning bid, the clearing price.
+	LotPricingUniform LotPricing = "UNIFORM"
+	// LotPricingDiscriminatory charges every winner their own bid (pay-as-bid).
+	LotPricingDiscriminatory LotPricing = "DISCRIMINATORY"
+)
+
+// ParseLotPricing returns the pricing named by s; an empty s is uniform pricing.
+func ParseLotPricing(s string) (LotPricing, error) {
+	switch p := LotPricing(s); p {
+	case "":
+		return LotPricingUniform, nil
+	case LotPricingUniform, LotPricingDiscriminatory:
+		return p, nil
+	}
+	return "", ErrInvalidLotPricing
+}
+
+// LotWinner is a bid that won units of an auction, and the price paid per unit.
+type LotWinner struct {
+	BidID    string  `json:"bid_id"`
+	BidderID string  `json:"bidder_id"`
+	Units    int     `json:"units"`
+	Price    float64 `json:"price"`
+}
+
+// LotWinners is stored as JSONB; an empty list is stored as NULL.
+type LotWinners []LotWinner
+
+func (w LotWinners) Value() (driver.Value, error) {
+	if len(w) == 0 {
+		return nil, nil
+	}
+	return json.Marshal([]LotWinner(w))
+}
+
+func (w *LotWinners) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*w = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*[]LotWinner)(w))
+	case string:
+		return json.Unmarshal([]byte(v), (*[]LotWinner)(w))
+	default:
+		return fmt.Errorf("cannot scan %T into LotWinners", src)
+	}
+}
+
+// IsMultiLot reports whether the auction sells several identical units.
+func (a *Auction) IsMultiLot() bool {
+	return a.Quantity > 1
+}
+
+// SettleLots prices allocated, the winning bids of a multi-lot auction best first with
+// the units each won, under the auction's lot pricing. It also returns the clearing
+// price, the lowest winning bid, which is 0 without bids.
+func (a *Auction) SettleLots(allocated []WinningBid) (LotWinners, float64) {
+	if len(allocated) == 0 {
+		return nil, 0
+	}
+	clearing := allocated[len(allocated)-1].Amount
+
+	winners := make(LotWinners, len(allocated))
+	for i, bid := range allocated {
+		price := bid.Amount
+		if a.LotPricing != LotPricingDiscriminatory {
+			price = clearing
+		}
+		winners[i] = LotWinner{BidID: bid.BidID, BidderID: bid.BidderID, Units: bid.Units, Price: price}
+	}
+	return winners, clearing
+}
+
+// AllWinners lists who won the closed auction: the settled winners of a multi-lot
+// auction, or the single winner of any other, for one unit at the final price.
+func (a *Auction) AllWinners() LotWinners {
+	if a.IsMultiLot() || a.WinnerID == "" {
+		return a.Winners
+	}
+	return LotWinners{{BidID: a.WinningBidID, BidderID: a.WinnerID, Units: 1, Price: a.CurrentPrice}}
+}
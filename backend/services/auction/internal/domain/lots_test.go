@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLotPricing(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    LotPricing
+		wantErr bool
+	}{
+		{"", LotPricingUniform, false},
+		{"UNIFORM", LotPricingUniform, false},
+		{"DISCRIMINATORY", LotPricingDiscriminatory, false},
+		{"VICKREY", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLotPricing(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseLotPricing(%q) = %q, %v; want %q, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestAuction_SettleLots(t *testing.T) {
+	allocated := []WinningBid{
+		{BidID: "bid-1", BidderID: "alice", Amount: 120, Units: 2},
+		{BidID: "bid-2", BidderID: "bob", Amount: 110, Units: 1},
+		{BidID: "bid-3", BidderID: "carol", Amount: 100, Units: 2}, // partially filled
+	}
+
+	tests := []struct {
+		name    string
+		pricing LotPricing
+		prices  []float64
+	}{
+		{"Uniform", LotPricingUniform, []float64{100, 100, 100}},
+		{"Discriminatory", LotPricingDiscriminatory, []float64{120, 110, 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auction := &Auction{Quantity: 5, LotPricing: tt.pricing}
+
+			winners, clearing := auction.SettleLots(allocated)
+
+			if clearing != 100 {
+				t.Errorf("expected clearing price 100, got %v", clearing)
+			}
+			var prices []float64
+			for i, w := range winners {
+				if w.BidID != allocated[i].BidID || w.Units != allocated[i].Units {
+					t.Errorf("winner %d: expected %s for %d units, got %+v", i, allocated[i].BidID, allocated[i].Units, w)
+				}
+				prices = append(prices, w.Price)
+			}
+			if !reflect.DeepEqual(prices, tt.prices) {
+				t.Errorf("expected prices %v, got %v", tt.prices, prices)
+			}
+		})
+	}
+
+	t.Run("No Bids", func(t *testing.T) {
+		winners, clearing := (&Auction{Quantity: 5}).SettleLots(nil)
+		if winners != nil || clearing != 0 {
+			t.Errorf("expected no winners, got %v at %v", winners, clearing)
+		}
+	})
+}
+
+func TestAuction_AllWinners(t *testing.T) {
+	single := &Auction{Quantity: 1, CurrentPrice: 150, WinnerID: "alice", WinningBidID: "bid-1"}
+	want := LotWinners{{BidID: "bid-1", BidderID: "alice", Units: 1, Price: 150}}
+	if got := single.AllWinners(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if got := (&Auction{Quantity: 1}).AllWinners(); got != nil {
+		t.Errorf("expected no winners without a winning bid, got %v", got)
+	}
+
+	multi := &Auction{Quantity: 3, Winners: want}
+	if got := multi.AllWinners(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the settled winners, got %v", got)
+	}
+}
@@ -5,9 +5,10 @@ import (
 )
 
 const (
-	TopicAuctionCreated = "auction.created"
-	TopicAuctionUpdated = "auction.updated"
-	TopicAuctionClosed  = "auction.closed"
+	TopicAuctionCreated  = "auction.created"
+	TopicAuctionUpdated  = "auction.updated"
+	TopicAuctionClosed   = "auction.closed"
+	TopicAuctionExtended = "auction.extended"
 )
 
 type AuctionCreatedEvent struct {
@@ -19,6 +20,12 @@ type AuctionCreatedEvent struct {
 	EndTime    time.Time `json:"end_time"`
 	Category   string    `json:"category"`
 	Timestamp  time.Time `json:"timestamp"`
+
+	AuctionType string `json:"auction_type"`
+	// Dutch auctions only: enough to run the price clock without asking the auction service
+	ClockStep     float64 `json:"clock_step,omitempty"`
+	ClockInterval int64   `json:"clock_interval,omitempty"` // seconds
+	FloorPrice    float64 `json:"floor_price,omitempty"`
 }
 
 type AuctionUpdatedEvent struct {
@@ -30,8 +37,30 @@ type AuctionUpdatedEvent struct {
 }
 
 type AuctionClosedEvent struct {
-	AuctionID  string    `json:"auction_id"`
-	FinalPrice float64   `json:"final_price"`
-	WinnerID   string    `json:"winner_id,omitempty"`
-	Timestamp  time.Time `json:"timestamp"`
+	AuctionID  string  `json:"auction_id"`
+	SellerID   string  `json:"seller_id"`
+	Title      string  `json:"title"`
+	FinalPrice float64 `json:"final_price"` // the clearing price of a multi-lot auction
+	// Winners lists every winning bid, best first; a single-item auction has at most one
+	Winners   []Winner  `json:"winners"`
+	Status    string    `json:"status"` // CLOSED, or RESERVE_NOT_MET when there is no winner below the reserve
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Winner is a bid that won units of a closed auction.
+type Winner struct {
+	BidID    string  `json:"bid_id"`
+	BidderID string  `json:"bidder_id"`
+	Units    int     `json:"units"`
+	Price    float64 `json:"price"` // per unit
+}
+
+// AuctionExtendedEvent is published when a late bid pushes an auction's end time back.
+type AuctionExtendedEvent struct {
+	AuctionID       string    `json:"auction_id"`
+	PreviousEndTime time.Time `json:"previous_end_time"`
+	EndTime         time.Time `json:"end_time"`
+	ExtensionCount  int       `json:"extension_count"`
+	MaxExtensions   int       `json:"max_extensions"`
+	Timestamp       time.Time `json:"timestamp"`
 }
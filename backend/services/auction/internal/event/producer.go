@@ -9,10 +9,12 @@ import (
 )
 
 type KafkaEventProducer struct {
-	producer *kafka.Producer
+	producer kafka.Publisher
 }
 
-func NewKafkaEventProducer(producer *kafka.Producer) domain.EventProducer {
+// NewKafkaEventProducer publishes through producer; pass a kafka.Outbox to
+// commit events atomically with the repository writes.
+func NewKafkaEventProducer(producer kafka.Publisher) domain.EventProducer {
 	return &KafkaEventProducer{producer: producer}
 }
 
@@ -26,6 +28,11 @@ func (p *KafkaEventProducer) PublishAuctionCreated(ctx context.Context, auction
 		EndTime:    auction.EndTime,
 		Category:   auction.Category,
 		Timestamp:  time.Now(),
+
+		AuctionType:   string(auction.AuctionType),
+		ClockStep:     auction.ClockStep,
+		ClockInterval: int64(auction.ClockInterval),
+		FloorPrice:    auction.FloorPrice,
 	}
 	return p.producer.Publish(ctx, TopicAuctionCreated, auction.ID, event)
 }
@@ -41,12 +48,32 @@ func (p *KafkaEventProducer) PublishAuctionUpdated(ctx context.Context, auction
 	return p.producer.Publish(ctx, TopicAuctionUpdated, auction.ID, event)
 }
 
-func (p *KafkaEventProducer) PublishAuctionClosed(ctx context.Context, auction *domain.Auction, winnerID string) error {
+func (p *KafkaEventProducer) PublishAuctionClosed(ctx context.Context, auction *domain.Auction) error {
+	winners := []Winner{}
+	for _, w := range auction.AllWinners() {
+		winners = append(winners, Winner{BidID: w.BidID, BidderID: w.BidderID, Units: w.Units, Price: w.Price})
+	}
+
 	event := AuctionClosedEvent{
 		AuctionID:  auction.ID,
+		SellerID:   auction.SellerID,
+		Title:      auction.Title,
 		FinalPrice: auction.CurrentPrice,
-		WinnerID:   winnerID,
+		Winners:    winners,
+		Status:     string(auction.Status),
 		Timestamp:  time.Now(),
 	}
 	return p.producer.Publish(ctx, TopicAuctionClosed, auction.ID, event)
 }
+
+func (p *KafkaEventProducer) PublishAuctionExtended(ctx context.Context, auction *domain.Auction, previousEndTime time.Time) error {
+	event := AuctionExtendedEvent{
+		AuctionID:       auction.ID,
+		PreviousEndTime: previousEndTime,
+		EndTime:         auction.EndTime,
+		ExtensionCount:  auction.ExtensionCount,
+		MaxExtensions:   auction.MaxExtensions,
+		Timestamp:       time.Now(),
+	}
+	return p.producer.Publish(ctx, TopicAuctionExtended, auction.ID, event)
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	pb "github.com/temesgen-abebayehu/bidflow/backend/proto/pb"
@@ -33,25 +34,27 @@ func (h *GrpcHandler) CreateAuction(ctx context.Context, req *pb.CreateAuctionRe
 		endTime,
 		req.Category,
 		req.ImageUrl,
+		domain.AuctionOptions{
+			MinIncrement:      fromPbIncrement(req.MinIncrement),
+			ReservePrice:      req.ReservePrice,
+			ExtensionWindow:   domain.Seconds(req.ExtensionWindow),
+			ExtensionDuration: domain.Seconds(req.ExtensionDuration),
+			MaxExtensions:     int(req.MaxExtensions),
+			BuyNowPrice:       req.BuyNowPrice,
+			AuctionType:       domain.AuctionType(req.AuctionType),
+			ClockStep:         req.ClockStep,
+			ClockInterval:     domain.Seconds(req.ClockInterval),
+			FloorPrice:        req.FloorPrice,
+			Quantity:          int(req.Quantity),
+			LotPricing:        domain.LotPricing(req.LotPricing),
+		},
 	)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create auction: %v", err)
 	}
 
 	return &pb.CreateAuctionResponse{
-		Auction: &pb.Auction{
-			Id:           auction.ID,
-			SellerId:     auction.SellerID,
-			Title:        auction.Title,
-			Description:  auction.Description,
-			StartPrice:   auction.StartPrice,
-			CurrentPrice: auction.CurrentPrice,
-			Status:       string(auction.Status),
-			StartTime:    auction.StartTime.Unix(),
-			EndTime:      auction.EndTime.Unix(),
-			Category:     auction.Category,
-			ImageUrl:     auction.ImageURL,
-		},
+		Auction: toPbAuction(auction),
 	}, nil
 }
 
@@ -62,19 +65,7 @@ func (h *GrpcHandler) GetAuction(ctx context.Context, req *pb.GetAuctionRequest)
 	}
 
 	return &pb.GetAuctionResponse{
-		Auction: &pb.Auction{
-			Id:           auction.ID,
-			SellerId:     auction.SellerID,
-			Title:        auction.Title,
-			Description:  auction.Description,
-			StartPrice:   auction.StartPrice,
-			CurrentPrice: auction.CurrentPrice,
-			Status:       string(auction.Status),
-			StartTime:    auction.StartTime.Unix(),
-			EndTime:      auction.EndTime.Unix(),
-			Category:     auction.Category,
-			ImageUrl:     auction.ImageURL,
-		},
+		Auction: toPbAuction(auction),
 	}, nil
 }
 
@@ -85,20 +76,8 @@ func (h *GrpcHandler) ListAuctions(ctx context.Context, req *pb.ListAuctionsRequ
 	}
 
 	var pbAuctions []*pb.Auction
-	for _, a := range auctions {
-		pbAuctions = append(pbAuctions, &pb.Auction{
-			Id:           a.ID,
-			SellerId:     a.SellerID,
-			Title:        a.Title,
-			Description:  a.Description,
-			StartPrice:   a.StartPrice,
-			CurrentPrice: a.CurrentPrice,
-			Status:       string(a.Status),
-			StartTime:    a.StartTime.Unix(),
-			EndTime:      a.EndTime.Unix(),
-			Category:     a.Category,
-			ImageUrl:     a.ImageURL,
-		})
+	for i := range auctions {
+		pbAuctions = append(pbAuctions, toPbAuction(&auctions[i]))
 	}
 
 	return &pb.ListAuctionsResponse{
@@ -114,19 +93,7 @@ func (h *GrpcHandler) UpdateAuction(ctx context.Context, req *pb.UpdateAuctionRe
 	}
 
 	return &pb.UpdateAuctionResponse{
-		Auction: &pb.Auction{
-			Id:           auction.ID,
-			SellerId:     auction.SellerID,
-			Title:        auction.Title,
-			Description:  auction.Description,
-			StartPrice:   auction.StartPrice,
-			CurrentPrice: auction.CurrentPrice,
-			Status:       string(auction.Status),
-			StartTime:    auction.StartTime.Unix(),
-			EndTime:      auction.EndTime.Unix(),
-			Category:     auction.Category,
-			ImageUrl:     auction.ImageURL,
-		},
+		Auction: toPbAuction(auction),
 	}, nil
 }
 
@@ -139,7 +106,7 @@ func (h *GrpcHandler) CloseAuction(ctx context.Context, req *pb.CloseAuctionRequ
 }
 
 func (h *GrpcHandler) ValidateBid(ctx context.Context, req *pb.BidRequest) (*pb.BidResponse, error) {
-	isValid, msg, err := h.service.ValidateBid(ctx, req.AuctionId, req.Amount)
+	isValid, msg, err := h.service.ValidateBid(ctx, req.AuctionId, req.BidderId, req.Amount)
 	if err != nil {
 		// If error is "not found", return valid=false with message
 		return &pb.BidResponse{IsValid: false, Message: msg}, nil
@@ -164,19 +131,177 @@ func (h *GrpcHandler) GetAuctionStatus(ctx context.Context, req *pb.StatusReques
 		return nil, status.Errorf(codes.NotFound, "auction not found")
 	}
 
+	var nextTick int64
+	if next, ok := auction.NextTick(time.Now()); ok && auction.Status == domain.AuctionStatusActive {
+		nextTick = next.Unix()
+	}
+
 	return &pb.StatusResponse{
 		AuctionId:    auction.ID,
 		Title:        auction.Title,
 		CurrentPrice: auction.CurrentPrice,
 		Status:       string(auction.Status),
 		EndTimeUnix:  auction.EndTime.Unix(),
+		HasReserve:   auction.HasReserve(),
+		ReserveMet:   auction.ReserveMet(),
+
+		BuyNowAvailable: h.service.BuyNowAvailable(auction),
+		AuctionType:     string(auction.AuctionType),
+		NextTickUnix:    nextTick,
+		Quantity:        int32(max(auction.Quantity, 1)),
+		SellerId:        auction.SellerID,
+	}, nil
+}
+
+func (h *GrpcHandler) AcceptBid(ctx context.Context, req *pb.AcceptBidRequest) (*pb.AcceptBidResponse, error) {
+	decision, err := h.service.AcceptBid(ctx, req.AuctionId, req.BidderId, req.Amount, int(req.Quantity))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to accept bid: %v", err)
+	}
+
+	return &pb.AcceptBidResponse{
+		Accepted:     decision.Accepted,
+		CurrentPrice: decision.CurrentPrice,
+		MinNextBid:   decision.MinNextBid,
+		Reason:       toPbRejectionReason(decision.Reason),
+		Message:      decision.Message,
+		Sealed:       decision.Sealed,
+		MaxNextBid:   decision.MaxNextBid,
+		Reverse:      decision.Reverse,
+		MultiLot:     decision.MultiLot,
+		SellerId:     decision.SellerID,
+	}, nil
+}
+
+func (h *GrpcHandler) AcceptBuyNow(ctx context.Context, req *pb.AcceptBuyNowRequest) (*pb.AcceptBuyNowResponse, error) {
+	decision, err := h.service.AcceptBuyNow(ctx, req.AuctionId, req.BuyerId, req.BidId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to accept buy now: %v", err)
+	}
+
+	return &pb.AcceptBuyNowResponse{
+		Accepted: decision.Accepted,
+		Price:    decision.CurrentPrice,
+		Reason:   toPbRejectionReason(decision.Reason),
+		Message:  decision.Message,
+	}, nil
+}
+
+func (h *GrpcHandler) AcceptClockPrice(ctx context.Context, req *pb.AcceptClockPriceRequest) (*pb.AcceptClockPriceResponse, error) {
+	decision, err := h.service.AcceptClockPrice(ctx, req.AuctionId, req.BuyerId, req.BidId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to accept clock price: %v", err)
+	}
+
+	return &pb.AcceptClockPriceResponse{
+		Accepted: decision.Accepted,
+		Price:    decision.CurrentPrice,
+		Reason:   toPbRejectionReason(decision.Reason),
+		Message:  decision.Message,
 	}, nil
 }
 
-func (h *GrpcHandler) UpdateAuctionPrice(ctx context.Context, req *pb.UpdateAuctionPriceRequest) (*pb.UpdateAuctionPriceResponse, error) {
-	err := h.service.UpdateCurrentPrice(ctx, req.AuctionId, req.Amount)
+func (h *GrpcHandler) RestorePrice(ctx context.Context, req *pb.RestorePriceRequest) (*pb.RestorePriceResponse, error) {
+	price, restored, err := h.service.RestorePrice(ctx, req.AuctionId, req.RetractedAmount)
+	if errors.Is(err, domain.ErrAuctionNotFound) {
+		return nil, status.Errorf(codes.NotFound, "auction not found")
+	}
 	if err != nil {
-		return &pb.UpdateAuctionPriceResponse{Success: false, Message: err.Error()}, nil
+		return nil, status.Errorf(codes.Internal, "failed to restore price: %v", err)
+	}
+
+	return &pb.RestorePriceResponse{
+		Restored:     restored,
+		CurrentPrice: price,
+	}, nil
+}
+
+func (h *GrpcHandler) ReleaseSealedBid(ctx context.Context, req *pb.ReleaseSealedBidRequest) (*pb.ReleaseSealedBidResponse, error) {
+	released, err := h.service.ReleaseSealedBid(ctx, req.AuctionId, req.BidderId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to release sealed bid: %v", err)
+	}
+
+	return &pb.ReleaseSealedBidResponse{Released: released}, nil
+}
+
+func toPbRejectionReason(reason domain.BidRejectionReason) pb.BidRejectionReason {
+	switch reason {
+	case domain.BidRejectionAuctionNotFound:
+		return pb.BidRejectionReason_AUCTION_NOT_FOUND
+	case domain.BidRejectionNotActive:
+		return pb.BidRejectionReason_AUCTION_NOT_ACTIVE
+	case domain.BidRejectionEnded:
+		return pb.BidRejectionReason_AUCTION_ENDED
+	case domain.BidRejectionTooLow:
+		return pb.BidRejectionReason_BID_TOO_LOW
+	case domain.BidRejectionBuyNowUnavailable:
+		return pb.BidRejectionReason_BUY_NOW_UNAVAILABLE
+	case domain.BidRejectionAlreadyBid:
+		return pb.BidRejectionReason_ALREADY_BID
+	case domain.BidRejectionTooHigh:
+		return pb.BidRejectionReason_BID_TOO_HIGH
+	case domain.BidRejectionWrongType:
+		return pb.BidRejectionReason_WRONG_AUCTION_TYPE
+	case domain.BidRejectionInvalidQuantity:
+		return pb.BidRejectionReason_INVALID_QUANTITY
+	default:
+		return pb.BidRejectionReason_BID_REJECTION_REASON_UNSPECIFIED
+	}
+}
+
+func toPbAuction(a *domain.Auction) *pb.Auction {
+	return &pb.Auction{
+		Id:           a.ID,
+		SellerId:     a.SellerID,
+		Title:        a.Title,
+		Description:  a.Description,
+		StartPrice:   a.StartPrice,
+		CurrentPrice: a.CurrentPrice,
+		Status:       string(a.Status),
+		StartTime:    a.StartTime.Unix(),
+		EndTime:      a.EndTime.Unix(),
+		Category:     a.Category,
+		ImageUrl:     a.ImageURL,
+		WinnerId:     a.WinnerID,
+		WinningBidId: a.WinningBidID,
+		MinIncrement: toPbIncrement(a.MinIncrement),
+
+		ExtensionWindow:   int64(a.ExtensionWindow),
+		ExtensionDuration: int64(a.ExtensionDuration),
+		MaxExtensions:     int32(a.MaxExtensions),
+		ExtensionCount:    int32(a.ExtensionCount),
+		BuyNowPrice:       a.BuyNowPrice,
+		AuctionType:       string(a.AuctionType),
+		ClockStep:         a.ClockStep,
+		ClockInterval:     int64(a.ClockInterval),
+		FloorPrice:        a.FloorPrice,
+		Quantity:          int32(a.Quantity),
+		LotPricing:        string(a.LotPricing),
+		Winners:           toPbWinners(a.Winners),
+	}
+}
+
+func toPbWinners(winners domain.LotWinners) []*pb.LotWinner {
+	var lots []*pb.LotWinner
+	for _, w := range winners {
+		lots = append(lots, &pb.LotWinner{BidId: w.BidID, BidderId: w.BidderID, Units: int32(w.Units), Price: w.Price})
+	}
+	return lots
+}
+
+func toPbIncrement(rule domain.IncrementRule) []*pb.IncrementTier {
+	var tiers []*pb.IncrementTier
+	for _, t := range rule {
+		tiers = append(tiers, &pb.IncrementTier{MinPrice: t.MinPrice, Amount: t.Amount, Percent: t.Percent})
+	}
+	return tiers
+}
+
+func fromPbIncrement(tiers []*pb.IncrementTier) domain.IncrementRule {
+	var rule domain.IncrementRule
+	for _, t := range tiers {
+		rule = append(rule, domain.IncrementTier{MinPrice: t.MinPrice, Amount: t.Amount, Percent: t.Percent})
 	}
-	return &pb.UpdateAuctionPriceResponse{Success: true, Message: "Price updated successfully"}, nil
+	return rule
 }
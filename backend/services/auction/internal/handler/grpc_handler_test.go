@@ -14,18 +14,22 @@ import (
 
 // MockAuctionService is a mock implementation of domain.AuctionService
 type MockAuctionService struct {
-	CreateAuctionFunc      func(ctx context.Context, sellerID, title, description string, startPrice float64, startTime, endTime time.Time, category, imageURL string) (*domain.Auction, error)
-	GetAuctionFunc         func(ctx context.Context, id string) (*domain.Auction, error)
-	ListAuctionsFunc       func(ctx context.Context, page, limit int, status string, category string) ([]domain.Auction, int64, error)
-	UpdateAuctionFunc      func(ctx context.Context, id string, title, description, imageURL string) (*domain.Auction, error)
-	CloseAuctionFunc       func(ctx context.Context, id string) error
-	ValidateBidFunc        func(ctx context.Context, auctionID string, amount float64) (bool, string, error)
-	UpdateCurrentPriceFunc func(ctx context.Context, auctionID string, amount float64) error
+	CreateAuctionFunc    func(ctx context.Context, sellerID, title, description string, startPrice float64, startTime, endTime time.Time, category, imageURL string, opts domain.AuctionOptions) (*domain.Auction, error)
+	GetAuctionFunc       func(ctx context.Context, id string) (*domain.Auction, error)
+	ListAuctionsFunc     func(ctx context.Context, page, limit int, status string, category string) ([]domain.Auction, int64, error)
+	UpdateAuctionFunc    func(ctx context.Context, id string, title, description, imageURL string) (*domain.Auction, error)
+	CloseAuctionFunc     func(ctx context.Context, id string) error
+	ValidateBidFunc      func(ctx context.Context, auctionID, bidderID string, amount float64) (bool, string, error)
+	AcceptBidFunc        func(ctx context.Context, auctionID, bidderID string, amount float64, quantity int) (*domain.BidDecision, error)
+	AcceptBuyNowFunc     func(ctx context.Context, auctionID, buyerID, bidID string) (*domain.BidDecision, error)
+	AcceptClockPriceFunc func(ctx context.Context, auctionID, buyerID, bidID string) (*domain.BidDecision, error)
+	RestorePriceFunc     func(ctx context.Context, auctionID string, retractedAmount float64) (float64, bool, error)
+	ReleaseSealedBidFunc func(ctx context.Context, auctionID, bidderID string) (bool, error)
 }
 
-func (m *MockAuctionService) CreateAuction(ctx context.Context, sellerID, title, description string, startPrice float64, startTime, endTime time.Time, category, imageURL string) (*domain.Auction, error) {
+func (m *MockAuctionService) CreateAuction(ctx context.Context, sellerID, title, description string, startPrice float64, startTime, endTime time.Time, category, imageURL string, opts domain.AuctionOptions) (*domain.Auction, error) {
 	if m.CreateAuctionFunc != nil {
-		return m.CreateAuctionFunc(ctx, sellerID, title, description, startPrice, startTime, endTime, category, imageURL)
+		return m.CreateAuctionFunc(ctx, sellerID, title, description, startPrice, startTime, endTime, category, imageURL, opts)
 	}
 	return nil, nil
 }
@@ -58,23 +62,55 @@ func (m *MockAuctionService) CloseAuction(ctx context.Context, id string) error
 	return nil
 }
 
-func (m *MockAuctionService) ValidateBid(ctx context.Context, auctionID string, amount float64) (bool, string, error) {
+func (m *MockAuctionService) ValidateBid(ctx context.Context, auctionID, bidderID string, amount float64) (bool, string, error) {
 	if m.ValidateBidFunc != nil {
-		return m.ValidateBidFunc(ctx, auctionID, amount)
+		return m.ValidateBidFunc(ctx, auctionID, bidderID, amount)
 	}
 	return false, "", nil
 }
 
-func (m *MockAuctionService) UpdateCurrentPrice(ctx context.Context, auctionID string, amount float64) error {
-	if m.UpdateCurrentPriceFunc != nil {
-		return m.UpdateCurrentPriceFunc(ctx, auctionID, amount)
+func (m *MockAuctionService) AcceptBid(ctx context.Context, auctionID, bidderID string, amount float64, quantity int) (*domain.BidDecision, error) {
+	if m.AcceptBidFunc != nil {
+		return m.AcceptBidFunc(ctx, auctionID, bidderID, amount, quantity)
 	}
-	return nil
+	return &domain.BidDecision{}, nil
+}
+
+func (m *MockAuctionService) AcceptBuyNow(ctx context.Context, auctionID, buyerID, bidID string) (*domain.BidDecision, error) {
+	if m.AcceptBuyNowFunc != nil {
+		return m.AcceptBuyNowFunc(ctx, auctionID, buyerID, bidID)
+	}
+	return &domain.BidDecision{}, nil
+}
+
+func (m *MockAuctionService) AcceptClockPrice(ctx context.Context, auctionID, buyerID, bidID string) (*domain.BidDecision, error) {
+	if m.AcceptClockPriceFunc != nil {
+		return m.AcceptClockPriceFunc(ctx, auctionID, buyerID, bidID)
+	}
+	return &domain.BidDecision{}, nil
+}
+
+func (m *MockAuctionService) RestorePrice(ctx context.Context, auctionID string, retractedAmount float64) (float64, bool, error) {
+	if m.RestorePriceFunc != nil {
+		return m.RestorePriceFunc(ctx, auctionID, retractedAmount)
+	}
+	return 0, false, nil
+}
+
+func (m *MockAuctionService) ReleaseSealedBid(ctx context.Context, auctionID, bidderID string) (bool, error) {
+	if m.ReleaseSealedBidFunc != nil {
+		return m.ReleaseSealedBidFunc(ctx, auctionID, bidderID)
+	}
+	return false, nil
+}
+
+func (m *MockAuctionService) BuyNowAvailable(auction *domain.Auction) bool {
+	return auction.BuyNowAvailable(0.5)
 }
 
 func TestCreateAuction_Grpc(t *testing.T) {
 	mockSvc := &MockAuctionService{
-		CreateAuctionFunc: func(ctx context.Context, sellerID, title, description string, startPrice float64, startTime, endTime time.Time, category, imageURL string) (*domain.Auction, error) {
+		CreateAuctionFunc: func(ctx context.Context, sellerID, title, description string, startPrice float64, startTime, endTime time.Time, category, imageURL string, opts domain.AuctionOptions) (*domain.Auction, error) {
 			return &domain.Auction{ID: "123"}, nil
 		},
 	}
@@ -104,7 +140,7 @@ func TestGetAuction_Grpc(t *testing.T) {
 	mockSvc := &MockAuctionService{
 		GetAuctionFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
 			if id == "found" {
-				return &domain.Auction{ID: "found"}, nil
+				return &domain.Auction{ID: "found", Status: domain.AuctionStatusClosed, WinnerID: "user-1", WinningBidID: "bid-1"}, nil
 			}
 			return nil, errors.New("not found")
 		},
@@ -119,6 +155,9 @@ func TestGetAuction_Grpc(t *testing.T) {
 		if resp.Auction.Id != "found" {
 			t.Errorf("expected id found, got %s", resp.Auction.Id)
 		}
+		if resp.Auction.WinnerId != "user-1" || resp.Auction.WinningBidId != "bid-1" {
+			t.Errorf("expected winner user-1 with bid-1, got %s with %s", resp.Auction.WinnerId, resp.Auction.WinningBidId)
+		}
 	})
 
 	t.Run("Not Found", func(t *testing.T) {
@@ -148,7 +187,7 @@ func TestListAuctions_Grpc(t *testing.T) {
 
 func TestValidateBid_Grpc(t *testing.T) {
 	mockSvc := &MockAuctionService{
-		ValidateBidFunc: func(ctx context.Context, auctionID string, amount float64) (bool, string, error) {
+		ValidateBidFunc: func(ctx context.Context, auctionID, bidderID string, amount float64) (bool, string, error) {
 			if amount > 100 {
 				return true, "valid", nil
 			}
@@ -180,3 +219,57 @@ func TestValidateBid_Grpc(t *testing.T) {
 		}
 	})
 }
+
+func TestAcceptBid_Grpc(t *testing.T) {
+	mockSvc := &MockAuctionService{
+		AcceptBidFunc: func(ctx context.Context, auctionID, bidderID string, amount float64, quantity int) (*domain.BidDecision, error) {
+			if amount > 100 {
+				return &domain.BidDecision{Accepted: true, CurrentPrice: amount}, nil
+			}
+			return &domain.BidDecision{CurrentPrice: 100, Reason: domain.BidRejectionTooLow, Message: "low bid"}, nil
+		},
+	}
+	h := NewGrpcHandler(mockSvc)
+
+	t.Run("Accepted", func(t *testing.T) {
+		resp, err := h.AcceptBid(context.Background(), &pb.AcceptBidRequest{AuctionId: "1", Amount: 150})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !resp.Accepted || resp.CurrentPrice != 150 {
+			t.Errorf("expected accepted at 150, got %+v", resp)
+		}
+	})
+
+	t.Run("Too Low", func(t *testing.T) {
+		resp, err := h.AcceptBid(context.Background(), &pb.AcceptBidRequest{AuctionId: "1", Amount: 50})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if resp.Accepted || resp.Reason != pb.BidRejectionReason_BID_TOO_LOW {
+			t.Errorf("expected BID_TOO_LOW rejection, got %+v", resp)
+		}
+	})
+}
+
+func TestGetAuctionStatus_Dutch(t *testing.T) {
+	start := time.Now().Add(-90 * time.Second)
+	mockSvc := &MockAuctionService{
+		GetAuctionFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+			return &domain.Auction{
+				ID: id, Status: domain.AuctionStatusActive, AuctionType: domain.AuctionTypeDutch,
+				StartPrice: 100, CurrentPrice: 95, ClockStep: 5, ClockInterval: 60, FloorPrice: 50,
+				StartTime: start, EndTime: start.Add(time.Hour),
+			}, nil
+		},
+	}
+	h := NewGrpcHandler(mockSvc)
+
+	resp, err := h.GetAuctionStatus(context.Background(), &pb.StatusRequest{AuctionId: "1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := start.Add(2 * time.Minute).Unix(); resp.AuctionType != "DUTCH" || resp.NextTickUnix != want {
+		t.Errorf("expected the next tick at %d, got %+v", want, resp)
+	}
+}
@@ -25,6 +25,28 @@ type createAuctionRequest struct {
 	EndTime     int64   `json:"end_time" binding:"required"`
 	Category    string  `json:"category" binding:"required"`
 	ImageURL    string  `json:"image_url"`
+	// MinIncrement is a fixed amount (e.g. 5) or a tier table
+	// (e.g. [{"min_price":0,"amount":1},{"min_price":100,"percent":5}]).
+	MinIncrement domain.IncrementRule `json:"min_increment"`
+	ReservePrice float64              `json:"reserve_price" binding:"omitempty,gte=0"`
+	// Anti-sniping: a bid within ExtensionWindow seconds of the end pushes the end
+	// back by ExtensionDuration seconds, at most MaxExtensions times.
+	ExtensionWindow   int64 `json:"extension_window" binding:"omitempty,gte=0"`
+	ExtensionDuration int64 `json:"extension_duration" binding:"omitempty,gte=0"`
+	MaxExtensions     int   `json:"max_extensions" binding:"omitempty,gte=0"`
+	// BuyNowPrice lets a buyer end the auction immediately at this price.
+	BuyNowPrice float64 `json:"buy_now_price" binding:"omitempty,gt=0"`
+	// AuctionType defaults to ENGLISH. Sealed formats hide bids until the auction closes;
+	// REVERSE auctions are bid down from StartPrice by verified companies.
+	AuctionType string `json:"auction_type" binding:"omitempty,oneof=ENGLISH SEALED_FIRST_PRICE SEALED_SECOND_PRICE REVERSE DUTCH"`
+	// DUTCH auctions drop the price by ClockStep every ClockInterval seconds, down to FloorPrice.
+	ClockStep     float64 `json:"clock_step" binding:"omitempty,gt=0"`
+	ClockInterval int64   `json:"clock_interval" binding:"omitempty,gt=0"`
+	FloorPrice    float64 `json:"floor_price" binding:"omitempty,gte=0"`
+	// Quantity identical units are sold to the highest bids, each paying the lowest winning
+	// bid (UNIFORM, the default) or their own bid (DISCRIMINATORY).
+	Quantity   int    `json:"quantity" binding:"omitempty,gte=1"`
+	LotPricing string `json:"lot_pricing" binding:"omitempty,oneof=UNIFORM DISCRIMINATORY"`
 }
 
 func (h *HttpHandler) CreateAuction(c *gin.Context) {
@@ -54,6 +76,20 @@ func (h *HttpHandler) CreateAuction(c *gin.Context) {
 		endTime,
 		req.Category,
 		req.ImageURL,
+		domain.AuctionOptions{
+			MinIncrement:      req.MinIncrement,
+			ReservePrice:      req.ReservePrice,
+			ExtensionWindow:   domain.Seconds(req.ExtensionWindow),
+			ExtensionDuration: domain.Seconds(req.ExtensionDuration),
+			MaxExtensions:     req.MaxExtensions,
+			BuyNowPrice:       req.BuyNowPrice,
+			AuctionType:       domain.AuctionType(req.AuctionType),
+			ClockStep:         req.ClockStep,
+			ClockInterval:     domain.Seconds(req.ClockInterval),
+			FloorPrice:        req.FloorPrice,
+			Quantity:          req.Quantity,
+			LotPricing:        domain.LotPricing(req.LotPricing),
+		},
 	)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -18,7 +18,7 @@ func TestCreateAuction_Http(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	mockSvc := &MockAuctionService{
-		CreateAuctionFunc: func(ctx context.Context, sellerID, title, description string, startPrice float64, startTime, endTime time.Time, category, imageURL string) (*domain.Auction, error) {
+		CreateAuctionFunc: func(ctx context.Context, sellerID, title, description string, startPrice float64, startTime, endTime time.Time, category, imageURL string, opts domain.AuctionOptions) (*domain.Auction, error) {
 			return &domain.Auction{ID: "123"}, nil
 		},
 	}
@@ -6,9 +6,20 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/temesgen-abebayehu/bidflow/backend/common/database"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/auction/internal/domain"
 )
 
+// auctionColumns is the column list of every auction read, in scanAuction order.
+const auctionColumns = `id, seller_id, title, description, start_price, current_price,
+	status, start_time, end_time, category, image_url,
+	COALESCE(winner_id, ''), COALESCE(winning_bid_id, ''),
+	min_increment, COALESCE(reserve_price, 0),
+	extension_window, extension_duration, max_extensions, extension_count,
+	COALESCE(buy_now_price, 0), auction_type,
+	clock_step, clock_interval, floor_price,
+	quantity, COALESCE(lot_pricing, ''), winners, created_at, updated_at`
+
 type postgresRepo struct {
 	db *sql.DB
 }
@@ -17,41 +28,48 @@ func NewPostgresRepo(db *sql.DB) domain.AuctionRepository {
 	return &postgresRepo{db: db}
 }
 
+// conn joins the transaction carried by ctx, if any.
+func (r *postgresRepo) conn(ctx context.Context) database.DBTX {
+	return database.Conn(ctx, r.db)
+}
+
 func (r *postgresRepo) Create(ctx context.Context, auction *domain.Auction) error {
 	query := `
 		INSERT INTO auctions (
 			id, seller_id, title, description, start_price, current_price, 
-			status, start_time, end_time, category, image_url, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			status, start_time, end_time, category, image_url, min_increment,
+			reserve_price, extension_window, extension_duration, max_extensions,
+			buy_now_price, auction_type, clock_step, clock_interval, floor_price,
+			quantity, lot_pricing, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25)
 	`
 
 	now := time.Now()
 	auction.CreatedAt = now
 	auction.UpdatedAt = now
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := r.conn(ctx).ExecContext(ctx, query,
 		auction.ID, auction.SellerID, auction.Title, auction.Description,
 		auction.StartPrice, auction.CurrentPrice, auction.Status,
 		auction.StartTime, auction.EndTime, auction.Category,
-		auction.ImageURL, auction.CreatedAt, auction.UpdatedAt,
+		auction.ImageURL, auction.MinIncrement,
+		sql.NullFloat64{Float64: auction.ReservePrice, Valid: auction.HasReserve()},
+		auction.ExtensionWindow, auction.ExtensionDuration, auction.MaxExtensions,
+		sql.NullFloat64{Float64: auction.BuyNowPrice, Valid: auction.BuyNowPrice > 0},
+		auction.AuctionType, auction.ClockStep, auction.ClockInterval, auction.FloorPrice,
+		auction.Quantity, sql.NullString{String: string(auction.LotPricing), Valid: auction.LotPricing != ""},
+		auction.CreatedAt, auction.UpdatedAt,
 	)
 	return err
 }
 
 func (r *postgresRepo) GetByID(ctx context.Context, id string) (*domain.Auction, error) {
-	query := `
-		SELECT id, seller_id, title, description, start_price, current_price, 
-		       status, start_time, end_time, category, image_url, created_at, updated_at
-		FROM auctions WHERE id = $1
-	`
+	query := `SELECT ` + auctionColumns + ` FROM auctions WHERE id = $1`
 
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := r.conn(ctx).QueryRowContext(ctx, query, id)
 
 	var a domain.Auction
-	err := row.Scan(
-		&a.ID, &a.SellerID, &a.Title, &a.Description, &a.StartPrice, &a.CurrentPrice,
-		&a.Status, &a.StartTime, &a.EndTime, &a.Category, &a.ImageURL, &a.CreatedAt, &a.UpdatedAt,
-	)
+	err := scanAuction(row, &a)
 
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrAuctionNotFound
@@ -67,15 +85,15 @@ func (r *postgresRepo) Update(ctx context.Context, auction *domain.Auction) erro
 	query := `
 		UPDATE auctions SET 
 			title = $1, description = $2, current_price = $3, status = $4, 
-			image_url = $5, updated_at = $6
-		WHERE id = $7
+			image_url = $5, end_time = $6, updated_at = $7
+		WHERE id = $8
 	`
 
 	auction.UpdatedAt = time.Now()
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := r.conn(ctx).ExecContext(ctx, query,
 		auction.Title, auction.Description, auction.CurrentPrice, auction.Status,
-		auction.ImageURL, auction.UpdatedAt, auction.ID,
+		auction.ImageURL, auction.EndTime, auction.UpdatedAt, auction.ID,
 	)
 	if err != nil {
 		return err
@@ -94,7 +112,7 @@ func (r *postgresRepo) Update(ctx context.Context, auction *domain.Auction) erro
 
 func (r *postgresRepo) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM auctions WHERE id = $1`
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := r.conn(ctx).ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -113,9 +131,7 @@ func (r *postgresRepo) Delete(ctx context.Context, id string) error {
 func (r *postgresRepo) List(ctx context.Context, page, limit int, status domain.AuctionStatus, category string) ([]domain.Auction, int64, error) {
 	offset := (page - 1) * limit
 
-	baseQuery := `SELECT id, seller_id, title, description, start_price, current_price, 
-		                 status, start_time, end_time, category, image_url, created_at, updated_at
-		          FROM auctions WHERE 1=1`
+	baseQuery := `SELECT ` + auctionColumns + ` FROM auctions WHERE 1=1`
 
 	countQuery := `SELECT COUNT(*) FROM auctions WHERE 1=1`
 
@@ -140,7 +156,7 @@ func (r *postgresRepo) List(ctx context.Context, page, limit int, status domain.
 
 	// Get total count
 	var total int64
-	err := r.db.QueryRowContext(ctx, countQuery, args...).Scan(&total)
+	err := r.conn(ctx).QueryRowContext(ctx, countQuery, args...).Scan(&total)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -149,24 +165,292 @@ func (r *postgresRepo) List(ctx context.Context, page, limit int, status domain.
 	baseQuery += fmt.Sprintf(" LIMIT $%d OFFSET $%d", argID, argID+1)
 	args = append(args, limit, offset)
 
-	rows, err := r.db.QueryContext(ctx, baseQuery, args...)
+	rows, err := r.conn(ctx).QueryContext(ctx, baseQuery, args...)
 	if err != nil {
 		return nil, 0, err
 	}
 	defer rows.Close()
 
+	auctions, err := scanAuctions(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return auctions, total, nil
+}
+
+// ActivateDue claims due rows with FOR UPDATE SKIP LOCKED and flips their status in a
+// single statement, so concurrent replicas never pick up the same auction twice.
+func (r *postgresRepo) ActivateDue(ctx context.Context, now time.Time, limit int) ([]domain.Auction, error) {
+	query := `
+		UPDATE auctions SET status = $1, updated_at = $2
+		WHERE id IN (
+			SELECT id FROM auctions
+			WHERE status = $3 AND start_time <= $2
+			ORDER BY start_time
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + auctionColumns
+
+	rows, err := r.conn(ctx).QueryContext(ctx, query, domain.AuctionStatusActive, now, domain.AuctionStatusPending, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAuctions(rows)
+}
+
+func (r *postgresRepo) ListExpired(ctx context.Context, now time.Time, limit int) ([]domain.Auction, error) {
+	query := `SELECT ` + auctionColumns + `
+		FROM auctions
+		WHERE status = $1 AND end_time <= $2
+		ORDER BY end_time
+		LIMIT $3
+	`
+
+	rows, err := r.conn(ctx).QueryContext(ctx, query, domain.AuctionStatusActive, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAuctions(rows)
+}
+
+// Close only matches auctions that are still open, so when several replicas race to close
+// the same auction exactly one of them sees the row change. Matching on the end time that
+// was read keeps an auction open when a late bid extended it in the meantime. The final
+// status is taken from auction.Status (CLOSED or RESERVE_NOT_MET).
+func (r *postgresRepo) LockOpen(ctx context.Context, auction *domain.Auction) (bool, error) {
+	query := `
+		SELECT id FROM auctions
+		WHERE id = $1 AND status IN ($2, $3) AND end_time = $4
+		FOR UPDATE
+	`
+
+	var id string
+	err := r.conn(ctx).QueryRowContext(ctx, query,
+		auction.ID, domain.AuctionStatusActive, domain.AuctionStatusPending, auction.EndTime,
+	).Scan(&id)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *postgresRepo) Close(ctx context.Context, auction *domain.Auction) (bool, error) {
+	query := `
+		UPDATE auctions SET
+			status = $1, current_price = $2, winner_id = NULLIF($3, ''),
+			winning_bid_id = NULLIF($4, ''), winners = $5, updated_at = $6
+		WHERE id = $7 AND status IN ($8, $9) AND end_time = $10
+	`
+
+	auction.UpdatedAt = time.Now()
+
+	result, err := r.conn(ctx).ExecContext(ctx, query,
+		auction.Status, auction.CurrentPrice, auction.WinnerID,
+		auction.WinningBidID, auction.Winners, auction.UpdatedAt, auction.ID,
+		domain.AuctionStatusActive, domain.AuctionStatusPending, auction.EndTime,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *postgresRepo) RaisePrice(ctx context.Context, auctionID string, expectedPrice, amount float64, now time.Time) (bool, error) {
+	query := `
+		UPDATE auctions SET current_price = $1, updated_at = $2
+		WHERE id = $3 AND status = $4 AND end_time > $2 AND current_price = $5
+	`
+
+	result, err := r.conn(ctx).ExecContext(ctx, query, amount, now, auctionID, domain.AuctionStatusActive, expectedPrice)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *postgresRepo) Extend(ctx context.Context, auctionID string, endTime, newEndTime time.Time) (bool, error) {
+	query := `
+		UPDATE auctions SET
+			end_time = $1, extension_count = extension_count + 1, updated_at = $2
+		WHERE id = $3 AND status = $4 AND end_time = $5 AND extension_count < max_extensions
+	`
+
+	result, err := r.conn(ctx).ExecContext(ctx, query,
+		newEndTime, time.Now(), auctionID, domain.AuctionStatusActive, endTime,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// BuyNow is a compare-and-set on the price like RaisePrice, so of two buyers racing (or
+// a buyer racing a bid) exactly one sees the row change.
+func (r *postgresRepo) BuyNow(ctx context.Context, auction *domain.Auction, expectedPrice float64, now time.Time) (bool, error) {
+	query := `
+		UPDATE auctions SET
+			status = $1, current_price = buy_now_price, winner_id = $2,
+			winning_bid_id = $3, updated_at = $4
+		WHERE id = $5 AND status = $6 AND end_time > $4
+			AND current_price = $7 AND buy_now_price IS NOT NULL
+	`
+
+	result, err := r.conn(ctx).ExecContext(ctx, query,
+		domain.AuctionStatusClosed, auction.WinnerID, auction.WinningBidID, now,
+		auction.ID, domain.AuctionStatusActive, expectedPrice,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	auction.Status = domain.AuctionStatusClosed
+	auction.CurrentPrice = auction.BuyNowPrice
+	auction.UpdatedAt = now
+	return true, nil
+}
+
+// ClaimClock needs no compare-and-set on the price: a Dutch auction's price only moves
+// with the clock, so the status check alone lets exactly one buyer through.
+func (r *postgresRepo) ClaimClock(ctx context.Context, auction *domain.Auction, price float64, now time.Time) (bool, error) {
+	query := `
+		UPDATE auctions SET
+			status = $1, current_price = $2, winner_id = $3,
+			winning_bid_id = $4, updated_at = $5
+		WHERE id = $6 AND status = $7 AND end_time > $5 AND auction_type = $8
+	`
+
+	result, err := r.conn(ctx).ExecContext(ctx, query,
+		domain.AuctionStatusClosed, price, auction.WinnerID, auction.WinningBidID, now,
+		auction.ID, domain.AuctionStatusActive, domain.AuctionTypeDutch,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	auction.Status = domain.AuctionStatusClosed
+	auction.CurrentPrice = price
+	auction.UpdatedAt = now
+	return true, nil
+}
+
+// AddSealedBidder inserts only while the auction is open, and the (auction_id, bidder_id)
+// primary key turns a second bid from the same bidder into a no-op, so of two concurrent
+// bids by one bidder exactly one is recorded.
+func (r *postgresRepo) AddSealedBidder(ctx context.Context, auctionID, bidderID string, now time.Time) (bool, error) {
+	query := `
+		INSERT INTO sealed_bidders (auction_id, bidder_id, created_at)
+		SELECT id, $1, $2 FROM auctions
+		WHERE id = $3 AND status = $4 AND end_time > $2
+		ON CONFLICT (auction_id, bidder_id) DO NOTHING
+	`
+
+	result, err := r.conn(ctx).ExecContext(ctx, query, bidderID, now, auctionID, domain.AuctionStatusActive)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+func (r *postgresRepo) HasSealedBidder(ctx context.Context, auctionID, bidderID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM sealed_bidders WHERE auction_id = $1 AND bidder_id = $2)`
+
+	var exists bool
+	err := r.conn(ctx).QueryRowContext(ctx, query, auctionID, bidderID).Scan(&exists)
+	return exists, err
+}
+
+// RemoveSealedBidder only deletes while the auction is ACTIVE, so a bid that counted at
+// close can't be taken back.
+func (r *postgresRepo) RemoveSealedBidder(ctx context.Context, auctionID, bidderID string) (bool, error) {
+	query := `
+		DELETE FROM sealed_bidders
+		WHERE auction_id = $1 AND bidder_id = $2
+		AND EXISTS (SELECT 1 FROM auctions WHERE id = $1 AND status = $3)
+	`
+
+	result, err := r.conn(ctx).ExecContext(ctx, query, auctionID, bidderID, domain.AuctionStatusActive)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAuction(row rowScanner, a *domain.Auction) error {
+	return row.Scan(
+		&a.ID, &a.SellerID, &a.Title, &a.Description, &a.StartPrice, &a.CurrentPrice,
+		&a.Status, &a.StartTime, &a.EndTime, &a.Category, &a.ImageURL,
+		&a.WinnerID, &a.WinningBidID, &a.MinIncrement, &a.ReservePrice,
+		&a.ExtensionWindow, &a.ExtensionDuration, &a.MaxExtensions, &a.ExtensionCount,
+		&a.BuyNowPrice, &a.AuctionType,
+		&a.ClockStep, &a.ClockInterval, &a.FloorPrice,
+		&a.Quantity, &a.LotPricing, &a.Winners, &a.CreatedAt, &a.UpdatedAt,
+	)
+}
+
+func scanAuctions(rows *sql.Rows) ([]domain.Auction, error) {
 	var auctions []domain.Auction
 	for rows.Next() {
 		var a domain.Auction
-		err := rows.Scan(
-			&a.ID, &a.SellerID, &a.Title, &a.Description, &a.StartPrice, &a.CurrentPrice,
-			&a.Status, &a.StartTime, &a.EndTime, &a.Category, &a.ImageURL, &a.CreatedAt, &a.UpdatedAt,
-		)
-		if err != nil {
-			return nil, 0, err
+		if err := scanAuction(rows, &a); err != nil {
+			return nil, err
 		}
 		auctions = append(auctions, a)
 	}
 
-	return auctions, total, nil
+	return auctions, rows.Err()
 }
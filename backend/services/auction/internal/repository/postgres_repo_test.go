@@ -31,7 +31,7 @@ func TestCreate(t *testing.T) {
 	}
 
 	mock.ExpectExec("INSERT INTO auctions").
-		WithArgs(auction.ID, auction.SellerID, auction.Title, auction.Description, auction.StartPrice, auction.CurrentPrice, auction.Status, auction.StartTime, auction.EndTime, auction.Category, auction.ImageURL, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(auction.ID, auction.SellerID, auction.Title, auction.Description, auction.StartPrice, auction.CurrentPrice, auction.Status, auction.StartTime, auction.EndTime, auction.Category, auction.ImageURL, nil, nil, 0, 0, 0, nil, domain.AuctionType(""), 0.0, domain.Seconds(0), 0.0, 0, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err = repo.Create(context.Background(), auction)
@@ -53,8 +53,8 @@ func TestGetByID(t *testing.T) {
 
 	repo := NewPostgresRepo(db)
 
-	rows := sqlmock.NewRows([]string{"id", "seller_id", "title", "description", "start_price", "current_price", "status", "start_time", "end_time", "category", "image_url", "created_at", "updated_at"}).
-		AddRow("1", "seller-1", "Test", "Desc", 10.0, 10.0, "ACTIVE", time.Now(), time.Now().Add(time.Hour), "Cat", "url", time.Now(), time.Now())
+	rows := sqlmock.NewRows([]string{"id", "seller_id", "title", "description", "start_price", "current_price", "status", "start_time", "end_time", "category", "image_url", "winner_id", "winning_bid_id", "min_increment", "reserve_price", "extension_window", "extension_duration", "max_extensions", "extension_count", "buy_now_price", "auction_type", "clock_step", "clock_interval", "floor_price", "quantity", "lot_pricing", "winners", "created_at", "updated_at"}).
+		AddRow("1", "seller-1", "Test", "Desc", 10.0, 10.0, "ACTIVE", time.Now(), time.Now().Add(time.Hour), "Cat", "url", "", "", []byte(`[{"min_price":0,"amount":1}]`), 50.0, 120, 60, 5, 2, 200.0, "SEALED_SECOND_PRICE", 0.0, 0, 0.0, 1, "", nil, time.Now(), time.Now())
 
 	mock.ExpectQuery("SELECT .* FROM auctions WHERE id = \\$1").
 		WithArgs("1").
@@ -67,6 +67,18 @@ func TestGetByID(t *testing.T) {
 	if auction.ID != "1" {
 		t.Errorf("expected id 1, got %s", auction.ID)
 	}
+	if auction.MinIncrement.MinNextBid(10) != 11 || auction.ReservePrice != 50 {
+		t.Errorf("expected increment and reserve to be scanned, got %+v", auction)
+	}
+	if auction.ExtensionWindow != 120 || auction.ExtensionDuration != 60 || auction.MaxExtensions != 5 || auction.ExtensionCount != 2 {
+		t.Errorf("expected extension settings to be scanned, got %+v", auction)
+	}
+	if auction.BuyNowPrice != 200 {
+		t.Errorf("expected buy now price 200, got %.2f", auction.BuyNowPrice)
+	}
+	if auction.AuctionType != domain.AuctionTypeSealedSecondPrice {
+		t.Errorf("expected auction type SEALED_SECOND_PRICE, got %s", auction.AuctionType)
+	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -90,7 +102,7 @@ func TestUpdate(t *testing.T) {
 	}
 
 	mock.ExpectExec("UPDATE auctions SET").
-		WithArgs(auction.Title, auction.Description, auction.CurrentPrice, auction.Status, auction.ImageURL, sqlmock.AnyArg(), auction.ID).
+		WithArgs(auction.Title, auction.Description, auction.CurrentPrice, auction.Status, auction.ImageURL, auction.EndTime, sqlmock.AnyArg(), auction.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err = repo.Update(context.Background(), auction)
@@ -135,8 +147,8 @@ func TestList(t *testing.T) {
 
 	repo := NewPostgresRepo(db)
 
-	rows := sqlmock.NewRows([]string{"id", "seller_id", "title", "description", "start_price", "current_price", "status", "start_time", "end_time", "category", "image_url", "created_at", "updated_at"}).
-		AddRow("1", "seller-1", "Test", "Desc", 10.0, 10.0, "ACTIVE", time.Now(), time.Now().Add(time.Hour), "Cat", "url", time.Now(), time.Now())
+	rows := sqlmock.NewRows([]string{"id", "seller_id", "title", "description", "start_price", "current_price", "status", "start_time", "end_time", "category", "image_url", "winner_id", "winning_bid_id", "min_increment", "reserve_price", "extension_window", "extension_duration", "max_extensions", "extension_count", "buy_now_price", "auction_type", "clock_step", "clock_interval", "floor_price", "quantity", "lot_pricing", "winners", "created_at", "updated_at"}).
+		AddRow("1", "seller-1", "Test", "Desc", 10.0, 10.0, "ACTIVE", time.Now(), time.Now().Add(time.Hour), "Cat", "url", "", "", nil, 0.0, 0, 0, 0, 0, 0.0, "ENGLISH", 0.0, 0, 0.0, 3, "DISCRIMINATORY", []byte(`[{"bid_id":"bid-1","bidder_id":"user-1","units":2,"price":12}]`), time.Now(), time.Now())
 
 	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM auctions").
 		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
@@ -151,6 +163,69 @@ func TestList(t *testing.T) {
 	if count != 1 {
 		t.Errorf("expected count 1, got %d", count)
 	}
+	if len(auctions) != 1 {
+		t.Fatalf("expected 1 auction, got %d", len(auctions))
+	}
+	if a := auctions[0]; a.Quantity != 3 || a.LotPricing != domain.LotPricingDiscriminatory || len(a.Winners) != 1 || a.Winners[0].Units != 2 {
+		t.Errorf("expected lots and winners to be scanned, got %+v", a)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestActivateDue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "seller_id", "title", "description", "start_price", "current_price", "status", "start_time", "end_time", "category", "image_url", "winner_id", "winning_bid_id", "min_increment", "reserve_price", "extension_window", "extension_duration", "max_extensions", "extension_count", "buy_now_price", "auction_type", "clock_step", "clock_interval", "floor_price", "quantity", "lot_pricing", "winners", "created_at", "updated_at"}).
+		AddRow("1", "seller-1", "Test", "Desc", 10.0, 10.0, "ACTIVE", now.Add(-time.Minute), now.Add(time.Hour), "Cat", "url", "", "", nil, 0.0, 0, 0, 0, 0, 0.0, "ENGLISH", 0.0, 0, 0.0, 1, "", nil, now, now)
+
+	mock.ExpectQuery("UPDATE auctions SET status = \\$1.*status = \\$3 AND start_time <= \\$2.*FOR UPDATE SKIP LOCKED").
+		WithArgs(domain.AuctionStatusActive, now, domain.AuctionStatusPending, 50).
+		WillReturnRows(rows)
+
+	auctions, err := repo.ActivateDue(context.Background(), now, 50)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(auctions) != 1 || auctions[0].Status != domain.AuctionStatusActive {
+		t.Errorf("expected 1 active auction, got %+v", auctions)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestListExpired(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "seller_id", "title", "description", "start_price", "current_price", "status", "start_time", "end_time", "category", "image_url", "winner_id", "winning_bid_id", "min_increment", "reserve_price", "extension_window", "extension_duration", "max_extensions", "extension_count", "buy_now_price", "auction_type", "clock_step", "clock_interval", "floor_price", "quantity", "lot_pricing", "winners", "created_at", "updated_at"}).
+		AddRow("1", "seller-1", "Test", "Desc", 10.0, 25.0, "ACTIVE", now.Add(-2*time.Hour), now.Add(-time.Minute), "Cat", "url", "", "", nil, 0.0, 0, 0, 0, 0, 0.0, "ENGLISH", 0.0, 0, 0.0, 1, "", nil, now, now)
+
+	mock.ExpectQuery("SELECT .* FROM auctions\\s+WHERE status = \\$1 AND end_time <= \\$2").
+		WithArgs(domain.AuctionStatusActive, now, 50).
+		WillReturnRows(rows)
+
+	auctions, err := repo.ListExpired(context.Background(), now, 50)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
 	if len(auctions) != 1 {
 		t.Errorf("expected 1 auction, got %d", len(auctions))
 	}
@@ -159,3 +234,308 @@ func TestList(t *testing.T) {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 }
+
+func TestLockOpen(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+	auction := &domain.Auction{ID: "1", EndTime: time.Now()}
+
+	t.Run("Open", func(t *testing.T) {
+		mock.ExpectQuery("SELECT id FROM auctions.*WHERE id = \\$1 AND status IN \\(\\$2, \\$3\\) AND end_time = \\$4.*FOR UPDATE").
+			WithArgs("1", domain.AuctionStatusActive, domain.AuctionStatusPending, auction.EndTime).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+
+		open, err := repo.LockOpen(context.Background(), auction)
+		if err != nil || !open {
+			t.Errorf("expected the open auction to be locked, got %v, %v", open, err)
+		}
+	})
+
+	t.Run("Already Closed", func(t *testing.T) {
+		mock.ExpectQuery("SELECT id FROM auctions").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+		open, err := repo.LockOpen(context.Background(), auction)
+		if err != nil || open {
+			t.Errorf("expected no lock on a closed auction, got %v, %v", open, err)
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestClose(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+
+	auction := &domain.Auction{
+		ID:           "1",
+		Status:       domain.AuctionStatusClosed,
+		CurrentPrice: 150.0,
+		EndTime:      time.Now(),
+		WinnerID:     "user-1",
+		WinningBidID: "bid-1",
+	}
+
+	t.Run("Closed", func(t *testing.T) {
+		mock.ExpectExec("UPDATE auctions SET.*WHERE id = \\$7 AND status IN \\(\\$8, \\$9\\) AND end_time = \\$10").
+			WithArgs(domain.AuctionStatusClosed, 150.0, "user-1", "bid-1", nil, sqlmock.AnyArg(), "1", domain.AuctionStatusActive, domain.AuctionStatusPending, auction.EndTime).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		closed, err := repo.Close(context.Background(), auction)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !closed {
+			t.Error("expected auction to be closed")
+		}
+	})
+
+	t.Run("Multi-Lot", func(t *testing.T) {
+		lots := &domain.Auction{
+			ID:           "2",
+			Status:       domain.AuctionStatusClosed,
+			CurrentPrice: 120.0,
+			EndTime:      auction.EndTime,
+			Quantity:     3,
+			Winners:      domain.LotWinners{{BidID: "bid-1", BidderID: "user-1", Units: 2, Price: 120}},
+		}
+		mock.ExpectExec("UPDATE auctions SET").
+			WithArgs(domain.AuctionStatusClosed, 120.0, "", "", []byte(`[{"bid_id":"bid-1","bidder_id":"user-1","units":2,"price":120}]`), sqlmock.AnyArg(), "2", domain.AuctionStatusActive, domain.AuctionStatusPending, auction.EndTime).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		closed, err := repo.Close(context.Background(), lots)
+		if err != nil || !closed {
+			t.Errorf("expected the winners to be stored, got %v, %v", closed, err)
+		}
+	})
+
+	t.Run("Already Closed", func(t *testing.T) {
+		mock.ExpectExec("UPDATE auctions SET").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		closed, err := repo.Close(context.Background(), auction)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if closed {
+			t.Error("expected no transition for an already closed auction")
+		}
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRaisePrice(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+	now := time.Now()
+
+	mock.ExpectExec("UPDATE auctions SET current_price = \\$1.*status = \\$4 AND end_time > \\$2 AND current_price = \\$5").
+		WithArgs(150.0, now, "1", domain.AuctionStatusActive, 100.0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE auctions SET current_price").
+		WithArgs(120.0, now, "1", domain.AuctionStatusActive, 100.0).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	raised, err := repo.RaisePrice(context.Background(), "1", 100.0, 150.0, now)
+	if err != nil || !raised {
+		t.Errorf("expected price to be raised, got raised=%v err=%v", raised, err)
+	}
+
+	// The price moved on since it was read at 100, so the compare-and-set misses.
+	raised, err = repo.RaisePrice(context.Background(), "1", 100.0, 120.0, now)
+	if err != nil || raised {
+		t.Errorf("expected stale bid to be rejected, got raised=%v err=%v", raised, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestExtend(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+	endTime := time.Now()
+	newEndTime := endTime.Add(time.Minute)
+
+	mock.ExpectExec("UPDATE auctions SET.*end_time = \\$1, extension_count = extension_count \\+ 1.*end_time = \\$5 AND extension_count < max_extensions").
+		WithArgs(newEndTime, sqlmock.AnyArg(), "1", domain.AuctionStatusActive, endTime).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE auctions SET").
+		WithArgs(newEndTime, sqlmock.AnyArg(), "1", domain.AuctionStatusActive, endTime).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	extended, err := repo.Extend(context.Background(), "1", endTime, newEndTime)
+	if err != nil || !extended {
+		t.Errorf("expected auction to be extended, got extended=%v err=%v", extended, err)
+	}
+
+	// The cap was reached or the end time already moved
+	extended, err = repo.Extend(context.Background(), "1", endTime, newEndTime)
+	if err != nil || extended {
+		t.Errorf("expected no extension, got extended=%v err=%v", extended, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestBuyNow(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+	now := time.Now()
+	auction := &domain.Auction{ID: "1", Status: domain.AuctionStatusActive, CurrentPrice: 100, BuyNowPrice: 500, WinnerID: "buyer-1", WinningBidID: "bid-1"}
+
+	mock.ExpectExec("UPDATE auctions SET.*current_price = buy_now_price.*WHERE id = \\$5 AND status = \\$6 AND end_time > \\$4\\s+AND current_price = \\$7 AND buy_now_price IS NOT NULL").
+		WithArgs(domain.AuctionStatusClosed, "buyer-1", "bid-1", now, "1", domain.AuctionStatusActive, 100.0).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	bought, err := repo.BuyNow(context.Background(), auction, 100, now)
+	if err != nil || !bought {
+		t.Errorf("expected auction to be bought, got bought=%v err=%v", bought, err)
+	}
+	if auction.Status != domain.AuctionStatusClosed || auction.CurrentPrice != 500 {
+		t.Errorf("expected closed auction at the buy now price, got %+v", auction)
+	}
+
+	// A second buyer racing the first no longer matches the row
+	second := &domain.Auction{ID: "1", Status: domain.AuctionStatusActive, CurrentPrice: 100, BuyNowPrice: 500, WinnerID: "buyer-2", WinningBidID: "bid-2"}
+	mock.ExpectExec("UPDATE auctions SET").
+		WithArgs(domain.AuctionStatusClosed, "buyer-2", "bid-2", now, "1", domain.AuctionStatusActive, 100.0).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	bought, err = repo.BuyNow(context.Background(), second, 100, now)
+	if err != nil || bought {
+		t.Errorf("expected second buyer to lose, got bought=%v err=%v", bought, err)
+	}
+	if second.Status != domain.AuctionStatusActive {
+		t.Errorf("expected losing auction copy to be left untouched, got %s", second.Status)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestClaimClock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+	now := time.Now()
+	auction := &domain.Auction{ID: "1", Status: domain.AuctionStatusActive, CurrentPrice: 100, AuctionType: domain.AuctionTypeDutch, WinnerID: "buyer-1", WinningBidID: "bid-1"}
+
+	mock.ExpectExec("UPDATE auctions SET.*current_price = \\$2.*WHERE id = \\$6 AND status = \\$7 AND end_time > \\$5 AND auction_type = \\$8").
+		WithArgs(domain.AuctionStatusClosed, 85.0, "buyer-1", "bid-1", now, "1", domain.AuctionStatusActive, domain.AuctionTypeDutch).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	claimed, err := repo.ClaimClock(context.Background(), auction, 85, now)
+	if err != nil || !claimed {
+		t.Errorf("expected the clock to be claimed, got claimed=%v err=%v", claimed, err)
+	}
+	if auction.Status != domain.AuctionStatusClosed || auction.CurrentPrice != 85 {
+		t.Errorf("expected closed auction at the clock price, got %+v", auction)
+	}
+
+	// A second buyer finds the auction closed
+	second := &domain.Auction{ID: "1", Status: domain.AuctionStatusActive, CurrentPrice: 100, AuctionType: domain.AuctionTypeDutch, WinnerID: "buyer-2", WinningBidID: "bid-2"}
+	mock.ExpectExec("UPDATE auctions SET").
+		WithArgs(domain.AuctionStatusClosed, 85.0, "buyer-2", "bid-2", now, "1", domain.AuctionStatusActive, domain.AuctionTypeDutch).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	claimed, err = repo.ClaimClock(context.Background(), second, 85, now)
+	if err != nil || claimed {
+		t.Errorf("expected second buyer to lose, got claimed=%v err=%v", claimed, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestAddSealedBidder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+	now := time.Now()
+
+	mock.ExpectExec("INSERT INTO sealed_bidders.*SELECT id, \\$1, \\$2 FROM auctions\\s+WHERE id = \\$3 AND status = \\$4 AND end_time > \\$2\\s+ON CONFLICT \\(auction_id, bidder_id\\) DO NOTHING").
+		WithArgs("bidder-1", now, "1", domain.AuctionStatusActive).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	added, err := repo.AddSealedBidder(context.Background(), "1", "bidder-1", now)
+	if err != nil || !added {
+		t.Errorf("expected bidder to be added, got added=%v err=%v", added, err)
+	}
+
+	// The same bidder's second bid hits the primary key and inserts nothing
+	mock.ExpectExec("INSERT INTO sealed_bidders").
+		WithArgs("bidder-1", now, "1", domain.AuctionStatusActive).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	added, err = repo.AddSealedBidder(context.Background(), "1", "bidder-1", now)
+	if err != nil || added {
+		t.Errorf("expected second bid to be refused, got added=%v err=%v", added, err)
+	}
+
+	mock.ExpectQuery("SELECT EXISTS \\(SELECT 1 FROM sealed_bidders WHERE auction_id = \\$1 AND bidder_id = \\$2\\)").
+		WithArgs("1", "bidder-1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	exists, err := repo.HasSealedBidder(context.Background(), "1", "bidder-1")
+	if err != nil || !exists {
+		t.Errorf("expected bidder to be found, got exists=%v err=%v", exists, err)
+	}
+
+	mock.ExpectExec("DELETE FROM sealed_bidders").
+		WithArgs("1", "bidder-1", domain.AuctionStatusActive).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	removed, err := repo.RemoveSealedBidder(context.Background(), "1", "bidder-1")
+	if err != nil || !removed {
+		t.Errorf("expected bidder to be removed, got removed=%v err=%v", removed, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
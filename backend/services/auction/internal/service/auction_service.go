@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,21 +12,40 @@ import (
 	"go.uber.org/zap"
 )
 
+// lifecycleBatchSize caps how many auctions a single scheduler tick transitions per status.
+const lifecycleBatchSize = 100
+
 type AuctionService struct {
-	repo     domain.AuctionRepository
-	producer domain.EventProducer
-	log      logger.Logger
+	repo          domain.AuctionRepository
+	tx            domain.Transactor
+	producer      domain.EventProducer
+	biddingClient domain.BiddingClient
+	settings      Settings
+	log           logger.Logger
+}
+
+// Settings are the service-wide auction rules.
+type Settings struct {
+	// MaxExtensions caps how often a late bid may extend one auction; it is also the
+	// default for auctions that enable extensions without setting their own cap.
+	MaxExtensions int
+	// BuyNowCutoff disables buy-now once the current price reaches this fraction of
+	// the buy-now price.
+	BuyNowCutoff float64
 }
 
-func NewAuctionService(repo domain.AuctionRepository, producer domain.EventProducer, log logger.Logger) *AuctionService {
+func NewAuctionService(repo domain.AuctionRepository, tx domain.Transactor, producer domain.EventProducer, biddingClient domain.BiddingClient, settings Settings, log logger.Logger) *AuctionService {
 	return &AuctionService{
-		repo:     repo,
-		producer: producer,
-		log:      log,
+		repo:          repo,
+		tx:            tx,
+		producer:      producer,
+		biddingClient: biddingClient,
+		settings:      settings,
+		log:           log,
 	}
 }
 
-func (s *AuctionService) CreateAuction(ctx context.Context, sellerID, title, description string, startPrice float64, startTime, endTime time.Time, category, imageURL string) (*domain.Auction, error) {
+func (s *AuctionService) CreateAuction(ctx context.Context, sellerID, title, description string, startPrice float64, startTime, endTime time.Time, category, imageURL string, opts domain.AuctionOptions) (*domain.Auction, error) {
 	if startTime.After(endTime) {
 		return nil, errors.New("start time must be before end time")
 	}
@@ -34,6 +54,63 @@ func (s *AuctionService) CreateAuction(ctx context.Context, sellerID, title, des
 		return nil, errors.New("start price cannot be negative")
 	}
 
+	if err := opts.MinIncrement.Validate(); err != nil {
+		return nil, err
+	}
+
+	if opts.ReservePrice < 0 {
+		return nil, errors.New("reserve price cannot be negative")
+	}
+	if opts.ReservePrice > 0 && opts.ReservePrice < startPrice {
+		return nil, errors.New("reserve price cannot be below the start price")
+	}
+
+	if opts.ExtensionWindow < 0 || opts.ExtensionDuration < 0 || opts.MaxExtensions < 0 {
+		return nil, errors.New("extension settings cannot be negative")
+	}
+	if (opts.ExtensionWindow > 0) != (opts.ExtensionDuration > 0) {
+		return nil, errors.New("extension window and extension duration must be set together")
+	}
+	if opts.MaxExtensions > s.settings.MaxExtensions {
+		return nil, fmt.Errorf("max extensions cannot exceed %d", s.settings.MaxExtensions)
+	}
+	maxExtensions := 0
+	if opts.ExtensionWindow > 0 {
+		maxExtensions = opts.MaxExtensions
+		if maxExtensions == 0 {
+			maxExtensions = s.settings.MaxExtensions
+		}
+	}
+
+	auctionType, err := domain.ParseAuctionType(string(opts.AuctionType))
+	if err != nil {
+		return nil, err
+	}
+	// Sealed bids are never raised against each other, so increments and late-bid extensions do not apply
+	if auctionType.IsSealed() && (len(opts.MinIncrement) > 0 || opts.ExtensionWindow > 0) {
+		return nil, errors.New("min increment and extensions are only supported by english auctions")
+	}
+	// Reserve and buy-now are thresholds a rising price reaches; a falling one never does
+	if auctionType.IsReverse() && (opts.ReservePrice > 0 || opts.BuyNowPrice > 0) {
+		return nil, errors.New("reserve and buy now prices are not supported by reverse auctions")
+	}
+
+	if err := validateClock(auctionType, startPrice, opts); err != nil {
+		return nil, err
+	}
+
+	quantity, lotPricing, err := validateLots(auctionType, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.BuyNowPrice < 0 {
+		return nil, errors.New("buy now price cannot be negative")
+	}
+	if opts.BuyNowPrice > 0 && (opts.BuyNowPrice <= startPrice || opts.BuyNowPrice < opts.ReservePrice) {
+		return nil, errors.New("buy now price must be above the start price and not below the reserve price")
+	}
+
 	auction := &domain.Auction{
 		ID:           uuid.New().String(),
 		SellerID:     sellerID,
@@ -46,26 +123,113 @@ func (s *AuctionService) CreateAuction(ctx context.Context, sellerID, title, des
 		EndTime:      endTime,
 		Category:     category,
 		ImageURL:     imageURL,
+		MinIncrement: opts.MinIncrement,
+		ReservePrice: opts.ReservePrice,
+
+		ExtensionWindow:   opts.ExtensionWindow,
+		ExtensionDuration: opts.ExtensionDuration,
+		MaxExtensions:     maxExtensions,
+		BuyNowPrice:       opts.BuyNowPrice,
+		AuctionType:       auctionType,
+		ClockStep:         opts.ClockStep,
+		ClockInterval:     opts.ClockInterval,
+		FloorPrice:        opts.FloorPrice,
+		Quantity:          quantity,
+		LotPricing:        lotPricing,
 	}
 
-	if startTime.Before(time.Now()) {
+	if now := time.Now(); startTime.Before(now) {
 		auction.Status = domain.AuctionStatusActive
+		// The clock starts when the auction opens, not at a start time already past
+		if auctionType.IsDutch() {
+			auction.StartTime = now
+		}
 	}
 
-	err := s.repo.Create(ctx, auction)
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, auction); err != nil {
+			return err
+		}
+		// Scheduled auctions are announced by the lifecycle scheduler once they open
+		if auction.Status == domain.AuctionStatusActive {
+			return s.producer.PublishAuctionCreated(ctx, auction)
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.producer.PublishAuctionCreated(ctx, auction); err != nil {
-		s.log.Error("failed to publish auction created event", zap.Error(err), zap.String("auction_id", auction.ID))
+	return auction, nil
+}
+
+// validateClock checks the clock settings, which Dutch auctions require and no other
+// format takes. A Dutch auction is won by the first acceptance, so there is nothing
+// for reserves, buy-now, increments or extensions to act on.
+func validateClock(auctionType domain.AuctionType, startPrice float64, opts domain.AuctionOptions) error {
+	if !auctionType.IsDutch() {
+		if opts.ClockStep != 0 || opts.ClockInterval != 0 || opts.FloorPrice != 0 {
+			return errors.New("clock settings are only supported by dutch auctions")
+		}
+		return nil
 	}
 
-	return auction, nil
+	if opts.ClockStep <= 0 || opts.ClockInterval <= 0 {
+		return errors.New("dutch auctions require a positive clock step and clock interval")
+	}
+	if opts.FloorPrice < 0 || opts.FloorPrice >= startPrice {
+		return errors.New("floor price must be below the start price and not negative")
+	}
+	if len(opts.MinIncrement) > 0 || opts.ReservePrice > 0 || opts.BuyNowPrice > 0 || opts.ExtensionWindow > 0 {
+		return errors.New("min increment, reserve, buy now and extensions are not supported by dutch auctions")
+	}
+	return nil
 }
 
+// validateLots checks the quantity and lot pricing and returns them with their
+// defaults applied. Only English auctions sell several units. Which bids win depends
+// on all of them, so there is no single price for an increment, reserve or buy-now
+// price to act on.
+func validateLots(auctionType domain.AuctionType, opts domain.AuctionOptions) (int, domain.LotPricing, error) {
+	if opts.Quantity < 0 {
+		return 0, "", errors.New("quantity cannot be negative")
+	}
+	if opts.Quantity <= 1 {
+		if opts.LotPricing != "" {
+			return 0, "", errors.New("lot pricing is only supported by multi-lot auctions")
+		}
+		return 1, "", nil
+	}
+
+	if auctionType != domain.AuctionTypeEnglish {
+		return 0, "", errors.New("multi-lot auctions must be english auctions")
+	}
+	if len(opts.MinIncrement) > 0 || opts.ReservePrice > 0 || opts.BuyNowPrice > 0 {
+		return 0, "", errors.New("min increment, reserve and buy now prices are not supported by multi-lot auctions")
+	}
+	lotPricing, err := domain.ParseLotPricing(string(opts.LotPricing))
+	if err != nil {
+		return 0, "", err
+	}
+	return opts.Quantity, lotPricing, nil
+}
+
+// GetAuction returns the auction. An open Dutch auction is reported at its clock price.
 func (s *AuctionService) GetAuction(ctx context.Context, id string) (*domain.Auction, error) {
-	return s.repo.GetByID(ctx, id)
+	auction, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	readClock(auction, time.Now())
+	return auction, nil
+}
+
+// readClock sets an active Dutch auction's CurrentPrice to its clock price at now. The
+// stored price only changes when the auction is accepted.
+func readClock(auction *domain.Auction, now time.Time) {
+	if auction.AuctionType.IsDutch() && auction.Status == domain.AuctionStatusActive {
+		auction.CurrentPrice = auction.ClockPrice(now)
+	}
 }
 
 func (s *AuctionService) ListAuctions(ctx context.Context, page, limit int, status string, category string) ([]domain.Auction, int64, error) {
@@ -75,7 +239,15 @@ func (s *AuctionService) ListAuctions(ctx context.Context, page, limit int, stat
 	if limit < 1 {
 		limit = 10
 	}
-	return s.repo.List(ctx, page, limit, domain.AuctionStatus(status), category)
+	auctions, total, err := s.repo.List(ctx, page, limit, domain.AuctionStatus(status), category)
+	if err != nil {
+		return nil, 0, err
+	}
+	now := time.Now()
+	for i := range auctions {
+		readClock(&auctions[i], now)
+	}
+	return auctions, total, nil
 }
 
 func (s *AuctionService) UpdateAuction(ctx context.Context, id string, title, description, imageURL string) (*domain.Auction, error) {
@@ -84,7 +256,7 @@ func (s *AuctionService) UpdateAuction(ctx context.Context, id string, title, de
 		return nil, err
 	}
 
-	if auction.Status == domain.AuctionStatusClosed || auction.Status == domain.AuctionStatusCancelled {
+	if auction.Status.IsFinal() {
 		return nil, errors.New("cannot update closed or cancelled auction")
 	}
 
@@ -98,15 +270,16 @@ func (s *AuctionService) UpdateAuction(ctx context.Context, id string, title, de
 		auction.ImageURL = imageURL
 	}
 
-	err = s.repo.Update(ctx, auction)
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Update(ctx, auction); err != nil {
+			return err
+		}
+		return s.producer.PublishAuctionUpdated(ctx, auction)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	if err := s.producer.PublishAuctionUpdated(ctx, auction); err != nil {
-		s.log.Error("failed to publish auction updated event", zap.Error(err), zap.String("auction_id", auction.ID))
-	}
-
 	return auction, nil
 }
 
@@ -116,24 +289,155 @@ func (s *AuctionService) CloseAuction(ctx context.Context, id string) error {
 		return err
 	}
 
-	if auction.Status == domain.AuctionStatusClosed {
+	if auction.Status.IsFinal() {
+		return nil
+	}
+
+	_, err = s.closeAuction(ctx, auction)
+	return err
+}
+
+// closeAuction determines the winner from the bidding service and persists the close.
+// The winner and the price they pay depend on the auction type (see Auction.Settle).
+// A top bid under the reserve price closes the auction as RESERVE_NOT_MET with no winner.
+// Multi-lot auctions are settled by settleLots instead.
+// The auction is locked before the bids are read, so a bid accepted meanwhile can't be
+// left out of the settlement: it waits for the close and is then turned down.
+// If the winner cannot be determined the auction is left open so the close can be retried.
+// It reports whether this call closed the auction; false means another caller got there first.
+func (s *AuctionService) closeAuction(ctx context.Context, auction *domain.Auction) (bool, error) {
+	var closed bool
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		open, err := s.repo.LockOpen(ctx, auction)
+		if err != nil || !open {
+			return err
+		}
+		if err := s.settle(ctx, auction); err != nil {
+			return err
+		}
+
+		closed, err = s.repo.Close(ctx, auction)
+		if err != nil || !closed {
+			return err
+		}
+		return s.producer.PublishAuctionClosed(ctx, auction)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return closed, nil
+}
+
+// settle sets the auction's final status, price and winners from its bids.
+func (s *AuctionService) settle(ctx context.Context, auction *domain.Auction) error {
+	if auction.IsMultiLot() {
+		if err := s.settleLots(ctx, auction); err != nil {
+			return fmt.Errorf("failed to determine winners: %w", err)
+		}
 		return nil
 	}
 
+	topBids, err := s.topBids(ctx, auction)
+	if err != nil {
+		return fmt.Errorf("failed to determine winner: %w", err)
+	}
+
 	auction.Status = domain.AuctionStatusClosed
-	err = s.repo.Update(ctx, auction)
+	if winningBid, price := auction.Settle(topBids); winningBid != nil {
+		auction.CurrentPrice = price
+		if auction.ReserveMet() {
+			auction.WinnerID = winningBid.BidderID
+			auction.WinningBidID = winningBid.BidID
+		} else {
+			auction.Status = domain.AuctionStatusReserveNotMet
+		}
+	}
+	return nil
+}
+
+// settleLots allocates a multi-lot auction's units to the highest bids, prices them by
+// the auction's lot pricing and records the winners. The final price is the clearing
+// price, the lowest winning bid.
+func (s *AuctionService) settleLots(ctx context.Context, auction *domain.Auction) error {
+	allocated, err := s.biddingClient.GetAllocation(ctx, auction.ID, auction.Quantity)
 	if err != nil {
 		return err
 	}
 
-	if err := s.producer.PublishAuctionClosed(ctx, auction, ""); err != nil {
-		s.log.Error("failed to publish auction closed event", zap.Error(err), zap.String("auction_id", auction.ID))
+	auction.Status = domain.AuctionStatusClosed
+	if winners, clearing := auction.SettleLots(allocated); winners != nil {
+		auction.Winners = winners
+		auction.CurrentPrice = clearing
 	}
-
 	return nil
 }
 
-func (s *AuctionService) ValidateBid(ctx context.Context, auctionID string, amount float64) (bool, string, error) {
+// topBids fetches as many of the highest bids as settling the auction's format needs.
+func (s *AuctionService) topBids(ctx context.Context, auction *domain.Auction) ([]domain.WinningBid, error) {
+	if auction.AuctionType.IsSealed() {
+		return s.biddingClient.GetTopBids(ctx, auction.ID, auction.AuctionType.SettlementBids())
+	}
+
+	getLeadingBid := s.biddingClient.GetHighestBid
+	if auction.AuctionType.IsReverse() {
+		getLeadingBid = s.biddingClient.GetLowestBid
+	}
+	winningBid, err := getLeadingBid(ctx, auction.ID)
+	if err != nil || winningBid == nil {
+		return nil, err
+	}
+	return []domain.WinningBid{*winningBid}, nil
+}
+
+// ActivateDueAuctions opens every PENDING auction whose start time is at or before now
+// and announces it. It returns the number of auctions opened.
+func (s *AuctionService) ActivateDueAuctions(ctx context.Context, now time.Time) (int, error) {
+	var auctions []domain.Auction
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		auctions, err = s.repo.ActivateDue(ctx, now, lifecycleBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range auctions {
+			if err := s.producer.PublishAuctionCreated(ctx, &auctions[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return len(auctions), nil
+}
+
+// CloseExpiredAuctions closes every ACTIVE auction whose end time is at or before now.
+// It returns the number of auctions closed by this call.
+func (s *AuctionService) CloseExpiredAuctions(ctx context.Context, now time.Time) (int, error) {
+	auctions, err := s.repo.ListExpired(ctx, now, lifecycleBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := range auctions {
+		closed, err := s.closeAuction(ctx, &auctions[i])
+		if err != nil {
+			s.log.Error("failed to close expired auction", zap.Error(err), zap.String("auction_id", auctions[i].ID))
+			continue
+		}
+		if closed {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (s *AuctionService) ValidateBid(ctx context.Context, auctionID, bidderID string, amount float64) (bool, string, error) {
 	auction, err := s.repo.GetByID(ctx, auctionID)
 	if err != nil {
 		return false, "Auction not found", err
@@ -144,23 +448,391 @@ func (s *AuctionService) ValidateBid(ctx context.Context, auctionID string, amou
 	}
 
 	if time.Now().After(auction.EndTime) {
-		// Should probably close it here or have a background job
+		// The lifecycle scheduler will close it on its next tick
 		return false, "Auction has ended", nil
 	}
 
-	if amount <= auction.CurrentPrice {
-		return false, "Bid amount must be higher than current price", nil
+	if auction.AuctionType.IsDutch() {
+		return false, "Dutch auctions are won by accepting the clock price", nil
+	}
+
+	if auction.AuctionType.IsSealed() {
+		if amount < auction.StartPrice {
+			return false, fmt.Sprintf("Bid amount must be at least %.2f", auction.StartPrice), nil
+		}
+		placed, err := s.repo.HasSealedBidder(ctx, auctionID, bidderID)
+		if err != nil {
+			return false, "Failed to check previous bids", err
+		}
+		if placed {
+			return false, "You have already placed a bid on this auction", nil
+		}
+		return true, "Valid bid", nil
+	}
+
+	if auction.IsMultiLot() {
+		if amount < auction.StartPrice {
+			return false, fmt.Sprintf("Bid amount must be at least %.2f", auction.StartPrice), nil
+		}
+		return true, "Valid bid", nil
+	}
+
+	if auction.AuctionType.IsReverse() {
+		if maxNext := auction.MinIncrement.MaxNextBid(auction.CurrentPrice); amount > maxNext {
+			return false, fmt.Sprintf("Bid amount must be at most %.2f", maxNext), nil
+		}
+		return true, "Valid bid", nil
+	}
+
+	if minNext := auction.MinIncrement.MinNextBid(auction.CurrentPrice); amount < minNext {
+		return false, fmt.Sprintf("Bid amount must be at least %.2f", minNext), nil
 	}
 
 	return true, "Valid bid", nil
 }
 
-func (s *AuctionService) UpdateCurrentPrice(ctx context.Context, auctionID string, amount float64) error {
+// extendOnLateBid pushes the end time back when a bid accepted at now falls inside the
+// auction's extension window. It runs in the transaction that raised the price, so the row
+// stays locked and a concurrent close either waits for the extension or sees the old end
+// time change under it.
+func (s *AuctionService) extendOnLateBid(ctx context.Context, auction *domain.Auction, now time.Time) error {
+	newEndTime, ok := auction.ExtensionFor(now)
+	if !ok {
+		return nil
+	}
+
+	extended, err := s.repo.Extend(ctx, auction.ID, auction.EndTime, newEndTime)
+	if err != nil || !extended {
+		return err
+	}
+
+	previousEndTime := auction.EndTime
+	auction.EndTime = newEndTime
+	auction.ExtensionCount++
+	s.log.Info("auction extended by late bid",
+		zap.String("auction_id", auction.ID),
+		zap.Time("end_time", newEndTime),
+		zap.Int("extension_count", auction.ExtensionCount),
+	)
+	return s.producer.PublishAuctionExtended(ctx, auction, previousEndTime)
+}
+
+// quoteAt is the decision for an auction standing at price, before it is accepted or
+// rejected: the bound the next bid has to clear, a ceiling in reverse auctions and a
+// floor otherwise.
+func quoteAt(auction *domain.Auction, price float64) *domain.BidDecision {
+	if auction.AuctionType.IsReverse() {
+		return &domain.BidDecision{CurrentPrice: price, MaxNextBid: auction.MinIncrement.MaxNextBid(price), Reverse: true}
+	}
+	return &domain.BidDecision{CurrentPrice: price, MinNextBid: auction.MinIncrement.MinNextBid(price)}
+}
+
+// AcceptBid raises the auction price to amount if, at the moment of the update, the auction
+// is open and amount is at least one minimum increment over the current price (in a reverse
+// auction, lowers it to an amount at least one increment under). The price is read,
+// checked and then written with a compare-and-set on the price that was read; if a
+// concurrent bid moved it in between, the bid is re-checked against the new price, so a
+// concurrent lower bid can never win. A bid accepted inside the extension window also
+// extends the auction (see extendOnLateBid).
+// Sealed and multi-lot auctions are handled by acceptSealedBid and acceptLotBid instead.
+// A quantity of 0 is one unit; only multi-lot auctions take more.
+func (s *AuctionService) AcceptBid(ctx context.Context, auctionID, bidderID string, amount float64, quantity int) (*domain.BidDecision, error) {
+	if quantity == 0 {
+		quantity = 1
+	}
+	for {
+		auction, err := s.repo.GetByID(ctx, auctionID)
+		if errors.Is(err, domain.ErrAuctionNotFound) {
+			return &domain.BidDecision{Reason: domain.BidRejectionAuctionNotFound, Message: "Auction not found"}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		decision := quoteAt(auction, auction.CurrentPrice)
+		switch {
+		case auction.Status != domain.AuctionStatusActive:
+			decision.Reason, decision.Message = domain.BidRejectionNotActive, "Auction is not active"
+			return decision, nil
+		case !now.Before(auction.EndTime):
+			decision.Reason, decision.Message = domain.BidRejectionEnded, "Auction has ended"
+			return decision, nil
+		case auction.AuctionType.IsDutch():
+			decision.Reason, decision.Message = domain.BidRejectionWrongType, "Dutch auctions are won by accepting the clock price"
+			return decision, nil
+		case quantity < 1 || quantity > max(auction.Quantity, 1):
+			decision.Reason, decision.Message = domain.BidRejectionInvalidQuantity, fmt.Sprintf("Quantity must be between 1 and %d", max(auction.Quantity, 1))
+			return decision, nil
+		case auction.IsMultiLot():
+			return s.acceptLotBid(ctx, auction, amount, now)
+		case auction.AuctionType.IsSealed():
+			return s.acceptSealedBid(ctx, auction, bidderID, amount, now)
+		case auction.AuctionType.IsReverse() && amount > decision.MaxNextBid:
+			decision.Reason, decision.Message = domain.BidRejectionTooHigh, fmt.Sprintf("Bid amount must be at most %.2f", decision.MaxNextBid)
+			return decision, nil
+		case !auction.AuctionType.IsReverse() && amount < decision.MinNextBid:
+			decision.Reason, decision.Message = domain.BidRejectionTooLow, fmt.Sprintf("Bid amount must be at least %.2f", decision.MinNextBid)
+			return decision, nil
+		}
+
+		var raised bool
+		err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			raised, err = s.repo.RaisePrice(ctx, auctionID, auction.CurrentPrice, amount, now)
+			if err != nil || !raised {
+				return err
+			}
+			return s.extendOnLateBid(ctx, auction, now)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if raised {
+			decision := quoteAt(auction, amount)
+			decision.Accepted, decision.Message = true, "Bid accepted"
+			decision.SellerID = auction.SellerID
+			return decision, nil
+		}
+
+		// Another bid won the race (or the auction just closed); re-check against the new state.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// acceptSealedBid takes bidderID's one bid on an open sealed auction. The bid only has to
+// reach the start price; the price does not move, so nothing about the bid leaks through
+// the auction, and the winner is picked from all bids at close.
+func (s *AuctionService) acceptSealedBid(ctx context.Context, auction *domain.Auction, bidderID string, amount float64, now time.Time) (*domain.BidDecision, error) {
+	decision := &domain.BidDecision{CurrentPrice: auction.CurrentPrice, MinNextBid: auction.StartPrice, Sealed: true}
+	if amount < auction.StartPrice {
+		decision.Reason, decision.Message = domain.BidRejectionTooLow, fmt.Sprintf("Bid amount must be at least %.2f", auction.StartPrice)
+		return decision, nil
+	}
+
+	added, err := s.repo.AddSealedBidder(ctx, auction.ID, bidderID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		// Either the bidder already bid or the auction closed since it was read
+		placed, err := s.repo.HasSealedBidder(ctx, auction.ID, bidderID)
+		if err != nil {
+			return nil, err
+		}
+		if placed {
+			decision.Reason, decision.Message = domain.BidRejectionAlreadyBid, "You have already placed a bid on this auction"
+		} else {
+			decision.Reason, decision.Message = domain.BidRejectionEnded, "Auction has ended"
+		}
+		return decision, nil
+	}
+
+	decision.Accepted, decision.Message = true, "Bid accepted"
+	decision.SellerID = auction.SellerID
+	return decision, nil
+}
+
+// acceptLotBid takes a bid on an open multi-lot auction. Which bids win units depends on
+// all of them, so the price does not move: any bid of at least the start price stands,
+// the current allocation is shown by the bidding service, and the units are allocated
+// at close. A late bid still extends the auction.
+func (s *AuctionService) acceptLotBid(ctx context.Context, auction *domain.Auction, amount float64, now time.Time) (*domain.BidDecision, error) {
+	decision := &domain.BidDecision{CurrentPrice: auction.CurrentPrice, MinNextBid: auction.StartPrice, MultiLot: true}
+	if amount < auction.StartPrice {
+		decision.Reason, decision.Message = domain.BidRejectionTooLow, fmt.Sprintf("Bid amount must be at least %.2f", auction.StartPrice)
+		return decision, nil
+	}
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.extendOnLateBid(ctx, auction, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	decision.Accepted, decision.Message = true, "Bid accepted"
+	decision.SellerID = auction.SellerID
+	return decision, nil
+}
+
+func (s *AuctionService) BuyNowAvailable(auction *domain.Auction) bool {
+	return auction.BuyNowAvailable(s.settings.BuyNowCutoff)
+}
+
+// AcceptBuyNow closes the auction at its buy-now price with buyerID as the winner. Like
+// AcceptBid it checks the auction it read and then closes it with a compare-and-set on
+// the price, so of two buyers racing exactly one wins, and a bid that lands first either
+// keeps buy-now available (and the purchase is retried) or pushes it past the cutoff.
+func (s *AuctionService) AcceptBuyNow(ctx context.Context, auctionID, buyerID, bidID string) (*domain.BidDecision, error) {
+	for {
+		auction, err := s.repo.GetByID(ctx, auctionID)
+		if errors.Is(err, domain.ErrAuctionNotFound) {
+			return &domain.BidDecision{Reason: domain.BidRejectionAuctionNotFound, Message: "Auction not found"}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		readPrice := auction.CurrentPrice
+		decision := &domain.BidDecision{CurrentPrice: readPrice, MinNextBid: auction.MinIncrement.MinNextBid(readPrice)}
+		switch {
+		case auction.Status != domain.AuctionStatusActive:
+			decision.Reason, decision.Message = domain.BidRejectionNotActive, "Auction is not active"
+			return decision, nil
+		case !now.Before(auction.EndTime):
+			decision.Reason, decision.Message = domain.BidRejectionEnded, "Auction has ended"
+			return decision, nil
+		case !s.BuyNowAvailable(auction):
+			decision.Reason, decision.Message = domain.BidRejectionBuyNowUnavailable, "Buy now is not available for this auction"
+			return decision, nil
+		}
+
+		auction.WinnerID, auction.WinningBidID = buyerID, bidID
+		var bought bool
+		err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			bought, err = s.repo.BuyNow(ctx, auction, readPrice, now)
+			if err != nil || !bought {
+				return err
+			}
+			return s.producer.PublishAuctionClosed(ctx, auction)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if bought {
+			s.log.Info("auction bought now", zap.String("auction_id", auctionID), zap.String("winner_id", buyerID))
+			return &domain.BidDecision{Accepted: true, CurrentPrice: auction.BuyNowPrice, Message: "Auction bought"}, nil
+		}
+
+		// A bid or another buyer changed the auction; re-check against the new state.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// AcceptClockPrice closes a Dutch auction at its clock price with buyerID as winner and
+// bidID as the winning bid. The price is read from the clock at the moment of the
+// update, and the update only matches an open auction, so the first acceptance wins
+// and every later one is rejected as not active.
+func (s *AuctionService) AcceptClockPrice(ctx context.Context, auctionID, buyerID, bidID string) (*domain.BidDecision, error) {
 	auction, err := s.repo.GetByID(ctx, auctionID)
+	if errors.Is(err, domain.ErrAuctionNotFound) {
+		return &domain.BidDecision{Reason: domain.BidRejectionAuctionNotFound, Message: "Auction not found"}, nil
+	}
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	now := time.Now()
+	price := auction.ClockPrice(now)
+	decision := &domain.BidDecision{CurrentPrice: price}
+	switch {
+	case !auction.AuctionType.IsDutch():
+		decision.CurrentPrice = auction.CurrentPrice
+		decision.Reason, decision.Message = domain.BidRejectionWrongType, "Only dutch auctions have a clock price to accept"
+		return decision, nil
+	case auction.Status != domain.AuctionStatusActive:
+		decision.CurrentPrice = auction.CurrentPrice
+		decision.Reason, decision.Message = domain.BidRejectionNotActive, "Auction is not active"
+		return decision, nil
+	case !now.Before(auction.EndTime):
+		decision.Reason, decision.Message = domain.BidRejectionEnded, "Auction has ended"
+		return decision, nil
+	}
+
+	auction.WinnerID, auction.WinningBidID = buyerID, bidID
+	var claimed bool
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		claimed, err = s.repo.ClaimClock(ctx, auction, price, now)
+		if err != nil || !claimed {
+			return err
+		}
+		return s.producer.PublishAuctionClosed(ctx, auction)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		// Another buyer accepted first, or the auction closed between the read and the update
+		decision.Reason, decision.Message = domain.BidRejectionNotActive, "Auction is not active"
+		return decision, nil
+	}
+
+	s.log.Info("dutch auction accepted", zap.String("auction_id", auctionID), zap.String("winner_id", buyerID), zap.Float64("price", price))
+	return &domain.BidDecision{Accepted: true, CurrentPrice: price, Message: "Clock price accepted"}, nil
+}
+
+// RestorePrice moves the price of an auction whose leading bid was just retracted or
+// cancelled back to the leading live bid (the lowest in a reverse auction), or to the
+// start price if no live bid is left. The bidding service marks the bid retracted before
+// calling, so the leader read here already excludes it. Only a price still standing at
+// retractedAmount is moved, with the same compare-and-set as AcceptBid: if another bid
+// raised it in between, the withdrawn bid no longer set the price and nothing changes.
+// Sealed-bid, multi-lot and Dutch auctions have no running price and are left alone.
+func (s *AuctionService) RestorePrice(ctx context.Context, auctionID string, retractedAmount float64) (float64, bool, error) {
+	auction, err := s.repo.GetByID(ctx, auctionID)
+	if err != nil {
+		return 0, false, err
+	}
+
+	switch {
+	case auction.Status != domain.AuctionStatusActive,
+		auction.AuctionType.IsSealed(), auction.AuctionType.IsDutch(), auction.IsMultiLot(),
+		auction.CurrentPrice != retractedAmount:
+		return auction.CurrentPrice, false, nil
 	}
 
-	auction.CurrentPrice = amount
-	return s.repo.Update(ctx, auction)
+	topBids, err := s.topBids(ctx, auction)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to determine leading bid: %w", err)
+	}
+	price := auction.StartPrice
+	if len(topBids) > 0 {
+		price = topBids[0].Amount
+	}
+	if price == auction.CurrentPrice {
+		return price, false, nil
+	}
+
+	restored, err := s.repo.RaisePrice(ctx, auctionID, retractedAmount, price, time.Now())
+	if err != nil {
+		return 0, false, err
+	}
+	if !restored {
+		// A new bid moved the price (or the auction closed) since it was read
+		auction, err := s.repo.GetByID(ctx, auctionID)
+		if err != nil {
+			return 0, false, err
+		}
+		return auction.CurrentPrice, false, nil
+	}
+
+	s.log.Info("auction price restored after bid retraction",
+		zap.String("auction_id", auctionID),
+		zap.Float64("retracted_amount", retractedAmount),
+		zap.Float64("current_price", price),
+	)
+	return price, true, nil
+}
+
+// ReleaseSealedBid removes bidderID from the bidders of a sealed auction, so that the
+// bidder can bid again after the bidding service failed to record the bid AcceptBid
+// accepted. Nothing else about a sealed auction changes when a bid is accepted, so this
+// undoes it completely.
+func (s *AuctionService) ReleaseSealedBid(ctx context.Context, auctionID, bidderID string) (bool, error) {
+	released, err := s.repo.RemoveSealedBidder(ctx, auctionID, bidderID)
+	if err != nil {
+		return false, err
+	}
+	if released {
+		s.log.Info("sealed bidder released", zap.String("auction_id", auctionID), zap.String("bidder_id", bidderID))
+	}
+	return released, nil
 }
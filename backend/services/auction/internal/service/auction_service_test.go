@@ -3,6 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,6 +15,8 @@ import (
 	"go.uber.org/zap"
 )
 
+var testSettings = Settings{MaxExtensions: 10, BuyNowCutoff: 0.5}
+
 type MockLogger struct{}
 
 func (m *MockLogger) Debug(msg string, fields ...zap.Field)  {}
@@ -27,6 +33,19 @@ type MockAuctionRepo struct {
 	UpdateFunc  func(ctx context.Context, auction *domain.Auction) error
 	DeleteFunc  func(ctx context.Context, id string) error
 	ListFunc    func(ctx context.Context, page, limit int, status domain.AuctionStatus, category string) ([]domain.Auction, int64, error)
+
+	ActivateDueFunc func(ctx context.Context, now time.Time, limit int) ([]domain.Auction, error)
+	ListExpiredFunc func(ctx context.Context, now time.Time, limit int) ([]domain.Auction, error)
+	LockOpenFunc    func(ctx context.Context, auction *domain.Auction) (bool, error)
+	CloseFunc       func(ctx context.Context, auction *domain.Auction) (bool, error)
+	RaisePriceFunc  func(ctx context.Context, auctionID string, expectedPrice, amount float64, now time.Time) (bool, error)
+	ExtendFunc      func(ctx context.Context, auctionID string, endTime, newEndTime time.Time) (bool, error)
+	BuyNowFunc      func(ctx context.Context, auction *domain.Auction, expectedPrice float64, now time.Time) (bool, error)
+
+	AddSealedBidderFunc    func(ctx context.Context, auctionID, bidderID string, now time.Time) (bool, error)
+	HasSealedBidderFunc    func(ctx context.Context, auctionID, bidderID string) (bool, error)
+	RemoveSealedBidderFunc func(ctx context.Context, auctionID, bidderID string) (bool, error)
+	ClaimClockFunc         func(ctx context.Context, auction *domain.Auction, price float64, now time.Time) (bool, error)
 }
 
 func (m *MockAuctionRepo) Create(ctx context.Context, auction *domain.Auction) error {
@@ -64,10 +83,134 @@ func (m *MockAuctionRepo) List(ctx context.Context, page, limit int, status doma
 	return nil, 0, nil
 }
 
+func (m *MockAuctionRepo) ActivateDue(ctx context.Context, now time.Time, limit int) ([]domain.Auction, error) {
+	if m.ActivateDueFunc != nil {
+		return m.ActivateDueFunc(ctx, now, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockAuctionRepo) ListExpired(ctx context.Context, now time.Time, limit int) ([]domain.Auction, error) {
+	if m.ListExpiredFunc != nil {
+		return m.ListExpiredFunc(ctx, now, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockAuctionRepo) LockOpen(ctx context.Context, auction *domain.Auction) (bool, error) {
+	if m.LockOpenFunc != nil {
+		return m.LockOpenFunc(ctx, auction)
+	}
+	return true, nil
+}
+
+func (m *MockAuctionRepo) Close(ctx context.Context, auction *domain.Auction) (bool, error) {
+	if m.CloseFunc != nil {
+		return m.CloseFunc(ctx, auction)
+	}
+	return true, nil
+}
+
+func (m *MockAuctionRepo) RaisePrice(ctx context.Context, auctionID string, expectedPrice, amount float64, now time.Time) (bool, error) {
+	if m.RaisePriceFunc != nil {
+		return m.RaisePriceFunc(ctx, auctionID, expectedPrice, amount, now)
+	}
+	return false, nil
+}
+
+func (m *MockAuctionRepo) Extend(ctx context.Context, auctionID string, endTime, newEndTime time.Time) (bool, error) {
+	if m.ExtendFunc != nil {
+		return m.ExtendFunc(ctx, auctionID, endTime, newEndTime)
+	}
+	return false, nil
+}
+
+func (m *MockAuctionRepo) BuyNow(ctx context.Context, auction *domain.Auction, expectedPrice float64, now time.Time) (bool, error) {
+	if m.BuyNowFunc != nil {
+		return m.BuyNowFunc(ctx, auction, expectedPrice, now)
+	}
+	return false, nil
+}
+
+func (m *MockAuctionRepo) AddSealedBidder(ctx context.Context, auctionID, bidderID string, now time.Time) (bool, error) {
+	if m.AddSealedBidderFunc != nil {
+		return m.AddSealedBidderFunc(ctx, auctionID, bidderID, now)
+	}
+	return false, nil
+}
+
+func (m *MockAuctionRepo) HasSealedBidder(ctx context.Context, auctionID, bidderID string) (bool, error) {
+	if m.HasSealedBidderFunc != nil {
+		return m.HasSealedBidderFunc(ctx, auctionID, bidderID)
+	}
+	return false, nil
+}
+
+func (m *MockAuctionRepo) RemoveSealedBidder(ctx context.Context, auctionID, bidderID string) (bool, error) {
+	if m.RemoveSealedBidderFunc != nil {
+		return m.RemoveSealedBidderFunc(ctx, auctionID, bidderID)
+	}
+	return false, nil
+}
+
+func (m *MockAuctionRepo) ClaimClock(ctx context.Context, auction *domain.Auction, price float64, now time.Time) (bool, error) {
+	if m.ClaimClockFunc != nil {
+		return m.ClaimClockFunc(ctx, auction, price, now)
+	}
+	return false, nil
+}
+
+type MockBiddingClient struct {
+	GetHighestBidFunc func(ctx context.Context, auctionID string) (*domain.WinningBid, error)
+	GetTopBidsFunc    func(ctx context.Context, auctionID string, limit int) ([]domain.WinningBid, error)
+	GetLowestBidFunc  func(ctx context.Context, auctionID string) (*domain.WinningBid, error)
+	GetAllocationFunc func(ctx context.Context, auctionID string, quantity int) ([]domain.WinningBid, error)
+}
+
+func (m *MockBiddingClient) GetHighestBid(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+	if m.GetHighestBidFunc != nil {
+		return m.GetHighestBidFunc(ctx, auctionID)
+	}
+	return nil, nil
+}
+
+func (m *MockBiddingClient) GetTopBids(ctx context.Context, auctionID string, limit int) ([]domain.WinningBid, error) {
+	if m.GetTopBidsFunc != nil {
+		return m.GetTopBidsFunc(ctx, auctionID, limit)
+	}
+	return nil, nil
+}
+
+func (m *MockBiddingClient) GetLowestBid(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+	if m.GetLowestBidFunc != nil {
+		return m.GetLowestBidFunc(ctx, auctionID)
+	}
+	return nil, nil
+}
+
+func (m *MockBiddingClient) GetAllocation(ctx context.Context, auctionID string, quantity int) ([]domain.WinningBid, error) {
+	if m.GetAllocationFunc != nil {
+		return m.GetAllocationFunc(ctx, auctionID, quantity)
+	}
+	return nil, nil
+}
+
+// MockTransactor runs fn inline; there is no real transaction to commit.
+type MockTransactor struct {
+	Calls int
+}
+
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Calls++
+	return fn(ctx)
+}
+
 type MockEventProducer struct {
 	PublishAuctionCreatedFunc func(ctx context.Context, auction *domain.Auction) error
 	PublishAuctionUpdatedFunc func(ctx context.Context, auction *domain.Auction) error
-	PublishAuctionClosedFunc  func(ctx context.Context, auction *domain.Auction, winnerID string) error
+	PublishAuctionClosedFunc  func(ctx context.Context, auction *domain.Auction) error
+
+	PublishAuctionExtendedFunc func(ctx context.Context, auction *domain.Auction, previousEndTime time.Time) error
 }
 
 func (m *MockEventProducer) PublishAuctionCreated(ctx context.Context, auction *domain.Auction) error {
@@ -84,9 +227,16 @@ func (m *MockEventProducer) PublishAuctionUpdated(ctx context.Context, auction *
 	return nil
 }
 
-func (m *MockEventProducer) PublishAuctionClosed(ctx context.Context, auction *domain.Auction, winnerID string) error {
+func (m *MockEventProducer) PublishAuctionClosed(ctx context.Context, auction *domain.Auction) error {
 	if m.PublishAuctionClosedFunc != nil {
-		return m.PublishAuctionClosedFunc(ctx, auction, winnerID)
+		return m.PublishAuctionClosedFunc(ctx, auction)
+	}
+	return nil
+}
+
+func (m *MockEventProducer) PublishAuctionExtended(ctx context.Context, auction *domain.Auction, previousEndTime time.Time) error {
+	if m.PublishAuctionExtendedFunc != nil {
+		return m.PublishAuctionExtendedFunc(ctx, auction, previousEndTime)
 	}
 	return nil
 }
@@ -102,6 +252,7 @@ func TestCreateAuction(t *testing.T) {
 		endTime     time.Time
 		category    string
 		imageURL    string
+		opts        domain.AuctionOptions
 		mockRepo    func() *MockAuctionRepo
 		mockProd    func() *MockEventProducer
 		wantErr     bool
@@ -135,6 +286,28 @@ func TestCreateAuction(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:        "Outbox Write Fails",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(-1 * time.Minute),
+			endTime:     time.Now().Add(2 * time.Hour),
+			category:    "Electronics",
+			imageURL:    "http://image.com",
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{
+					PublishAuctionCreatedFunc: func(ctx context.Context, auction *domain.Auction) error {
+						return errors.New("outbox insert failed")
+					},
+				}
+			},
+			wantErr: true,
+		},
 		{
 			name:        "Invalid Time Range",
 			sellerID:    "seller-1",
@@ -171,118 +344,811 @@ func TestCreateAuction(t *testing.T) {
 			},
 			wantErr: true,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			repo := tt.mockRepo()
-			prod := tt.mockProd()
-			svc := NewAuctionService(repo, prod, &MockLogger{})
-
-			_, err := svc.CreateAuction(context.Background(), tt.sellerID, tt.title, tt.description, tt.startPrice, tt.startTime, tt.endTime, tt.category, tt.imageURL)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("CreateAuction() error = %v, wantErr %v", err, tt.wantErr)
-			}
-		})
-	}
-}
-
-func TestGetAuction(t *testing.T) {
-	mockRepo := &MockAuctionRepo{
-		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
-			if id == "found" {
-				return &domain.Auction{ID: "found"}, nil
-			}
-			return nil, errors.New("not found")
-		},
-	}
-	svc := NewAuctionService(mockRepo, &MockEventProducer{}, &MockLogger{})
-
-	t.Run("Found", func(t *testing.T) {
-		auction, err := svc.GetAuction(context.Background(), "found")
-		if err != nil {
-			t.Errorf("unexpected error: %v", err)
-		}
-		if auction.ID != "found" {
-			t.Errorf("expected id found, got %s", auction.ID)
-		}
-	})
-
-	t.Run("Not Found", func(t *testing.T) {
-		_, err := svc.GetAuction(context.Background(), "missing")
-		if err == nil {
-			t.Error("expected error, got nil")
-		}
-	})
-}
-
-func TestUpdateAuction(t *testing.T) {
-	mockRepo := &MockAuctionRepo{
-		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
-			if id == "active" {
-				return &domain.Auction{ID: "active", Status: domain.AuctionStatusActive}, nil
-			}
-			if id == "closed" {
-				return &domain.Auction{ID: "closed", Status: domain.AuctionStatusClosed}, nil
-			}
-			return nil, errors.New("not found")
-		},
-		UpdateFunc: func(ctx context.Context, auction *domain.Auction) error {
-			return nil
+		{
+			name:        "Invalid Increment",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{MinIncrement: domain.IncrementRule{{MinPrice: 0, Amount: 1}, {MinPrice: 0, Percent: 5}}},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
 		},
-	}
-	mockProd := &MockEventProducer{
-		PublishAuctionUpdatedFunc: func(ctx context.Context, auction *domain.Auction) error {
-			return nil
+		{
+			name:        "Reserve Below Start Price",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{ReservePrice: 5},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
 		},
-	}
-	svc := NewAuctionService(mockRepo, mockProd, &MockLogger{})
-
-	t.Run("Success", func(t *testing.T) {
-		_, err := svc.UpdateAuction(context.Background(), "active", "New Title", "", "")
-		if err != nil {
-			t.Errorf("unexpected error: %v", err)
-		}
-	})
-
-	t.Run("Closed Auction", func(t *testing.T) {
-		_, err := svc.UpdateAuction(context.Background(), "closed", "New Title", "", "")
-		if err == nil {
-			t.Error("expected error for closed auction, got nil")
-		}
-	})
-}
-
-func TestCloseAuction(t *testing.T) {
-	mockRepo := &MockAuctionRepo{
-		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
-			return &domain.Auction{ID: id, Status: domain.AuctionStatusActive}, nil
+		{
+			name:        "With Increment And Reserve",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{MinIncrement: domain.FixedIncrement(1), ReservePrice: 50},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{
+					CreateFunc: func(ctx context.Context, auction *domain.Auction) error {
+						if auction.ReservePrice != 50 || len(auction.MinIncrement) != 1 {
+							return errors.New("options not stored")
+						}
+						return nil
+					},
+				}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: false,
 		},
-		UpdateFunc: func(ctx context.Context, auction *domain.Auction) error {
-			if auction.Status != domain.AuctionStatusClosed {
-				return errors.New("status not updated")
-			}
-			return nil
+		{
+			name:        "Extension Window Without Duration",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{ExtensionWindow: 120},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
 		},
-	}
-	mockProd := &MockEventProducer{
-		PublishAuctionClosedFunc: func(ctx context.Context, auction *domain.Auction, winnerID string) error {
-			return nil
+		{
+			name:        "Max Extensions Above Cap",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{ExtensionWindow: 120, ExtensionDuration: 60, MaxExtensions: 11},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
 		},
-	}
-	svc := NewAuctionService(mockRepo, mockProd, &MockLogger{})
-
-	err := svc.CloseAuction(context.Background(), "1")
-	if err != nil {
-		t.Errorf("unexpected error: %v", err)
-	}
-}
-
-func TestValidateBid(t *testing.T) {
-	now := time.Now()
-	mockRepo := &MockAuctionRepo{
-		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
-			if id == "active" {
+		{
+			name:        "Extensions Default To Cap",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{ExtensionWindow: 120, ExtensionDuration: 60},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{
+					CreateFunc: func(ctx context.Context, auction *domain.Auction) error {
+						if auction.MaxExtensions != 10 || auction.ExtensionWindow != 120 || auction.ExtensionDuration != 60 {
+							return errors.New("extension settings not stored")
+						}
+						return nil
+					},
+				}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: false,
+		},
+		{
+			name:        "Buy Now Below Start Price",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{BuyNowPrice: 10},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Buy Now Below Reserve",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{ReservePrice: 100, BuyNowPrice: 50},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Sealed With Increment",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{AuctionType: domain.AuctionTypeSealedFirstPrice, MinIncrement: domain.FixedIncrement(5)},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Reverse With Reserve",
+			sellerID:    "buyer-1",
+			title:       "Test Tender",
+			description: "Description",
+			startPrice:  1000.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{AuctionType: domain.AuctionTypeReverse, ReservePrice: 800},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Dutch",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  100.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{AuctionType: domain.AuctionTypeDutch, ClockStep: 5, ClockInterval: 60, FloorPrice: 40},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: false,
+		},
+		{
+			name:        "Dutch Without Clock",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  100.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{AuctionType: domain.AuctionTypeDutch, FloorPrice: 40},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Dutch Floor Above Start",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  100.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{AuctionType: domain.AuctionTypeDutch, ClockStep: 5, ClockInterval: 60, FloorPrice: 100},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Clock On English Auction",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  100.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{ClockStep: 5, ClockInterval: 60},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Multi-Lot",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{Quantity: 5, LotPricing: domain.LotPricingDiscriminatory},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: false,
+		},
+		{
+			name:        "Multi-Lot Sealed",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{Quantity: 5, AuctionType: domain.AuctionTypeSealedFirstPrice},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Multi-Lot With Reserve",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{Quantity: 5, ReservePrice: 50},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Lot Pricing Without Quantity",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{LotPricing: domain.LotPricingUniform},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Unknown Lot Pricing",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{Quantity: 5, LotPricing: "VICKREY"},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Unknown Auction Type",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{AuctionType: "JAPANESE"},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Sealed Second Price",
+			sellerID:    "seller-1",
+			title:       "Test Auction",
+			description: "Description",
+			startPrice:  10.0,
+			startTime:   time.Now().Add(1 * time.Hour),
+			endTime:     time.Now().Add(2 * time.Hour),
+			opts:        domain.AuctionOptions{AuctionType: domain.AuctionTypeSealedSecondPrice},
+			mockRepo: func() *MockAuctionRepo {
+				return &MockAuctionRepo{
+					CreateFunc: func(ctx context.Context, auction *domain.Auction) error {
+						if auction.AuctionType != domain.AuctionTypeSealedSecondPrice {
+							return errors.New("auction type not stored")
+						}
+						return nil
+					},
+				}
+			},
+			mockProd: func() *MockEventProducer {
+				return &MockEventProducer{}
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := tt.mockRepo()
+			prod := tt.mockProd()
+			svc := NewAuctionService(repo, &MockTransactor{}, prod, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+			_, err := svc.CreateAuction(context.Background(), tt.sellerID, tt.title, tt.description, tt.startPrice, tt.startTime, tt.endTime, tt.category, tt.imageURL, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateAuction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetAuction(t *testing.T) {
+	mockRepo := &MockAuctionRepo{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+			if id == "found" {
+				return &domain.Auction{ID: "found"}, nil
+			}
+			if id == "dutch" {
+				return &domain.Auction{
+					ID: "dutch", Status: domain.AuctionStatusActive, AuctionType: domain.AuctionTypeDutch,
+					StartPrice: 100, CurrentPrice: 100, ClockStep: 5, ClockInterval: 60,
+					StartTime: time.Now().Add(-150 * time.Second), EndTime: time.Now().Add(time.Hour),
+				}, nil
+			}
+			return nil, errors.New("not found")
+		},
+	}
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, &MockEventProducer{}, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+	t.Run("Found", func(t *testing.T) {
+		auction, err := svc.GetAuction(context.Background(), "found")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if auction.ID != "found" {
+			t.Errorf("expected id found, got %s", auction.ID)
+		}
+	})
+
+	t.Run("Dutch At Clock Price", func(t *testing.T) {
+		auction, err := svc.GetAuction(context.Background(), "dutch")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if auction.CurrentPrice != 90 {
+			t.Errorf("expected the clock price 90, got %.2f", auction.CurrentPrice)
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		_, err := svc.GetAuction(context.Background(), "missing")
+		if err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestUpdateAuction(t *testing.T) {
+	mockRepo := &MockAuctionRepo{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+			if id == "active" {
+				return &domain.Auction{ID: "active", Status: domain.AuctionStatusActive}, nil
+			}
+			if id == "closed" {
+				return &domain.Auction{ID: "closed", Status: domain.AuctionStatusClosed}, nil
+			}
+			return nil, errors.New("not found")
+		},
+		UpdateFunc: func(ctx context.Context, auction *domain.Auction) error {
+			return nil
+		},
+	}
+	mockProd := &MockEventProducer{
+		PublishAuctionUpdatedFunc: func(ctx context.Context, auction *domain.Auction) error {
+			return nil
+		},
+	}
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, mockProd, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+	t.Run("Success", func(t *testing.T) {
+		_, err := svc.UpdateAuction(context.Background(), "active", "New Title", "", "")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Closed Auction", func(t *testing.T) {
+		_, err := svc.UpdateAuction(context.Background(), "closed", "New Title", "", "")
+		if err == nil {
+			t.Error("expected error for closed auction, got nil")
+		}
+	})
+}
+
+func TestCloseAuction(t *testing.T) {
+	mockRepo := &MockAuctionRepo{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+			return &domain.Auction{ID: id, Status: domain.AuctionStatusActive, CurrentPrice: 100}, nil
+		},
+		CloseFunc: func(ctx context.Context, auction *domain.Auction) (bool, error) {
+			auction.Status = domain.AuctionStatusClosed
+			return true, nil
+		},
+	}
+
+	t.Run("With Winner", func(t *testing.T) {
+		var event *domain.Auction
+		var eventWinner string
+		mockProd := &MockEventProducer{
+			PublishAuctionClosedFunc: func(ctx context.Context, auction *domain.Auction) error {
+				event, eventWinner = auction, auction.WinnerID
+				return nil
+			},
+		}
+		mockBidding := &MockBiddingClient{
+			GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+				return &domain.WinningBid{BidID: "bid-1", BidderID: "user-1", Amount: 150}, nil
+			},
+		}
+		svc := NewAuctionService(mockRepo, &MockTransactor{}, mockProd, mockBidding, testSettings, &MockLogger{})
+
+		err := svc.CloseAuction(context.Background(), "1")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if event == nil || eventWinner != "user-1" || event.WinningBidID != "bid-1" || event.CurrentPrice != 150 {
+			t.Errorf("expected closed event with winner user-1 at 150, got %+v (winner %q)", event, eventWinner)
+		}
+	})
+
+	t.Run("No Bids", func(t *testing.T) {
+		var eventWinner = "unset"
+		mockProd := &MockEventProducer{
+			PublishAuctionClosedFunc: func(ctx context.Context, auction *domain.Auction) error {
+				eventWinner = auction.WinnerID
+				return nil
+			},
+		}
+		svc := NewAuctionService(mockRepo, &MockTransactor{}, mockProd, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+		err := svc.CloseAuction(context.Background(), "1")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if eventWinner != "" {
+			t.Errorf("expected no winner, got %q", eventWinner)
+		}
+	})
+
+	t.Run("Reserve Not Met", func(t *testing.T) {
+		repo := *mockRepo
+		repo.GetByIDFunc = func(ctx context.Context, id string) (*domain.Auction, error) {
+			return &domain.Auction{ID: id, Status: domain.AuctionStatusActive, CurrentPrice: 100, ReservePrice: 200}, nil
+		}
+		var closedStatus domain.AuctionStatus
+		repo.CloseFunc = func(ctx context.Context, auction *domain.Auction) (bool, error) {
+			closedStatus = auction.Status
+			return true, nil
+		}
+		var event *domain.Auction
+		eventWinner := "unset"
+		mockProd := &MockEventProducer{
+			PublishAuctionClosedFunc: func(ctx context.Context, auction *domain.Auction) error {
+				event, eventWinner = auction, auction.WinnerID
+				return nil
+			},
+		}
+		mockBidding := &MockBiddingClient{
+			GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+				return &domain.WinningBid{BidID: "bid-1", BidderID: "user-1", Amount: 150}, nil
+			},
+		}
+		svc := NewAuctionService(&repo, &MockTransactor{}, mockProd, mockBidding, testSettings, &MockLogger{})
+
+		if err := svc.CloseAuction(context.Background(), "1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if closedStatus != domain.AuctionStatusReserveNotMet {
+			t.Errorf("expected status %s, got %s", domain.AuctionStatusReserveNotMet, closedStatus)
+		}
+		if event == nil || eventWinner != "" || event.WinnerID != "" || event.CurrentPrice != 150 {
+			t.Errorf("expected closed event without a winner at 150, got %+v (winner %q)", event, eventWinner)
+		}
+	})
+
+	t.Run("Locks Before Reading Bids", func(t *testing.T) {
+		var calls []string
+		repo := *mockRepo
+		repo.LockOpenFunc = func(ctx context.Context, auction *domain.Auction) (bool, error) {
+			calls = append(calls, "lock")
+			return true, nil
+		}
+		mockBidding := &MockBiddingClient{
+			GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+				calls = append(calls, "bids")
+				return &domain.WinningBid{BidID: "bid-1", BidderID: "user-1", Amount: 150}, nil
+			},
+		}
+		svc := NewAuctionService(&repo, &MockTransactor{}, &MockEventProducer{}, mockBidding, testSettings, &MockLogger{})
+
+		if err := svc.CloseAuction(context.Background(), "1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(calls) != 2 || calls[0] != "lock" || calls[1] != "bids" {
+			t.Errorf("expected the auction to be locked before its bids are read, got %v", calls)
+		}
+	})
+
+	t.Run("Closed Meanwhile", func(t *testing.T) {
+		repo := *mockRepo
+		repo.LockOpenFunc = func(ctx context.Context, auction *domain.Auction) (bool, error) {
+			return false, nil
+		}
+		repo.CloseFunc = func(ctx context.Context, auction *domain.Auction) (bool, error) {
+			t.Error("an auction closed by another caller must not be closed again")
+			return false, nil
+		}
+		mockBidding := &MockBiddingClient{
+			GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+				t.Error("bids must not be read for an auction closed by another caller")
+				return nil, nil
+			},
+		}
+		svc := NewAuctionService(&repo, &MockTransactor{}, &MockEventProducer{}, mockBidding, testSettings, &MockLogger{})
+
+		if err := svc.CloseAuction(context.Background(), "1"); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Bidding Service Down", func(t *testing.T) {
+		mockBidding := &MockBiddingClient{
+			GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+				return nil, errors.New("unavailable")
+			},
+		}
+		repo := *mockRepo
+		repo.CloseFunc = func(ctx context.Context, auction *domain.Auction) (bool, error) {
+			t.Error("auction must stay open when the winner cannot be determined")
+			return false, nil
+		}
+		svc := NewAuctionService(&repo, &MockTransactor{}, &MockEventProducer{}, mockBidding, testSettings, &MockLogger{})
+
+		if err := svc.CloseAuction(context.Background(), "1"); err == nil {
+			t.Error("expected error, got nil")
+		}
+	})
+}
+
+func TestCloseAuction_SecondPrice(t *testing.T) {
+	var closed *domain.Auction
+	mockRepo := &MockAuctionRepo{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+			return &domain.Auction{ID: id, Status: domain.AuctionStatusActive, StartPrice: 100, CurrentPrice: 100, AuctionType: domain.AuctionTypeSealedSecondPrice}, nil
+		},
+		CloseFunc: func(ctx context.Context, auction *domain.Auction) (bool, error) {
+			closed = auction
+			return true, nil
+		},
+	}
+	var limit int
+	mockBidding := &MockBiddingClient{
+		GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+			return nil, errors.New("sealed auctions are settled from the top bids")
+		},
+		GetTopBidsFunc: func(ctx context.Context, auctionID string, n int) ([]domain.WinningBid, error) {
+			limit = n
+			return []domain.WinningBid{
+				{BidID: "bid-1", BidderID: "user-1", Amount: 180},
+				{BidID: "bid-2", BidderID: "user-2", Amount: 140},
+			}, nil
+		},
+	}
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, &MockEventProducer{}, mockBidding, testSettings, &MockLogger{})
+
+	if err := svc.CloseAuction(context.Background(), "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if limit != 2 {
+		t.Errorf("expected the top 2 bids to be requested, got %d", limit)
+	}
+	if closed == nil || closed.WinnerID != "user-1" || closed.WinningBidID != "bid-1" || closed.CurrentPrice != 140 {
+		t.Errorf("expected user-1 to win paying the runner-up's 140, got %+v", closed)
+	}
+}
+
+func TestCloseAuction_Reverse(t *testing.T) {
+	var closed *domain.Auction
+	mockRepo := &MockAuctionRepo{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+			return &domain.Auction{ID: id, Status: domain.AuctionStatusActive, StartPrice: 1000, CurrentPrice: 850, AuctionType: domain.AuctionTypeReverse}, nil
+		},
+		CloseFunc: func(ctx context.Context, auction *domain.Auction) (bool, error) {
+			closed = auction
+			return true, nil
+		},
+	}
+	mockBidding := &MockBiddingClient{
+		GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+			return &domain.WinningBid{BidID: "bid-1", BidderID: "user-1", Amount: 950}, nil
+		},
+		GetLowestBidFunc: func(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+			return &domain.WinningBid{BidID: "bid-2", BidderID: "user-2", Amount: 850}, nil
+		},
+	}
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, &MockEventProducer{}, mockBidding, testSettings, &MockLogger{})
+
+	if err := svc.CloseAuction(context.Background(), "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if closed == nil || closed.WinnerID != "user-2" || closed.WinningBidID != "bid-2" || closed.CurrentPrice != 850 {
+		t.Errorf("expected the lowest bidder user-2 to win at 850, got %+v", closed)
+	}
+}
+
+func TestCloseAuction_MultiLot(t *testing.T) {
+	var closed *domain.Auction
+	mockRepo := &MockAuctionRepo{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+			return &domain.Auction{ID: id, Status: domain.AuctionStatusActive, StartPrice: 100, CurrentPrice: 100, Quantity: 3, LotPricing: domain.LotPricingUniform}, nil
+		},
+		CloseFunc: func(ctx context.Context, auction *domain.Auction) (bool, error) {
+			closed = auction
+			return true, nil
+		},
+	}
+	var quantity int
+	mockBidding := &MockBiddingClient{
+		GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+			return nil, errors.New("multi-lot auctions are settled from the allocation")
+		},
+		GetAllocationFunc: func(ctx context.Context, auctionID string, n int) ([]domain.WinningBid, error) {
+			quantity = n
+			return []domain.WinningBid{
+				{BidID: "bid-1", BidderID: "user-1", Amount: 150, Units: 2},
+				{BidID: "bid-2", BidderID: "user-2", Amount: 120, Units: 1},
+			}, nil
+		},
+	}
+	var published domain.LotWinners
+	mockProd := &MockEventProducer{
+		PublishAuctionClosedFunc: func(ctx context.Context, auction *domain.Auction) error {
+			published = auction.AllWinners()
+			return nil
+		},
+	}
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, mockProd, mockBidding, testSettings, &MockLogger{})
+
+	if err := svc.CloseAuction(context.Background(), "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if quantity != 3 {
+		t.Errorf("expected 3 units to be allocated, got %d", quantity)
+	}
+	want := domain.LotWinners{
+		{BidID: "bid-1", BidderID: "user-1", Units: 2, Price: 120},
+		{BidID: "bid-2", BidderID: "user-2", Units: 1, Price: 120},
+	}
+	if closed == nil || closed.Status != domain.AuctionStatusClosed || closed.CurrentPrice != 120 || closed.WinnerID != "" || !reflect.DeepEqual(closed.Winners, want) {
+		t.Errorf("expected both bidders to win at the clearing price 120, got %+v", closed)
+	}
+	if !reflect.DeepEqual(published, want) {
+		t.Errorf("expected auction.closed to list the winners, got %+v", published)
+	}
+}
+
+func TestAcceptBid_MultiLot(t *testing.T) {
+	now := time.Now()
+	auctions := map[string]*domain.Auction{
+		"lots":   {ID: "lots", Status: domain.AuctionStatusActive, StartPrice: 100, CurrentPrice: 100, EndTime: now.Add(time.Hour), Quantity: 5},
+		"single": {ID: "single", Status: domain.AuctionStatusActive, StartPrice: 100, CurrentPrice: 100, EndTime: now.Add(time.Hour), Quantity: 1},
+	}
+	mockRepo := &MockAuctionRepo{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+			return auctions[id], nil
+		},
+		RaisePriceFunc: func(ctx context.Context, auctionID string, expectedPrice, amount float64, now time.Time) (bool, error) {
+			if auctionID == "lots" {
+				t.Error("a multi-lot bid must not move the price")
+			}
+			return true, nil
+		},
+	}
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, &MockEventProducer{}, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+	tests := []struct {
+		name       string
+		auctionID  string
+		amount     float64
+		quantity   int
+		wantAccept bool
+		wantReason domain.BidRejectionReason
+	}{
+		{"Several Units", "lots", 120, 3, true, domain.BidRejectionNone},
+		{"At Start Price", "lots", 100, 0, true, domain.BidRejectionNone},
+		{"Below Start Price", "lots", 99, 1, false, domain.BidRejectionTooLow},
+		{"More Units Than Offered", "lots", 120, 6, false, domain.BidRejectionInvalidQuantity},
+		{"Negative Quantity", "lots", 120, -1, false, domain.BidRejectionInvalidQuantity},
+		{"Units Of A Single Item", "single", 120, 2, false, domain.BidRejectionInvalidQuantity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := svc.AcceptBid(context.Background(), tt.auctionID, "bidder-1", tt.amount, tt.quantity)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if decision.Accepted != tt.wantAccept || decision.Reason != tt.wantReason {
+				t.Errorf("AcceptBid() = %+v, want accepted=%v reason=%q", decision, tt.wantAccept, tt.wantReason)
+			}
+			if tt.wantAccept && (!decision.MultiLot || decision.CurrentPrice != 100) {
+				t.Errorf("expected a multi-lot decision at the unmoved price, got %+v", decision)
+			}
+		})
+	}
+}
+
+func TestValidateBid(t *testing.T) {
+	now := time.Now()
+	mockRepo := &MockAuctionRepo{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+			if id == "active" {
 				return &domain.Auction{
 					ID:           "active",
 					Status:       domain.AuctionStatusActive,
@@ -301,96 +1167,867 @@ func TestValidateBid(t *testing.T) {
 			return nil, errors.New("not found")
 		},
 	}
-	svc := NewAuctionService(mockRepo, &MockEventProducer{}, &MockLogger{})
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, &MockEventProducer{}, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+	t.Run("Valid Bid", func(t *testing.T) {
+		valid, msg, err := svc.ValidateBid(context.Background(), "active", "bidder-1", 150)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if !valid {
+			t.Errorf("expected valid bid, got invalid: %s", msg)
+		}
+	})
+
+	t.Run("Low Bid", func(t *testing.T) {
+		valid, _, err := svc.ValidateBid(context.Background(), "active", "bidder-1", 50)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if valid {
+			t.Error("expected invalid bid, got valid")
+		}
+	})
+
+	t.Run("Ended Auction", func(t *testing.T) {
+		valid, _, err := svc.ValidateBid(context.Background(), "ended", "bidder-1", 150)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if valid {
+			t.Error("expected invalid bid for ended auction, got valid")
+		}
+	})
+}
+
+func TestValidateBid_Sealed(t *testing.T) {
+	mockRepo := &MockAuctionRepo{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+			return &domain.Auction{
+				ID:           id,
+				Status:       domain.AuctionStatusActive,
+				StartPrice:   100,
+				CurrentPrice: 100,
+				EndTime:      time.Now().Add(time.Hour),
+				AuctionType:  domain.AuctionTypeSealedFirstPrice,
+			}, nil
+		},
+		HasSealedBidderFunc: func(ctx context.Context, auctionID, bidderID string) (bool, error) {
+			return bidderID == "bidder-2", nil
+		},
+	}
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, &MockEventProducer{}, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+	tests := []struct {
+		name      string
+		bidderID  string
+		amount    float64
+		wantValid bool
+	}{
+		{"At Start Price", "bidder-1", 100, true},
+		{"Below Start Price", "bidder-1", 99, false},
+		{"Already Bid", "bidder-2", 150, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, msg, err := svc.ValidateBid(context.Background(), "1", tt.bidderID, tt.amount)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if valid != tt.wantValid {
+				t.Errorf("ValidateBid() = %v (%s), want %v", valid, msg, tt.wantValid)
+			}
+		})
+	}
+}
+
+func TestListAuctions(t *testing.T) {
+	mockRepo := &MockAuctionRepo{
+		ListFunc: func(ctx context.Context, page, limit int, status domain.AuctionStatus, category string) ([]domain.Auction, int64, error) {
+			if page == 1 && limit == 10 {
+				return []domain.Auction{{ID: "1"}}, 1, nil
+			}
+			return nil, 0, errors.New("invalid params")
+		},
+	}
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, &MockEventProducer{}, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+	t.Run("Success", func(t *testing.T) {
+		auctions, count, err := svc.ListAuctions(context.Background(), 1, 10, "", "")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if count != 1 {
+			t.Errorf("expected count 1, got %d", count)
+		}
+		if len(auctions) != 1 {
+			t.Errorf("expected 1 auction, got %d", len(auctions))
+		}
+	})
+
+	t.Run("Default Params", func(t *testing.T) {
+		// Test that 0, 0 defaults to 1, 10
+		auctions, _, err := svc.ListAuctions(context.Background(), 0, 0, "", "")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if len(auctions) != 1 {
+			t.Errorf("expected 1 auction, got %d", len(auctions))
+		}
+	})
+}
+
+func TestActivateDueAuctions(t *testing.T) {
+	var published []string
+	mockRepo := &MockAuctionRepo{
+		ActivateDueFunc: func(ctx context.Context, now time.Time, limit int) ([]domain.Auction, error) {
+			return []domain.Auction{
+				{ID: "1", Status: domain.AuctionStatusActive},
+				{ID: "2", Status: domain.AuctionStatusActive},
+			}, nil
+		},
+	}
+	mockProd := &MockEventProducer{
+		PublishAuctionCreatedFunc: func(ctx context.Context, auction *domain.Auction) error {
+			published = append(published, auction.ID)
+			return nil
+		},
+	}
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, mockProd, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+	count, err := svc.ActivateDueAuctions(context.Background(), time.Now())
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 activated auctions, got %d", count)
+	}
+	if len(published) != 2 {
+		t.Errorf("expected 2 auction created events, got %d", len(published))
+	}
+}
+
+func TestCloseExpiredAuctions(t *testing.T) {
+	var closed []string
+	mockRepo := &MockAuctionRepo{
+		ListExpiredFunc: func(ctx context.Context, now time.Time, limit int) ([]domain.Auction, error) {
+			return []domain.Auction{
+				{ID: "1", Status: domain.AuctionStatusActive},
+				{ID: "2", Status: domain.AuctionStatusActive},
+			}, nil
+		},
+		CloseFunc: func(ctx context.Context, auction *domain.Auction) (bool, error) {
+			// Auction 2 was closed by another replica in the meantime
+			return auction.ID == "1", nil
+		},
+	}
+	mockProd := &MockEventProducer{
+		PublishAuctionClosedFunc: func(ctx context.Context, auction *domain.Auction) error {
+			closed = append(closed, auction.ID)
+			return nil
+		},
+	}
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, mockProd, &MockBiddingClient{}, testSettings, &MockLogger{})
 
-	t.Run("Valid Bid", func(t *testing.T) {
-		valid, msg, err := svc.ValidateBid(context.Background(), "active", 150)
+	t.Run("Success", func(t *testing.T) {
+		count, err := svc.CloseExpiredAuctions(context.Background(), time.Now())
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
-		if !valid {
-			t.Errorf("expected valid bid, got invalid: %s", msg)
+		if count != 1 || len(closed) != 1 || closed[0] != "1" {
+			t.Errorf("expected only auction 1 to be closed and published, got %d and %v", count, closed)
 		}
 	})
 
-	t.Run("Low Bid", func(t *testing.T) {
-		valid, _, err := svc.ValidateBid(context.Background(), "active", 50)
-		if err != nil {
-			t.Errorf("unexpected error: %v", err)
+	t.Run("Repo Error", func(t *testing.T) {
+		mockRepo.ListExpiredFunc = func(ctx context.Context, now time.Time, limit int) ([]domain.Auction, error) {
+			return nil, errors.New("db error")
 		}
-		if valid {
-			t.Error("expected invalid bid, got valid")
+		if _, err := svc.CloseExpiredAuctions(context.Background(), time.Now()); err == nil {
+			t.Error("expected error, got nil")
 		}
 	})
+}
 
-	t.Run("Ended Auction", func(t *testing.T) {
-		valid, _, err := svc.ValidateBid(context.Background(), "ended", 150)
-		if err != nil {
-			t.Errorf("unexpected error: %v", err)
-		}
-		if valid {
-			t.Error("expected invalid bid for ended auction, got valid")
-		}
-	})
+func TestAcceptBid(t *testing.T) {
+	now := time.Now()
+	auctions := map[string]*domain.Auction{
+		"active":  {ID: "active", SellerID: "seller-1", Status: domain.AuctionStatusActive, CurrentPrice: 100, EndTime: now.Add(time.Hour)},
+		"pending": {ID: "pending", Status: domain.AuctionStatusPending, CurrentPrice: 100, EndTime: now.Add(time.Hour)},
+		"ended":   {ID: "ended", Status: domain.AuctionStatusActive, CurrentPrice: 100, EndTime: now.Add(-time.Minute)},
+		"dutch":   {ID: "dutch", Status: domain.AuctionStatusActive, CurrentPrice: 100, EndTime: now.Add(time.Hour), AuctionType: domain.AuctionTypeDutch},
+		"tiered": {ID: "tiered", Status: domain.AuctionStatusActive, CurrentPrice: 100, EndTime: now.Add(time.Hour), MinIncrement: domain.IncrementRule{
+			{MinPrice: 0, Amount: 1},
+			{MinPrice: 100, Percent: 5},
+		}},
+	}
+	mockRepo := &MockAuctionRepo{
+		RaisePriceFunc: func(ctx context.Context, auctionID string, expectedPrice, amount float64, now time.Time) (bool, error) {
+			a, ok := auctions[auctionID]
+			return ok && a.Status == domain.AuctionStatusActive && now.Before(a.EndTime) && a.CurrentPrice == expectedPrice, nil
+		},
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+			if a, ok := auctions[id]; ok {
+				return a, nil
+			}
+			return nil, domain.ErrAuctionNotFound
+		},
+	}
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, &MockEventProducer{}, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+	tests := []struct {
+		name       string
+		auctionID  string
+		amount     float64
+		wantAccept bool
+		wantReason domain.BidRejectionReason
+	}{
+		{"Accepted", "active", 150, true, domain.BidRejectionNone},
+		{"Too Low", "active", 90, false, domain.BidRejectionTooLow},
+		{"Not Active", "pending", 150, false, domain.BidRejectionNotActive},
+		{"Ended", "ended", 150, false, domain.BidRejectionEnded},
+		{"Not Found", "missing", 150, false, domain.BidRejectionAuctionNotFound},
+		{"Below Tier Increment", "tiered", 104.99, false, domain.BidRejectionTooLow},
+		{"Dutch", "dutch", 150, false, domain.BidRejectionWrongType},
+		{"At Tier Increment", "tiered", 105, true, domain.BidRejectionNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := svc.AcceptBid(context.Background(), tt.auctionID, "bidder-1", tt.amount, 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if decision.Accepted != tt.wantAccept || decision.Reason != tt.wantReason {
+				t.Errorf("AcceptBid() = %+v, want accepted=%v reason=%q", decision, tt.wantAccept, tt.wantReason)
+			}
+			if decision.Reason != domain.BidRejectionAuctionNotFound && decision.MinNextBid <= decision.CurrentPrice {
+				t.Errorf("expected MinNextBid above the current price, got %+v", decision)
+			}
+			if tt.auctionID == "active" && decision.Accepted != (decision.SellerID == "seller-1") {
+				t.Errorf("expected the seller only on an accepted bid, got %+v", decision)
+			}
+		})
+	}
 }
 
-func TestUpdateCurrentPrice(t *testing.T) {
+func TestAcceptBid_Sealed(t *testing.T) {
+	now := time.Now()
+	auctions := map[string]*domain.Auction{
+		"sealed": {ID: "sealed", Status: domain.AuctionStatusActive, StartPrice: 100, CurrentPrice: 100, EndTime: now.Add(time.Hour), AuctionType: domain.AuctionTypeSealedSecondPrice},
+		// Closes between the read and the insert
+		"closing": {ID: "closing", Status: domain.AuctionStatusActive, StartPrice: 100, CurrentPrice: 100, EndTime: now.Add(time.Hour), AuctionType: domain.AuctionTypeSealedFirstPrice},
+	}
+	bidders := map[string]bool{}
 	mockRepo := &MockAuctionRepo{
 		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
-			return &domain.Auction{ID: id, CurrentPrice: 100}, nil
+			if a, ok := auctions[id]; ok {
+				return a, nil
+			}
+			return nil, domain.ErrAuctionNotFound
 		},
-		UpdateFunc: func(ctx context.Context, auction *domain.Auction) error {
-			if auction.CurrentPrice != 200 {
-				return errors.New("price not updated")
+		RaisePriceFunc: func(ctx context.Context, auctionID string, expectedPrice, amount float64, now time.Time) (bool, error) {
+			t.Errorf("sealed bid must not move the price, got RaisePrice(%.2f)", amount)
+			return false, nil
+		},
+		AddSealedBidderFunc: func(ctx context.Context, auctionID, bidderID string, now time.Time) (bool, error) {
+			key := auctionID + "/" + bidderID
+			if auctionID == "closing" || bidders[key] {
+				return false, nil
 			}
-			return nil
+			bidders[key] = true
+			return true, nil
 		},
-	}
-	mockProd := &MockEventProducer{
-		PublishAuctionUpdatedFunc: func(ctx context.Context, auction *domain.Auction) error {
-			return nil
+		HasSealedBidderFunc: func(ctx context.Context, auctionID, bidderID string) (bool, error) {
+			return bidders[auctionID+"/"+bidderID], nil
 		},
 	}
-	svc := NewAuctionService(mockRepo, mockProd, &MockLogger{})
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, &MockEventProducer{}, &MockBiddingClient{}, testSettings, &MockLogger{})
 
-	err := svc.UpdateCurrentPrice(context.Background(), "1", 200)
-	if err != nil {
-		t.Errorf("unexpected error: %v", err)
+	tests := []struct {
+		name       string
+		auctionID  string
+		bidderID   string
+		amount     float64
+		wantAccept bool
+		wantReason domain.BidRejectionReason
+	}{
+		{"Accepted", "sealed", "bidder-1", 150, true, domain.BidRejectionNone},
+		{"Lower Than Other Bids", "sealed", "bidder-2", 120, true, domain.BidRejectionNone},
+		{"Below Start Price", "sealed", "bidder-3", 99, false, domain.BidRejectionTooLow},
+		{"Second Bid", "sealed", "bidder-1", 200, false, domain.BidRejectionAlreadyBid},
+		{"Closed Meanwhile", "closing", "bidder-1", 150, false, domain.BidRejectionEnded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := svc.AcceptBid(context.Background(), tt.auctionID, tt.bidderID, tt.amount, 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if decision.Accepted != tt.wantAccept || decision.Reason != tt.wantReason {
+				t.Errorf("AcceptBid() = %+v, want accepted=%v reason=%q", decision, tt.wantAccept, tt.wantReason)
+			}
+			if !decision.Sealed || decision.CurrentPrice != 100 {
+				t.Errorf("expected a sealed decision at the unchanged start price, got %+v", decision)
+			}
+		})
 	}
 }
 
-func TestListAuctions(t *testing.T) {
+func TestAcceptBid_Reverse(t *testing.T) {
+	now := time.Now()
+	auction := &domain.Auction{ID: "tender", Status: domain.AuctionStatusActive, StartPrice: 1000, CurrentPrice: 1000, EndTime: now.Add(time.Hour), AuctionType: domain.AuctionTypeReverse, MinIncrement: domain.FixedIncrement(10)}
 	mockRepo := &MockAuctionRepo{
-		ListFunc: func(ctx context.Context, page, limit int, status domain.AuctionStatus, category string) ([]domain.Auction, int64, error) {
-			if page == 1 && limit == 10 {
-				return []domain.Auction{{ID: "1"}}, 1, nil
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+			return auction, nil
+		},
+		RaisePriceFunc: func(ctx context.Context, auctionID string, expectedPrice, amount float64, now time.Time) (bool, error) {
+			if auction.CurrentPrice != expectedPrice {
+				return false, nil
 			}
-			return nil, 0, errors.New("invalid params")
+			auction.CurrentPrice = amount
+			return true, nil
 		},
 	}
-	svc := NewAuctionService(mockRepo, &MockEventProducer{}, &MockLogger{})
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, &MockEventProducer{}, &MockBiddingClient{}, testSettings, &MockLogger{})
 
-	t.Run("Success", func(t *testing.T) {
-		auctions, count, err := svc.ListAuctions(context.Background(), 1, 10, "", "")
-		if err != nil {
-			t.Errorf("unexpected error: %v", err)
+	tests := []struct {
+		name       string
+		amount     float64
+		wantAccept bool
+		wantReason domain.BidRejectionReason
+		wantPrice  float64
+	}{
+		{"Above Current Price", 1100, false, domain.BidRejectionTooHigh, 1000},
+		{"Within Decrement", 995, false, domain.BidRejectionTooHigh, 1000},
+		{"Accepted", 990, true, domain.BidRejectionNone, 990},
+		{"Undercut", 900, true, domain.BidRejectionNone, 900},
+		{"Higher Than Leader", 950, false, domain.BidRejectionTooHigh, 900},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := svc.AcceptBid(context.Background(), "tender", "bidder-1", tt.amount, 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if decision.Accepted != tt.wantAccept || decision.Reason != tt.wantReason || decision.CurrentPrice != tt.wantPrice {
+				t.Errorf("AcceptBid() = %+v, want accepted=%v reason=%q price=%.2f", decision, tt.wantAccept, tt.wantReason, tt.wantPrice)
+			}
+			if !decision.Reverse || decision.MinNextBid != 0 || decision.MaxNextBid != tt.wantPrice-10 {
+				t.Errorf("expected a reverse decision capping the next bid at %.2f, got %+v", tt.wantPrice-10, decision)
+			}
+		})
+	}
+}
+
+func TestAcceptBid_Extension(t *testing.T) {
+	now := time.Now()
+	newAuction := func(endsIn time.Duration, count int) *domain.Auction {
+		return &domain.Auction{
+			ID:                "1",
+			Status:            domain.AuctionStatusActive,
+			CurrentPrice:      100,
+			EndTime:           now.Add(endsIn),
+			ExtensionWindow:   120,
+			ExtensionDuration: 60,
+			MaxExtensions:     3,
+			ExtensionCount:    count,
 		}
-		if count != 1 {
-			t.Errorf("expected count 1, got %d", count)
+	}
+
+	tests := []struct {
+		name         string
+		auction      *domain.Auction
+		wantExtended bool
+	}{
+		{"Inside Window", newAuction(30*time.Second, 0), true},
+		{"Repeated Extension", newAuction(90*time.Second, 2), true},
+		{"Outside Window", newAuction(10*time.Minute, 0), false},
+		{"Cap Reached", newAuction(30*time.Second, 3), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var extendedTo time.Time
+			var event *domain.Auction
+			var previousEnd time.Time
+			mockRepo := &MockAuctionRepo{
+				GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+					a := *tt.auction
+					return &a, nil
+				},
+				RaisePriceFunc: func(ctx context.Context, auctionID string, expectedPrice, amount float64, now time.Time) (bool, error) {
+					return true, nil
+				},
+				ExtendFunc: func(ctx context.Context, auctionID string, endTime, newEndTime time.Time) (bool, error) {
+					if !endTime.Equal(tt.auction.EndTime) {
+						t.Errorf("expected extension from %v, got %v", tt.auction.EndTime, endTime)
+					}
+					extendedTo = newEndTime
+					return true, nil
+				},
+			}
+			mockProd := &MockEventProducer{
+				PublishAuctionExtendedFunc: func(ctx context.Context, auction *domain.Auction, previousEndTime time.Time) error {
+					event, previousEnd = auction, previousEndTime
+					return nil
+				},
+			}
+			tx := &MockTransactor{}
+			svc := NewAuctionService(mockRepo, tx, mockProd, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+			decision, err := svc.AcceptBid(context.Background(), "1", "bidder-1", 150, 1)
+			if err != nil || !decision.Accepted {
+				t.Fatalf("expected bid to be accepted, got %+v (err %v)", decision, err)
+			}
+			if tx.Calls != 1 {
+				t.Errorf("expected the raise and the extension to share one transaction, got %d", tx.Calls)
+			}
+
+			if !tt.wantExtended {
+				if !extendedTo.IsZero() || event != nil {
+					t.Errorf("expected no extension, got end time %v", extendedTo)
+				}
+				return
+			}
+			wantEnd := tt.auction.EndTime.Add(time.Minute)
+			if !extendedTo.Equal(wantEnd) {
+				t.Errorf("expected end time %v, got %v", wantEnd, extendedTo)
+			}
+			if event == nil || !event.EndTime.Equal(wantEnd) || !previousEnd.Equal(tt.auction.EndTime) || event.ExtensionCount != tt.auction.ExtensionCount+1 {
+				t.Errorf("expected auction.extended event to %v, got %+v (previous %v)", wantEnd, event, previousEnd)
+			}
+		})
+	}
+
+	t.Run("Event Failure Rejects Bid", func(t *testing.T) {
+		mockRepo := &MockAuctionRepo{
+			GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+				return newAuction(30*time.Second, 0), nil
+			},
+			RaisePriceFunc: func(ctx context.Context, auctionID string, expectedPrice, amount float64, now time.Time) (bool, error) {
+				return true, nil
+			},
+			ExtendFunc: func(ctx context.Context, auctionID string, endTime, newEndTime time.Time) (bool, error) {
+				return true, nil
+			},
 		}
-		if len(auctions) != 1 {
-			t.Errorf("expected 1 auction, got %d", len(auctions))
+		mockProd := &MockEventProducer{
+			PublishAuctionExtendedFunc: func(ctx context.Context, auction *domain.Auction, previousEndTime time.Time) error {
+				return errors.New("outbox insert failed")
+			},
+		}
+		svc := NewAuctionService(mockRepo, &MockTransactor{}, mockProd, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+		if _, err := svc.AcceptBid(context.Background(), "1", "bidder-1", 150, 1); err == nil {
+			t.Error("expected error, got nil")
 		}
 	})
+}
 
-	t.Run("Default Params", func(t *testing.T) {
-		// Test that 0, 0 defaults to 1, 10
-		auctions, _, err := svc.ListAuctions(context.Background(), 0, 0, "", "")
-		if err != nil {
-			t.Errorf("unexpected error: %v", err)
+func TestAcceptBuyNow(t *testing.T) {
+	now := time.Now()
+	auctions := map[string]*domain.Auction{
+		"available":   {ID: "available", Status: domain.AuctionStatusActive, CurrentPrice: 100, BuyNowPrice: 500, EndTime: now.Add(time.Hour)},
+		"past-cutoff": {ID: "past-cutoff", Status: domain.AuctionStatusActive, CurrentPrice: 250, BuyNowPrice: 500, EndTime: now.Add(time.Hour)},
+		"no-buy-now":  {ID: "no-buy-now", Status: domain.AuctionStatusActive, CurrentPrice: 100, EndTime: now.Add(time.Hour)},
+		"closed":      {ID: "closed", Status: domain.AuctionStatusClosed, CurrentPrice: 100, BuyNowPrice: 500, EndTime: now.Add(time.Hour)},
+	}
+
+	tests := []struct {
+		name       string
+		auctionID  string
+		wantAccept bool
+		wantReason domain.BidRejectionReason
+	}{
+		{"Bought", "available", true, domain.BidRejectionNone},
+		{"Past Cutoff", "past-cutoff", false, domain.BidRejectionBuyNowUnavailable},
+		{"No Buy Now Price", "no-buy-now", false, domain.BidRejectionBuyNowUnavailable},
+		{"Closed", "closed", false, domain.BidRejectionNotActive},
+		{"Not Found", "missing", false, domain.BidRejectionAuctionNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var closedWinner string
+			mockRepo := &MockAuctionRepo{
+				GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+					if a, ok := auctions[id]; ok {
+						copied := *a
+						return &copied, nil
+					}
+					return nil, domain.ErrAuctionNotFound
+				},
+				BuyNowFunc: func(ctx context.Context, auction *domain.Auction, expectedPrice float64, now time.Time) (bool, error) {
+					if auction.WinnerID != "buyer-1" || auction.WinningBidID != "bid-1" {
+						t.Errorf("expected buyer-1 and bid-1 as winner, got %+v", auction)
+					}
+					auction.Status, auction.CurrentPrice = domain.AuctionStatusClosed, auction.BuyNowPrice
+					return true, nil
+				},
+			}
+			mockProd := &MockEventProducer{
+				PublishAuctionClosedFunc: func(ctx context.Context, auction *domain.Auction) error {
+					closedWinner = auction.WinnerID
+					return nil
+				},
+			}
+			svc := NewAuctionService(mockRepo, &MockTransactor{}, mockProd, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+			decision, err := svc.AcceptBuyNow(context.Background(), tt.auctionID, "buyer-1", "bid-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if decision.Accepted != tt.wantAccept || decision.Reason != tt.wantReason {
+				t.Errorf("AcceptBuyNow() = %+v, want accepted=%v reason=%q", decision, tt.wantAccept, tt.wantReason)
+			}
+			if tt.wantAccept && (decision.CurrentPrice != 500 || closedWinner != "buyer-1") {
+				t.Errorf("expected purchase at 500 announced for buyer-1, got %+v (winner %q)", decision, closedWinner)
+			}
+			if !tt.wantAccept && closedWinner != "" {
+				t.Error("expected no auction.closed event for a rejected purchase")
+			}
+		})
+	}
+}
+
+func TestAcceptBuyNow_ConcurrentBuyers(t *testing.T) {
+	repo := &memAuctionRepo{auction: domain.Auction{
+		ID:           "1",
+		Status:       domain.AuctionStatusActive,
+		CurrentPrice: 100,
+		BuyNowPrice:  500,
+		EndTime:      time.Now().Add(time.Hour),
+	}}
+	var mu sync.Mutex
+	var closedEvents int
+	mockProd := &MockEventProducer{
+		PublishAuctionClosedFunc: func(ctx context.Context, auction *domain.Auction) error {
+			mu.Lock()
+			closedEvents++
+			mu.Unlock()
+			return nil
+		},
+	}
+	svc := NewAuctionService(repo, &MockTransactor{}, mockProd, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+	const buyers = 50
+	var wg sync.WaitGroup
+	var winners []string
+	start := make(chan struct{})
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func(buyerID string) {
+			defer wg.Done()
+			<-start
+			decision, err := svc.AcceptBuyNow(context.Background(), "1", buyerID, "bid-"+buyerID)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if decision.Accepted {
+				mu.Lock()
+				winners = append(winners, buyerID)
+				mu.Unlock()
+			}
+		}(fmt.Sprintf("buyer-%d", i))
+	}
+	close(start)
+	wg.Wait()
+
+	if len(winners) != 1 || closedEvents != 1 {
+		t.Fatalf("expected exactly one buyer and one auction.closed event, got %v and %d events", winners, closedEvents)
+	}
+	if repo.auction.WinnerID != winners[0] || repo.auction.Status != domain.AuctionStatusClosed || repo.auction.CurrentPrice != 500 {
+		t.Errorf("expected auction closed at 500 for %s, got %+v", winners[0], repo.auction)
+	}
+}
+
+// memAuctionRepo applies RaisePrice under a lock, mirroring the single compare-and-set
+// UPDATE the Postgres repository issues.
+type memAuctionRepo struct {
+	MockAuctionRepo
+	mu      sync.Mutex
+	auction domain.Auction
+}
+
+func (r *memAuctionRepo) RaisePrice(ctx context.Context, auctionID string, expectedPrice, amount float64, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.auction.Status != domain.AuctionStatusActive || !now.Before(r.auction.EndTime) || r.auction.CurrentPrice != expectedPrice {
+		return false, nil
+	}
+	r.auction.CurrentPrice = amount
+	return true, nil
+}
+
+func (r *memAuctionRepo) BuyNow(ctx context.Context, auction *domain.Auction, expectedPrice float64, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.auction.Status != domain.AuctionStatusActive || !now.Before(r.auction.EndTime) || r.auction.CurrentPrice != expectedPrice || r.auction.BuyNowPrice == 0 {
+		return false, nil
+	}
+	r.auction.Status, r.auction.CurrentPrice = domain.AuctionStatusClosed, r.auction.BuyNowPrice
+	r.auction.WinnerID, r.auction.WinningBidID = auction.WinnerID, auction.WinningBidID
+	auction.Status, auction.CurrentPrice = r.auction.Status, r.auction.CurrentPrice
+	return true, nil
+}
+
+func (r *memAuctionRepo) ClaimClock(ctx context.Context, auction *domain.Auction, price float64, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.auction.Status != domain.AuctionStatusActive || !now.Before(r.auction.EndTime) || !r.auction.AuctionType.IsDutch() {
+		return false, nil
+	}
+	r.auction.Status, r.auction.CurrentPrice = domain.AuctionStatusClosed, price
+	r.auction.WinnerID, r.auction.WinningBidID = auction.WinnerID, auction.WinningBidID
+	auction.Status, auction.CurrentPrice = r.auction.Status, r.auction.CurrentPrice
+	return true, nil
+}
+
+func (r *memAuctionRepo) GetByID(ctx context.Context, id string) (*domain.Auction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a := r.auction
+	return &a, nil
+}
+
+func TestAcceptClockPrice(t *testing.T) {
+	now := time.Now()
+	dutch := func(status domain.AuctionStatus, endTime time.Time) *domain.Auction {
+		return &domain.Auction{
+			ID: "dutch", Status: status, AuctionType: domain.AuctionTypeDutch,
+			StartPrice: 100, CurrentPrice: 100, ClockStep: 5, ClockInterval: 60, FloorPrice: 50,
+			StartTime: now.Add(-150 * time.Second), EndTime: endTime,
 		}
-		if len(auctions) != 1 {
-			t.Errorf("expected 1 auction, got %d", len(auctions))
+	}
+
+	tests := []struct {
+		name       string
+		auction    *domain.Auction
+		wantAccept bool
+		wantReason domain.BidRejectionReason
+		wantPrice  float64
+	}{
+		{"Accepted At Clock Price", dutch(domain.AuctionStatusActive, now.Add(time.Hour)), true, domain.BidRejectionNone, 90},
+		{"Already Accepted", dutch(domain.AuctionStatusClosed, now.Add(time.Hour)), false, domain.BidRejectionNotActive, 100},
+		{"Ended", dutch(domain.AuctionStatusActive, now.Add(-time.Second)), false, domain.BidRejectionEnded, 90},
+		{"English Auction", &domain.Auction{ID: "english", Status: domain.AuctionStatusActive, CurrentPrice: 120, EndTime: now.Add(time.Hour)}, false, domain.BidRejectionWrongType, 120},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var closed bool
+			mockRepo := &MockAuctionRepo{
+				GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+					return tt.auction, nil
+				},
+				ClaimClockFunc: func(ctx context.Context, auction *domain.Auction, price float64, now time.Time) (bool, error) {
+					auction.Status, auction.CurrentPrice = domain.AuctionStatusClosed, price
+					return true, nil
+				},
+			}
+			mockProd := &MockEventProducer{
+				PublishAuctionClosedFunc: func(ctx context.Context, auction *domain.Auction) error {
+					closed = auction.WinnerID == "buyer-1" && auction.WinningBidID == "bid-1"
+					return nil
+				},
+			}
+			svc := NewAuctionService(mockRepo, &MockTransactor{}, mockProd, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+			decision, err := svc.AcceptClockPrice(context.Background(), tt.auction.ID, "buyer-1", "bid-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if decision.Accepted != tt.wantAccept || decision.Reason != tt.wantReason || decision.CurrentPrice != tt.wantPrice {
+				t.Errorf("AcceptClockPrice() = %+v, want accepted=%v reason=%q price=%.2f", decision, tt.wantAccept, tt.wantReason, tt.wantPrice)
+			}
+			if closed != tt.wantAccept {
+				t.Errorf("expected auction.closed for buyer-1 only on acceptance, published=%v", closed)
+			}
+		})
+	}
+}
+
+func TestAcceptClockPrice_ConcurrentBuyers(t *testing.T) {
+	repo := &memAuctionRepo{auction: domain.Auction{
+		ID:            "1",
+		Status:        domain.AuctionStatusActive,
+		AuctionType:   domain.AuctionTypeDutch,
+		StartPrice:    100,
+		CurrentPrice:  100,
+		ClockStep:     1,
+		ClockInterval: 3600,
+		StartTime:     time.Now(),
+		EndTime:       time.Now().Add(2 * time.Hour),
+	}}
+	svc := NewAuctionService(repo, &MockTransactor{}, &MockEventProducer{}, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+	const buyers = 50
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var winners []string
+	start := make(chan struct{})
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func(buyerID string) {
+			defer wg.Done()
+			<-start
+			decision, err := svc.AcceptClockPrice(context.Background(), "1", buyerID, "bid-"+buyerID)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if decision.Accepted {
+				mu.Lock()
+				winners = append(winners, buyerID)
+				mu.Unlock()
+			}
+		}(fmt.Sprintf("buyer-%d", i))
+	}
+	close(start)
+	wg.Wait()
+
+	if len(winners) != 1 {
+		t.Fatalf("expected exactly one buyer, got %v", winners)
+	}
+	if repo.auction.WinnerID != winners[0] || repo.auction.CurrentPrice != 100 {
+		t.Errorf("expected auction closed at 100 for %s, got %+v", winners[0], repo.auction)
+	}
+}
+
+func TestAcceptBid_ConcurrentBids(t *testing.T) {
+	repo := &memAuctionRepo{auction: domain.Auction{
+		ID:           "1",
+		Status:       domain.AuctionStatusActive,
+		CurrentPrice: 100,
+		EndTime:      time.Now().Add(time.Hour),
+	}}
+	svc := NewAuctionService(repo, &MockTransactor{}, &MockEventProducer{}, &MockBiddingClient{}, testSettings, &MockLogger{})
+
+	const bidders = 500
+	amounts := make([]float64, bidders)
+	highest := 0.0
+	for i := range amounts {
+		amounts[i] = 100 + float64(rand.Intn(10000))/100
+		if amounts[i] > highest {
+			highest = amounts[i]
 		}
-	})
+	}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for _, amount := range amounts {
+		wg.Add(1)
+		go func(amount float64) {
+			defer wg.Done()
+			<-start
+			decision, err := svc.AcceptBid(context.Background(), "1", "bidder-1", amount, 1)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if !decision.Accepted && decision.Reason != domain.BidRejectionTooLow {
+				t.Errorf("unexpected rejection reason %q", decision.Reason)
+			}
+		}(amount)
+	}
+	close(start)
+	wg.Wait()
+
+	if repo.auction.CurrentPrice != highest {
+		t.Errorf("expected final price to be the highest bid %.2f, got %.2f", highest, repo.auction.CurrentPrice)
+	}
+}
+
+func TestRestorePrice(t *testing.T) {
+	now := time.Now()
+	newRepo := func(auction *domain.Auction) *MockAuctionRepo {
+		return &MockAuctionRepo{
+			GetByIDFunc: func(ctx context.Context, id string) (*domain.Auction, error) {
+				return auction, nil
+			},
+			RaisePriceFunc: func(ctx context.Context, auctionID string, expectedPrice, amount float64, now time.Time) (bool, error) {
+				if auction.CurrentPrice != expectedPrice {
+					return false, nil
+				}
+				auction.CurrentPrice = amount
+				return true, nil
+			},
+		}
+	}
+	leader := &MockBiddingClient{
+		GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+			return &domain.WinningBid{BidID: "bid-2", BidderID: "bidder-2", Amount: 150}, nil
+		},
+		GetLowestBidFunc: func(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+			return &domain.WinningBid{BidID: "bid-2", BidderID: "bidder-2", Amount: 950}, nil
+		},
+	}
+
+	tests := []struct {
+		name         string
+		auction      *domain.Auction
+		bidding      *MockBiddingClient
+		retracted    float64
+		wantPrice    float64
+		wantRestored bool
+	}{
+		{
+			name:      "Falls Back To Next Bid",
+			auction:   &domain.Auction{ID: "1", Status: domain.AuctionStatusActive, StartPrice: 100, CurrentPrice: 15000, EndTime: now.Add(time.Hour)},
+			bidding:   leader,
+			retracted: 15000, wantPrice: 150, wantRestored: true,
+		},
+		{
+			name:      "Falls Back To Start Price",
+			auction:   &domain.Auction{ID: "1", Status: domain.AuctionStatusActive, StartPrice: 100, CurrentPrice: 15000, EndTime: now.Add(time.Hour)},
+			bidding:   &MockBiddingClient{},
+			retracted: 15000, wantPrice: 100, wantRestored: true,
+		},
+		{
+			name:      "Reverse Rises To Next Lowest",
+			auction:   &domain.Auction{ID: "1", Status: domain.AuctionStatusActive, StartPrice: 1000, CurrentPrice: 9, EndTime: now.Add(time.Hour), AuctionType: domain.AuctionTypeReverse},
+			bidding:   leader,
+			retracted: 9, wantPrice: 950, wantRestored: true,
+		},
+		{
+			name:      "Retracted Bid Was Not Leading",
+			auction:   &domain.Auction{ID: "1", Status: domain.AuctionStatusActive, StartPrice: 100, CurrentPrice: 200, EndTime: now.Add(time.Hour)},
+			bidding:   leader,
+			retracted: 150, wantPrice: 200, wantRestored: false,
+		},
+		{
+			name:      "Sealed Price Never Moves",
+			auction:   &domain.Auction{ID: "1", Status: domain.AuctionStatusActive, StartPrice: 100, CurrentPrice: 100, EndTime: now.Add(time.Hour), AuctionType: domain.AuctionTypeSealedFirstPrice},
+			bidding:   leader,
+			retracted: 100, wantPrice: 100, wantRestored: false,
+		},
+		{
+			name:      "Closed Auction",
+			auction:   &domain.Auction{ID: "1", Status: domain.AuctionStatusClosed, StartPrice: 100, CurrentPrice: 15000, EndTime: now.Add(-time.Hour)},
+			bidding:   leader,
+			retracted: 15000, wantPrice: 15000, wantRestored: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewAuctionService(newRepo(tt.auction), &MockTransactor{}, &MockEventProducer{}, tt.bidding, testSettings, &MockLogger{})
+
+			price, restored, err := svc.RestorePrice(context.Background(), "1", tt.retracted)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if price != tt.wantPrice || restored != tt.wantRestored {
+				t.Errorf("RestorePrice() = %.2f, %v, want %.2f, %v", price, restored, tt.wantPrice, tt.wantRestored)
+			}
+			if tt.auction.CurrentPrice != tt.wantPrice {
+				t.Errorf("expected the stored price to be %.2f, got %.2f", tt.wantPrice, tt.auction.CurrentPrice)
+			}
+		})
+	}
 }
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+
+	pb "github.com/temesgen-abebayehu/bidflow/backend/proto/pb"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/auction/internal/domain"
+	"google.golang.org/grpc"
+)
+
+type biddingClient struct {
+	client pb.BiddingServiceClient
+}
+
+func NewBiddingClient(conn *grpc.ClientConn) domain.BiddingClient {
+	return &biddingClient{
+		client: pb.NewBiddingServiceClient(conn),
+	}
+}
+
+func (c *biddingClient) GetHighestBid(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+	req := &pb.GetHighestBidRequest{
+		AuctionId: auctionID,
+	}
+
+	res, err := c.client.GetHighestBid(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Found {
+		return nil, nil
+	}
+
+	return &domain.WinningBid{
+		BidID:    res.Bid.Id,
+		BidderID: res.Bid.BidderId,
+		Amount:   res.Bid.Amount,
+	}, nil
+}
+
+func (c *biddingClient) GetTopBids(ctx context.Context, auctionID string, limit int) ([]domain.WinningBid, error) {
+	req := &pb.GetTopBidsRequest{
+		AuctionId: auctionID,
+		Limit:     int32(limit),
+	}
+
+	res, err := c.client.GetTopBids(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	bids := make([]domain.WinningBid, 0, len(res.Bids))
+	for _, b := range res.Bids {
+		bids = append(bids, domain.WinningBid{
+			BidID:    b.Id,
+			BidderID: b.BidderId,
+			Amount:   b.Amount,
+		})
+	}
+	return bids, nil
+}
+
+func (c *biddingClient) GetLowestBid(ctx context.Context, auctionID string) (*domain.WinningBid, error) {
+	req := &pb.GetTopBidsRequest{
+		AuctionId:   auctionID,
+		Limit:       1,
+		LowestFirst: true,
+	}
+
+	res, err := c.client.GetTopBids(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Bids) == 0 {
+		return nil, nil
+	}
+
+	return &domain.WinningBid{
+		BidID:    res.Bids[0].Id,
+		BidderID: res.Bids[0].BidderId,
+		Amount:   res.Bids[0].Amount,
+	}, nil
+}
+
+func (c *biddingClient) GetAllocation(ctx context.Context, auctionID string, quantity int) ([]domain.WinningBid, error) {
+	req := &pb.GetAllocationRequest{
+		AuctionId: auctionID,
+		Quantity:  int32(quantity),
+	}
+
+	res, err := c.client.GetAllocation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	bids := make([]domain.WinningBid, 0, len(res.Bids))
+	for _, b := range res.Bids {
+		bids = append(bids, domain.WinningBid{
+			BidID:    b.Id,
+			BidderID: b.BidderId,
+			Amount:   b.Amount,
+			Units:    int(b.Allocated),
+		})
+	}
+	return bids, nil
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"go.uber.org/zap"
+)
+
+// LifecycleScheduler periodically opens auctions that have reached their start time and
+// closes auctions that have passed their end time. Every replica of the auction service
+// may run one; the repository only applies a transition to rows still in the expected
+// status, so each auction is opened and closed exactly once.
+type LifecycleScheduler struct {
+	service  *AuctionService
+	interval time.Duration
+	log      logger.Logger
+}
+
+func NewLifecycleScheduler(service *AuctionService, interval time.Duration, log logger.Logger) *LifecycleScheduler {
+	return &LifecycleScheduler{
+		service:  service,
+		interval: interval,
+		log:      log,
+	}
+}
+
+// Start runs the scheduler in a background goroutine until ctx is cancelled.
+func (s *LifecycleScheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				s.log.Info("auction lifecycle scheduler stopped")
+				return
+			case <-ticker.C:
+				s.Tick(ctx, time.Now())
+			}
+		}
+	}()
+}
+
+// Tick applies all transitions that are due at now.
+func (s *LifecycleScheduler) Tick(ctx context.Context, now time.Time) {
+	opened, err := s.service.ActivateDueAuctions(ctx, now)
+	if err != nil {
+		s.log.Error("failed to activate due auctions", zap.Error(err))
+	} else if opened > 0 {
+		s.log.Info("activated auctions", zap.Int("count", opened))
+	}
+
+	closed, err := s.service.CloseExpiredAuctions(ctx, now)
+	if err != nil {
+		s.log.Error("failed to close expired auctions", zap.Error(err))
+	} else if closed > 0 {
+		s.log.Info("closed auctions", zap.Int("count", closed))
+	}
+}
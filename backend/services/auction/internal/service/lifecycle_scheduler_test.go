@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/services/auction/internal/domain"
+)
+
+func TestLifecycleSchedulerTick(t *testing.T) {
+	tickTime := time.Now()
+	var activatedAt, closedAt time.Time
+
+	mockRepo := &MockAuctionRepo{
+		ActivateDueFunc: func(ctx context.Context, now time.Time, limit int) ([]domain.Auction, error) {
+			activatedAt = now
+			return nil, nil
+		},
+		ListExpiredFunc: func(ctx context.Context, now time.Time, limit int) ([]domain.Auction, error) {
+			closedAt = now
+			return nil, nil
+		},
+	}
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, &MockEventProducer{}, &MockBiddingClient{}, testSettings, &MockLogger{})
+	scheduler := NewLifecycleScheduler(svc, time.Second, &MockLogger{})
+
+	scheduler.Tick(context.Background(), tickTime)
+
+	if !activatedAt.Equal(tickTime) {
+		t.Errorf("expected activation sweep at %v, got %v", tickTime, activatedAt)
+	}
+	if !closedAt.Equal(tickTime) {
+		t.Errorf("expected close sweep at %v, got %v", tickTime, closedAt)
+	}
+}
+
+func TestLifecycleSchedulerStart(t *testing.T) {
+	swept := make(chan struct{}, 1)
+	mockRepo := &MockAuctionRepo{
+		ListExpiredFunc: func(ctx context.Context, now time.Time, limit int) ([]domain.Auction, error) {
+			select {
+			case swept <- struct{}{}:
+			default:
+			}
+			return nil, nil
+		},
+	}
+	svc := NewAuctionService(mockRepo, &MockTransactor{}, &MockEventProducer{}, &MockBiddingClient{}, testSettings, &MockLogger{})
+	scheduler := NewLifecycleScheduler(svc, 10*time.Millisecond, &MockLogger{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scheduler.Start(ctx)
+
+	select {
+	case <-swept:
+	case <-time.After(time.Second):
+		t.Fatal("expected scheduler to run a sweep")
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net"
@@ -11,6 +12,7 @@ import (
 	_ "github.com/lib/pq"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/auth"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/config"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/database"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/kafka"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
 	pb "github.com/temesgen-abebayehu/bidflow/backend/proto/pb"
@@ -20,6 +22,7 @@ import (
 	"github.com/temesgen-abebayehu/bidflow/backend/services/auction/internal/service"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -49,11 +52,38 @@ func main() {
 	// Setup layers
 	repo := repository.NewPostgresRepo(db)
 
-	// Setup Kafka
+	// Setup Kafka: events are written to the outbox with the auction rows
+	// and relayed to Kafka in the background
 	kafkaProducer := kafka.NewProducer(cfg.KafkaBrokers, log)
-	eventProducer := event.NewKafkaEventProducer(kafkaProducer)
+	defer kafkaProducer.Close()
+	eventProducer := event.NewKafkaEventProducer(kafka.NewOutbox(db))
+	tx := database.NewTransactor(db)
+
+	// Connect to Bidding Service (winner determination on close)
+	biddingSvcURL := os.Getenv("BIDDING_SERVICE_URL")
+	if biddingSvcURL == "" {
+		biddingSvcURL = "localhost:50052" // Default
+	}
+	conn, err := grpc.NewClient(biddingSvcURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatal("failed to connect to bidding service", zap.Error(err))
+	}
+	defer conn.Close()
+	biddingClient := service.NewBiddingClient(conn)
+
+	svc := service.NewAuctionService(repo, tx, eventProducer, biddingClient, service.Settings{
+		MaxExtensions: cfg.MaxAuctionExtensions,
+		BuyNowCutoff:  cfg.BuyNowCutoff,
+	}, log)
+
+	// Start lifecycle scheduler (opens and closes auctions on time)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scheduler := service.NewLifecycleScheduler(svc, cfg.SchedulerInterval, log)
+	scheduler.Start(ctx)
 
-	svc := service.NewAuctionService(repo, eventProducer, log)
+	relay := kafka.NewOutboxRelay(db, kafkaProducer, cfg.OutboxRelayInterval, log)
+	relay.Start(ctx)
 
 	grpcHandler := handler.NewGrpcHandler(svc)
 	httpHandler := handler.NewHttpHandler(svc)
@@ -76,7 +106,12 @@ func main() {
 	}()
 
 	// Start HTTP server
-	tm := auth.NewTokenManager(cfg.JWTSecret)
+	tm := auth.NewVerifier(ctx, cfg.JWKSURL, cfg.JWKSRefreshInterval, cfg.JWTSecret, log)
+	// Reject revoked tokens as the auth service revokes them
+	denylist := auth.NewDenylist()
+	tm.UseDenylist(denylist)
+	revocations := auth.WatchRevocations(ctx, cfg.KafkaBrokers, denylist, log)
+	defer revocations.Close()
 	r := handler.SetupRouter(httpHandler, tm)
 
 	// Graceful shutdown
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/auth"
@@ -14,6 +15,8 @@ type UserRepository interface {
 	UpdateUser(ctx context.Context, user *User) error
 	Update2FA(ctx context.Context, userID uuid.UUID, enabled bool, secret string) error
 	VerifyUser(ctx context.Context, userID uuid.UUID) error
+	// ListIDsByCompany returns the ids of the users that belong to the company.
+	ListIDsByCompany(ctx context.Context, companyID uuid.UUID) ([]uuid.UUID, error)
 }
 
 type CompanyRepository interface {
@@ -23,10 +26,24 @@ type CompanyRepository interface {
 	VerifyCompany(ctx context.Context, id uuid.UUID) error
 }
 
+type RefreshTokenRepository interface {
+	Create(ctx context.Context, token *RefreshToken) error
+	// GetByHash returns ErrInvalidRefreshToken if no token has the hash.
+	GetByHash(ctx context.Context, hash string) (*RefreshToken, error)
+	// MarkUsed marks the token used unless it already is, and reports whether it did.
+	MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	// RevokeFamily revokes the tokens of the family that aren't revoked yet.
+	RevokeFamily(ctx context.Context, familyID uuid.UUID, at time.Time) error
+}
+
 type AuthService interface {
 	Register(ctx context.Context, req auth.RegisterRequest) error
-	Login(ctx context.Context, email, password string) (*auth.UserDTO, string, bool, error)
-	Verify2FA(ctx context.Context, email, code string) (string, error)
+	Login(ctx context.Context, email, password string) (*auth.UserDTO, *auth.Tokens, bool, error)
+	Verify2FA(ctx context.Context, email, code string) (*auth.Tokens, error)
+	// Refresh exchanges a refresh token for new tokens of the same session.
+	Refresh(ctx context.Context, refreshToken string) (*auth.Tokens, error)
+	// Logout revokes the session of the refresh token, along with its access tokens.
+	Logout(ctx context.Context, refreshToken string) error
 	Toggle2FA(ctx context.Context, userID string, enable bool) (string, error) // Returns secret if enabling
 }
 
@@ -41,7 +58,17 @@ type UserService interface {
 	GetCompany(ctx context.Context, companyID string) (*auth.CompanyDTO, error)
 }
 
+// Transactor runs fn in one database transaction. Repository calls and
+// EventProducer calls made with the ctx passed to fn are committed together.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type EventProducer interface {
 	PublishUserRegistered(ctx context.Context, user *User) error
 	PublishUserVerified(ctx context.Context, userID uuid.UUID) error
+	PublishCompanyVerified(ctx context.Context, company *Company, memberIDs []uuid.UUID) error
+	// PublishTokensRevoked tells the other services to reject the access tokens of
+	// the sessions, and those with the jtis tokenIDs.
+	PublishTokensRevoked(ctx context.Context, userID uuid.UUID, sessionIDs, tokenIDs []string) error
 }
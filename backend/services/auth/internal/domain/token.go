@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused is returned for a refresh token that was already
+	// exchanged. Someone else holds a copy, so the whole session is revoked.
+	ErrRefreshTokenReused = errors.New("refresh token reused, session revoked")
+)
+
+// RefreshToken is one of the opaque refresh tokens of a login. Only its hash is
+// kept. Exchanging it uses it up and issues a successor in the same family; the
+// family ID is also the session ID of the access tokens issued with them.
+type RefreshToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	FamilyID  uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    sql.NullTime
+	RevokedAt sql.NullTime
+}
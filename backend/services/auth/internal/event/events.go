@@ -9,6 +9,9 @@ import (
 const (
 	TopicUserRegistered = "user.registered"
 	TopicUserVerified   = "user.verified"
+	// TopicCompanyVerified feeds the bidding service's cache of companies allowed
+	// to bid on reverse auctions.
+	TopicCompanyVerified = "company.verified"
 )
 
 type UserRegisteredEvent struct {
@@ -24,3 +27,10 @@ type UserVerifiedEvent struct {
 	UserID    uuid.UUID `json:"user_id"`
 	Timestamp time.Time `json:"timestamp"`
 }
+
+type CompanyVerifiedEvent struct {
+	CompanyID uuid.UUID   `json:"company_id"`
+	Name      string      `json:"name"`
+	MemberIDs []uuid.UUID `json:"member_ids"`
+	Timestamp time.Time   `json:"timestamp"`
+}
@@ -5,15 +5,18 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/auth"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/kafka"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/auth/internal/domain"
 )
 
 type KafkaEventProducer struct {
-	producer *kafka.Producer
+	producer kafka.Publisher
 }
 
-func NewKafkaEventProducer(producer *kafka.Producer) domain.EventProducer {
+// NewKafkaEventProducer publishes through producer; pass a kafka.Outbox to
+// commit events atomically with the repository writes.
+func NewKafkaEventProducer(producer kafka.Publisher) domain.EventProducer {
 	return &KafkaEventProducer{producer: producer}
 }
 
@@ -36,3 +39,26 @@ func (p *KafkaEventProducer) PublishUserVerified(ctx context.Context, userID uui
 	}
 	return p.producer.Publish(ctx, TopicUserVerified, userID.String(), event)
 }
+
+func (p *KafkaEventProducer) PublishCompanyVerified(ctx context.Context, company *domain.Company, memberIDs []uuid.UUID) error {
+	event := CompanyVerifiedEvent{
+		CompanyID: company.ID,
+		Name:      company.Name,
+		MemberIDs: memberIDs,
+		Timestamp: time.Now(),
+	}
+	return p.producer.Publish(ctx, TopicCompanyVerified, company.ID.String(), event)
+}
+
+func (p *KafkaEventProducer) PublishTokensRevoked(ctx context.Context, userID uuid.UUID, sessionIDs, tokenIDs []string) error {
+	now := time.Now()
+	event := auth.TokensRevokedEvent{
+		UserID:     userID.String(),
+		SessionIDs: sessionIDs,
+		TokenIDs:   tokenIDs,
+		// No access token issued before now outlives this
+		ExpiresAt: now.Add(auth.AccessTokenTTL),
+		Timestamp: now,
+	}
+	return p.producer.Publish(ctx, auth.TopicTokensRevoked, userID.String(), event)
+}
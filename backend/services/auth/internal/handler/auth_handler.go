@@ -2,6 +2,8 @@ package handler
 
 // AuthHandler handles authentication requests
 import (
+	"errors"
+
 	"github.com/gin-gonic/gin"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/auth"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/auth/internal/domain"
@@ -37,7 +39,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	user, token, mfaRequired, err := h.service.Login(c.Request.Context(), req.Email, req.Password)
+	user, tokens, mfaRequired, err := h.service.Login(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
 		c.JSON(401, gin.H{"error": err.Error()})
 		return
@@ -49,8 +51,8 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	c.JSON(200, auth.AuthResponse{
-		Token: token,
-		User:  *user,
+		Tokens: *tokens,
+		User:   *user,
 	})
 }
 
@@ -61,13 +63,54 @@ func (h *AuthHandler) VerifyOTP(c *gin.Context) {
 		return
 	}
 
-	token, err := h.service.Verify2FA(c.Request.Context(), req.Email, req.Code)
+	tokens, err := h.service.Verify2FA(c.Request.Context(), req.Email, req.Code)
 	if err != nil {
 		c.JSON(401, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(200, gin.H{"token": token})
+	c.JSON(200, tokens)
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req auth.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := h.service.Refresh(c.Request.Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRefreshToken) || errors.Is(err, domain.ErrRefreshTokenReused) {
+			c.JSON(401, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, tokens)
+}
+
+// Logout takes the refresh token rather than the access token, which may already
+// have expired.
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req auth.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(400, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+		if errors.Is(err, domain.ErrInvalidRefreshToken) {
+			c.JSON(401, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(500, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(200, gin.H{"message": "Logged out"})
 }
 
 func (h *AuthHandler) Toggle2FA(c *gin.Context) {
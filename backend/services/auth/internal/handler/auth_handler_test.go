@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/auth"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/auth/internal/domain"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/auth/internal/handler"
 )
 
@@ -26,17 +27,38 @@ func (m *MockAuthService) Register(ctx context.Context, req auth.RegisterRequest
 	return args.Error(0)
 }
 
-func (m *MockAuthService) Login(ctx context.Context, email, password string) (*auth.UserDTO, string, bool, error) {
+func (m *MockAuthService) Login(ctx context.Context, email, password string) (*auth.UserDTO, *auth.Tokens, bool, error) {
 	args := m.Called(ctx, email, password)
-	if args.Get(0) == nil {
-		return nil, args.String(1), args.Bool(2), args.Error(3)
+	var user *auth.UserDTO
+	if args.Get(0) != nil {
+		user = args.Get(0).(*auth.UserDTO)
+	}
+	var tokens *auth.Tokens
+	if args.Get(1) != nil {
+		tokens = args.Get(1).(*auth.Tokens)
 	}
-	return args.Get(0).(*auth.UserDTO), args.String(1), args.Bool(2), args.Error(3)
+	return user, tokens, args.Bool(2), args.Error(3)
 }
 
-func (m *MockAuthService) Verify2FA(ctx context.Context, email, code string) (string, error) {
+func (m *MockAuthService) Verify2FA(ctx context.Context, email, code string) (*auth.Tokens, error) {
 	args := m.Called(ctx, email, code)
-	return args.String(0), args.Error(1)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Tokens), args.Error(1)
+}
+
+func (m *MockAuthService) Refresh(ctx context.Context, refreshToken string) (*auth.Tokens, error) {
+	args := m.Called(ctx, refreshToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Tokens), args.Error(1)
+}
+
+func (m *MockAuthService) Logout(ctx context.Context, refreshToken string) error {
+	args := m.Called(ctx, refreshToken)
+	return args.Error(0)
 }
 
 func (m *MockAuthService) Toggle2FA(ctx context.Context, userID string, enable bool) (string, error) {
@@ -123,7 +145,7 @@ func TestLogin(t *testing.T) {
 			Password: "password123",
 		}
 		userDTO := &auth.UserDTO{ID: "1", Email: "test@example.com"}
-		mockSvc.On("Login", mock.Anything, reqBody.Email, reqBody.Password).Return(userDTO, "token123", false, nil)
+		mockSvc.On("Login", mock.Anything, reqBody.Email, reqBody.Password).Return(userDTO, &auth.Tokens{Token: "token123", RefreshToken: "refresh123", ExpiresIn: 900}, false, nil)
 
 		body, _ := json.Marshal(reqBody)
 		req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(body))
@@ -136,6 +158,7 @@ func TestLogin(t *testing.T) {
 		err := json.Unmarshal(w.Body.Bytes(), &resp)
 		assert.NoError(t, err)
 		assert.Equal(t, "token123", resp.Token)
+		assert.Equal(t, "refresh123", resp.RefreshToken)
 		assert.Equal(t, "test@example.com", resp.User.Email)
 	})
 
@@ -149,7 +172,7 @@ func TestLogin(t *testing.T) {
 			Email:    "test@example.com",
 			Password: "password123",
 		}
-		mockSvc.On("Login", mock.Anything, reqBody.Email, reqBody.Password).Return(nil, "", true, nil)
+		mockSvc.On("Login", mock.Anything, reqBody.Email, reqBody.Password).Return(nil, nil, true, nil)
 
 		body, _ := json.Marshal(reqBody)
 		req, _ := http.NewRequest(http.MethodPost, "/login", bytes.NewBuffer(body))
@@ -164,3 +187,96 @@ func TestLogin(t *testing.T) {
 		assert.Equal(t, true, resp["mfa_required"])
 	})
 }
+
+func TestRefresh(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"Success", nil, http.StatusOK},
+		{"Invalid", domain.ErrInvalidRefreshToken, http.StatusUnauthorized},
+		{"Reused", domain.ErrRefreshTokenReused, http.StatusUnauthorized},
+		{"ServiceError", errors.New("db down"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc := new(MockAuthService)
+			h := handler.NewAuthHandler(mockSvc)
+			r := gin.Default()
+			r.POST("/refresh", h.Refresh)
+
+			if tt.err != nil {
+				mockSvc.On("Refresh", mock.Anything, "refresh123").Return(nil, tt.err)
+			} else {
+				mockSvc.On("Refresh", mock.Anything, "refresh123").Return(&auth.Tokens{Token: "token456", RefreshToken: "refresh456"}, nil)
+			}
+
+			body, _ := json.Marshal(auth.RefreshRequest{RefreshToken: "refresh123"})
+			req, _ := http.NewRequest(http.MethodPost, "/refresh", bytes.NewBuffer(body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.code, w.Code)
+			if tt.err == nil {
+				var resp auth.Tokens
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				assert.Equal(t, "refresh456", resp.RefreshToken)
+			}
+		})
+	}
+
+	t.Run("BadRequest", func(t *testing.T) {
+		r := gin.Default()
+		r.POST("/refresh", handler.NewAuthHandler(new(MockAuthService)).Refresh)
+
+		req, _ := http.NewRequest(http.MethodPost, "/refresh", bytes.NewBufferString("{}"))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Success", func(t *testing.T) {
+		mockSvc := new(MockAuthService)
+		h := handler.NewAuthHandler(mockSvc)
+		r := gin.Default()
+		r.POST("/logout", h.Logout)
+
+		mockSvc.On("Logout", mock.Anything, "refresh123").Return(nil)
+
+		body, _ := json.Marshal(auth.RefreshRequest{RefreshToken: "refresh123"})
+		req, _ := http.NewRequest(http.MethodPost, "/logout", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("UnknownToken", func(t *testing.T) {
+		mockSvc := new(MockAuthService)
+		h := handler.NewAuthHandler(mockSvc)
+		r := gin.Default()
+		r.POST("/logout", h.Logout)
+
+		mockSvc.On("Logout", mock.Anything, "refresh123").Return(domain.ErrInvalidRefreshToken)
+
+		body, _ := json.Marshal(auth.RefreshRequest{RefreshToken: "refresh123"})
+		req, _ := http.NewRequest(http.MethodPost, "/logout", bytes.NewBuffer(body))
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/database"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/auth/internal/domain"
+)
+
+type refreshTokenRepo struct {
+	db *sql.DB
+}
+
+func NewRefreshTokenRepo(db *sql.DB) domain.RefreshTokenRepository {
+	return &refreshTokenRepo{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *refreshTokenRepo) conn(ctx context.Context) database.DBTX {
+	return database.Conn(ctx, r.db)
+}
+
+func (r *refreshTokenRepo) Create(ctx context.Context, t *domain.RefreshToken) error {
+	_, err := r.conn(ctx).ExecContext(ctx,
+		`INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6)`,
+		t.ID, t.UserID, t.FamilyID, t.TokenHash, t.ExpiresAt, t.CreatedAt)
+	return err
+}
+
+func (r *refreshTokenRepo) GetByHash(ctx context.Context, hash string) (*domain.RefreshToken, error) {
+	t := &domain.RefreshToken{}
+	err := r.conn(ctx).QueryRowContext(ctx,
+		"SELECT id, user_id, family_id, token_hash, expires_at, created_at, used_at, revoked_at FROM refresh_tokens WHERE token_hash = $1",
+		hash).Scan(&t.ID, &t.UserID, &t.FamilyID, &t.TokenHash, &t.ExpiresAt, &t.CreatedAt, &t.UsedAt, &t.RevokedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (r *refreshTokenRepo) MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	res, err := r.conn(ctx).ExecContext(ctx,
+		"UPDATE refresh_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL",
+		at, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func (r *refreshTokenRepo) RevokeFamily(ctx context.Context, familyID uuid.UUID, at time.Time) error {
+	_, err := r.conn(ctx).ExecContext(ctx,
+		"UPDATE refresh_tokens SET revoked_at = $1 WHERE family_id = $2 AND revoked_at IS NULL",
+		at, familyID)
+	return err
+}
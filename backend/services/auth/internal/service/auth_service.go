@@ -2,7 +2,12 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/pquerna/otp/totp"
@@ -10,14 +15,20 @@ import (
 	"github.com/temesgen-abebayehu/bidflow/backend/services/auth/internal/domain"
 )
 
+// RefreshTokenTTL is how long a refresh token can be exchanged, and so how long a
+// session lasts without a refresh.
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
 type AuthService struct {
-	repo         domain.UserRepository
-	tokenManager *auth.TokenManager
-	producer     domain.EventProducer
+	repo          domain.UserRepository
+	refreshTokens domain.RefreshTokenRepository
+	tx            domain.Transactor
+	tokenManager  *auth.TokenManager
+	producer      domain.EventProducer
 }
 
-func NewAuthService(r domain.UserRepository, tm *auth.TokenManager, p domain.EventProducer) domain.AuthService {
-	return &AuthService{repo: r, tokenManager: tm, producer: p}
+func NewAuthService(r domain.UserRepository, rt domain.RefreshTokenRepository, tx domain.Transactor, tm *auth.TokenManager, p domain.EventProducer) domain.AuthService {
+	return &AuthService{repo: r, refreshTokens: rt, tx: tx, tokenManager: tm, producer: p}
 }
 
 func (s *AuthService) Register(ctx context.Context, req auth.RegisterRequest) error {
@@ -30,21 +41,23 @@ func (s *AuthService) Register(ctx context.Context, req auth.RegisterRequest) er
 		Role:     req.Role,
 		IsActive: true,
 	}
-	if err := s.repo.CreateUser(ctx, user); err != nil {
-		return err
-	}
-	return s.producer.PublishUserRegistered(ctx, user)
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateUser(ctx, user); err != nil {
+			return err
+		}
+		return s.producer.PublishUserRegistered(ctx, user)
+	})
 }
 
-// Login returns (userDTO, token, mfaRequired, error)
-func (s *AuthService) Login(ctx context.Context, email, password string) (*auth.UserDTO, string, bool, error) {
+// Login returns (userDTO, tokens, mfaRequired, error)
+func (s *AuthService) Login(ctx context.Context, email, password string) (*auth.UserDTO, *auth.Tokens, bool, error) {
 	u, err := s.repo.GetByEmail(ctx, email)
 	if err != nil {
-		return nil, "", false, errors.New("user not found")
+		return nil, nil, false, errors.New("user not found")
 	}
 
 	if !auth.CheckPasswordHash(password, u.Password) {
-		return nil, "", false, errors.New("invalid credentials")
+		return nil, nil, false, errors.New("invalid credentials")
 	}
 
 	userDTO := &auth.UserDTO{
@@ -58,25 +71,135 @@ func (s *AuthService) Login(ctx context.Context, email, password string) (*auth.
 
 	// If 2FA is on, don't give the JWT yet
 	if u.TwoFactorEnabled {
-		return userDTO, "", true, nil
+		return userDTO, nil, true, nil
 	}
 
-	token, _ := s.tokenManager.GenerateToken(u.ID.String(), "", u.Role)
-	return userDTO, token, false, nil
+	tokens, err := s.issue(ctx, u, uuid.New())
+	if err != nil {
+		return nil, nil, false, err
+	}
+	return userDTO, tokens, false, nil
 }
 
-func (s *AuthService) Verify2FA(ctx context.Context, email, code string) (string, error) {
+func (s *AuthService) Verify2FA(ctx context.Context, email, code string) (*auth.Tokens, error) {
 	u, err := s.repo.GetByEmail(ctx, email)
 	if err != nil || !u.TwoFactorSecret.Valid {
-		return "", errors.New("2FA not configured")
+		return nil, errors.New("2FA not configured")
 	}
 
 	valid := totp.Validate(code, u.TwoFactorSecret.String)
 	if !valid {
-		return "", errors.New("invalid OTP code")
+		return nil, errors.New("invalid OTP code")
+	}
+
+	return s.issue(ctx, u, uuid.New())
+}
+
+// Refresh uses up the refresh token and issues its successor. A token that was
+// already used has leaked: the session is revoked, for its rightful holder too.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*auth.Tokens, error) {
+	now := time.Now()
+	var tokens *auth.Tokens
+	reused := false
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		t, err := s.refreshTokens.GetByHash(ctx, hashRefreshToken(refreshToken))
+		if err != nil {
+			return err
+		}
+		if t.RevokedAt.Valid || !now.Before(t.ExpiresAt) {
+			return domain.ErrInvalidRefreshToken
+		}
+
+		// Of two refreshes racing with the same token, only one marks it
+		marked, err := s.refreshTokens.MarkUsed(ctx, t.ID, now)
+		if err != nil {
+			return err
+		}
+		if !marked {
+			reused = true
+			return s.revokeSession(ctx, t, now)
+		}
+
+		u, err := s.repo.GetByID(ctx, t.UserID)
+		if err != nil {
+			return err
+		}
+		tokens, err = s.issue(ctx, u, t.FamilyID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		return nil, domain.ErrRefreshTokenReused
 	}
+	return tokens, nil
+}
+
+func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		t, err := s.refreshTokens.GetByHash(ctx, hashRefreshToken(refreshToken))
+		if err != nil {
+			return err
+		}
+		if t.RevokedAt.Valid {
+			return nil
+		}
+		return s.revokeSession(ctx, t, time.Now())
+	})
+}
+
+// revokeSession revokes the refresh tokens of t's family, and tells the other
+// services to reject the access tokens of the session.
+func (s *AuthService) revokeSession(ctx context.Context, t *domain.RefreshToken, at time.Time) error {
+	if err := s.refreshTokens.RevokeFamily(ctx, t.FamilyID, at); err != nil {
+		return err
+	}
+	return s.producer.PublishTokensRevoked(ctx, t.UserID, []string{t.FamilyID.String()}, nil)
+}
+
+// issue hands u a new refresh token in the family, and an access token for the
+// session the family stands for.
+func (s *AuthService) issue(ctx context.Context, u *domain.User, familyID uuid.UUID) (*auth.Tokens, error) {
+	refreshToken, err := newRefreshToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	t := &domain.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    u.ID,
+		FamilyID:  familyID,
+		TokenHash: hashRefreshToken(refreshToken),
+		ExpiresAt: now.Add(RefreshTokenTTL),
+		CreatedAt: now,
+	}
+	if err := s.refreshTokens.Create(ctx, t); err != nil {
+		return nil, err
+	}
+
+	token, err := s.tokenManager.GenerateSessionToken(u.ID.String(), u.CompanyID.String, u.Role, familyID.String())
+	if err != nil {
+		return nil, err
+	}
+	return &auth.Tokens{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(auth.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func newRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
 
-	return s.tokenManager.GenerateToken(u.ID.String(), "", u.Role)
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
 
 func (s *AuthService) Toggle2FA(ctx context.Context, userID string, enable bool) (string, error) {
@@ -3,6 +3,7 @@ package service_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
 	"time"
 
@@ -56,6 +57,14 @@ func (m *MockUserRepository) VerifyUser(ctx context.Context, userID uuid.UUID) e
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) ListIDsByCompany(ctx context.Context, companyID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, companyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 // MockEventProducer
 type MockEventProducer struct {
 	mock.Mock
@@ -71,11 +80,74 @@ func (m *MockEventProducer) PublishUserVerified(ctx context.Context, userID uuid
 	return args.Error(0)
 }
 
+func (m *MockEventProducer) PublishCompanyVerified(ctx context.Context, company *domain.Company, memberIDs []uuid.UUID) error {
+	args := m.Called(ctx, company, memberIDs)
+	return args.Error(0)
+}
+
+func (m *MockEventProducer) PublishTokensRevoked(ctx context.Context, userID uuid.UUID, sessionIDs, tokenIDs []string) error {
+	args := m.Called(ctx, userID, sessionIDs, tokenIDs)
+	return args.Error(0)
+}
+
+// memoryRefreshTokens is a RefreshTokenRepository kept in a map.
+type memoryRefreshTokens struct {
+	tokens map[string]*domain.RefreshToken // By hash
+}
+
+func newMemoryRefreshTokens() *memoryRefreshTokens {
+	return &memoryRefreshTokens{tokens: make(map[string]*domain.RefreshToken)}
+}
+
+func (m *memoryRefreshTokens) Create(ctx context.Context, token *domain.RefreshToken) error {
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *memoryRefreshTokens) GetByHash(ctx context.Context, hash string) (*domain.RefreshToken, error) {
+	t, ok := m.tokens[hash]
+	if !ok {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+	copied := *t
+	return &copied, nil
+}
+
+func (m *memoryRefreshTokens) MarkUsed(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	for _, t := range m.tokens {
+		if t.ID == id && !t.UsedAt.Valid {
+			t.UsedAt = sql.NullTime{Time: at, Valid: true}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memoryRefreshTokens) RevokeFamily(ctx context.Context, familyID uuid.UUID, at time.Time) error {
+	for _, t := range m.tokens {
+		if t.FamilyID == familyID && !t.RevokedAt.Valid {
+			t.RevokedAt = sql.NullTime{Time: at, Valid: true}
+		}
+	}
+	return nil
+}
+
+// MockTransactor runs fn inline and records its outcome; a non-nil Err means
+// a real transaction would have been rolled back.
+type MockTransactor struct {
+	Err error
+}
+
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Err = fn(ctx)
+	return m.Err
+}
+
 func TestRegister(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockProducer := new(MockEventProducer)
 	tm := auth.NewTokenManager("secret")
-	svc := service.NewAuthService(mockRepo, tm, mockProducer)
+	svc := service.NewAuthService(mockRepo, newMemoryRefreshTokens(), &MockTransactor{}, tm, mockProducer)
 
 	req := auth.RegisterRequest{
 		Email:    "test@example.com",
@@ -95,11 +167,25 @@ func TestRegister(t *testing.T) {
 	mockProducer.AssertExpectations(t)
 }
 
+func TestRegister_OutboxFailureRollsBack(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockProducer := new(MockEventProducer)
+	tx := &MockTransactor{}
+	svc := service.NewAuthService(mockRepo, newMemoryRefreshTokens(), tx, auth.NewTokenManager("secret"), mockProducer)
+
+	mockRepo.On("CreateUser", mock.Anything, mock.Anything).Return(nil)
+	mockProducer.On("PublishUserRegistered", mock.Anything, mock.Anything).Return(errors.New("outbox insert failed"))
+
+	err := svc.Register(context.Background(), auth.RegisterRequest{Email: "test@example.com", Password: "password", Role: "BIDDER"})
+	assert.Error(t, err)
+	assert.Error(t, tx.Err, "user row must be rolled back with the event")
+}
+
 func TestLogin(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockProducer := new(MockEventProducer)
 	tm := auth.NewTokenManager("secret")
-	svc := service.NewAuthService(mockRepo, tm, mockProducer)
+	svc := service.NewAuthService(mockRepo, newMemoryRefreshTokens(), &MockTransactor{}, tm, mockProducer)
 
 	hashedPassword, _ := auth.HashPassword("password")
 	user := &domain.User{
@@ -111,18 +197,25 @@ func TestLogin(t *testing.T) {
 
 	mockRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(user, nil)
 
-	userDTO, token, mfa, err := svc.Login(context.Background(), "test@example.com", "password")
+	userDTO, tokens, mfa, err := svc.Login(context.Background(), "test@example.com", "password")
 	assert.NoError(t, err)
 	assert.NotNil(t, userDTO)
-	assert.NotEmpty(t, token)
+	assert.NotEmpty(t, tokens.Token)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.Equal(t, int(auth.AccessTokenTTL.Seconds()), tokens.ExpiresIn)
 	assert.False(t, mfa)
+
+	claims, err := tm.VerifyToken(tokens.Token)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, claims.ID)
+	assert.NotEmpty(t, claims.SessionID)
 }
 
 func TestLogin_InvalidPassword(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockProducer := new(MockEventProducer)
 	tm := auth.NewTokenManager("secret")
-	svc := service.NewAuthService(mockRepo, tm, mockProducer)
+	svc := service.NewAuthService(mockRepo, newMemoryRefreshTokens(), &MockTransactor{}, tm, mockProducer)
 
 	hashedPassword, _ := auth.HashPassword("password")
 	user := &domain.User{
@@ -142,7 +235,7 @@ func TestVerify2FA(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockProducer := new(MockEventProducer)
 	tm := auth.NewTokenManager("secret")
-	svc := service.NewAuthService(mockRepo, tm, mockProducer)
+	svc := service.NewAuthService(mockRepo, newMemoryRefreshTokens(), &MockTransactor{}, tm, mockProducer)
 
 	// Generate a real secret for testing
 	key, _ := totp.Generate(totp.GenerateOpts{Issuer: "Test", AccountName: "test@example.com"})
@@ -158,16 +251,119 @@ func TestVerify2FA(t *testing.T) {
 
 	mockRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(user, nil)
 
-	token, err := svc.Verify2FA(context.Background(), "test@example.com", code)
+	tokens, err := svc.Verify2FA(context.Background(), "test@example.com", code)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, tokens.Token)
+	assert.NotEmpty(t, tokens.RefreshToken)
+}
+
+// login signs the user in, and returns the tokens.
+func login(t *testing.T, svc domain.AuthService, mockRepo *MockUserRepository) (*domain.User, *auth.Tokens) {
+	hashedPassword, _ := auth.HashPassword("password")
+	user := &domain.User{ID: uuid.New(), Email: "test@example.com", Password: hashedPassword, Role: "BIDDER"}
+	mockRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+	_, tokens, _, err := svc.Login(context.Background(), user.Email, "password")
+	assert.NoError(t, err)
+	return user, tokens
+}
+
+func TestRefresh(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	tm := auth.NewTokenManager("secret")
+	svc := service.NewAuthService(mockRepo, newMemoryRefreshTokens(), &MockTransactor{}, tm, new(MockEventProducer))
+	_, first := login(t, svc, mockRepo)
+
+	second, err := svc.Refresh(context.Background(), first.RefreshToken)
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+
+	// The new access token belongs to the same session
+	before, _ := tm.VerifyToken(first.Token)
+	after, err := tm.VerifyToken(second.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, before.SessionID, after.SessionID)
+	assert.NotEqual(t, before.ID, after.ID)
+
+	_, err = svc.Refresh(context.Background(), "not-a-token")
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+}
+
+func TestLogin_CarriesCompany(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	tm := auth.NewTokenManager("secret")
+	svc := service.NewAuthService(mockRepo, newMemoryRefreshTokens(), &MockTransactor{}, tm, new(MockEventProducer))
+
+	hashedPassword, _ := auth.HashPassword("password")
+	user := &domain.User{
+		ID:        uuid.New(),
+		Email:     "buyer@example.com",
+		Password:  hashedPassword,
+		Role:      "BIDDER",
+		CompanyID: sql.NullString{String: "company-verified", Valid: true},
+	}
+	mockRepo.On("GetByEmail", mock.Anything, user.Email).Return(user, nil)
+	mockRepo.On("GetByID", mock.Anything, user.ID).Return(user, nil)
+
+	// Reverse auctions admit bidders by the company_id claim
+	_, tokens, _, err := svc.Login(context.Background(), user.Email, "password")
 	assert.NoError(t, err)
-	assert.NotEmpty(t, token)
+	claims, err := tm.VerifyToken(tokens.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, "company-verified", claims.CompanyID)
+
+	refreshed, err := svc.Refresh(context.Background(), tokens.RefreshToken)
+	assert.NoError(t, err)
+	claims, err = tm.VerifyToken(refreshed.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, "company-verified", claims.CompanyID)
+}
+
+func TestRefresh_ReuseRevokesSession(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockProducer := new(MockEventProducer)
+	tm := auth.NewTokenManager("secret")
+	svc := service.NewAuthService(mockRepo, newMemoryRefreshTokens(), &MockTransactor{}, tm, mockProducer)
+	user, first := login(t, svc, mockRepo)
+	claims, _ := tm.VerifyToken(first.Token)
+
+	second, err := svc.Refresh(context.Background(), first.RefreshToken)
+	assert.NoError(t, err)
+
+	mockProducer.On("PublishTokensRevoked", mock.Anything, user.ID, []string{claims.SessionID}, []string(nil)).Return(nil).Once()
+	_, err = svc.Refresh(context.Background(), first.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+	mockProducer.AssertExpectations(t)
+
+	// The successor went with the rest of the family
+	_, err = svc.Refresh(context.Background(), second.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+}
+
+func TestLogout(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockProducer := new(MockEventProducer)
+	tm := auth.NewTokenManager("secret")
+	svc := service.NewAuthService(mockRepo, newMemoryRefreshTokens(), &MockTransactor{}, tm, mockProducer)
+	user, tokens := login(t, svc, mockRepo)
+	claims, _ := tm.VerifyToken(tokens.Token)
+
+	mockProducer.On("PublishTokensRevoked", mock.Anything, user.ID, []string{claims.SessionID}, []string(nil)).Return(nil).Once()
+	assert.NoError(t, svc.Logout(context.Background(), tokens.RefreshToken))
+	// Logging out again is a no-op
+	assert.NoError(t, svc.Logout(context.Background(), tokens.RefreshToken))
+	mockProducer.AssertExpectations(t)
+
+	_, err := svc.Refresh(context.Background(), tokens.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
 }
 
 func TestToggle2FA(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockProducer := new(MockEventProducer)
 	tm := auth.NewTokenManager("secret")
-	svc := service.NewAuthService(mockRepo, tm, mockProducer)
+	svc := service.NewAuthService(mockRepo, newMemoryRefreshTokens(), &MockTransactor{}, tm, mockProducer)
 
 	userID := uuid.New()
 
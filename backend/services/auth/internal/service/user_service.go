@@ -15,11 +15,12 @@ import (
 type UserService struct {
 	repo        domain.UserRepository
 	companyRepo domain.CompanyRepository
+	tx          domain.Transactor
 	producer    domain.EventProducer
 }
 
-func NewUserService(r domain.UserRepository, cr domain.CompanyRepository, p domain.EventProducer) domain.UserService {
-	return &UserService{repo: r, companyRepo: cr, producer: p}
+func NewUserService(r domain.UserRepository, cr domain.CompanyRepository, tx domain.Transactor, p domain.EventProducer) domain.UserService {
+	return &UserService{repo: r, companyRepo: cr, tx: tx, producer: p}
 }
 
 func (s *UserService) GetProfile(ctx context.Context, userID string) (*auth.UserDTO, error) {
@@ -69,10 +70,12 @@ func (s *UserService) VerifyUser(ctx context.Context, userID string) error {
 	if err != nil {
 		return errors.New("invalid user id")
 	}
-	if err := s.repo.VerifyUser(ctx, id); err != nil {
-		return err
-	}
-	return s.producer.PublishUserVerified(ctx, id)
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.VerifyUser(ctx, id); err != nil {
+			return err
+		}
+		return s.producer.PublishUserVerified(ctx, id)
+	})
 }
 
 func (s *UserService) CreateCompany(ctx context.Context, userID string, req auth.CreateCompanyRequest) (*auth.CompanyDTO, error) {
@@ -134,7 +137,20 @@ func (s *UserService) VerifyCompany(ctx context.Context, companyID string) error
 	if err != nil {
 		return errors.New("invalid company id")
 	}
-	return s.companyRepo.VerifyCompany(ctx, id)
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.companyRepo.VerifyCompany(ctx, id); err != nil {
+			return err
+		}
+		company, err := s.companyRepo.GetCompanyByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		memberIDs, err := s.repo.ListIDsByCompany(ctx, id)
+		if err != nil {
+			return err
+		}
+		return s.producer.PublishCompanyVerified(ctx, company, memberIDs)
+	})
 }
 
 func (s *UserService) GetCompany(ctx context.Context, companyID string) (*auth.CompanyDTO, error) {
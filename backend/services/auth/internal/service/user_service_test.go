@@ -45,7 +45,7 @@ func TestGetProfile(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockCompanyRepo := new(MockCompanyRepository)
 	mockProducer := new(MockEventProducer)
-	svc := service.NewUserService(mockRepo, mockCompanyRepo, mockProducer)
+	svc := service.NewUserService(mockRepo, mockCompanyRepo, &MockTransactor{}, mockProducer)
 
 	userID := uuid.New()
 	user := &domain.User{
@@ -64,7 +64,7 @@ func TestCreateCompany(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockCompanyRepo := new(MockCompanyRepository)
 	mockProducer := new(MockEventProducer)
-	svc := service.NewUserService(mockRepo, mockCompanyRepo, mockProducer)
+	svc := service.NewUserService(mockRepo, mockCompanyRepo, &MockTransactor{}, mockProducer)
 
 	userID := uuid.New()
 	user := &domain.User{
@@ -90,7 +90,7 @@ func TestUpdateProfile(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockCompanyRepo := new(MockCompanyRepository)
 	mockProducer := new(MockEventProducer)
-	svc := service.NewUserService(mockRepo, mockCompanyRepo, mockProducer)
+	svc := service.NewUserService(mockRepo, mockCompanyRepo, &MockTransactor{}, mockProducer)
 
 	userID := uuid.New()
 	user := &domain.User{
@@ -114,7 +114,7 @@ func TestVerifyUser(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockCompanyRepo := new(MockCompanyRepository)
 	mockProducer := new(MockEventProducer)
-	svc := service.NewUserService(mockRepo, mockCompanyRepo, mockProducer)
+	svc := service.NewUserService(mockRepo, mockCompanyRepo, &MockTransactor{}, mockProducer)
 
 	userID := uuid.New()
 
@@ -130,7 +130,7 @@ func TestUpdateCompany(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockCompanyRepo := new(MockCompanyRepository)
 	mockProducer := new(MockEventProducer)
-	svc := service.NewUserService(mockRepo, mockCompanyRepo, mockProducer)
+	svc := service.NewUserService(mockRepo, mockCompanyRepo, &MockTransactor{}, mockProducer)
 
 	companyID := uuid.New()
 	company := &domain.Company{
@@ -153,21 +153,27 @@ func TestVerifyCompany(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockCompanyRepo := new(MockCompanyRepository)
 	mockProducer := new(MockEventProducer)
-	svc := service.NewUserService(mockRepo, mockCompanyRepo, mockProducer)
+	svc := service.NewUserService(mockRepo, mockCompanyRepo, &MockTransactor{}, mockProducer)
 
 	companyID := uuid.New()
+	company := &domain.Company{ID: companyID, Name: "Acme", IsVerified: true}
+	members := []uuid.UUID{uuid.New(), uuid.New()}
 
 	mockCompanyRepo.On("VerifyCompany", mock.Anything, companyID).Return(nil)
+	mockCompanyRepo.On("GetCompanyByID", mock.Anything, companyID).Return(company, nil)
+	mockRepo.On("ListIDsByCompany", mock.Anything, companyID).Return(members, nil)
+	mockProducer.On("PublishCompanyVerified", mock.Anything, company, members).Return(nil)
 
 	err := svc.VerifyCompany(context.Background(), companyID.String())
 	assert.NoError(t, err)
+	mockProducer.AssertExpectations(t)
 }
 
 func TestGetCompany(t *testing.T) {
 	mockRepo := new(MockUserRepository)
 	mockCompanyRepo := new(MockCompanyRepository)
 	mockProducer := new(MockEventProducer)
-	svc := service.NewUserService(mockRepo, mockCompanyRepo, mockProducer)
+	svc := service.NewUserService(mockRepo, mockCompanyRepo, &MockTransactor{}, mockProducer)
 
 	companyID := uuid.New()
 	company := &domain.Company{
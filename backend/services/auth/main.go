@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 
 	_ "github.com/lib/pq"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/auth"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/config"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/database"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/kafka"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/auth/internal/event"
@@ -35,15 +37,29 @@ func main() {
 	// Setup layers
 	repo := repository.NewPostgresRepo(db)
 	companyRepo := repository.NewCompanyRepo(db)
-	tm := auth.NewTokenManager(cfg.JWTSecret)
+	tm := newTokenManager(cfg, log)
 
-	// Kafka Producer
+	// Kafka Producer: events are written to the outbox with the user rows
+	// and relayed to Kafka in the background
 	kafkaProducer := kafka.NewProducer(cfg.KafkaBrokers, log)
 	defer kafkaProducer.Close()
-	eventProducer := event.NewKafkaEventProducer(kafkaProducer)
+	eventProducer := event.NewKafkaEventProducer(kafka.NewOutbox(db))
+	tx := database.NewTransactor(db)
 
-	authSvc := service.NewAuthService(repo, tm, eventProducer)
-	userSvc := service.NewUserService(repo, companyRepo, eventProducer)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	relay := kafka.NewOutboxRelay(db, kafkaProducer, cfg.OutboxRelayInterval, log)
+	relay.Start(ctx)
+
+	// Reject revoked tokens as the auth service revokes them
+	denylist := auth.NewDenylist()
+	tm.UseDenylist(denylist)
+	revocations := auth.WatchRevocations(ctx, cfg.KafkaBrokers, denylist, log)
+	defer revocations.Close()
+
+	refreshTokenRepo := repository.NewRefreshTokenRepo(db)
+	authSvc := service.NewAuthService(repo, refreshTokenRepo, tx, tm, eventProducer)
+	userSvc := service.NewUserService(repo, companyRepo, tx, eventProducer)
 
 	authHandler := handler.NewAuthHandler(authSvc)
 	userHandler := handler.NewUserHandler(userSvc)
@@ -55,3 +71,23 @@ func main() {
 		log.Fatal("Failed to run server", zap.Error(err))
 	}
 }
+
+// newTokenManager signs tokens with the keys JWT_SIGNING_KEYS names, or with the
+// secret shared with the other services if it is unset.
+func newTokenManager(cfg *config.Config, log logger.Logger) *auth.TokenManager {
+	if len(cfg.JWTSigningKeys) == 0 {
+		log.Warn("JWT_SIGNING_KEYS is not set, signing tokens with the shared JWT_SECRET")
+		return auth.NewTokenManager(cfg.JWTSecret)
+	}
+
+	keys, err := auth.LoadSigningKeys(cfg.JWTSigningKeys)
+	if err != nil {
+		log.Fatal("failed to load JWT signing keys", zap.Error(err))
+	}
+	tm, err := auth.NewSigningTokenManager(keys)
+	if err != nil {
+		log.Fatal("failed to set up token signing", zap.Error(err))
+	}
+	log.Info("Signing tokens", zap.String("kid", keys[0].ID), zap.Int("keys", len(keys)))
+	return tm
+}
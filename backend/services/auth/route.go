@@ -15,6 +15,12 @@ func SetupRouter(authHandler *handler.AuthHandler, userHandler *handler.UserHand
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 
+	// Public keys the other services verify tokens with
+	r.GET("/.well-known/jwks.json", func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(200, tm.JWKS())
+	})
+
 	api := r.Group("/api/v1")
 	{
 		authGroup := api.Group("/auth")
@@ -22,6 +28,8 @@ func SetupRouter(authHandler *handler.AuthHandler, userHandler *handler.UserHand
 			authGroup.POST("/register", authHandler.Register)
 			authGroup.POST("/login", authHandler.Login)
 			authGroup.POST("/verify-otp", authHandler.VerifyOTP)
+			authGroup.POST("/refresh", authHandler.Refresh)
+			authGroup.POST("/logout", authHandler.Logout)
 			authGroup.POST("/2fa/toggle", middleware.AuthMiddleware(tm), authHandler.Toggle2FA)
 		}
 
@@ -0,0 +1,44 @@
+package domain
+
+import "sort"
+
+// Allocate hands out the quantity units of a multi-lot auction to its standing bids and
+// returns the bids that won any, best first, with Allocated set. A bidder's latest live
+// bid stands and replaces their earlier ones; retracted bids are skipped. Higher amounts
+// are served first, equal amounts by time placed, and the last bid served may be only
+// partly filled.
+func Allocate(bids []Bid, quantity int) []Bid {
+	latest := make(map[string]int, len(bids))
+	var standing []Bid
+	for _, b := range bids {
+		if b.Retracted {
+			continue
+		}
+		if i, ok := latest[b.BidderID]; ok {
+			if b.Timestamp.After(standing[i].Timestamp) {
+				standing[i] = b
+			}
+			continue
+		}
+		latest[b.BidderID] = len(standing)
+		standing = append(standing, b)
+	}
+
+	sort.SliceStable(standing, func(i, j int) bool {
+		if standing[i].Amount != standing[j].Amount {
+			return standing[i].Amount > standing[j].Amount
+		}
+		return standing[i].Timestamp.Before(standing[j].Timestamp)
+	})
+
+	var won []Bid
+	for _, b := range standing {
+		if quantity <= 0 {
+			break
+		}
+		b.Allocated = min(max(b.Quantity, 1), quantity)
+		quantity -= b.Allocated
+		won = append(won, b)
+	}
+	return won
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAllocate(t *testing.T) {
+	start := time.Now()
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+
+	bids := []Bid{
+		{ID: "alice-1", BidderID: "alice", Amount: 150, Quantity: 1, Timestamp: at(0)},
+		{ID: "bob-1", BidderID: "bob", Amount: 120, Quantity: 2, Timestamp: at(1)},
+		{ID: "carol-1", BidderID: "carol", Amount: 120, Quantity: 3, Timestamp: at(2)},
+		{ID: "dave-1", BidderID: "dave", Amount: 110, Quantity: 1, Timestamp: at(3)},
+		{ID: "alice-2", BidderID: "alice", Amount: 130, Quantity: 2, Timestamp: at(4)}, // replaces alice-1
+	}
+
+	won := Allocate(bids, 5)
+
+	want := []struct {
+		id    string
+		units int
+	}{
+		{"alice-2", 2},
+		{"bob-1", 2},   // ties go to the earlier bid
+		{"carol-1", 1}, // partly filled
+	}
+	if len(won) != len(want) {
+		t.Fatalf("expected %d winning bids, got %+v", len(want), won)
+	}
+	for i, w := range want {
+		if won[i].ID != w.id || won[i].Allocated != w.units {
+			t.Errorf("winner %d: expected %s with %d units, got %s with %d", i, w.id, w.units, won[i].ID, won[i].Allocated)
+		}
+	}
+}
+
+func TestAllocate_FewerBidsThanUnits(t *testing.T) {
+	won := Allocate([]Bid{{ID: "1", BidderID: "alice", Amount: 100}}, 3)
+
+	if len(won) != 1 || won[0].Allocated != 1 {
+		t.Errorf("expected the only bid to win one unit, got %+v", won)
+	}
+	if won := Allocate(nil, 3); len(won) != 0 {
+		t.Errorf("expected no winners without bids, got %+v", won)
+	}
+}
+
+func TestAllocate_SkipsRetractedBids(t *testing.T) {
+	start := time.Now()
+	bids := []Bid{
+		{ID: "alice-1", BidderID: "alice", Amount: 120, Quantity: 1, Timestamp: start},
+		{ID: "bob-1", BidderID: "bob", Amount: 110, Quantity: 1, Timestamp: start.Add(time.Minute)},
+		{ID: "alice-2", BidderID: "alice", Amount: 9000, Quantity: 1, Timestamp: start.Add(2 * time.Minute), Retracted: true},
+	}
+
+	won := Allocate(bids, 1)
+
+	// alice-2 was withdrawn, so alice's earlier bid stands again
+	if len(won) != 1 || won[0].ID != "alice-1" {
+		t.Errorf("expected alice-1 to win, got %+v", won)
+	}
+}
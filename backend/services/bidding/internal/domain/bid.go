@@ -7,30 +7,182 @@ import (
 )
 
 var (
-	ErrBidNotFound = errors.New("bid not found")
-	ErrInvalidBid  = errors.New("invalid bid")
+	ErrBidNotFound      = errors.New("bid not found")
+	ErrInvalidBid       = errors.New("invalid bid")
+	ErrInvalidMaxAmount = errors.New("max amount cannot be lower than the bid amount")
+	// ErrCompanyNotVerified rejects bids on reverse auctions from bidders whose
+	// company has not been verified.
+	ErrCompanyNotVerified = errors.New("only members of verified companies can bid on reverse auctions")
+	ErrBidRetracted       = errors.New("bid has already been retracted")
+	ErrNotBidOwner        = errors.New("only the bidder can retract their bid")
+	ErrNotAuctionSeller   = errors.New("only the auction's seller or an admin can cancel a bid")
+	ErrAuctionNotOpen     = errors.New("bids can only be withdrawn while the auction is open")
+	// ErrRetractionTooLate rejects retractions inside the auction's final stretch (see
+	// Settings.RetractionCutoff); the seller or an admin can still cancel the bid.
+	ErrRetractionTooLate = errors.New("bids can no longer be retracted this close to the end of the auction")
+	ErrSealedRetraction  = errors.New("sealed bids cannot be retracted")
+	ErrReasonRequired    = errors.New("a reason is required to cancel a bid")
 )
 
+// RoleAdmin is the token role allowed to cancel bids on any auction.
+const RoleAdmin = "ADMIN"
+
+// RejectionBidTooLow is the BidRejectedError reason for a bid under the auction's minimum.
+const RejectionBidTooLow = "BID_TOO_LOW"
+
 type Bid struct {
 	ID        string    `json:"id" gorm:"primaryKey"`
 	AuctionID string    `json:"auction_id"`
 	BidderID  string    `json:"bidder_id"`
-	Amount    float64   `json:"amount"`
+	Amount    float64   `json:"amount,omitempty"` // per unit; omitted for sealed bids while the auction is open
 	Timestamp time.Time `json:"timestamp"`
+	IsProxy   bool      `json:"is_proxy"`         // placed automatically on the bidder's behalf
+	Sealed    bool      `json:"sealed,omitempty"` // placed on a sealed-bid auction
+	Quantity  int       `json:"quantity"`         // units bid for; above 1 only in multi-lot auctions
+	// Allocated is how many units the bid currently wins in a multi-lot auction (see
+	// Allocate). It is computed when bids are listed and never stored.
+	Allocated int `json:"allocated,omitempty"`
+	// Retracted bids are kept for the record but never lead, win or set the price.
+	Retracted bool `json:"retracted,omitempty"`
+}
+
+// BidRetraction is the audit record of a bid withdrawn by its bidder, or cancelled by
+// the auction's seller or an admin.
+type BidRetraction struct {
+	BidID       string
+	AuctionID   string
+	BidderID    string
+	Amount      float64
+	RetractedBy string
+	Cancelled   bool // by the seller or an admin rather than the bidder
+	Reason      string
+	// WasLeading is whether the bid led the auction when it was withdrawn; if so the
+	// auction price falls back to LeaderID's LeadingAmount, or to the start price if
+	// no live bid is left (LeaderID empty).
+	WasLeading    bool
+	LeaderID      string
+	LeadingAmount float64
+	Sealed        bool // the amount must stay hidden until the auction closes
+	Timestamp     time.Time
+}
+
+// ProxyBid is a bidder's hidden ceiling on an auction. The service bids for
+// them up to MaxAmount; the ceiling itself is never exposed through bids or events.
+type ProxyBid struct {
+	AuctionID string
+	BidderID  string
+	MaxAmount float64
+	CreatedAt time.Time
 }
 
 type BidRepository interface {
 	Create(ctx context.Context, bid *Bid) error
 	GetByID(ctx context.Context, id string) (*Bid, error)
+	// ListByAuctionID returns every bid on the auction, retracted ones included.
 	ListByAuctionID(ctx context.Context, auctionID string) ([]Bid, error)
+	// GetHighestBid returns the highest live bid, or nil if there is none.
 	GetHighestBid(ctx context.Context, auctionID string) (*Bid, error)
+	// GetTopBids returns up to limit live bids, highest first (lowest first if lowestFirst)
+	// and ties by time placed.
+	GetTopBids(ctx context.Context, auctionID string, limit int, lowestFirst bool) ([]Bid, error)
+	// Retract marks the bid retracted and stores the audit record. It returns
+	// ErrBidRetracted if the bid was already retracted.
+	Retract(ctx context.Context, retraction *BidRetraction) error
+}
+
+// CompanyRepository is the bidding service's copy of which companies the auth
+// service has verified, fed by company.verified events.
+type CompanyRepository interface {
+	MarkVerified(ctx context.Context, companyID string, verifiedAt time.Time) error
+	IsVerified(ctx context.Context, companyID string) (bool, error)
+}
+
+type ProxyBidRepository interface {
+	// Upsert stores the bidder's ceiling. An existing ceiling is only ever raised,
+	// and keeps its CreatedAt so ties still go to whoever set theirs first.
+	Upsert(ctx context.Context, proxy *ProxyBid) error
+	// ListByAuctionID returns ceilings highest first, ties by CreatedAt.
+	ListByAuctionID(ctx context.Context, auctionID string) ([]ProxyBid, error)
+	// Delete drops the bidder's ceiling, if any.
+	Delete(ctx context.Context, auctionID, bidderID string) error
+}
+
+// Transactor runs fn in one database transaction. Repository calls and
+// EventProducer calls made with the ctx passed to fn are committed together.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// BidPlacement is what bid.placed tells besides the bid itself, so the seller and the
+// bidder it displaced can be notified.
+type BidPlacement struct {
+	SellerID         string
+	PreviousLeaderID string // the bidder who led before this bid, if it was someone else
 }
 
 type EventProducer interface {
-	PublishBidPlaced(ctx context.Context, bid *Bid) error
+	PublishBidPlaced(ctx context.Context, bid *Bid, placement BidPlacement) error
+	PublishBidRetracted(ctx context.Context, retraction *BidRetraction) error
+}
+
+// PriceQuote is an auction's price after AcceptBid and the lowest amount it will accept
+// next (the highest, MaxNextBid, in a reverse auction).
+type PriceQuote struct {
+	CurrentPrice float64
+	MinNextBid   float64
+	MaxNextBid   float64
+	Sealed       bool // sealed-bid auction: the price did not move and the bid amount stays hidden
+	Reverse      bool // reverse auction: bids go down and the lowest wins
+	MultiLot     bool // multi-lot auction: the price did not move and units are allocated from the bids
+	SellerID     string
+}
+
+// AuctionInfo is what the bidding service needs to know about an auction to decide
+// whether a bid on it may be withdrawn.
+type AuctionInfo struct {
+	SellerID string
+	Open     bool // pending or active
+	EndTime  time.Time
+	Sealed   bool
+	Reverse  bool
+	MultiLot bool
+}
+
+// BidRejectedError is returned when the auction service turns a bid down.
+type BidRejectedError struct {
+	Reason  string // e.g. BID_TOO_LOW, AUCTION_ENDED
+	Message string
+}
+
+func (e *BidRejectedError) Error() string {
+	return e.Message
 }
 
 type AuctionClient interface {
-	ValidateBid(ctx context.Context, auctionID string, amount float64, bidderID string) (bool, string, error)
-	UpdateAuctionPrice(ctx context.Context, auctionID string, amount float64) error
+	// AcceptBid atomically validates the bid for quantity units and raises the auction
+	// price to amount. It returns the resulting quote, or a *BidRejectedError if the bid
+	// was rejected; a rejection still carries the current quote when the auction exists.
+	AcceptBid(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*PriceQuote, error)
+	// AcceptBuyNow atomically closes the auction at its buy-now price with buyerID as
+	// winner and bidID as the winning bid. It returns the price paid, or a
+	// *BidRejectedError if buy-now is unavailable or another buyer got there first.
+	AcceptBuyNow(ctx context.Context, auctionID, buyerID, bidID string) (float64, error)
+	// AcceptClockPrice atomically closes a Dutch auction at its current clock price with
+	// buyerID as winner and bidID as the winning bid. It returns the price paid, or a
+	// *BidRejectedError if the auction is not Dutch or another buyer accepted first.
+	AcceptClockPrice(ctx context.Context, auctionID, buyerID, bidID string) (float64, error)
+	// IsAuctionOpen reports whether the auction is still pending or active.
+	IsAuctionOpen(ctx context.Context, auctionID string) (bool, error)
+	// IsReverseAuction reports whether the auction is a reverse (procurement) auction.
+	IsReverseAuction(ctx context.Context, auctionID string) (bool, error)
+	// GetQuantity returns how many units the auction sells; above 1 for multi-lot auctions.
+	GetQuantity(ctx context.Context, auctionID string) (int, error)
+	// GetAuction returns the seller, state and format of the auction.
+	GetAuction(ctx context.Context, auctionID string) (*AuctionInfo, error)
+	// RestorePrice has the auction service recompute the price after a bid of
+	// retractedAmount was withdrawn, and returns the current price.
+	RestorePrice(ctx context.Context, auctionID string, retractedAmount float64) (float64, error)
+	// ReleaseSealedBid has the auction service forget that bidderID bid on the sealed
+	// auction, after their bid could not be saved. It reports whether they were released.
+	ReleaseSealedBid(ctx context.Context, auctionID, bidderID string) (bool, error)
 }
@@ -0,0 +1,63 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/common/kafka"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
+	"go.uber.org/zap"
+)
+
+const (
+	TopicCompanyVerified = "company.verified"
+)
+
+// CompanyVerifiedEvent is published by the auth service when an admin verifies a company.
+type CompanyVerifiedEvent struct {
+	CompanyID string    `json:"company_id"`
+	Name      string    `json:"name"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// CompanyConsumer keeps the bidding service's copy of verified companies up to date,
+// so reverse-auction bids are checked without a call to the auth service.
+type CompanyConsumer struct {
+	consumer    *kafka.Consumer
+	companyRepo domain.CompanyRepository
+	log         logger.Logger
+}
+
+func NewCompanyConsumer(consumer *kafka.Consumer, companyRepo domain.CompanyRepository, log logger.Logger) *CompanyConsumer {
+	return &CompanyConsumer{
+		consumer:    consumer,
+		companyRepo: companyRepo,
+		log:         log,
+	}
+}
+
+func (c *CompanyConsumer) Start(ctx context.Context) {
+	c.log.Info("Starting company consumer")
+	c.consumer.Start(ctx, c.handleMessage)
+}
+
+func (c *CompanyConsumer) handleMessage(ctx context.Context, topic string, key, value []byte) error {
+	if topic != TopicCompanyVerified {
+		c.log.Warn("Unknown topic", zap.String("topic", topic))
+		return nil
+	}
+
+	var event CompanyVerifiedEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		c.log.Error("Failed to unmarshal CompanyVerifiedEvent", zap.Error(err))
+		return nil // Don't retry on unmarshal error
+	}
+
+	if err := c.companyRepo.MarkVerified(ctx, event.CompanyID, event.Timestamp); err != nil {
+		c.log.Error("Failed to mark company verified", zap.Error(err), zap.String("company_id", event.CompanyID))
+		return err
+	}
+	return nil
+}
@@ -9,33 +9,91 @@ import (
 )
 
 const (
-	TopicBidPlaced = "bid.placed"
+	TopicBidPlaced    = "bid.placed"
+	TopicBidRetracted = "bid.retracted"
 )
 
 type BidPlacedEvent struct {
 	BidID     string    `json:"bid_id"`
 	AuctionID string    `json:"auction_id"`
 	BidderID  string    `json:"bidder_id"`
-	Amount    float64   `json:"amount"`
+	Amount    float64   `json:"amount,omitempty"` // omitted for sealed bids
 	Timestamp time.Time `json:"timestamp"`
+	IsProxy   bool      `json:"is_proxy"`
+	Sealed    bool      `json:"sealed,omitempty"`
+	Quantity  int       `json:"quantity"` // units bid for, at amount each
+	SellerID  string    `json:"seller_id,omitempty"`
+	// PreviousLeaderID is the bidder this bid took the lead from. Sealed and multi-lot
+	// auctions have no single leader while open, so there it is never set.
+	PreviousLeaderID string `json:"previous_leader_id,omitempty"`
+}
+
+// BidRetractedEvent announces a bid withdrawn by its bidder, or cancelled by the
+// auction's seller or an admin. When the bid was leading, LeaderID and LeadingAmount
+// are the live bid that leads now; both are empty when none is left.
+type BidRetractedEvent struct {
+	BidID         string    `json:"bid_id"`
+	AuctionID     string    `json:"auction_id"`
+	BidderID      string    `json:"bidder_id"`
+	Amount        float64   `json:"amount,omitempty"` // omitted for sealed bids
+	RetractedBy   string    `json:"retracted_by"`
+	Cancelled     bool      `json:"cancelled"`
+	Reason        string    `json:"reason,omitempty"`
+	WasLeading    bool      `json:"was_leading"`
+	LeaderID      string    `json:"leader_id,omitempty"`
+	LeadingAmount float64   `json:"leading_amount,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
 }
 
 type KafkaEventProducer struct {
-	producer *kafka.Producer
+	producer kafka.Publisher
 }
 
-func NewKafkaEventProducer(producer *kafka.Producer) domain.EventProducer {
+// NewKafkaEventProducer publishes through producer; pass a kafka.Outbox to
+// commit events atomically with the repository writes.
+func NewKafkaEventProducer(producer kafka.Publisher) domain.EventProducer {
 	return &KafkaEventProducer{producer: producer}
 }
 
-func (p *KafkaEventProducer) PublishBidPlaced(ctx context.Context, bid *domain.Bid) error {
+func (p *KafkaEventProducer) PublishBidPlaced(ctx context.Context, bid *domain.Bid, placement domain.BidPlacement) error {
 	event := BidPlacedEvent{
 		BidID:     bid.ID,
 		AuctionID: bid.AuctionID,
 		BidderID:  bid.BidderID,
 		Amount:    bid.Amount,
 		Timestamp: bid.Timestamp,
+		IsProxy:   bid.IsProxy,
+		Sealed:    bid.Sealed,
+		Quantity:  bid.Quantity,
+		SellerID:  placement.SellerID,
+
+		PreviousLeaderID: placement.PreviousLeaderID,
+	}
+	if bid.Sealed {
+		// The auction is still open when a bid is placed, so a sealed amount must not travel
+		event.Amount = 0
 	}
 	// Keying by AuctionID ensures ordering for bids on the same auction
 	return p.producer.Publish(ctx, TopicBidPlaced, bid.AuctionID, event)
 }
+
+func (p *KafkaEventProducer) PublishBidRetracted(ctx context.Context, retraction *domain.BidRetraction) error {
+	event := BidRetractedEvent{
+		BidID:         retraction.BidID,
+		AuctionID:     retraction.AuctionID,
+		BidderID:      retraction.BidderID,
+		Amount:        retraction.Amount,
+		RetractedBy:   retraction.RetractedBy,
+		Cancelled:     retraction.Cancelled,
+		Reason:        retraction.Reason,
+		WasLeading:    retraction.WasLeading,
+		LeaderID:      retraction.LeaderID,
+		LeadingAmount: retraction.LeadingAmount,
+		Timestamp:     retraction.Timestamp,
+	}
+	if retraction.Sealed {
+		event.Amount = 0
+	}
+	// Same key as bid.placed, so consumers see a retraction after the bid it withdraws
+	return p.producer.Publish(ctx, TopicBidRetracted, retraction.AuctionID, event)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	pb "github.com/temesgen-abebayehu/bidflow/backend/proto/pb"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/service"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -18,19 +19,57 @@ func NewGrpcHandler(service *service.BiddingService) *GrpcHandler {
 }
 
 func (h *GrpcHandler) PlaceBid(ctx context.Context, req *pb.PlaceBidRequest) (*pb.PlaceBidResponse, error) {
-	bid, err := h.service.PlaceBid(ctx, req.AuctionId, req.BidderId, req.Amount)
+	bid, err := h.service.PlaceBid(ctx, req.AuctionId, req.BidderId, req.CompanyId, req.Amount, req.MaxAmount, int(req.Quantity))
 	if err != nil {
 		return nil, err
 	}
 
 	return &pb.PlaceBidResponse{
-		Bid: &pb.Bid{
-			Id:        bid.ID,
-			AuctionId: bid.AuctionID,
-			BidderId:  bid.BidderID,
-			Amount:    bid.Amount,
-			Timestamp: timestamppb.New(bid.Timestamp),
-		},
+		Bid: toPbBid(bid),
+	}, nil
+}
+
+func (h *GrpcHandler) AcceptPrice(ctx context.Context, req *pb.AcceptPriceRequest) (*pb.AcceptPriceResponse, error) {
+	bid, err := h.service.AcceptPrice(ctx, req.AuctionId, req.BidderId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AcceptPriceResponse{
+		Bid: toPbBid(bid),
+	}, nil
+}
+
+func (h *GrpcHandler) BuyNow(ctx context.Context, req *pb.BuyNowRequest) (*pb.BuyNowResponse, error) {
+	bid, err := h.service.BuyNow(ctx, req.AuctionId, req.BuyerId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.BuyNowResponse{
+		Bid: toPbBid(bid),
+	}, nil
+}
+
+func (h *GrpcHandler) RetractBid(ctx context.Context, req *pb.RetractBidRequest) (*pb.RetractBidResponse, error) {
+	bid, err := h.service.RetractBid(ctx, req.BidId, req.BidderId, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RetractBidResponse{
+		Bid: toPbBid(bid),
+	}, nil
+}
+
+func (h *GrpcHandler) CancelBid(ctx context.Context, req *pb.CancelBidRequest) (*pb.CancelBidResponse, error) {
+	bid, err := h.service.CancelBid(ctx, req.BidId, req.ActorId, req.Admin, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CancelBidResponse{
+		Bid: toPbBid(bid),
 	}, nil
 }
 
@@ -42,16 +81,73 @@ func (h *GrpcHandler) GetBidsByAuction(ctx context.Context, req *pb.GetBidsByAuc
 
 	var pbBids []*pb.Bid
 	for _, b := range bids {
-		pbBids = append(pbBids, &pb.Bid{
-			Id:        b.ID,
-			AuctionId: b.AuctionID,
-			BidderId:  b.BidderID,
-			Amount:    b.Amount,
-			Timestamp: timestamppb.New(b.Timestamp),
-		})
+		pbBids = append(pbBids, toPbBid(&b))
 	}
 
 	return &pb.GetBidsByAuctionResponse{
 		Bids: pbBids,
 	}, nil
 }
+
+func (h *GrpcHandler) GetHighestBid(ctx context.Context, req *pb.GetHighestBidRequest) (*pb.GetHighestBidResponse, error) {
+	bid, err := h.service.GetHighestBid(ctx, req.AuctionId)
+	if err != nil {
+		return nil, err
+	}
+	if bid == nil {
+		return &pb.GetHighestBidResponse{Found: false}, nil
+	}
+
+	return &pb.GetHighestBidResponse{
+		Bid:   toPbBid(bid),
+		Found: true,
+	}, nil
+}
+
+func (h *GrpcHandler) GetTopBids(ctx context.Context, req *pb.GetTopBidsRequest) (*pb.GetTopBidsResponse, error) {
+	bids, err := h.service.GetTopBids(ctx, req.AuctionId, int(req.Limit), req.LowestFirst)
+	if err != nil {
+		return nil, err
+	}
+
+	var pbBids []*pb.Bid
+	for _, b := range bids {
+		pbBids = append(pbBids, toPbBid(&b))
+	}
+
+	return &pb.GetTopBidsResponse{
+		Bids: pbBids,
+	}, nil
+}
+
+func (h *GrpcHandler) GetAllocation(ctx context.Context, req *pb.GetAllocationRequest) (*pb.GetAllocationResponse, error) {
+	bids, err := h.service.GetAllocation(ctx, req.AuctionId, int(req.Quantity))
+	if err != nil {
+		return nil, err
+	}
+
+	var pbBids []*pb.Bid
+	for _, b := range bids {
+		pbBids = append(pbBids, toPbBid(&b))
+	}
+
+	return &pb.GetAllocationResponse{
+		Bids: pbBids,
+	}, nil
+}
+
+// toPbBid never carries the bidder's proxy ceiling, only whether the bid was placed by it.
+func toPbBid(b *domain.Bid) *pb.Bid {
+	return &pb.Bid{
+		Id:        b.ID,
+		AuctionId: b.AuctionID,
+		BidderId:  b.BidderID,
+		Amount:    b.Amount,
+		Timestamp: timestamppb.New(b.Timestamp),
+		IsProxy:   b.IsProxy,
+		Sealed:    b.Sealed,
+		Quantity:  int32(b.Quantity),
+		Allocated: int32(b.Allocated),
+		Retracted: b.Retracted,
+	}
+}
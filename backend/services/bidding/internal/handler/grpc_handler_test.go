@@ -20,7 +20,7 @@ func TestPlaceBidGrpc(t *testing.T) {
 	}
 	// MockAuctionClient and MockEventProducer are defined in http_handler_test.go
 	// and are available here since they are in the same package (handler)
-	svc := service.NewBiddingService(repo, &MockEventProducer{}, &MockAuctionClient{})
+	svc := service.NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, service.Settings{}, &MockLogger{})
 	h := NewGrpcHandler(svc)
 
 	req := &pb.PlaceBidRequest{
@@ -51,7 +51,7 @@ func TestGetBidsByAuctionGrpc(t *testing.T) {
 			}, nil
 		},
 	}
-	svc := service.NewBiddingService(repo, &MockEventProducer{}, &MockAuctionClient{})
+	svc := service.NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, service.Settings{}, &MockLogger{})
 	h := NewGrpcHandler(svc)
 
 	req := &pb.GetBidsByAuctionRequest{
@@ -67,3 +67,36 @@ func TestGetBidsByAuctionGrpc(t *testing.T) {
 		t.Errorf("expected 2 bids, got %d", len(resp.Bids))
 	}
 }
+
+func TestGetHighestBidGrpc(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		repo := &MockBidRepo{
+			GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.Bid, error) {
+				return &domain.Bid{ID: "bid-9", AuctionID: auctionID, BidderID: "user-9", Amount: 300, Timestamp: time.Now()}, nil
+			},
+		}
+		svc := service.NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, service.Settings{}, &MockLogger{})
+		h := NewGrpcHandler(svc)
+
+		resp, err := h.GetHighestBid(context.Background(), &pb.GetHighestBidRequest{AuctionId: "auction-1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.Found || resp.Bid.BidderId != "user-9" {
+			t.Errorf("expected highest bid from user-9, got %+v", resp)
+		}
+	})
+
+	t.Run("No Bids", func(t *testing.T) {
+		svc := service.NewBiddingService(&MockBidRepo{}, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, service.Settings{}, &MockLogger{})
+		h := NewGrpcHandler(svc)
+
+		resp, err := h.GetHighestBid(context.Background(), &pb.GetHighestBidRequest{AuctionId: "auction-1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Found {
+			t.Error("expected no highest bid")
+		}
+	})
+}
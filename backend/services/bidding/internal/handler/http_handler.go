@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/service"
 )
 
@@ -18,6 +20,8 @@ func NewHttpHandler(service *service.BiddingService) *HttpHandler {
 type placeBidRequest struct {
 	AuctionID string  `json:"auction_id" binding:"required"`
 	Amount    float64 `json:"amount" binding:"required,gt=0"`
+	MaxAmount float64 `json:"max_amount" binding:"omitempty,gtefield=Amount"` // optional proxy-bidding ceiling
+	Quantity  int     `json:"quantity" binding:"omitempty,gte=1"`             // units wanted in a multi-lot auction
 }
 
 func (h *HttpHandler) PlaceBid(c *gin.Context) {
@@ -34,7 +38,64 @@ func (h *HttpHandler) PlaceBid(c *gin.Context) {
 		return
 	}
 
-	bid, err := h.service.PlaceBid(c.Request.Context(), req.AuctionID, userID.(string), req.Amount)
+	bid, err := h.service.PlaceBid(c.Request.Context(), req.AuctionID, userID.(string), c.GetString("company_id"), req.Amount, req.MaxAmount, req.Quantity)
+	if errors.Is(err, domain.ErrCompanyNotVerified) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, bid)
+}
+
+type buyNowRequest struct {
+	AuctionID string `json:"auction_id" binding:"required"`
+}
+
+func (h *HttpHandler) BuyNow(c *gin.Context) {
+	var req buyNowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	bid, err := h.service.BuyNow(c.Request.Context(), req.AuctionID, userID.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, bid)
+}
+
+type acceptPriceRequest struct {
+	AuctionID string `json:"auction_id" binding:"required"`
+}
+
+// AcceptPrice wins a Dutch auction at its current clock price.
+func (h *HttpHandler) AcceptPrice(c *gin.Context) {
+	var req acceptPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	bid, err := h.service.AcceptPrice(c.Request.Context(), req.AuctionID, userID.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -43,6 +104,78 @@ func (h *HttpHandler) PlaceBid(c *gin.Context) {
 	c.JSON(http.StatusCreated, bid)
 }
 
+type retractBidRequest struct {
+	Reason string `json:"reason"`
+}
+
+// RetractBid withdraws one of the caller's own bids.
+func (h *HttpHandler) RetractBid(c *gin.Context) {
+	var req retractBidRequest
+	// The reason is optional, and so is the body
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	bid, err := h.service.RetractBid(c.Request.Context(), c.Param("bid_id"), userID.(string), req.Reason)
+	if err != nil {
+		c.JSON(retractionStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bid)
+}
+
+type cancelBidRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// CancelBid withdraws a bid on an auction the caller sells, or on any auction for admins.
+func (h *HttpHandler) CancelBid(c *gin.Context) {
+	var req cancelBidRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	admin := c.GetString("role") == domain.RoleAdmin
+	bid, err := h.service.CancelBid(c.Request.Context(), c.Param("bid_id"), userID.(string), admin, req.Reason)
+	if err != nil {
+		c.JSON(retractionStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bid)
+}
+
+func retractionStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrBidNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrNotBidOwner), errors.Is(err, domain.ErrNotAuctionSeller):
+		return http.StatusForbidden
+	case errors.Is(err, domain.ErrBidRetracted), errors.Is(err, domain.ErrAuctionNotOpen),
+		errors.Is(err, domain.ErrRetractionTooLate), errors.Is(err, domain.ErrSealedRetraction):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
 func (h *HttpHandler) GetBids(c *gin.Context) {
 	auctionID := c.Param("auction_id")
 	if auctionID == "" {
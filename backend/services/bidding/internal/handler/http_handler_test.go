@@ -7,16 +7,22 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/auth"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/service"
+	"go.uber.org/zap"
 )
 
 // Mocks for Service Dependencies (reused from service test logic, but simplified here)
 type MockBidRepo struct {
 	CreateFunc          func(ctx context.Context, bid *domain.Bid) error
+	GetByIDFunc         func(ctx context.Context, id string) (*domain.Bid, error)
 	ListByAuctionIDFunc func(ctx context.Context, auctionID string) ([]domain.Bid, error)
+	GetHighestBidFunc   func(ctx context.Context, auctionID string) (*domain.Bid, error)
 }
 
 func (m *MockBidRepo) Create(ctx context.Context, bid *domain.Bid) error {
@@ -25,7 +31,12 @@ func (m *MockBidRepo) Create(ctx context.Context, bid *domain.Bid) error {
 	}
 	return nil
 }
-func (m *MockBidRepo) GetByID(ctx context.Context, id string) (*domain.Bid, error) { return nil, nil }
+func (m *MockBidRepo) GetByID(ctx context.Context, id string) (*domain.Bid, error) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(ctx, id)
+	}
+	return nil, domain.ErrBidNotFound
+}
 func (m *MockBidRepo) ListByAuctionID(ctx context.Context, auctionID string) ([]domain.Bid, error) {
 	if m.ListByAuctionIDFunc != nil {
 		return m.ListByAuctionIDFunc(ctx, auctionID)
@@ -33,27 +44,111 @@ func (m *MockBidRepo) ListByAuctionID(ctx context.Context, auctionID string) ([]
 	return nil, nil
 }
 func (m *MockBidRepo) GetHighestBid(ctx context.Context, auctionID string) (*domain.Bid, error) {
+	if m.GetHighestBidFunc != nil {
+		return m.GetHighestBidFunc(ctx, auctionID)
+	}
+	return nil, nil
+}
+func (m *MockBidRepo) GetTopBids(ctx context.Context, auctionID string, limit int, lowestFirst bool) ([]domain.Bid, error) {
 	return nil, nil
 }
+func (m *MockBidRepo) Retract(ctx context.Context, retraction *domain.BidRetraction) error {
+	return nil
+}
 
 type MockEventProducer struct{}
 
-func (m *MockEventProducer) PublishBidPlaced(ctx context.Context, bid *domain.Bid) error { return nil }
+func (m *MockEventProducer) PublishBidPlaced(ctx context.Context, bid *domain.Bid, placement domain.BidPlacement) error {
+	return nil
+}
+func (m *MockEventProducer) PublishBidRetracted(ctx context.Context, retraction *domain.BidRetraction) error {
+	return nil
+}
+
+type MockTransactor struct{}
+
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
 
 type MockAuctionClient struct{}
 
-func (m *MockAuctionClient) ValidateBid(ctx context.Context, auctionID string, amount float64, bidderID string) (bool, string, error) {
-	return true, "valid", nil
+func (m *MockAuctionClient) AcceptBid(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error) {
+	return &domain.PriceQuote{CurrentPrice: amount, MinNextBid: amount + 0.01}, nil
+}
+
+func (m *MockAuctionClient) AcceptBuyNow(ctx context.Context, auctionID, buyerID, bidID string) (float64, error) {
+	if auctionID == "sold" {
+		return 0, &domain.BidRejectedError{Reason: "AUCTION_NOT_ACTIVE", Message: "Auction is not active"}
+	}
+	return 500, nil
+}
+
+func (m *MockAuctionClient) AcceptClockPrice(ctx context.Context, auctionID, buyerID, bidID string) (float64, error) {
+	if auctionID != "dutch" {
+		return 0, &domain.BidRejectedError{Reason: "WRONG_AUCTION_TYPE", Message: "Only dutch auctions have a clock price to accept"}
+	}
+	return 85, nil
+}
+
+func (m *MockAuctionClient) IsAuctionOpen(ctx context.Context, auctionID string) (bool, error) {
+	return auctionID != "sold", nil
+}
+
+func (m *MockAuctionClient) IsReverseAuction(ctx context.Context, auctionID string) (bool, error) {
+	return auctionID == "tender", nil
+}
+
+func (m *MockAuctionClient) GetQuantity(ctx context.Context, auctionID string) (int, error) {
+	return 1, nil
+}
+
+// GetAuction describes every auction as open and owned by seller-1.
+func (m *MockAuctionClient) GetAuction(ctx context.Context, auctionID string) (*domain.AuctionInfo, error) {
+	return &domain.AuctionInfo{SellerID: "seller-1", Open: true, EndTime: time.Now().Add(24 * time.Hour)}, nil
+}
+
+func (m *MockAuctionClient) RestorePrice(ctx context.Context, auctionID string, retractedAmount float64) (float64, error) {
+	return 0, nil
+}
+
+func (m *MockAuctionClient) ReleaseSealedBid(ctx context.Context, auctionID, bidderID string) (bool, error) {
+	return false, nil
+}
+
+type MockProxyBidRepo struct{}
+
+func (m *MockProxyBidRepo) Upsert(ctx context.Context, proxy *domain.ProxyBid) error { return nil }
+func (m *MockProxyBidRepo) ListByAuctionID(ctx context.Context, auctionID string) ([]domain.ProxyBid, error) {
+	return nil, nil
 }
-func (m *MockAuctionClient) UpdateAuctionPrice(ctx context.Context, auctionID string, amount float64) error {
+func (m *MockProxyBidRepo) Delete(ctx context.Context, auctionID, bidderID string) error { return nil }
+
+// MockCompanyRepo knows a single verified company.
+type MockCompanyRepo struct{}
+
+func (m *MockCompanyRepo) MarkVerified(ctx context.Context, companyID string, verifiedAt time.Time) error {
 	return nil
 }
+func (m *MockCompanyRepo) IsVerified(ctx context.Context, companyID string) (bool, error) {
+	return companyID == "company-verified", nil
+}
+
+type MockLogger struct{}
+
+func (m *MockLogger) Debug(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) Info(msg string, fields ...zap.Field)   {}
+func (m *MockLogger) Warn(msg string, fields ...zap.Field)   {}
+func (m *MockLogger) Error(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) Fatal(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) With(fields ...zap.Field) logger.Logger { return m }
+func (m *MockLogger) Sync() error                            { return nil }
 
 func TestPlaceBidHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	repo := &MockBidRepo{}
-	svc := service.NewBiddingService(repo, &MockEventProducer{}, &MockAuctionClient{})
+	svc := service.NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, service.Settings{}, &MockLogger{})
 	h := NewHttpHandler(svc)
 
 	r := gin.Default()
@@ -79,15 +174,265 @@ func TestPlaceBidHandler(t *testing.T) {
 	}
 }
 
+func TestPlaceBidHandler_MaxBelowAmount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := service.NewBiddingService(&MockBidRepo{}, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, service.Settings{}, &MockLogger{})
+	h := NewHttpHandler(svc)
+
+	r := gin.Default()
+	r.POST("/bids", func(c *gin.Context) {
+		c.Set("user_id", "user-123")
+		h.PlaceBid(c)
+	})
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"auction_id": "auction-1",
+		"amount":     150.0,
+		"max_amount": 120.0,
+	})
+	req, _ := http.NewRequest("POST", "/bids", bytes.NewBuffer(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestPlaceBidHandler_ReverseAuction(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := service.NewBiddingService(&MockBidRepo{}, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, service.Settings{}, &MockLogger{})
+	h := NewHttpHandler(svc)
+
+	tests := []struct {
+		name       string
+		companyID  string
+		wantStatus int
+	}{
+		{"Verified Company", "company-verified", http.StatusCreated},
+		{"Unverified Company", "company-pending", http.StatusForbidden},
+		{"No Company", "", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.Default()
+			r.POST("/bids", func(c *gin.Context) {
+				c.Set("user_id", "user-123")
+				c.Set("company_id", tt.companyID)
+				h.PlaceBid(c)
+			})
+
+			body, _ := json.Marshal(map[string]interface{}{
+				"auction_id": "tender",
+				"amount":     900.0,
+			})
+			req, _ := http.NewRequest("POST", "/bids", bytes.NewBuffer(body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+// A member of a verified company bids on a reverse auction with the token the auth
+// service issued at login, through the real middleware.
+func TestPlaceBidHandler_ReverseAuctionWithToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := service.NewBiddingService(&MockBidRepo{}, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, service.Settings{}, &MockLogger{})
+	tm := auth.NewTokenManager("secret")
+	r := SetupRouter(NewHttpHandler(svc), tm)
+
+	token, err := tm.GenerateSessionToken("user-123", "company-verified", "BIDDER", "session-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"auction_id": "tender",
+		"amount":     900.0,
+	})
+	req, _ := http.NewRequest("POST", "/api/v1/bids", bytes.NewBuffer(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected status 201, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestBuyNowHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := service.NewBiddingService(&MockBidRepo{}, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, service.Settings{}, &MockLogger{})
+	h := NewHttpHandler(svc)
+
+	r := gin.Default()
+	r.POST("/bids/buy-now", func(c *gin.Context) {
+		c.Set("user_id", "user-123")
+		h.BuyNow(c)
+	})
+
+	tests := []struct {
+		name       string
+		auctionID  string
+		wantStatus int
+	}{
+		{"Bought", "auction-1", http.StatusCreated},
+		{"Already Sold", "sold", http.StatusBadRequest},
+		{"Missing Auction", "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]interface{}{"auction_id": tt.auctionID})
+			req, _ := http.NewRequest("POST", "/bids/buy-now", bytes.NewBuffer(body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			var bid domain.Bid
+			if err := json.Unmarshal(w.Body.Bytes(), &bid); err != nil || bid.Amount != 500 || bid.BidderID != "user-123" {
+				t.Errorf("expected bid at 500 by user-123, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAcceptPriceHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	svc := service.NewBiddingService(&MockBidRepo{}, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, service.Settings{}, &MockLogger{})
+	h := NewHttpHandler(svc)
+
+	r := gin.Default()
+	r.POST("/bids/accept", func(c *gin.Context) {
+		c.Set("user_id", "user-123")
+		h.AcceptPrice(c)
+	})
+
+	tests := []struct {
+		name       string
+		auctionID  string
+		wantStatus int
+	}{
+		{"Accepted", "dutch", http.StatusCreated},
+		{"Not Dutch", "auction-1", http.StatusBadRequest},
+		{"Missing Auction", "", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(map[string]interface{}{"auction_id": tt.auctionID})
+			req, _ := http.NewRequest("POST", "/bids/accept", bytes.NewBuffer(body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			var bid domain.Bid
+			if err := json.Unmarshal(w.Body.Bytes(), &bid); err != nil || bid.Amount != 85 || bid.BidderID != "user-123" {
+				t.Errorf("expected bid at 85 by user-123, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRetractAndCancelBidHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	repo := &MockBidRepo{
+		GetByIDFunc: func(ctx context.Context, id string) (*domain.Bid, error) {
+			if id != "bid-1" {
+				return nil, domain.ErrBidNotFound
+			}
+			return &domain.Bid{ID: "bid-1", AuctionID: "auction-1", BidderID: "user-123", Amount: 100}, nil
+		},
+	}
+	svc := service.NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, service.Settings{}, &MockLogger{})
+	h := NewHttpHandler(svc)
+
+	r := gin.Default()
+	withUser := func(handler gin.HandlerFunc) gin.HandlerFunc {
+		return func(c *gin.Context) {
+			c.Set("user_id", c.GetHeader("X-User"))
+			c.Set("role", c.GetHeader("X-Role"))
+			handler(c)
+		}
+	}
+	r.POST("/bids/:bid_id/retract", withUser(h.RetractBid))
+	r.POST("/bids/:bid_id/cancel", withUser(h.CancelBid))
+
+	tests := []struct {
+		name       string
+		path       string
+		user       string
+		role       string
+		body       string
+		wantStatus int
+	}{
+		{"Retracted By Bidder", "/bids/bid-1/retract", "user-123", "", "", http.StatusOK},
+		{"Retract Someone Else's Bid", "/bids/bid-1/retract", "user-456", "", "", http.StatusForbidden},
+		{"Retract Unknown Bid", "/bids/bid-9/retract", "user-123", "", "", http.StatusNotFound},
+		{"Cancelled By Seller", "/bids/bid-1/cancel", "seller-1", "", `{"reason": "shill bidding"}`, http.StatusOK},
+		{"Cancelled By Admin", "/bids/bid-1/cancel", "admin-1", domain.RoleAdmin, `{"reason": "fraud"}`, http.StatusOK},
+		{"Cancel By Other User", "/bids/bid-1/cancel", "user-456", "", `{"reason": "no"}`, http.StatusForbidden},
+		{"Cancel Without Reason", "/bids/bid-1/cancel", "seller-1", "", `{}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("X-User", tt.user)
+			req.Header.Set("X-Role", tt.role)
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var bid domain.Bid
+			if err := json.Unmarshal(w.Body.Bytes(), &bid); err != nil || !bid.Retracted {
+				t.Errorf("expected a retracted bid, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
 func TestGetBidsHandler(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	repo := &MockBidRepo{
 		ListByAuctionIDFunc: func(ctx context.Context, auctionID string) ([]domain.Bid, error) {
-			return []domain.Bid{{ID: "1", Amount: 100}}, nil
+			return []domain.Bid{{ID: "1", Amount: 100}, {ID: "2", Amount: 101, IsProxy: true}}, nil
 		},
 	}
-	svc := service.NewBiddingService(repo, &MockEventProducer{}, &MockAuctionClient{})
+	svc := service.NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, service.Settings{}, &MockLogger{})
 	h := NewHttpHandler(svc)
 
 	r := gin.Default()
@@ -101,4 +446,7 @@ func TestGetBidsHandler(t *testing.T) {
 	if w.Code != http.StatusOK {
 		t.Errorf("expected status 200, got %d", w.Code)
 	}
+	if bytes.Contains(w.Body.Bytes(), []byte("max_amount")) {
+		t.Errorf("bid listing must not expose proxy ceilings: %s", w.Body.String())
+	}
 }
@@ -22,6 +22,10 @@ func SetupRouter(h *HttpHandler, tm *auth.TokenManager) *gin.Engine {
 		protected.Use(middleware.AuthMiddleware(tm))
 		{
 			protected.POST("", h.PlaceBid)
+			protected.POST("/buy-now", h.BuyNow)
+			protected.POST("/accept", h.AcceptPrice)
+			protected.POST("/:bid_id/retract", h.RetractBid)
+			protected.POST("/:bid_id/cancel", h.CancelBid)
 		}
 	}
 
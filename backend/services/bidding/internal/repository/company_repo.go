@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/common/database"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
+)
+
+type companyRepo struct {
+	db *sql.DB
+}
+
+func NewCompanyRepo(db *sql.DB) domain.CompanyRepository {
+	return &companyRepo{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *companyRepo) conn(ctx context.Context) database.DBTX {
+	return database.Conn(ctx, r.db)
+}
+
+// MarkVerified records the company as verified. Redelivered events keep the first
+// verification time.
+func (r *companyRepo) MarkVerified(ctx context.Context, companyID string, verifiedAt time.Time) error {
+	query := `
+		INSERT INTO verified_companies (company_id, verified_at)
+		VALUES ($1, $2)
+		ON CONFLICT (company_id) DO NOTHING
+	`
+
+	_, err := r.conn(ctx).ExecContext(ctx, query, companyID, verifiedAt)
+	return err
+}
+
+func (r *companyRepo) IsVerified(ctx context.Context, companyID string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM verified_companies WHERE company_id = $1)`
+
+	var verified bool
+	if err := r.conn(ctx).QueryRowContext(ctx, query, companyID).Scan(&verified); err != nil {
+		return false, err
+	}
+	return verified, nil
+}
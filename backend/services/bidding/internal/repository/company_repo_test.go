@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestMarkCompanyVerified(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewCompanyRepo(db)
+	verifiedAt := time.Now()
+
+	// Redelivered company.verified events are no-ops.
+	mock.ExpectExec("INSERT INTO verified_companies .* ON CONFLICT \\(company_id\\) DO NOTHING").
+		WithArgs("company-1", verifiedAt).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.MarkVerified(context.Background(), "company-1", verifiedAt); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestIsCompanyVerified(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewCompanyRepo(db)
+
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM verified_companies WHERE company_id = \\$1\\)").
+		WithArgs("company-1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	verified, err := repo.IsVerified(context.Background(), "company-1")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !verified {
+		t.Error("expected the company to be verified")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
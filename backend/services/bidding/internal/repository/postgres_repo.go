@@ -5,9 +5,13 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/temesgen-abebayehu/bidflow/backend/common/database"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
 )
 
+// bidColumns is the column list of every bid read, in scanBid order.
+const bidColumns = `id, auction_id, bidder_id, amount, timestamp, is_proxy, sealed, quantity, retracted`
+
 type postgresRepo struct {
 	db *sql.DB
 }
@@ -16,27 +20,32 @@ func NewPostgresRepo(db *sql.DB) domain.BidRepository {
 	return &postgresRepo{db: db}
 }
 
+// conn joins the transaction carried by ctx, if any.
+func (r *postgresRepo) conn(ctx context.Context) database.DBTX {
+	return database.Conn(ctx, r.db)
+}
+
 func (r *postgresRepo) Create(ctx context.Context, bid *domain.Bid) error {
 	query := `
-		INSERT INTO bids (id, auction_id, bidder_id, amount, timestamp)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO bids (id, auction_id, bidder_id, amount, timestamp, is_proxy, sealed, quantity)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 	if bid.Timestamp.IsZero() {
 		bid.Timestamp = time.Now()
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
-		bid.ID, bid.AuctionID, bid.BidderID, bid.Amount, bid.Timestamp,
+	_, err := r.conn(ctx).ExecContext(ctx, query,
+		bid.ID, bid.AuctionID, bid.BidderID, bid.Amount, bid.Timestamp, bid.IsProxy, bid.Sealed, max(bid.Quantity, 1),
 	)
 	return err
 }
 
 func (r *postgresRepo) GetByID(ctx context.Context, id string) (*domain.Bid, error) {
-	query := `SELECT id, auction_id, bidder_id, amount, timestamp FROM bids WHERE id = $1`
-	row := r.db.QueryRowContext(ctx, query, id)
+	query := `SELECT ` + bidColumns + ` FROM bids WHERE id = $1`
+	row := r.conn(ctx).QueryRowContext(ctx, query, id)
 
 	var b domain.Bid
-	err := row.Scan(&b.ID, &b.AuctionID, &b.BidderID, &b.Amount, &b.Timestamp)
+	err := scanBid(row, &b)
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrBidNotFound
 	}
@@ -47,30 +56,23 @@ func (r *postgresRepo) GetByID(ctx context.Context, id string) (*domain.Bid, err
 }
 
 func (r *postgresRepo) ListByAuctionID(ctx context.Context, auctionID string) ([]domain.Bid, error) {
-	query := `SELECT id, auction_id, bidder_id, amount, timestamp FROM bids WHERE auction_id = $1 ORDER BY amount DESC`
-	rows, err := r.db.QueryContext(ctx, query, auctionID)
+	query := `SELECT ` + bidColumns + ` FROM bids WHERE auction_id = $1 ORDER BY amount DESC`
+	rows, err := r.conn(ctx).QueryContext(ctx, query, auctionID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var bids []domain.Bid
-	for rows.Next() {
-		var b domain.Bid
-		if err := rows.Scan(&b.ID, &b.AuctionID, &b.BidderID, &b.Amount, &b.Timestamp); err != nil {
-			return nil, err
-		}
-		bids = append(bids, b)
-	}
-	return bids, nil
+	return scanBids(rows)
 }
 
 func (r *postgresRepo) GetHighestBid(ctx context.Context, auctionID string) (*domain.Bid, error) {
-	query := `SELECT id, auction_id, bidder_id, amount, timestamp FROM bids WHERE auction_id = $1 ORDER BY amount DESC LIMIT 1`
-	row := r.db.QueryRowContext(ctx, query, auctionID)
+	// Ties go to the earliest bid
+	query := `SELECT ` + bidColumns + ` FROM bids WHERE auction_id = $1 AND NOT retracted ORDER BY amount DESC, timestamp ASC LIMIT 1`
+	row := r.conn(ctx).QueryRowContext(ctx, query, auctionID)
 
 	var b domain.Bid
-	err := row.Scan(&b.ID, &b.AuctionID, &b.BidderID, &b.Amount, &b.Timestamp)
+	err := scanBid(row, &b)
 	if err == sql.ErrNoRows {
 		return nil, nil // No bids yet
 	}
@@ -79,3 +81,69 @@ func (r *postgresRepo) GetHighestBid(ctx context.Context, auctionID string) (*do
 	}
 	return &b, nil
 }
+
+func (r *postgresRepo) GetTopBids(ctx context.Context, auctionID string, limit int, lowestFirst bool) ([]domain.Bid, error) {
+	order := "DESC"
+	if lowestFirst {
+		order = "ASC"
+	}
+	query := `SELECT ` + bidColumns + ` FROM bids WHERE auction_id = $1 AND NOT retracted ORDER BY amount ` + order + `, timestamp ASC LIMIT $2`
+	rows, err := r.conn(ctx).QueryContext(ctx, query, auctionID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBids(rows)
+}
+
+// Retract marks the bid and writes its audit row; call it inside a transaction so both
+// land together. Only a live bid is marked, so of two concurrent retractions one fails.
+func (r *postgresRepo) Retract(ctx context.Context, retraction *domain.BidRetraction) error {
+	if retraction.Timestamp.IsZero() {
+		retraction.Timestamp = time.Now()
+	}
+
+	result, err := r.conn(ctx).ExecContext(ctx, `UPDATE bids SET retracted = TRUE WHERE id = $1 AND NOT retracted`, retraction.BidID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrBidRetracted
+	}
+
+	query := `
+		INSERT INTO bid_retractions (bid_id, auction_id, bidder_id, amount, retracted_by, cancelled, reason, was_leading, leader_id, leading_amount, retracted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err = r.conn(ctx).ExecContext(ctx, query,
+		retraction.BidID, retraction.AuctionID, retraction.BidderID, retraction.Amount, retraction.RetractedBy,
+		retraction.Cancelled, retraction.Reason, retraction.WasLeading, retraction.LeaderID, retraction.LeadingAmount,
+		retraction.Timestamp,
+	)
+	return err
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBid(row rowScanner, b *domain.Bid) error {
+	return row.Scan(&b.ID, &b.AuctionID, &b.BidderID, &b.Amount, &b.Timestamp, &b.IsProxy, &b.Sealed, &b.Quantity, &b.Retracted)
+}
+
+func scanBids(rows *sql.Rows) ([]domain.Bid, error) {
+	var bids []domain.Bid
+	for rows.Next() {
+		var b domain.Bid
+		if err := scanBid(rows, &b); err != nil {
+			return nil, err
+		}
+		bids = append(bids, b)
+	}
+	return bids, rows.Err()
+}
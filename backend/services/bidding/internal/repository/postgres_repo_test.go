@@ -27,7 +27,7 @@ func TestCreate(t *testing.T) {
 	}
 
 	mock.ExpectExec("INSERT INTO bids").
-		WithArgs(bid.ID, bid.AuctionID, bid.BidderID, bid.Amount, bid.Timestamp).
+		WithArgs(bid.ID, bid.AuctionID, bid.BidderID, bid.Amount, bid.Timestamp, bid.IsProxy, bid.Sealed, 1).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err = repo.Create(context.Background(), bid)
@@ -49,10 +49,10 @@ func TestGetByID(t *testing.T) {
 
 	repo := NewPostgresRepo(db)
 
-	rows := sqlmock.NewRows([]string{"id", "auction_id", "bidder_id", "amount", "timestamp"}).
-		AddRow("bid-1", "auction-1", "user-1", 100.0, time.Now())
+	rows := sqlmock.NewRows([]string{"id", "auction_id", "bidder_id", "amount", "timestamp", "is_proxy", "sealed", "quantity", "retracted"}).
+		AddRow("bid-1", "auction-1", "user-1", 100.0, time.Now(), false, false, 1, false)
 
-	mock.ExpectQuery("SELECT id, auction_id, bidder_id, amount, timestamp FROM bids WHERE id = \\$1").
+	mock.ExpectQuery("SELECT id, auction_id, bidder_id, amount, timestamp, is_proxy, sealed, quantity, retracted FROM bids WHERE id = \\$1").
 		WithArgs("bid-1").
 		WillReturnRows(rows)
 
@@ -74,11 +74,11 @@ func TestListByAuctionID(t *testing.T) {
 
 	repo := NewPostgresRepo(db)
 
-	rows := sqlmock.NewRows([]string{"id", "auction_id", "bidder_id", "amount", "timestamp"}).
-		AddRow("bid-1", "auction-1", "user-1", 100.0, time.Now()).
-		AddRow("bid-2", "auction-1", "user-2", 90.0, time.Now())
+	rows := sqlmock.NewRows([]string{"id", "auction_id", "bidder_id", "amount", "timestamp", "is_proxy", "sealed", "quantity", "retracted"}).
+		AddRow("bid-1", "auction-1", "user-1", 100.0, time.Now(), false, false, 1, false).
+		AddRow("bid-2", "auction-1", "user-2", 90.0, time.Now(), false, false, 1, false)
 
-	mock.ExpectQuery("SELECT id, auction_id, bidder_id, amount, timestamp FROM bids WHERE auction_id = \\$1 ORDER BY amount DESC").
+	mock.ExpectQuery("SELECT id, auction_id, bidder_id, amount, timestamp, is_proxy, sealed, quantity, retracted FROM bids WHERE auction_id = \\$1 ORDER BY amount DESC").
 		WithArgs("auction-1").
 		WillReturnRows(rows)
 
@@ -90,3 +90,146 @@ func TestListByAuctionID(t *testing.T) {
 		t.Errorf("expected 2 bids, got %d", len(bids))
 	}
 }
+
+func TestGetHighestBid(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+
+	rows := sqlmock.NewRows([]string{"id", "auction_id", "bidder_id", "amount", "timestamp", "is_proxy", "sealed", "quantity", "retracted"}).
+		AddRow("bid-1", "auction-1", "user-1", 100.0, time.Now(), false, false, 1, false)
+
+	mock.ExpectQuery("SELECT id, auction_id, bidder_id, amount, timestamp, is_proxy, sealed, quantity, retracted FROM bids WHERE auction_id = \\$1 AND NOT retracted ORDER BY amount DESC, timestamp ASC LIMIT 1").
+		WithArgs("auction-1").
+		WillReturnRows(rows)
+
+	bid, err := repo.GetHighestBid(context.Background(), "auction-1")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if bid == nil || bid.ID != "bid-1" {
+		t.Errorf("expected bid 'bid-1', got %+v", bid)
+	}
+
+	mock.ExpectQuery("SELECT id, auction_id, bidder_id, amount, timestamp, is_proxy, sealed, quantity, retracted FROM bids WHERE auction_id = \\$1").
+		WithArgs("auction-2").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "auction_id", "bidder_id", "amount", "timestamp", "is_proxy", "sealed", "quantity", "retracted"}))
+
+	bid, err = repo.GetHighestBid(context.Background(), "auction-2")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if bid != nil {
+		t.Errorf("expected no bid, got %+v", bid)
+	}
+}
+
+func TestGetTopBids(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+
+	rows := sqlmock.NewRows([]string{"id", "auction_id", "bidder_id", "amount", "timestamp", "is_proxy", "sealed", "quantity", "retracted"}).
+		AddRow("bid-1", "auction-1", "user-1", 150.0, time.Now(), false, true, 1, false).
+		AddRow("bid-2", "auction-1", "user-2", 120.0, time.Now(), false, true, 1, false)
+
+	mock.ExpectQuery("SELECT .* FROM bids WHERE auction_id = \\$1 AND NOT retracted ORDER BY amount DESC, timestamp ASC LIMIT \\$2").
+		WithArgs("auction-1", 2).
+		WillReturnRows(rows)
+
+	bids, err := repo.GetTopBids(context.Background(), "auction-1", 2, false)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(bids) != 2 || bids[0].ID != "bid-1" || !bids[1].Sealed {
+		t.Errorf("expected the two sealed bids highest first, got %+v", bids)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetTopBids_LowestFirst(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+
+	rows := sqlmock.NewRows([]string{"id", "auction_id", "bidder_id", "amount", "timestamp", "is_proxy", "sealed", "quantity", "retracted"}).
+		AddRow("bid-2", "auction-1", "user-2", 850.0, time.Now(), false, false, 1, false)
+
+	mock.ExpectQuery("SELECT .* FROM bids WHERE auction_id = \\$1 AND NOT retracted ORDER BY amount ASC, timestamp ASC LIMIT \\$2").
+		WithArgs("auction-1", 1).
+		WillReturnRows(rows)
+
+	bids, err := repo.GetTopBids(context.Background(), "auction-1", 1, true)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(bids) != 1 || bids[0].ID != "bid-2" {
+		t.Errorf("expected the lowest bid, got %+v", bids)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestRetract(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+
+	retraction := &domain.BidRetraction{
+		BidID:         "bid-1",
+		AuctionID:     "auction-1",
+		BidderID:      "user-1",
+		Amount:        15000,
+		RetractedBy:   "user-1",
+		Reason:        "typo",
+		WasLeading:    true,
+		LeaderID:      "user-2",
+		LeadingAmount: 150,
+		Timestamp:     time.Now(),
+	}
+
+	mock.ExpectExec("UPDATE bids SET retracted = TRUE WHERE id = \\$1 AND NOT retracted").
+		WithArgs("bid-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO bid_retractions").
+		WithArgs("bid-1", "auction-1", "user-1", 15000.0, "user-1", false, "typo", true, "user-2", 150.0, retraction.Timestamp).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := repo.Retract(context.Background(), retraction); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// A bid that is already retracted matches no row and gets no second audit row
+	mock.ExpectExec("UPDATE bids SET retracted = TRUE").
+		WithArgs("bid-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := repo.Retract(context.Background(), retraction); err != domain.ErrBidRetracted {
+		t.Errorf("expected ErrBidRetracted, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/common/database"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
+)
+
+type proxyRepo struct {
+	db *sql.DB
+}
+
+func NewProxyBidRepo(db *sql.DB) domain.ProxyBidRepository {
+	return &proxyRepo{db: db}
+}
+
+// conn joins the transaction carried by ctx, if any.
+func (r *proxyRepo) conn(ctx context.Context) database.DBTX {
+	return database.Conn(ctx, r.db)
+}
+
+func (r *proxyRepo) Upsert(ctx context.Context, proxy *domain.ProxyBid) error {
+	query := `
+		INSERT INTO proxy_bids (auction_id, bidder_id, max_amount, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (auction_id, bidder_id) DO UPDATE
+		SET max_amount = EXCLUDED.max_amount, updated_at = EXCLUDED.updated_at
+		WHERE proxy_bids.max_amount < EXCLUDED.max_amount
+	`
+
+	if proxy.CreatedAt.IsZero() {
+		proxy.CreatedAt = time.Now()
+	}
+
+	_, err := r.conn(ctx).ExecContext(ctx, query,
+		proxy.AuctionID, proxy.BidderID, proxy.MaxAmount, proxy.CreatedAt, time.Now(),
+	)
+	return err
+}
+
+func (r *proxyRepo) ListByAuctionID(ctx context.Context, auctionID string) ([]domain.ProxyBid, error) {
+	query := `SELECT auction_id, bidder_id, max_amount, created_at FROM proxy_bids WHERE auction_id = $1 ORDER BY max_amount DESC, created_at ASC`
+	rows, err := r.conn(ctx).QueryContext(ctx, query, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var proxies []domain.ProxyBid
+	for rows.Next() {
+		var p domain.ProxyBid
+		if err := rows.Scan(&p.AuctionID, &p.BidderID, &p.MaxAmount, &p.CreatedAt); err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, p)
+	}
+	return proxies, rows.Err()
+}
+
+func (r *proxyRepo) Delete(ctx context.Context, auctionID, bidderID string) error {
+	_, err := r.conn(ctx).ExecContext(ctx, `DELETE FROM proxy_bids WHERE auction_id = $1 AND bidder_id = $2`, auctionID, bidderID)
+	return err
+}
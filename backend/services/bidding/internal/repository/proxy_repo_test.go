@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
+)
+
+func TestUpsertProxyBid(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewProxyBidRepo(db)
+
+	proxy := &domain.ProxyBid{
+		AuctionID: "auction-1",
+		BidderID:  "user-1",
+		MaxAmount: 250.0,
+		CreatedAt: time.Now(),
+	}
+
+	// Ceilings only move up; a lower max_amount leaves the row untouched.
+	mock.ExpectExec("INSERT INTO proxy_bids .* ON CONFLICT \\(auction_id, bidder_id\\) DO UPDATE .* WHERE proxy_bids.max_amount < EXCLUDED.max_amount").
+		WithArgs(proxy.AuctionID, proxy.BidderID, proxy.MaxAmount, proxy.CreatedAt, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := repo.Upsert(context.Background(), proxy); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestListProxyBidsByAuctionID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	repo := NewProxyBidRepo(db)
+
+	rows := sqlmock.NewRows([]string{"auction_id", "bidder_id", "max_amount", "created_at"}).
+		AddRow("auction-1", "user-2", 300.0, time.Now()).
+		AddRow("auction-1", "user-1", 250.0, time.Now())
+
+	mock.ExpectQuery("SELECT auction_id, bidder_id, max_amount, created_at FROM proxy_bids WHERE auction_id = \\$1 ORDER BY max_amount DESC, created_at ASC").
+		WithArgs("auction-1").
+		WillReturnRows(rows)
+
+	proxies, err := repo.ListByAuctionID(context.Background(), "auction-1")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(proxies) != 2 || proxies[0].BidderID != "user-2" {
+		t.Errorf("expected user-2's ceiling first, got %+v", proxies)
+	}
+}
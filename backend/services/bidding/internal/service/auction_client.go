@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	pb "github.com/temesgen-abebayehu/bidflow/backend/proto/pb"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
@@ -18,27 +19,134 @@ func NewAuctionClient(conn *grpc.ClientConn) domain.AuctionClient {
 	}
 }
 
-func (c *auctionClient) ValidateBid(ctx context.Context, auctionID string, amount float64, bidderID string) (bool, string, error) {
-	req := &pb.BidRequest{
+func (c *auctionClient) AcceptBid(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error) {
+	req := &pb.AcceptBidRequest{
 		AuctionId: auctionID,
 		Amount:    amount,
 		BidderId:  bidderID,
+		Quantity:  int32(quantity),
 	}
 
-	res, err := c.client.ValidateBid(ctx, req)
+	res, err := c.client.AcceptBid(ctx, req)
 	if err != nil {
-		return false, "", err
+		return nil, err
 	}
 
-	return res.IsValid, res.Message, nil
+	quote := &domain.PriceQuote{
+		CurrentPrice: res.CurrentPrice,
+		MinNextBid:   res.MinNextBid,
+		MaxNextBid:   res.MaxNextBid,
+		Sealed:       res.Sealed,
+		Reverse:      res.Reverse,
+		MultiLot:     res.MultiLot,
+		SellerID:     res.SellerId,
+	}
+	if !res.Accepted {
+		return quote, &domain.BidRejectedError{Reason: res.Reason.String(), Message: res.Message}
+	}
+
+	return quote, nil
 }
 
-func (c *auctionClient) UpdateAuctionPrice(ctx context.Context, auctionID string, amount float64) error {
-	req := &pb.UpdateAuctionPriceRequest{
+func (c *auctionClient) AcceptBuyNow(ctx context.Context, auctionID, buyerID, bidID string) (float64, error) {
+	req := &pb.AcceptBuyNowRequest{
 		AuctionId: auctionID,
-		Amount:    amount,
+		BuyerId:   buyerID,
+		BidId:     bidID,
+	}
+
+	res, err := c.client.AcceptBuyNow(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	if !res.Accepted {
+		return 0, &domain.BidRejectedError{Reason: res.Reason.String(), Message: res.Message}
+	}
+
+	return res.Price, nil
+}
+
+func (c *auctionClient) AcceptClockPrice(ctx context.Context, auctionID, buyerID, bidID string) (float64, error) {
+	req := &pb.AcceptClockPriceRequest{
+		AuctionId: auctionID,
+		BuyerId:   buyerID,
+		BidId:     bidID,
+	}
+
+	res, err := c.client.AcceptClockPrice(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	if !res.Accepted {
+		return 0, &domain.BidRejectedError{Reason: res.Reason.String(), Message: res.Message}
+	}
+
+	return res.Price, nil
+}
+
+func (c *auctionClient) IsAuctionOpen(ctx context.Context, auctionID string) (bool, error) {
+	res, err := c.client.GetAuctionStatus(ctx, &pb.StatusRequest{AuctionId: auctionID})
+	if err != nil {
+		return false, err
+	}
+
+	return res.Status == "PENDING" || res.Status == "ACTIVE", nil
+}
+
+func (c *auctionClient) IsReverseAuction(ctx context.Context, auctionID string) (bool, error) {
+	res, err := c.client.GetAuctionStatus(ctx, &pb.StatusRequest{AuctionId: auctionID})
+	if err != nil {
+		return false, err
+	}
+
+	return res.AuctionType == "REVERSE", nil
+}
+
+func (c *auctionClient) GetQuantity(ctx context.Context, auctionID string) (int, error) {
+	res, err := c.client.GetAuctionStatus(ctx, &pb.StatusRequest{AuctionId: auctionID})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(res.Quantity), nil
+}
+
+func (c *auctionClient) GetAuction(ctx context.Context, auctionID string) (*domain.AuctionInfo, error) {
+	res, err := c.client.GetAuctionStatus(ctx, &pb.StatusRequest{AuctionId: auctionID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AuctionInfo{
+		SellerID: res.SellerId,
+		Open:     res.Status == "PENDING" || res.Status == "ACTIVE",
+		EndTime:  time.Unix(res.EndTimeUnix, 0),
+		Sealed:   res.AuctionType == "SEALED_FIRST_PRICE" || res.AuctionType == "SEALED_SECOND_PRICE",
+		Reverse:  res.AuctionType == "REVERSE",
+		MultiLot: res.Quantity > 1,
+	}, nil
+}
+
+func (c *auctionClient) RestorePrice(ctx context.Context, auctionID string, retractedAmount float64) (float64, error) {
+	res, err := c.client.RestorePrice(ctx, &pb.RestorePriceRequest{
+		AuctionId:       auctionID,
+		RetractedAmount: retractedAmount,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return res.CurrentPrice, nil
+}
+
+func (c *auctionClient) ReleaseSealedBid(ctx context.Context, auctionID, bidderID string) (bool, error) {
+	res, err := c.client.ReleaseSealedBid(ctx, &pb.ReleaseSealedBidRequest{
+		AuctionId: auctionID,
+		BidderId:  bidderID,
+	})
+	if err != nil {
+		return false, err
 	}
 
-	_, err := c.client.UpdateAuctionPrice(ctx, req)
-	return err
+	return res.Released, nil
 }
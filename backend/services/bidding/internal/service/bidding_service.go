@@ -2,35 +2,78 @@ package service
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
+	"go.uber.org/zap"
+)
+
+const (
+	// How long undoing an accepted bid that could not be saved may take. The request
+	// may already be cancelled by then, so the calls don't inherit its deadline.
+	undoTimeout = 5 * time.Second
+
+	// How many times a buy-now or clock bid is saved before giving up. The auction is
+	// closed with the bid as winner by then, so the save is retried instead of undone.
+	winningBidSaveAttempts = 3
 )
 
 type BiddingService struct {
 	repo          domain.BidRepository
+	proxyRepo     domain.ProxyBidRepository
+	companyRepo   domain.CompanyRepository
+	tx            domain.Transactor
 	eventProducer domain.EventProducer
 	auctionClient domain.AuctionClient
+	settings      Settings
+	log           logger.Logger
 }
 
-func NewBiddingService(repo domain.BidRepository, eventProducer domain.EventProducer, auctionClient domain.AuctionClient) *BiddingService {
+// Settings are the service-wide bidding rules.
+type Settings struct {
+	// RetractionCutoff is how long before an auction ends bidders can no longer retract
+	// their bids. Sellers and admins can still cancel them.
+	RetractionCutoff time.Duration
+}
+
+func NewBiddingService(repo domain.BidRepository, proxyRepo domain.ProxyBidRepository, companyRepo domain.CompanyRepository, tx domain.Transactor, eventProducer domain.EventProducer, auctionClient domain.AuctionClient, settings Settings, log logger.Logger) *BiddingService {
 	return &BiddingService{
 		repo:          repo,
+		proxyRepo:     proxyRepo,
+		companyRepo:   companyRepo,
+		tx:            tx,
 		eventProducer: eventProducer,
 		auctionClient: auctionClient,
+		settings:      settings,
+		log:           log,
 	}
 }
 
-func (s *BiddingService) PlaceBid(ctx context.Context, auctionID, bidderID string, amount float64) (*domain.Bid, error) {
-	// 1. Validate with Auction Service
-	isValid, msg, err := s.auctionClient.ValidateBid(ctx, auctionID, amount, bidderID)
-	if err != nil {
+// PlaceBid places a bid of amount per unit for quantity units (0 means 1; only
+// multi-lot auctions take more). A non-zero maxAmount also records a hidden
+// ceiling up to which the service keeps outbidding competitors for the bidder.
+// Sealed auctions take a single bid per bidder, reverse auctions are bid down and
+// multi-lot auctions have no single price to beat, so there maxAmount is ignored.
+// Reverse auctions only take bids from members of a verified company, companyID
+// being the bidder's.
+func (s *BiddingService) PlaceBid(ctx context.Context, auctionID, bidderID, companyID string, amount, maxAmount float64, quantity int) (*domain.Bid, error) {
+	if maxAmount != 0 && maxAmount < amount {
+		return nil, domain.ErrInvalidMaxAmount
+	}
+	if quantity == 0 {
+		quantity = 1
+	}
+	if err := s.checkCompany(ctx, auctionID, companyID); err != nil {
 		return nil, err
 	}
-	if !isValid {
-		return nil, errors.New(msg)
+
+	// 1. Atomically validate and apply the new price in the Auction Service.
+	// Doing both in one call means a concurrent lower bid can never overwrite a higher price.
+	quote, err := s.auctionClient.AcceptBid(ctx, auctionID, amount, bidderID, quantity)
+	if err != nil {
+		return nil, err
 	}
 
 	// 2. Create Bid
@@ -40,31 +83,261 @@ func (s *BiddingService) PlaceBid(ctx context.Context, auctionID, bidderID strin
 		BidderID:  bidderID,
 		Amount:    amount,
 		Timestamp: time.Now(),
+		Sealed:    quote.Sealed,
+		Quantity:  quantity,
 	}
 
-	// 3. Save to DB
-	if err := s.repo.Create(ctx, bid); err != nil {
+	// 3. Save the bid, its bid.placed event (via the outbox) and the ceiling in one transaction
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.saveBid(ctx, bid, quote); err != nil {
+			return err
+		}
+		if maxAmount == 0 || bid.Sealed || quote.Reverse || quote.MultiLot {
+			return nil
+		}
+		return s.proxyRepo.Upsert(ctx, &domain.ProxyBid{
+			AuctionID: auctionID,
+			BidderID:  bidderID,
+			MaxAmount: maxAmount,
+			CreatedAt: bid.Timestamp,
+		})
+	})
+	if err != nil {
+		s.undoAcceptedBid(ctx, bid, quote)
 		return nil, err
 	}
 
-	// 4. Update Auction Price (Synchronous for consistency)
-	if err := s.auctionClient.UpdateAuctionPrice(ctx, auctionID, amount); err != nil {
-		// Log error but don't fail the bid? Or fail?
-		// If we fail here, we have an inconsistency (Bid saved, Price not updated).
-		// Ideally we should rollback the bid.
-		// For now, we return error.
+	// 4. Let proxy ceilings respond. The bid above already stands, so a failure
+	// here is only logged; the next bid on the auction re-runs the proxies.
+	if bid.Sealed || quote.Reverse || quote.MultiLot {
+		return bid, nil
+	}
+	if err := s.resolveProxyBids(ctx, auctionID, *quote); err != nil {
+		s.log.Error("failed to resolve proxy bids", zap.Error(err), zap.String("auction_id", auctionID))
+	}
+
+	return bid, nil
+}
+
+// checkCompany returns ErrCompanyNotVerified if the auction is a reverse auction and
+// companyID is not a verified company. The auction type never changes, so checking it
+// ahead of AcceptBid cannot race. Verified bidders skip the call to the auction service.
+func (s *BiddingService) checkCompany(ctx context.Context, auctionID, companyID string) error {
+	if companyID != "" {
+		verified, err := s.companyRepo.IsVerified(ctx, companyID)
+		if err != nil || verified {
+			return err
+		}
+	}
+
+	reverse, err := s.auctionClient.IsReverseAuction(ctx, auctionID)
+	if err != nil {
+		return err
+	}
+	if reverse {
+		return domain.ErrCompanyNotVerified
+	}
+	return nil
+}
+
+// BuyNow buys the auction outright at its buy-now price. The auction service closes the
+// auction with the buyer as winner (and publishes auction.closed) in one conditional
+// update, so of two buyers racing only one gets here with a price; the bid is then
+// recorded and bid.placed published like any other bid.
+func (s *BiddingService) BuyNow(ctx context.Context, auctionID, buyerID string) (*domain.Bid, error) {
+	bidID := uuid.New().String()
+
+	price, err := s.auctionClient.AcceptBuyNow(ctx, auctionID, buyerID, bidID)
+	if err != nil {
 		return nil, err
 	}
 
-	// 5. Publish Event
-	if err := s.eventProducer.PublishBidPlaced(ctx, bid); err != nil {
-		// In a real system, we might want to use the outbox pattern here
+	bid := &domain.Bid{
+		ID:        bidID,
+		AuctionID: auctionID,
+		BidderID:  buyerID,
+		Amount:    price,
+		Timestamp: time.Now(),
+		Quantity:  1,
+	}
+
+	if err := s.saveWinningBid(ctx, bid); err != nil {
 		return nil, err
 	}
 
 	return bid, nil
 }
 
+// AcceptPrice wins a Dutch auction at its current clock price. Like BuyNow, the auction
+// service closes the auction with the bidder as winner in one conditional update, so
+// only the first acceptance gets a price; the bid is then recorded and bid.placed
+// published like any other bid.
+func (s *BiddingService) AcceptPrice(ctx context.Context, auctionID, bidderID string) (*domain.Bid, error) {
+	bidID := uuid.New().String()
+
+	price, err := s.auctionClient.AcceptClockPrice(ctx, auctionID, bidderID, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	bid := &domain.Bid{
+		ID:        bidID,
+		AuctionID: auctionID,
+		BidderID:  bidderID,
+		Amount:    price,
+		Timestamp: time.Now(),
+		Quantity:  1,
+	}
+
+	if err := s.saveWinningBid(ctx, bid); err != nil {
+		return nil, err
+	}
+
+	return bid, nil
+}
+
+// undoAcceptedBid reverts what the auction service did for a bid it accepted but that
+// could not be saved, so that no price or sealed bidder stands for a bid that doesn't
+// exist. The price goes back to the leading saved bid with the same compare-and-set as a
+// retraction, which leaves it alone if a later bid moved it; a sealed bidder is released
+// so they can bid again. Multi-lot bids only moved the end time, which is left as it is.
+func (s *BiddingService) undoAcceptedBid(ctx context.Context, bid *domain.Bid, quote *domain.PriceQuote) {
+	if quote.MultiLot {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), undoTimeout)
+	defer cancel()
+
+	var err error
+	if bid.Sealed {
+		_, err = s.auctionClient.ReleaseSealedBid(ctx, bid.AuctionID, bid.BidderID)
+	} else {
+		_, err = s.auctionClient.RestorePrice(ctx, bid.AuctionID, bid.Amount)
+	}
+	if err != nil {
+		s.log.Error("failed to undo a bid that was accepted but not saved",
+			zap.Error(err),
+			zap.String("auction_id", bid.AuctionID),
+			zap.String("bidder_id", bid.BidderID),
+			zap.Float64("amount", bid.Amount),
+			zap.Bool("sealed", bid.Sealed),
+		)
+	}
+}
+
+// saveWinningBid saves a buy-now or clock bid. The auction service already closed the
+// auction with bid.ID as its winning bid, and announced it, so the close is not undone;
+// the save is retried instead, and if it still fails the bid is logged for it to be
+// recorded by hand.
+func (s *BiddingService) saveWinningBid(ctx context.Context, bid *domain.Bid) error {
+	// The auction is closed whether or not the client still waits for the answer
+	ctx = context.WithoutCancel(ctx)
+
+	var err error
+	for attempt := 0; attempt < winningBidSaveAttempts; attempt++ {
+		err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+			return s.saveBid(ctx, bid, nil)
+		})
+		if err == nil {
+			return nil
+		}
+	}
+	s.log.Error("auction closed with a winning bid that could not be saved",
+		zap.Error(err),
+		zap.String("auction_id", bid.AuctionID),
+		zap.String("bid_id", bid.ID),
+		zap.String("bidder_id", bid.BidderID),
+		zap.Float64("amount", bid.Amount),
+	)
+	return err
+}
+
+// saveBid stores bid and writes its bid.placed event. Call it inside a transaction.
+// quote is the auction's answer to the bid; the event names the seller and the bidder
+// who led until now from it. Buy-now and clock acceptances close the auction, which
+// auction.closed announces, so they pass none.
+func (s *BiddingService) saveBid(ctx context.Context, bid *domain.Bid, quote *domain.PriceQuote) error {
+	var placement domain.BidPlacement
+	if quote != nil {
+		placement.SellerID = quote.SellerID
+		leader, err := s.leadingBid(ctx, bid.AuctionID, &domain.AuctionInfo{Sealed: quote.Sealed, Reverse: quote.Reverse, MultiLot: quote.MultiLot})
+		if err != nil {
+			return err
+		}
+		if leader != nil && leader.BidderID != bid.BidderID {
+			placement.PreviousLeaderID = leader.BidderID
+		}
+	}
+
+	if err := s.repo.Create(ctx, bid); err != nil {
+		return err
+	}
+	return s.eventProducer.PublishBidPlaced(ctx, bid, placement)
+}
+
+// GetBidsByAuction lists the bids on an auction. Amounts of sealed bids are left out
+// until the auction closes; bids on a multi-lot auction show the units they currently
+// win.
 func (s *BiddingService) GetBidsByAuction(ctx context.Context, auctionID string) ([]domain.Bid, error) {
-	return s.repo.ListByAuctionID(ctx, auctionID)
+	bids, err := s.repo.ListByAuctionID(ctx, auctionID)
+	if err != nil || len(bids) == 0 {
+		return bids, err
+	}
+	// Bids on one auction are either all sealed or none are
+	if !bids[0].Sealed {
+		return s.withAllocation(ctx, auctionID, bids)
+	}
+
+	open, err := s.auctionClient.IsAuctionOpen(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	if open {
+		for i := range bids {
+			bids[i].Amount = 0
+		}
+	}
+	return bids, nil
+}
+
+// withAllocation sets Allocated on the bids of a multi-lot auction.
+func (s *BiddingService) withAllocation(ctx context.Context, auctionID string, bids []domain.Bid) ([]domain.Bid, error) {
+	quantity, err := s.auctionClient.GetQuantity(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	if quantity <= 1 {
+		return bids, nil
+	}
+
+	allocated := make(map[string]int)
+	for _, b := range domain.Allocate(bids, quantity) {
+		allocated[b.ID] = b.Allocated
+	}
+	for i := range bids {
+		bids[i].Allocated = allocated[bids[i].ID]
+	}
+	return bids, nil
+}
+
+// GetAllocation allocates quantity units to the standing bids on an auction and returns
+// the winning bids, best first (see domain.Allocate).
+func (s *BiddingService) GetAllocation(ctx context.Context, auctionID string, quantity int) ([]domain.Bid, error) {
+	bids, err := s.repo.ListByAuctionID(ctx, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.Allocate(bids, quantity), nil
+}
+
+// GetHighestBid returns the leading bid on an auction, or nil if there are no bids.
+func (s *BiddingService) GetHighestBid(ctx context.Context, auctionID string) (*domain.Bid, error) {
+	return s.repo.GetHighestBid(ctx, auctionID)
+}
+
+// GetTopBids returns up to limit of the highest bids on an auction, highest first, or
+// of the lowest bids if lowestFirst.
+func (s *BiddingService) GetTopBids(ctx context.Context, auctionID string, limit int, lowestFirst bool) ([]domain.Bid, error) {
+	return s.repo.GetTopBids(ctx, auctionID, limit, lowestFirst)
 }
@@ -4,16 +4,23 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
+	"go.uber.org/zap"
 )
 
+var testSettings = Settings{RetractionCutoff: time.Hour}
+
 // Mocks
 type MockBidRepo struct {
 	CreateFunc          func(ctx context.Context, bid *domain.Bid) error
 	GetByIDFunc         func(ctx context.Context, id string) (*domain.Bid, error)
 	ListByAuctionIDFunc func(ctx context.Context, auctionID string) ([]domain.Bid, error)
 	GetHighestBidFunc   func(ctx context.Context, auctionID string) (*domain.Bid, error)
+	GetTopBidsFunc      func(ctx context.Context, auctionID string, limit int, lowestFirst bool) ([]domain.Bid, error)
+	RetractFunc         func(ctx context.Context, retraction *domain.BidRetraction) error
 }
 
 func (m *MockBidRepo) Create(ctx context.Context, bid *domain.Bid) error {
@@ -40,37 +47,182 @@ func (m *MockBidRepo) GetHighestBid(ctx context.Context, auctionID string) (*dom
 	}
 	return nil, nil
 }
+func (m *MockBidRepo) GetTopBids(ctx context.Context, auctionID string, limit int, lowestFirst bool) ([]domain.Bid, error) {
+	if m.GetTopBidsFunc != nil {
+		return m.GetTopBidsFunc(ctx, auctionID, limit, lowestFirst)
+	}
+	return nil, nil
+}
+func (m *MockBidRepo) Retract(ctx context.Context, retraction *domain.BidRetraction) error {
+	if m.RetractFunc != nil {
+		return m.RetractFunc(ctx, retraction)
+	}
+	return nil
+}
 
 type MockEventProducer struct {
-	PublishBidPlacedFunc func(ctx context.Context, bid *domain.Bid) error
+	PublishBidPlacedFunc    func(ctx context.Context, bid *domain.Bid, placement domain.BidPlacement) error
+	PublishBidRetractedFunc func(ctx context.Context, retraction *domain.BidRetraction) error
 }
 
-func (m *MockEventProducer) PublishBidPlaced(ctx context.Context, bid *domain.Bid) error {
+func (m *MockEventProducer) PublishBidPlaced(ctx context.Context, bid *domain.Bid, placement domain.BidPlacement) error {
 	if m.PublishBidPlacedFunc != nil {
-		return m.PublishBidPlacedFunc(ctx, bid)
+		return m.PublishBidPlacedFunc(ctx, bid, placement)
+	}
+	return nil
+}
+
+func (m *MockEventProducer) PublishBidRetracted(ctx context.Context, retraction *domain.BidRetraction) error {
+	if m.PublishBidRetractedFunc != nil {
+		return m.PublishBidRetractedFunc(ctx, retraction)
 	}
 	return nil
 }
 
+type txCtxKey struct{}
+
+// MockTransactor marks the ctx it hands to fn so tests can assert which calls
+// ran inside the transaction.
+type MockTransactor struct{}
+
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, txCtxKey{}, true))
+}
+
 type MockAuctionClient struct {
-	ValidateBidFunc        func(ctx context.Context, auctionID string, amount float64, bidderID string) (bool, string, error)
-	UpdateAuctionPriceFunc func(ctx context.Context, auctionID string, amount float64) error
+	AcceptBidFunc        func(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error)
+	AcceptBuyNowFunc     func(ctx context.Context, auctionID, buyerID, bidID string) (float64, error)
+	AcceptClockPriceFunc func(ctx context.Context, auctionID, buyerID, bidID string) (float64, error)
+	IsAuctionOpenFunc    func(ctx context.Context, auctionID string) (bool, error)
+	IsReverseAuctionFunc func(ctx context.Context, auctionID string) (bool, error)
+	GetQuantityFunc      func(ctx context.Context, auctionID string) (int, error)
+	GetAuctionFunc       func(ctx context.Context, auctionID string) (*domain.AuctionInfo, error)
+	RestorePriceFunc     func(ctx context.Context, auctionID string, retractedAmount float64) (float64, error)
+	ReleaseSealedBidFunc func(ctx context.Context, auctionID, bidderID string) (bool, error)
 }
 
-func (m *MockAuctionClient) ValidateBid(ctx context.Context, auctionID string, amount float64, bidderID string) (bool, string, error) {
-	if m.ValidateBidFunc != nil {
-		return m.ValidateBidFunc(ctx, auctionID, amount, bidderID)
+func (m *MockAuctionClient) AcceptBid(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error) {
+	if m.AcceptBidFunc != nil {
+		return m.AcceptBidFunc(ctx, auctionID, amount, bidderID, quantity)
 	}
-	return true, "", nil
+	return quoteAt(amount), nil
 }
 
-func (m *MockAuctionClient) UpdateAuctionPrice(ctx context.Context, auctionID string, amount float64) error {
-	if m.UpdateAuctionPriceFunc != nil {
-		return m.UpdateAuctionPriceFunc(ctx, auctionID, amount)
+func (m *MockAuctionClient) AcceptBuyNow(ctx context.Context, auctionID, buyerID, bidID string) (float64, error) {
+	if m.AcceptBuyNowFunc != nil {
+		return m.AcceptBuyNowFunc(ctx, auctionID, buyerID, bidID)
+	}
+	return 0, &domain.BidRejectedError{Reason: "BUY_NOW_UNAVAILABLE", Message: "Buy now is not available for this auction"}
+}
+
+func (m *MockAuctionClient) AcceptClockPrice(ctx context.Context, auctionID, buyerID, bidID string) (float64, error) {
+	if m.AcceptClockPriceFunc != nil {
+		return m.AcceptClockPriceFunc(ctx, auctionID, buyerID, bidID)
+	}
+	return 0, &domain.BidRejectedError{Reason: "WRONG_AUCTION_TYPE", Message: "Only dutch auctions have a clock price to accept"}
+}
+
+func (m *MockAuctionClient) IsAuctionOpen(ctx context.Context, auctionID string) (bool, error) {
+	if m.IsAuctionOpenFunc != nil {
+		return m.IsAuctionOpenFunc(ctx, auctionID)
+	}
+	return true, nil
+}
+
+func (m *MockAuctionClient) IsReverseAuction(ctx context.Context, auctionID string) (bool, error) {
+	if m.IsReverseAuctionFunc != nil {
+		return m.IsReverseAuctionFunc(ctx, auctionID)
+	}
+	return false, nil
+}
+
+func (m *MockAuctionClient) GetQuantity(ctx context.Context, auctionID string) (int, error) {
+	if m.GetQuantityFunc != nil {
+		return m.GetQuantityFunc(ctx, auctionID)
+	}
+	return 1, nil
+}
+
+func (m *MockAuctionClient) GetAuction(ctx context.Context, auctionID string) (*domain.AuctionInfo, error) {
+	if m.GetAuctionFunc != nil {
+		return m.GetAuctionFunc(ctx, auctionID)
+	}
+	return &domain.AuctionInfo{SellerID: "seller-1", Open: true, EndTime: time.Now().Add(24 * time.Hour)}, nil
+}
+
+func (m *MockAuctionClient) RestorePrice(ctx context.Context, auctionID string, retractedAmount float64) (float64, error) {
+	if m.RestorePriceFunc != nil {
+		return m.RestorePriceFunc(ctx, auctionID, retractedAmount)
+	}
+	return 0, nil
+}
+
+func (m *MockAuctionClient) ReleaseSealedBid(ctx context.Context, auctionID, bidderID string) (bool, error) {
+	if m.ReleaseSealedBidFunc != nil {
+		return m.ReleaseSealedBidFunc(ctx, auctionID, bidderID)
+	}
+	return false, nil
+}
+
+func quoteAt(price float64) *domain.PriceQuote {
+	return &domain.PriceQuote{CurrentPrice: price, MinNextBid: price + 0.01}
+}
+
+type MockProxyBidRepo struct {
+	UpsertFunc          func(ctx context.Context, proxy *domain.ProxyBid) error
+	ListByAuctionIDFunc func(ctx context.Context, auctionID string) ([]domain.ProxyBid, error)
+	DeleteFunc          func(ctx context.Context, auctionID, bidderID string) error
+}
+
+func (m *MockProxyBidRepo) Upsert(ctx context.Context, proxy *domain.ProxyBid) error {
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc(ctx, proxy)
+	}
+	return nil
+}
+func (m *MockProxyBidRepo) ListByAuctionID(ctx context.Context, auctionID string) ([]domain.ProxyBid, error) {
+	if m.ListByAuctionIDFunc != nil {
+		return m.ListByAuctionIDFunc(ctx, auctionID)
+	}
+	return nil, nil
+}
+func (m *MockProxyBidRepo) Delete(ctx context.Context, auctionID, bidderID string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, auctionID, bidderID)
 	}
 	return nil
 }
 
+type MockCompanyRepo struct {
+	MarkVerifiedFunc func(ctx context.Context, companyID string, verifiedAt time.Time) error
+	IsVerifiedFunc   func(ctx context.Context, companyID string) (bool, error)
+}
+
+func (m *MockCompanyRepo) MarkVerified(ctx context.Context, companyID string, verifiedAt time.Time) error {
+	if m.MarkVerifiedFunc != nil {
+		return m.MarkVerifiedFunc(ctx, companyID, verifiedAt)
+	}
+	return nil
+}
+func (m *MockCompanyRepo) IsVerified(ctx context.Context, companyID string) (bool, error) {
+	if m.IsVerifiedFunc != nil {
+		return m.IsVerifiedFunc(ctx, companyID)
+	}
+	return false, nil
+}
+
+type MockLogger struct{}
+
+func (m *MockLogger) Debug(msg string, fields ...zap.Field) {}
+func (m *MockLogger) Info(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) Warn(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) Error(msg string, fields ...zap.Field) {}
+func (m *MockLogger) Fatal(msg string, fields ...zap.Field) {}
+func (m *MockLogger) With(fields ...zap.Field) logger.Logger {
+	return m
+}
+func (m *MockLogger) Sync() error { return nil }
+
 // Tests
 func TestPlaceBid(t *testing.T) {
 	tests := []struct {
@@ -78,6 +230,7 @@ func TestPlaceBid(t *testing.T) {
 		auctionID     string
 		bidderID      string
 		amount        float64
+		maxAmount     float64
 		mockSetup     func(*MockBidRepo, *MockEventProducer, *MockAuctionClient)
 		expectedError bool
 	}{
@@ -87,16 +240,13 @@ func TestPlaceBid(t *testing.T) {
 			bidderID:  "user-1",
 			amount:    100.0,
 			mockSetup: func(r *MockBidRepo, e *MockEventProducer, c *MockAuctionClient) {
-				c.ValidateBidFunc = func(ctx context.Context, auctionID string, amount float64, bidderID string) (bool, string, error) {
-					return true, "valid", nil
+				c.AcceptBidFunc = func(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error) {
+					return quoteAt(amount), nil
 				}
 				r.CreateFunc = func(ctx context.Context, bid *domain.Bid) error {
 					return nil
 				}
-				c.UpdateAuctionPriceFunc = func(ctx context.Context, auctionID string, amount float64) error {
-					return nil
-				}
-				e.PublishBidPlacedFunc = func(ctx context.Context, bid *domain.Bid) error {
+				e.PublishBidPlacedFunc = func(ctx context.Context, bid *domain.Bid, placement domain.BidPlacement) error {
 					return nil
 				}
 			},
@@ -108,8 +258,98 @@ func TestPlaceBid(t *testing.T) {
 			bidderID:  "user-1",
 			amount:    50.0,
 			mockSetup: func(r *MockBidRepo, e *MockEventProducer, c *MockAuctionClient) {
-				c.ValidateBidFunc = func(ctx context.Context, auctionID string, amount float64, bidderID string) (bool, string, error) {
-					return false, "too low", nil
+				c.AcceptBidFunc = func(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error) {
+					return quoteAt(100), &domain.BidRejectedError{Reason: domain.RejectionBidTooLow, Message: "too low"}
+				}
+				r.CreateFunc = func(ctx context.Context, bid *domain.Bid) error {
+					return errors.New("rejected bid must not be stored")
+				}
+			},
+			expectedError: true,
+		},
+		{
+			name:      "Bid And Event Share Transaction",
+			auctionID: "auction-1",
+			bidderID:  "user-1",
+			amount:    100.0,
+			mockSetup: func(r *MockBidRepo, e *MockEventProducer, c *MockAuctionClient) {
+				r.CreateFunc = func(ctx context.Context, bid *domain.Bid) error {
+					if ctx.Value(txCtxKey{}) == nil {
+						return errors.New("bid stored outside transaction")
+					}
+					return nil
+				}
+				e.PublishBidPlacedFunc = func(ctx context.Context, bid *domain.Bid, placement domain.BidPlacement) error {
+					if ctx.Value(txCtxKey{}) == nil {
+						return errors.New("event written outside transaction")
+					}
+					return nil
+				}
+			},
+			expectedError: false,
+		},
+		{
+			name:      "Names Seller And Previous Leader",
+			auctionID: "auction-1",
+			bidderID:  "user-1",
+			amount:    100.0,
+			mockSetup: func(r *MockBidRepo, e *MockEventProducer, c *MockAuctionClient) {
+				c.AcceptBidFunc = func(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error) {
+					quote := quoteAt(amount)
+					quote.SellerID = "seller-1"
+					return quote, nil
+				}
+				r.GetHighestBidFunc = func(ctx context.Context, auctionID string) (*domain.Bid, error) {
+					return &domain.Bid{ID: "bid-0", BidderID: "user-2", Amount: 90}, nil
+				}
+				e.PublishBidPlacedFunc = func(ctx context.Context, bid *domain.Bid, placement domain.BidPlacement) error {
+					if placement.SellerID != "seller-1" || placement.PreviousLeaderID != "user-2" {
+						return errors.New("expected the event to name seller-1 and user-2")
+					}
+					return nil
+				}
+			},
+			expectedError: false,
+		},
+		{
+			name:      "Raising Own Lead",
+			auctionID: "auction-1",
+			bidderID:  "user-1",
+			amount:    100.0,
+			mockSetup: func(r *MockBidRepo, e *MockEventProducer, c *MockAuctionClient) {
+				r.GetHighestBidFunc = func(ctx context.Context, auctionID string) (*domain.Bid, error) {
+					return &domain.Bid{ID: "bid-0", BidderID: "user-1", Amount: 90}, nil
+				}
+				e.PublishBidPlacedFunc = func(ctx context.Context, bid *domain.Bid, placement domain.BidPlacement) error {
+					if placement.PreviousLeaderID != "" {
+						return errors.New("a bidder can't outbid themselves")
+					}
+					return nil
+				}
+			},
+			expectedError: false,
+		},
+		{
+			name:      "Outbox Error",
+			auctionID: "auction-1",
+			bidderID:  "user-1",
+			amount:    100.0,
+			mockSetup: func(r *MockBidRepo, e *MockEventProducer, c *MockAuctionClient) {
+				e.PublishBidPlacedFunc = func(ctx context.Context, bid *domain.Bid, placement domain.BidPlacement) error {
+					return errors.New("outbox insert failed")
+				}
+			},
+			expectedError: true,
+		},
+		{
+			name:      "Max Below Amount",
+			auctionID: "auction-1",
+			bidderID:  "user-1",
+			amount:    100.0,
+			maxAmount: 90.0,
+			mockSetup: func(r *MockBidRepo, e *MockEventProducer, c *MockAuctionClient) {
+				c.AcceptBidFunc = func(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error) {
+					return nil, errors.New("invalid ceiling must not reach the auction service")
 				}
 			},
 			expectedError: true,
@@ -120,8 +360,8 @@ func TestPlaceBid(t *testing.T) {
 			bidderID:  "user-1",
 			amount:    100.0,
 			mockSetup: func(r *MockBidRepo, e *MockEventProducer, c *MockAuctionClient) {
-				c.ValidateBidFunc = func(ctx context.Context, auctionID string, amount float64, bidderID string) (bool, string, error) {
-					return true, "valid", nil
+				c.AcceptBidFunc = func(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error) {
+					return quoteAt(amount), nil
 				}
 				r.CreateFunc = func(ctx context.Context, bid *domain.Bid) error {
 					return errors.New("db error")
@@ -141,8 +381,8 @@ func TestPlaceBid(t *testing.T) {
 				tt.mockSetup(repo, producer, client)
 			}
 
-			svc := NewBiddingService(repo, producer, client)
-			_, err := svc.PlaceBid(context.Background(), tt.auctionID, tt.bidderID, tt.amount)
+			svc := NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, producer, client, testSettings, &MockLogger{})
+			_, err := svc.PlaceBid(context.Background(), tt.auctionID, tt.bidderID, "", tt.amount, tt.maxAmount, 0)
 
 			if (err != nil) != tt.expectedError {
 				t.Errorf("PlaceBid() error = %v, expectedError %v", err, tt.expectedError)
@@ -151,6 +391,146 @@ func TestPlaceBid(t *testing.T) {
 	}
 }
 
+func TestBuyNow(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var winningBidID string
+		var saved, published *domain.Bid
+		client := &MockAuctionClient{
+			AcceptBuyNowFunc: func(ctx context.Context, auctionID, buyerID, bidID string) (float64, error) {
+				winningBidID = bidID
+				return 500, nil
+			},
+		}
+		repo := &MockBidRepo{
+			CreateFunc: func(ctx context.Context, bid *domain.Bid) error {
+				if ctx.Value(txCtxKey{}) == nil {
+					t.Error("expected the bid to be saved inside a transaction")
+				}
+				saved = bid
+				return nil
+			},
+		}
+		producer := &MockEventProducer{
+			PublishBidPlacedFunc: func(ctx context.Context, bid *domain.Bid, placement domain.BidPlacement) error {
+				published = bid
+				return nil
+			},
+		}
+		svc := NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, producer, client, testSettings, &MockLogger{})
+
+		bid, err := svc.BuyNow(context.Background(), "auction-1", "buyer-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if bid.ID != winningBidID || bid.Amount != 500 || bid.BidderID != "buyer-1" {
+			t.Errorf("expected bid %s at 500 by buyer-1, got %+v", winningBidID, bid)
+		}
+		if saved != bid || published != bid {
+			t.Error("expected the bid to be saved and published as bid.placed")
+		}
+	})
+
+	t.Run("Retries Save", func(t *testing.T) {
+		attempts := 0
+		client := &MockAuctionClient{
+			AcceptBuyNowFunc: func(ctx context.Context, auctionID, buyerID, bidID string) (float64, error) {
+				return 500, nil
+			},
+		}
+		repo := &MockBidRepo{
+			CreateFunc: func(ctx context.Context, bid *domain.Bid) error {
+				attempts++
+				if attempts == 1 {
+					return errors.New("connection reset")
+				}
+				return nil
+			},
+		}
+		svc := NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, client, testSettings, &MockLogger{})
+
+		// The auction is already closed with the bid as winner, so a failed save is retried
+		bid, err := svc.BuyNow(context.Background(), "auction-1", "buyer-1")
+		if err != nil || bid == nil {
+			t.Fatalf("expected the retried save to succeed, got %v", err)
+		}
+		if attempts != 2 {
+			t.Errorf("expected 2 attempts, got %d", attempts)
+		}
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		repo := &MockBidRepo{
+			CreateFunc: func(ctx context.Context, bid *domain.Bid) error {
+				t.Error("a rejected purchase must not record a bid")
+				return nil
+			},
+		}
+		svc := NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, testSettings, &MockLogger{})
+
+		_, err := svc.BuyNow(context.Background(), "auction-1", "buyer-1")
+		var rejected *domain.BidRejectedError
+		if !errors.As(err, &rejected) || rejected.Reason != "BUY_NOW_UNAVAILABLE" {
+			t.Errorf("expected BUY_NOW_UNAVAILABLE rejection, got %v", err)
+		}
+	})
+}
+
+func TestAcceptPrice(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var winningBidID string
+		var saved, published *domain.Bid
+		client := &MockAuctionClient{
+			AcceptClockPriceFunc: func(ctx context.Context, auctionID, buyerID, bidID string) (float64, error) {
+				winningBidID = bidID
+				return 85, nil
+			},
+		}
+		repo := &MockBidRepo{
+			CreateFunc: func(ctx context.Context, bid *domain.Bid) error {
+				if ctx.Value(txCtxKey{}) == nil {
+					t.Error("expected the bid to be saved inside a transaction")
+				}
+				saved = bid
+				return nil
+			},
+		}
+		producer := &MockEventProducer{
+			PublishBidPlacedFunc: func(ctx context.Context, bid *domain.Bid, placement domain.BidPlacement) error {
+				published = bid
+				return nil
+			},
+		}
+		svc := NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, producer, client, testSettings, &MockLogger{})
+
+		bid, err := svc.AcceptPrice(context.Background(), "auction-1", "bidder-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if bid.ID != winningBidID || bid.Amount != 85 || bid.BidderID != "bidder-1" {
+			t.Errorf("expected bid %s at 85 by bidder-1, got %+v", winningBidID, bid)
+		}
+		if saved != bid || published != bid {
+			t.Error("expected the bid to be saved and published as bid.placed")
+		}
+	})
+
+	t.Run("Not Dutch", func(t *testing.T) {
+		repo := &MockBidRepo{
+			CreateFunc: func(ctx context.Context, bid *domain.Bid) error {
+				t.Error("a rejected acceptance must not record a bid")
+				return nil
+			},
+		}
+		svc := NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, testSettings, &MockLogger{})
+
+		_, err := svc.AcceptPrice(context.Background(), "auction-1", "bidder-1")
+		var rejected *domain.BidRejectedError
+		if !errors.As(err, &rejected) || rejected.Reason != "WRONG_AUCTION_TYPE" {
+			t.Errorf("expected WRONG_AUCTION_TYPE rejection, got %v", err)
+		}
+	})
+}
+
 func TestGetBidsByAuction(t *testing.T) {
 	repo := &MockBidRepo{
 		ListByAuctionIDFunc: func(ctx context.Context, auctionID string) ([]domain.Bid, error) {
@@ -160,7 +540,7 @@ func TestGetBidsByAuction(t *testing.T) {
 			}, nil
 		},
 	}
-	svc := NewBiddingService(repo, &MockEventProducer{}, &MockAuctionClient{})
+	svc := NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, testSettings, &MockLogger{})
 
 	bids, err := svc.GetBidsByAuction(context.Background(), "auction-1")
 	if err != nil {
@@ -170,3 +550,269 @@ func TestGetBidsByAuction(t *testing.T) {
 		t.Errorf("expected 2 bids, got %d", len(bids))
 	}
 }
+
+func TestGetBidsByAuction_Sealed(t *testing.T) {
+	repo := &MockBidRepo{
+		ListByAuctionIDFunc: func(ctx context.Context, auctionID string) ([]domain.Bid, error) {
+			return []domain.Bid{
+				{ID: "1", Amount: 100, Sealed: true},
+				{ID: "2", Amount: 90, Sealed: true},
+			}, nil
+		},
+	}
+
+	t.Run("Hidden While Open", func(t *testing.T) {
+		svc := NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, &MockAuctionClient{}, testSettings, &MockLogger{})
+
+		bids, err := svc.GetBidsByAuction(context.Background(), "auction-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, b := range bids {
+			if b.Amount != 0 {
+				t.Errorf("expected sealed amount to be hidden, got %+v", b)
+			}
+		}
+	})
+
+	t.Run("Revealed After Close", func(t *testing.T) {
+		client := &MockAuctionClient{
+			IsAuctionOpenFunc: func(ctx context.Context, auctionID string) (bool, error) {
+				return false, nil
+			},
+		}
+		svc := NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, client, testSettings, &MockLogger{})
+
+		bids, err := svc.GetBidsByAuction(context.Background(), "auction-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(bids) != 2 || bids[0].Amount != 100 {
+			t.Errorf("expected amounts once the auction closed, got %+v", bids)
+		}
+	})
+}
+
+func TestPlaceBid_Sealed(t *testing.T) {
+	var saved *domain.Bid
+	repo := &MockBidRepo{
+		CreateFunc: func(ctx context.Context, bid *domain.Bid) error {
+			saved = bid
+			return nil
+		},
+		GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.Bid, error) {
+			t.Error("proxy bidding must not run on a sealed auction")
+			return nil, nil
+		},
+	}
+	proxyRepo := &MockProxyBidRepo{
+		UpsertFunc: func(ctx context.Context, proxy *domain.ProxyBid) error {
+			t.Error("a ceiling must not be stored on a sealed auction")
+			return nil
+		},
+	}
+	client := &MockAuctionClient{
+		AcceptBidFunc: func(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error) {
+			// The price of a sealed auction stays at the start price
+			return &domain.PriceQuote{CurrentPrice: 50, MinNextBid: 50, Sealed: true}, nil
+		},
+	}
+	svc := NewBiddingService(repo, proxyRepo, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, client, testSettings, &MockLogger{})
+
+	bid, err := svc.PlaceBid(context.Background(), "auction-1", "user-1", "", 80, 120, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bid.Sealed || saved == nil || !saved.Sealed || saved.Amount != 80 {
+		t.Errorf("expected a stored sealed bid of 80, got %+v", saved)
+	}
+}
+
+func TestPlaceBid_UndoesUnsavedBid(t *testing.T) {
+	failingRepo := &MockBidRepo{
+		CreateFunc: func(ctx context.Context, bid *domain.Bid) error {
+			return errors.New("connection reset")
+		},
+	}
+
+	t.Run("Restores Price", func(t *testing.T) {
+		var restoredFrom float64
+		client := &MockAuctionClient{
+			RestorePriceFunc: func(ctx context.Context, auctionID string, retractedAmount float64) (float64, error) {
+				restoredFrom = retractedAmount
+				return 90, nil
+			},
+		}
+		svc := NewBiddingService(failingRepo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, client, testSettings, &MockLogger{})
+
+		if _, err := svc.PlaceBid(context.Background(), "auction-1", "user-1", "", 100, 0, 0); err == nil {
+			t.Fatal("expected the save error")
+		}
+		if restoredFrom != 100 {
+			t.Errorf("expected the price of 100 to be restored, got %.2f", restoredFrom)
+		}
+	})
+
+	t.Run("Releases Sealed Bidder", func(t *testing.T) {
+		var released string
+		client := &MockAuctionClient{
+			AcceptBidFunc: func(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error) {
+				return &domain.PriceQuote{CurrentPrice: 50, MinNextBid: 50, Sealed: true}, nil
+			},
+			RestorePriceFunc: func(ctx context.Context, auctionID string, retractedAmount float64) (float64, error) {
+				t.Error("a sealed auction has no price to restore")
+				return 0, nil
+			},
+			ReleaseSealedBidFunc: func(ctx context.Context, auctionID, bidderID string) (bool, error) {
+				released = bidderID
+				return true, nil
+			},
+		}
+		svc := NewBiddingService(failingRepo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, client, testSettings, &MockLogger{})
+
+		if _, err := svc.PlaceBid(context.Background(), "auction-1", "user-1", "", 80, 0, 0); err == nil {
+			t.Fatal("expected the save error")
+		}
+		if released != "user-1" {
+			t.Errorf("expected user-1 to be released, got %q", released)
+		}
+	})
+}
+
+func TestPlaceBid_Reverse(t *testing.T) {
+	verified := map[string]bool{"company-1": true}
+	companyRepo := &MockCompanyRepo{
+		IsVerifiedFunc: func(ctx context.Context, companyID string) (bool, error) {
+			return verified[companyID], nil
+		},
+	}
+	var accepted []string
+	client := &MockAuctionClient{
+		AcceptBidFunc: func(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error) {
+			accepted = append(accepted, bidderID)
+			if auctionID != "tender" {
+				return quoteAt(amount), nil
+			}
+			return &domain.PriceQuote{CurrentPrice: amount, MaxNextBid: amount - 0.01, Reverse: true}, nil
+		},
+		IsReverseAuctionFunc: func(ctx context.Context, auctionID string) (bool, error) {
+			return auctionID == "tender", nil
+		},
+	}
+	repo := &MockBidRepo{
+		GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.Bid, error) {
+			if auctionID == "tender" {
+				t.Error("proxy bidding must not run on a reverse auction")
+			}
+			return nil, nil
+		},
+	}
+	proxyRepo := &MockProxyBidRepo{
+		UpsertFunc: func(ctx context.Context, proxy *domain.ProxyBid) error {
+			if proxy.AuctionID == "tender" {
+				t.Error("a ceiling must not be stored on a reverse auction")
+			}
+			return nil
+		},
+	}
+	svc := NewBiddingService(repo, proxyRepo, companyRepo, &MockTransactor{}, &MockEventProducer{}, client, testSettings, &MockLogger{})
+
+	tests := []struct {
+		name      string
+		auctionID string
+		bidderID  string
+		companyID string
+		wantErr   error
+	}{
+		{"Verified Company", "tender", "user-1", "company-1", nil},
+		{"Unverified Company", "tender", "user-2", "company-2", domain.ErrCompanyNotVerified},
+		{"No Company", "tender", "user-3", "", domain.ErrCompanyNotVerified},
+		{"Unverified On English Auction", "auction-1", "user-4", "company-2", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accepted = nil
+			_, err := svc.PlaceBid(context.Background(), tt.auctionID, tt.bidderID, tt.companyID, 900, 1000, 0)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PlaceBid() error = %v, want %v", err, tt.wantErr)
+			}
+			if (len(accepted) == 1) != (tt.wantErr == nil) {
+				t.Errorf("expected the auction service to be asked only for accepted bids, got %v", accepted)
+			}
+		})
+	}
+}
+
+func TestPlaceBid_MultiLot(t *testing.T) {
+	var saved *domain.Bid
+	repo := &MockBidRepo{
+		CreateFunc: func(ctx context.Context, bid *domain.Bid) error {
+			saved = bid
+			return nil
+		},
+		GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.Bid, error) {
+			t.Error("proxy bidding must not run on a multi-lot auction")
+			return nil, nil
+		},
+	}
+	proxyRepo := &MockProxyBidRepo{
+		UpsertFunc: func(ctx context.Context, proxy *domain.ProxyBid) error {
+			t.Error("a ceiling must not be stored on a multi-lot auction")
+			return nil
+		},
+	}
+	var askedFor int
+	client := &MockAuctionClient{
+		AcceptBidFunc: func(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error) {
+			askedFor = quantity
+			return &domain.PriceQuote{CurrentPrice: 10, MinNextBid: 10, MultiLot: true}, nil
+		},
+	}
+	svc := NewBiddingService(repo, proxyRepo, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, client, testSettings, &MockLogger{})
+
+	if _, err := svc.PlaceBid(context.Background(), "auction-1", "user-1", "", 25, 40, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if askedFor != 3 || saved == nil || saved.Quantity != 3 || saved.Amount != 25 {
+		t.Errorf("expected a stored bid for 3 units at 25, got %+v (asked for %d)", saved, askedFor)
+	}
+}
+
+func TestGetBidsByAuction_MultiLot(t *testing.T) {
+	now := time.Now()
+	repo := &MockBidRepo{
+		ListByAuctionIDFunc: func(ctx context.Context, auctionID string) ([]domain.Bid, error) {
+			return []domain.Bid{
+				{ID: "bid-1", BidderID: "user-1", Amount: 30, Quantity: 2, Timestamp: now},
+				{ID: "bid-2", BidderID: "user-2", Amount: 25, Quantity: 2, Timestamp: now},
+				{ID: "bid-3", BidderID: "user-3", Amount: 20, Quantity: 1, Timestamp: now},
+			}, nil
+		},
+	}
+	client := &MockAuctionClient{
+		GetQuantityFunc: func(ctx context.Context, auctionID string) (int, error) {
+			return 3, nil
+		},
+	}
+	svc := NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, &MockEventProducer{}, client, testSettings, &MockLogger{})
+
+	bids, err := svc.GetBidsByAuction(context.Background(), "auction-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]int{"bid-1": 2, "bid-2": 1, "bid-3": 0}
+	for _, b := range bids {
+		if b.Allocated != want[b.ID] {
+			t.Errorf("bid %s: allocated = %d, want %d", b.ID, b.Allocated, want[b.ID])
+		}
+	}
+
+	winners, err := svc.GetAllocation(context.Background(), "auction-1", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(winners) != 2 || winners[0].ID != "bid-1" || winners[1].ID != "bid-2" {
+		t.Errorf("expected bid-1 and bid-2 to win, got %+v", winners)
+	}
+}
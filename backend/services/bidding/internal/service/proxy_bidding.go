@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
+)
+
+// maxProxyRounds bounds how many automatic bids a single placement can trigger.
+// Each round settles one pair of ceilings, so real auctions need only a few.
+const maxProxyRounds = 20
+
+// resolveProxyBids lets hidden ceilings answer the latest bid on an auction,
+// placing automatic bids until no ceiling can beat the current leader.
+func (s *BiddingService) resolveProxyBids(ctx context.Context, auctionID string, quote domain.PriceQuote) error {
+	for round := 0; round < maxProxyRounds; round++ {
+		leader, err := s.repo.GetHighestBid(ctx, auctionID)
+		if err != nil || leader == nil {
+			return err
+		}
+
+		ceilings, err := s.proxyRepo.ListByAuctionID(ctx, auctionID)
+		if err != nil {
+			return err
+		}
+
+		bidderID, amount, ok := nextProxyBid(leader, ceilings, quote)
+		if !ok {
+			return nil
+		}
+
+		next, err := s.auctionClient.AcceptBid(ctx, auctionID, amount, bidderID, 1)
+		var rejected *domain.BidRejectedError
+		if errors.As(err, &rejected) {
+			if rejected.Reason == domain.RejectionBidTooLow && next != nil {
+				// A concurrent bid moved the price; re-evaluate against it.
+				quote = *next
+				continue
+			}
+			return nil // auction closed or gone; nothing left to defend
+		}
+		if err != nil {
+			return err
+		}
+
+		bid := &domain.Bid{
+			ID:        uuid.New().String(),
+			AuctionID: auctionID,
+			BidderID:  bidderID,
+			Amount:    amount,
+			Timestamp: time.Now(),
+			IsProxy:   true,
+			Quantity:  1,
+		}
+		if err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+			return s.saveBid(ctx, bid, next)
+		}); err != nil {
+			return err
+		}
+		quote = *next
+	}
+	return nil
+}
+
+// nextProxyBid decides the automatic bid, if any, that answers the current
+// leader. ceilings must be ordered highest first. The strongest ceiling held
+// by anyone but the leader is the challenger:
+//   - if it beats the leader's reach, the challenger takes the lead at one
+//     increment over the leader's reach, capped at the challenger's ceiling;
+//   - otherwise the leader's own ceiling defends at one increment over the
+//     challenger's, capped at the leader's ceiling. Equal ceilings go to the leader.
+func nextProxyBid(leader *domain.Bid, ceilings []domain.ProxyBid, quote domain.PriceQuote) (string, float64, bool) {
+	increment := quote.MinNextBid - quote.CurrentPrice
+
+	// The leader's reach is their ceiling, or their standing bid if they have none.
+	leaderMax := leader.Amount
+	var challenger *domain.ProxyBid
+	for i := range ceilings {
+		c := &ceilings[i]
+		if c.BidderID == leader.BidderID {
+			leaderMax = math.Max(leaderMax, c.MaxAmount)
+			continue
+		}
+		if challenger == nil {
+			challenger = c
+		}
+	}
+
+	if challenger == nil || challenger.MaxAmount < quote.MinNextBid {
+		return "", 0, false
+	}
+
+	if challenger.MaxAmount > leaderMax {
+		amount := math.Min(challenger.MaxAmount, roundCents(leaderMax+increment))
+		return challenger.BidderID, math.Max(amount, quote.MinNextBid), true
+	}
+
+	return leader.BidderID, math.Min(leaderMax, roundCents(challenger.MaxAmount+increment)), true
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
+)
+
+// memAuction plays the auction service: it accepts a bid only if it meets the minimum.
+type memAuction struct {
+	price     float64
+	increment float64
+}
+
+func (a *memAuction) AcceptBid(ctx context.Context, auctionID string, amount float64, bidderID string, quantity int) (*domain.PriceQuote, error) {
+	if amount < roundCents(a.price+a.increment) {
+		return a.quote(), &domain.BidRejectedError{Reason: domain.RejectionBidTooLow, Message: "too low"}
+	}
+	a.price = amount
+	return a.quote(), nil
+}
+
+func (a *memAuction) AcceptBuyNow(ctx context.Context, auctionID, buyerID, bidID string) (float64, error) {
+	return 0, &domain.BidRejectedError{Reason: "BUY_NOW_UNAVAILABLE", Message: "Buy now is not available for this auction"}
+}
+
+func (a *memAuction) IsAuctionOpen(ctx context.Context, auctionID string) (bool, error) {
+	return true, nil
+}
+
+func (a *memAuction) AcceptClockPrice(ctx context.Context, auctionID, buyerID, bidID string) (float64, error) {
+	return 0, &domain.BidRejectedError{Reason: "WRONG_AUCTION_TYPE", Message: "Only dutch auctions have a clock price to accept"}
+}
+
+func (a *memAuction) IsReverseAuction(ctx context.Context, auctionID string) (bool, error) {
+	return false, nil
+}
+
+func (a *memAuction) GetQuantity(ctx context.Context, auctionID string) (int, error) {
+	return 1, nil
+}
+
+func (a *memAuction) GetAuction(ctx context.Context, auctionID string) (*domain.AuctionInfo, error) {
+	return &domain.AuctionInfo{Open: true}, nil
+}
+
+func (a *memAuction) RestorePrice(ctx context.Context, auctionID string, retractedAmount float64) (float64, error) {
+	return a.price, nil
+}
+
+func (a *memAuction) ReleaseSealedBid(ctx context.Context, auctionID, bidderID string) (bool, error) {
+	return false, nil
+}
+
+func (a *memAuction) quote() *domain.PriceQuote {
+	return &domain.PriceQuote{CurrentPrice: a.price, MinNextBid: roundCents(a.price + a.increment)}
+}
+
+// memBids backs both the bid and proxy repositories with slices.
+type memBids struct {
+	bids    []domain.Bid
+	proxies []domain.ProxyBid
+}
+
+func (m *memBids) repo() *MockBidRepo {
+	return &MockBidRepo{
+		CreateFunc: func(ctx context.Context, bid *domain.Bid) error {
+			m.bids = append(m.bids, *bid)
+			return nil
+		},
+		GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.Bid, error) {
+			var top *domain.Bid
+			for i := range m.bids {
+				if top == nil || m.bids[i].Amount > top.Amount {
+					top = &m.bids[i]
+				}
+			}
+			return top, nil
+		},
+	}
+}
+
+func (m *memBids) proxyRepo() *MockProxyBidRepo {
+	return &MockProxyBidRepo{
+		UpsertFunc: func(ctx context.Context, proxy *domain.ProxyBid) error {
+			for i := range m.proxies {
+				if m.proxies[i].BidderID == proxy.BidderID {
+					if proxy.MaxAmount > m.proxies[i].MaxAmount {
+						m.proxies[i].MaxAmount = proxy.MaxAmount
+					}
+					return nil
+				}
+			}
+			m.proxies = append(m.proxies, *proxy)
+			return nil
+		},
+		ListByAuctionIDFunc: func(ctx context.Context, auctionID string) ([]domain.ProxyBid, error) {
+			out := append([]domain.ProxyBid(nil), m.proxies...)
+			sort.SliceStable(out, func(i, j int) bool { return out[i].MaxAmount > out[j].MaxAmount })
+			return out, nil
+		},
+	}
+}
+
+func (m *memBids) last() domain.Bid {
+	return m.bids[len(m.bids)-1]
+}
+
+func TestPlaceBid_ProxyBidding(t *testing.T) {
+	ctx := context.Background()
+	auction := &memAuction{price: 50, increment: 1}
+	store := &memBids{}
+	var published []domain.Bid
+	producer := &MockEventProducer{
+		PublishBidPlacedFunc: func(ctx context.Context, bid *domain.Bid, placement domain.BidPlacement) error {
+			published = append(published, *bid)
+			return nil
+		},
+	}
+	svc := NewBiddingService(store.repo(), store.proxyRepo(), &MockCompanyRepo{}, &MockTransactor{}, producer, auction, testSettings, &MockLogger{})
+
+	// Alice opens at 60 with a hidden ceiling of 150.
+	if _, err := svc.PlaceBid(ctx, "auction-1", "alice", "", 60, 150, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.bids) != 1 {
+		t.Fatalf("expected no proxy bid without competition, got %+v", store.bids)
+	}
+
+	// Bob bids 100: Alice's proxy defends one increment above.
+	if _, err := svc.PlaceBid(ctx, "auction-1", "bob", "", 100, 0, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := store.last(); got.BidderID != "alice" || got.Amount != 101 || !got.IsProxy {
+		t.Errorf("expected alice proxy bid at 101, got %+v", got)
+	}
+
+	// Carol bids 110 with a 300 ceiling: she leads, and her proxy answers
+	// Alice's ceiling at 151 rather than revealing 300.
+	if _, err := svc.PlaceBid(ctx, "auction-1", "carol", "", 110, 300, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := store.last(); got.BidderID != "carol" || got.Amount != 151 || !got.IsProxy {
+		t.Errorf("expected carol proxy bid at 151, got %+v", got)
+	}
+	if auction.price != 151 {
+		t.Errorf("expected auction price 151, got %v", auction.price)
+	}
+
+	// Every stored bid, proxy or not, was emitted as bid.placed.
+	if len(published) != len(store.bids) {
+		t.Errorf("expected %d bid.placed events, got %d", len(store.bids), len(published))
+	}
+}
+
+func TestNextProxyBid(t *testing.T) {
+	quote := domain.PriceQuote{CurrentPrice: 100, MinNextBid: 101}
+	leader := &domain.Bid{BidderID: "leader", Amount: 100}
+
+	tests := []struct {
+		name       string
+		ceilings   []domain.ProxyBid
+		wantOK     bool
+		wantBidder string
+		wantAmount float64
+	}{
+		{
+			name:     "No Ceilings",
+			ceilings: nil,
+		},
+		{
+			name:     "Only Leader Has Ceiling",
+			ceilings: []domain.ProxyBid{{BidderID: "leader", MaxAmount: 200}},
+		},
+		{
+			name:     "Challenger Below Minimum",
+			ceilings: []domain.ProxyBid{{BidderID: "other", MaxAmount: 100.5}},
+		},
+		{
+			name:       "Challenger Takes Lead",
+			ceilings:   []domain.ProxyBid{{BidderID: "other", MaxAmount: 200}},
+			wantOK:     true,
+			wantBidder: "other",
+			wantAmount: 101,
+		},
+		{
+			name:       "Challenger Capped At Own Ceiling",
+			ceilings:   []domain.ProxyBid{{BidderID: "other", MaxAmount: 150.5}, {BidderID: "leader", MaxAmount: 150}},
+			wantOK:     true,
+			wantBidder: "other",
+			wantAmount: 150.5,
+		},
+		{
+			name:       "Leader Defends",
+			ceilings:   []domain.ProxyBid{{BidderID: "leader", MaxAmount: 200}, {BidderID: "other", MaxAmount: 120}},
+			wantOK:     true,
+			wantBidder: "leader",
+			wantAmount: 121,
+		},
+		{
+			name:       "Tie Goes To Leader",
+			ceilings:   []domain.ProxyBid{{BidderID: "leader", MaxAmount: 120}, {BidderID: "other", MaxAmount: 120}},
+			wantOK:     true,
+			wantBidder: "leader",
+			wantAmount: 120,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bidder, amount, ok := nextProxyBid(leader, tt.ceilings, quote)
+			if ok != tt.wantOK || bidder != tt.wantBidder || amount != tt.wantAmount {
+				t.Errorf("nextProxyBid() = (%q, %v, %v), want (%q, %v, %v)", bidder, amount, ok, tt.wantBidder, tt.wantAmount, tt.wantOK)
+			}
+		})
+	}
+}
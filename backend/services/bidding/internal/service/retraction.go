@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
+	"go.uber.org/zap"
+)
+
+// RetractBid withdraws bidderID's own bid. Bids can be retracted while the auction is
+// open, but not on sealed auctions, where every bid is binding, and not within the
+// RetractionCutoff before the auction ends.
+func (s *BiddingService) RetractBid(ctx context.Context, bidID, bidderID, reason string) (*domain.Bid, error) {
+	bid, err := s.repo.GetByID(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+	if bid.BidderID != bidderID {
+		return nil, domain.ErrNotBidOwner
+	}
+	if bid.Retracted {
+		return nil, domain.ErrBidRetracted
+	}
+
+	auction, err := s.auctionClient.GetAuction(ctx, bid.AuctionID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case !auction.Open:
+		return nil, domain.ErrAuctionNotOpen
+	case auction.Sealed:
+		return nil, domain.ErrSealedRetraction
+	case time.Until(auction.EndTime) < s.settings.RetractionCutoff:
+		return nil, domain.ErrRetractionTooLate
+	}
+
+	return s.withdraw(ctx, bid, auction, &domain.BidRetraction{RetractedBy: bidderID, Reason: reason})
+}
+
+// CancelBid withdraws a bid on behalf of the auction's seller, or of an admin if admin
+// is set. Unlike a retraction it is allowed on any open auction up to its end, and it
+// needs a reason, which is passed on to the bidder.
+func (s *BiddingService) CancelBid(ctx context.Context, bidID, actorID string, admin bool, reason string) (*domain.Bid, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, domain.ErrReasonRequired
+	}
+
+	bid, err := s.repo.GetByID(ctx, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	auction, err := s.auctionClient.GetAuction(ctx, bid.AuctionID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case !admin && auction.SellerID != actorID:
+		return nil, domain.ErrNotAuctionSeller
+	case bid.Retracted:
+		return nil, domain.ErrBidRetracted
+	case !auction.Open:
+		return nil, domain.ErrAuctionNotOpen
+	}
+
+	return s.withdraw(ctx, bid, auction, &domain.BidRetraction{RetractedBy: actorID, Cancelled: true, Reason: reason})
+}
+
+// withdraw marks bid retracted and drops the bidder's proxy ceiling, which would
+// otherwise bid straight back in, in one transaction with the audit row and the
+// bid.retracted event. Whether the bid was leading, and who leads after it, is read in
+// that transaction too. The auction service then moves the price back to the new
+// leader; the retraction already stands, so a failure there is only logged.
+func (s *BiddingService) withdraw(ctx context.Context, bid *domain.Bid, auction *domain.AuctionInfo, retraction *domain.BidRetraction) (*domain.Bid, error) {
+	retraction.BidID = bid.ID
+	retraction.AuctionID = bid.AuctionID
+	retraction.BidderID = bid.BidderID
+	retraction.Amount = bid.Amount
+	retraction.Sealed = bid.Sealed
+	retraction.Timestamp = time.Now()
+
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		leader, err := s.leadingBid(ctx, bid.AuctionID, auction)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.Retract(ctx, retraction); err != nil {
+			return err
+		}
+		if err := s.proxyRepo.Delete(ctx, bid.AuctionID, bid.BidderID); err != nil {
+			return err
+		}
+
+		if leader != nil && leader.ID == bid.ID {
+			retraction.WasLeading = true
+			next, err := s.leadingBid(ctx, bid.AuctionID, auction)
+			if err != nil {
+				return err
+			}
+			if next != nil {
+				retraction.LeaderID, retraction.LeadingAmount = next.BidderID, next.Amount
+			}
+		}
+		return s.eventProducer.PublishBidRetracted(ctx, retraction)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bid.Retracted = true
+	if bid.Sealed {
+		// The auction is still open, so the amount stays hidden even from the seller
+		bid.Amount = 0
+	}
+
+	if retraction.WasLeading {
+		if _, err := s.auctionClient.RestorePrice(ctx, bid.AuctionID, retraction.Amount); err != nil {
+			s.log.Error("failed to restore auction price after retraction", zap.Error(err), zap.String("auction_id", bid.AuctionID), zap.String("bid_id", bid.ID))
+		}
+	}
+
+	return bid, nil
+}
+
+// leadingBid returns the live bid that sets the price of the auction: the highest, or
+// the lowest in a reverse auction. Sealed and multi-lot auctions have no running price,
+// so no bid leads them.
+func (s *BiddingService) leadingBid(ctx context.Context, auctionID string, auction *domain.AuctionInfo) (*domain.Bid, error) {
+	if auction.Sealed || auction.MultiLot {
+		return nil, nil
+	}
+	if !auction.Reverse {
+		return s.repo.GetHighestBid(ctx, auctionID)
+	}
+
+	bids, err := s.repo.GetTopBids(ctx, auctionID, 1, true)
+	if err != nil || len(bids) == 0 {
+		return nil, err
+	}
+	return &bids[0], nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/services/bidding/internal/domain"
+)
+
+func TestRetractBid(t *testing.T) {
+	ctx := context.Background()
+	ownBid := &domain.Bid{ID: "bid-1", AuctionID: "auction-1", BidderID: "user-1", Amount: 150}
+
+	tests := []struct {
+		name        string
+		bid         *domain.Bid
+		bidderID    string
+		auction     *domain.AuctionInfo
+		wantErr     error
+		wantLeading bool
+	}{
+		{
+			name:        "Leading Bid",
+			bid:         ownBid,
+			bidderID:    "user-1",
+			auction:     &domain.AuctionInfo{Open: true, EndTime: time.Now().Add(24 * time.Hour)},
+			wantLeading: true,
+		},
+		{
+			name:     "Not Owner",
+			bid:      ownBid,
+			bidderID: "user-2",
+			auction:  &domain.AuctionInfo{Open: true, EndTime: time.Now().Add(24 * time.Hour)},
+			wantErr:  domain.ErrNotBidOwner,
+		},
+		{
+			name:     "Already Retracted",
+			bid:      &domain.Bid{ID: "bid-1", AuctionID: "auction-1", BidderID: "user-1", Amount: 150, Retracted: true},
+			bidderID: "user-1",
+			auction:  &domain.AuctionInfo{Open: true, EndTime: time.Now().Add(24 * time.Hour)},
+			wantErr:  domain.ErrBidRetracted,
+		},
+		{
+			name:     "Auction Closed",
+			bid:      ownBid,
+			bidderID: "user-1",
+			auction:  &domain.AuctionInfo{Open: false, EndTime: time.Now().Add(-time.Hour)},
+			wantErr:  domain.ErrAuctionNotOpen,
+		},
+		{
+			name:     "Sealed Auction",
+			bid:      ownBid,
+			bidderID: "user-1",
+			auction:  &domain.AuctionInfo{Open: true, Sealed: true, EndTime: time.Now().Add(24 * time.Hour)},
+			wantErr:  domain.ErrSealedRetraction,
+		},
+		{
+			name:     "Within Final Hour",
+			bid:      ownBid,
+			bidderID: "user-1",
+			auction:  &domain.AuctionInfo{Open: true, EndTime: time.Now().Add(30 * time.Minute)},
+			wantErr:  domain.ErrRetractionTooLate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bid := *tt.bid
+			retracted := false
+			var restored []float64
+			var published *domain.BidRetraction
+
+			repo := &MockBidRepo{
+				GetByIDFunc: func(ctx context.Context, id string) (*domain.Bid, error) {
+					return &bid, nil
+				},
+				GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.Bid, error) {
+					if !retracted {
+						return &domain.Bid{ID: "bid-1", BidderID: "user-1", Amount: 150}, nil
+					}
+					return &domain.Bid{ID: "bid-0", BidderID: "user-3", Amount: 120}, nil
+				},
+				RetractFunc: func(ctx context.Context, retraction *domain.BidRetraction) error {
+					if ctx.Value(txCtxKey{}) == nil {
+						t.Error("expected the retraction to be stored inside the transaction")
+					}
+					retracted = true
+					return nil
+				},
+			}
+			deleted := false
+			proxyRepo := &MockProxyBidRepo{
+				DeleteFunc: func(ctx context.Context, auctionID, bidderID string) error {
+					deleted = bidderID == "user-1"
+					return nil
+				},
+			}
+			producer := &MockEventProducer{
+				PublishBidRetractedFunc: func(ctx context.Context, retraction *domain.BidRetraction) error {
+					published = retraction
+					return nil
+				},
+			}
+			auctionClient := &MockAuctionClient{
+				GetAuctionFunc: func(ctx context.Context, auctionID string) (*domain.AuctionInfo, error) {
+					return tt.auction, nil
+				},
+				RestorePriceFunc: func(ctx context.Context, auctionID string, retractedAmount float64) (float64, error) {
+					restored = append(restored, retractedAmount)
+					return 120, nil
+				},
+			}
+
+			svc := NewBiddingService(repo, proxyRepo, &MockCompanyRepo{}, &MockTransactor{}, producer, auctionClient, testSettings, &MockLogger{})
+			got, err := svc.RetractBid(ctx, "bid-1", tt.bidderID, "typo")
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+				if retracted || published != nil {
+					t.Error("expected nothing to be retracted or published")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Retracted {
+				t.Error("expected the returned bid to be marked retracted")
+			}
+			if !deleted {
+				t.Error("expected the bidder's proxy ceiling to be dropped")
+			}
+			if published == nil || published.Cancelled || published.RetractedBy != "user-1" || published.Reason != "typo" {
+				t.Fatalf("expected a bid.retracted event from the bidder, got %+v", published)
+			}
+			if published.WasLeading != tt.wantLeading || published.LeaderID != "user-3" || published.LeadingAmount != 120 {
+				t.Errorf("expected user-3 to lead at 120 after the retraction, got %+v", published)
+			}
+			if len(restored) != 1 || restored[0] != 150 {
+				t.Errorf("expected the price to be restored from 150, got %v", restored)
+			}
+		})
+	}
+}
+
+func TestCancelBid(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		actorID string
+		admin   bool
+		reason  string
+		sealed  bool
+		wantErr error
+	}{
+		{name: "Seller", actorID: "seller-1", reason: "shill bidding"},
+		{name: "Admin", actorID: "admin-1", admin: true, reason: "fraud"},
+		{name: "Sealed Bid By Seller", actorID: "seller-1", reason: "duplicate account", sealed: true},
+		{name: "Other User", actorID: "user-2", reason: "no", wantErr: domain.ErrNotAuctionSeller},
+		{name: "No Reason", actorID: "seller-1", reason: "  ", wantErr: domain.ErrReasonRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var published *domain.BidRetraction
+			restored := false
+
+			repo := &MockBidRepo{
+				GetByIDFunc: func(ctx context.Context, id string) (*domain.Bid, error) {
+					return &domain.Bid{ID: "bid-1", AuctionID: "auction-1", BidderID: "user-1", Amount: 150, Sealed: tt.sealed}, nil
+				},
+				GetHighestBidFunc: func(ctx context.Context, auctionID string) (*domain.Bid, error) {
+					return &domain.Bid{ID: "bid-0", BidderID: "user-3", Amount: 200}, nil
+				},
+			}
+			producer := &MockEventProducer{
+				PublishBidRetractedFunc: func(ctx context.Context, retraction *domain.BidRetraction) error {
+					published = retraction
+					return nil
+				},
+			}
+			auctionClient := &MockAuctionClient{
+				GetAuctionFunc: func(ctx context.Context, auctionID string) (*domain.AuctionInfo, error) {
+					// Past the retraction cutoff, which doesn't apply to cancellations
+					return &domain.AuctionInfo{SellerID: "seller-1", Open: true, Sealed: tt.sealed, EndTime: time.Now().Add(time.Minute)}, nil
+				},
+				RestorePriceFunc: func(ctx context.Context, auctionID string, retractedAmount float64) (float64, error) {
+					restored = true
+					return 0, nil
+				},
+			}
+
+			svc := NewBiddingService(repo, &MockProxyBidRepo{}, &MockCompanyRepo{}, &MockTransactor{}, producer, auctionClient, testSettings, &MockLogger{})
+			got, err := svc.CancelBid(ctx, "bid-1", tt.actorID, tt.admin, tt.reason)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("expected %v, got %v", tt.wantErr, err)
+				}
+				if published != nil {
+					t.Error("expected no bid.retracted event")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if published == nil || !published.Cancelled || published.RetractedBy != tt.actorID || published.Reason != tt.reason {
+				t.Fatalf("expected a cancellation by %s, got %+v", tt.actorID, published)
+			}
+			// The bid wasn't leading, so the price stays where it is
+			if published.WasLeading || restored {
+				t.Errorf("expected the price to be left alone, got %+v", published)
+			}
+			if tt.sealed && got.Amount != 0 {
+				t.Errorf("expected the sealed amount to stay hidden, got %v", got.Amount)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"net"
@@ -9,6 +10,7 @@ import (
 	_ "github.com/lib/pq"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/auth"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/config"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/database"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/kafka"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
 	pb "github.com/temesgen-abebayehu/bidflow/backend/proto/pb"
@@ -56,14 +58,29 @@ func main() {
 	defer conn.Close()
 	auctionClient := service.NewAuctionClient(conn)
 
-	// Setup Kafka
+	// Setup Kafka: events are written to the outbox with the bid rows
+	// and relayed to Kafka in the background
 	kafkaProducer := kafka.NewProducer(cfg.KafkaBrokers, log)
 	defer kafkaProducer.Close()
-	eventProducer := event.NewKafkaEventProducer(kafkaProducer)
+	eventProducer := event.NewKafkaEventProducer(kafka.NewOutbox(db))
+	tx := database.NewTransactor(db)
 
 	// Setup Layers
 	repo := repository.NewPostgresRepo(db)
-	svc := service.NewBiddingService(repo, eventProducer, auctionClient)
+	proxyRepo := repository.NewProxyBidRepo(db)
+	companyRepo := repository.NewCompanyRepo(db)
+	svc := service.NewBiddingService(repo, proxyRepo, companyRepo, tx, eventProducer, auctionClient, service.Settings{RetractionCutoff: cfg.BidRetractionCutoff}, log)
+
+	// Relay outbox events to Kafka
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	relay := kafka.NewOutboxRelay(db, kafkaProducer, cfg.OutboxRelayInterval, log)
+	relay.Start(ctx)
+
+	// Track verified companies for reverse auctions
+	kafkaConsumer := kafka.NewConsumer(cfg.KafkaBrokers, []string{event.TopicCompanyVerified}, "bidding-service-group", log)
+	defer kafkaConsumer.Close()
+	event.NewCompanyConsumer(kafkaConsumer, companyRepo, log).Start(ctx)
 
 	// Handlers
 	httpHandler := handler.NewHttpHandler(svc)
@@ -87,7 +104,12 @@ func main() {
 	}()
 
 	// Start HTTP server
-	tm := auth.NewTokenManager(cfg.JWTSecret)
+	tm := auth.NewVerifier(ctx, cfg.JWKSURL, cfg.JWKSRefreshInterval, cfg.JWTSecret, log)
+	// Reject revoked tokens as the auth service revokes them
+	denylist := auth.NewDenylist()
+	tm.UseDenylist(denylist)
+	revocations := auth.WatchRevocations(ctx, cfg.KafkaBrokers, denylist, log)
+	defer revocations.Close()
 	r := handler.SetupRouter(httpHandler, tm)
 
 	log.Info("Bidding HTTP Service starting on port " + cfg.HTTPPort)
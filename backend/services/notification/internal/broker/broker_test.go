@@ -0,0 +1,77 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+type MockLogger struct{}
+
+func (m *MockLogger) Debug(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) Info(msg string, fields ...zap.Field)   {}
+func (m *MockLogger) Warn(msg string, fields ...zap.Field)   {}
+func (m *MockLogger) Error(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) Fatal(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) With(fields ...zap.Field) logger.Logger { return m }
+func (m *MockLogger) Sync() error                            { return nil }
+
+func TestPostgres_Publish(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	b := NewPostgres(db, "", &MockLogger{})
+	msg := &domain.HubMessage{Origin: "instance-a", UserIDs: []string{"user-1"}, Payload: json.RawMessage(`{"id":"n-1"}`), SentAt: time.Now()}
+	payload, _ := json.Marshal(msg)
+
+	mock.ExpectExec("SELECT pg_notify\\(\\$1, \\$2\\)").
+		WithArgs(pgChannel, string(payload)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, b.Publish(context.Background(), msg))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgres_PublishTooLarge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	b := NewPostgres(db, "", &MockLogger{})
+	big := `"` + strings.Repeat("x", pgMaxPayload) + `"`
+	msg := &domain.HubMessage{Origin: "instance-a", Payload: json.RawMessage(big)}
+
+	assert.ErrorIs(t, b.Publish(context.Background(), msg), domain.ErrMessageTooLarge)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+type recordingPublisher struct {
+	topic, key string
+	message    interface{}
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, topic string, key string, message interface{}) error {
+	p.topic, p.key, p.message = topic, key, message
+	return nil
+}
+
+func TestKafka_Publish(t *testing.T) {
+	publisher := &recordingPublisher{}
+	b := NewKafka(publisher, nil, "instance-a", &MockLogger{})
+	msg := &domain.HubMessage{Origin: "instance-a", All: true}
+
+	assert.NoError(t, b.Publish(context.Background(), msg))
+	assert.Equal(t, TopicHub, publisher.topic)
+	assert.Equal(t, "instance-a", publisher.key)
+	assert.Same(t, msg, publisher.message)
+}
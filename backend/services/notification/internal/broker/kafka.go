@@ -0,0 +1,48 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/common/kafka"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+// TopicHub carries hub messages between the instances.
+const TopicHub = "notification.hub"
+
+type kafkaBroker struct {
+	publisher  kafka.Publisher
+	brokers    []string
+	instanceID string
+	log        logger.Logger
+}
+
+// NewKafka returns a Broker using the TopicHub topic. Each instance reads it in a
+// consumer group of its own, so that every instance sees every message, starting from
+// the messages published once it is up.
+func NewKafka(publisher kafka.Publisher, brokers []string, instanceID string, log logger.Logger) domain.Broker {
+	return &kafkaBroker{publisher: publisher, brokers: brokers, instanceID: instanceID, log: log}
+}
+
+func (b *kafkaBroker) Publish(ctx context.Context, msg *domain.HubMessage) error {
+	return b.publisher.Publish(ctx, TopicHub, msg.Origin, msg)
+}
+
+func (b *kafkaBroker) Subscribe(ctx context.Context, handle func(*domain.HubMessage)) error {
+	consumer := kafka.NewTailConsumer(b.brokers, []string{TopicHub}, "notification-hub-"+b.instanceID, b.log)
+	consumer.Start(ctx, func(ctx context.Context, topic string, key, value []byte) error {
+		var msg domain.HubMessage
+		if err := json.Unmarshal(value, &msg); err != nil {
+			b.log.Error("Failed to decode hub message", zap.Error(err))
+			return nil
+		}
+		handle(&msg)
+		return nil
+	})
+
+	<-ctx.Done()
+	return consumer.Close()
+}
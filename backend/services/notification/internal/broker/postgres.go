@@ -0,0 +1,91 @@
+package broker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+const (
+	// The channel hub messages are sent on with NOTIFY.
+	pgChannel = "notification_hub"
+
+	// NOTIFY payloads must be shorter than 8000 bytes.
+	pgMaxPayload = 7999
+
+	// How often the listening connection is checked while idle.
+	pgPingInterval = 90 * time.Second
+)
+
+type postgresBroker struct {
+	db  *sql.DB
+	dsn string
+	log logger.Logger
+}
+
+// NewPostgres returns a Broker using Postgres LISTEN/NOTIFY. Messages are sent over db;
+// listening takes a connection of its own to dsn. Payloads over 8000 bytes are
+// rejected with domain.ErrMessageTooLarge.
+func NewPostgres(db *sql.DB, dsn string, log logger.Logger) domain.Broker {
+	return &postgresBroker{db: db, dsn: dsn, log: log}
+}
+
+func (b *postgresBroker) Publish(ctx context.Context, msg *domain.HubMessage) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(payload) > pgMaxPayload {
+		return domain.ErrMessageTooLarge
+	}
+
+	_, err = b.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, pgChannel, string(payload))
+	return err
+}
+
+func (b *postgresBroker) Subscribe(ctx context.Context, handle func(*domain.HubMessage)) error {
+	listener := pq.NewListener(b.dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			b.log.Error("Hub listener connection failed", zap.Error(err))
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(pgChannel); err != nil {
+		return err
+	}
+
+	ping := time.NewTicker(pgPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case n := <-listener.Notify:
+			if n == nil {
+				// NOTIFYs sent while the connection was down are gone
+				b.log.Warn("Hub listener reconnected; messages may have been missed")
+				continue
+			}
+			var msg domain.HubMessage
+			if err := json.Unmarshal([]byte(n.Extra), &msg); err != nil {
+				b.log.Error("Failed to decode hub message", zap.Error(err))
+				continue
+			}
+			handle(&msg)
+
+		case <-ping.C:
+			if err := listener.Ping(); err != nil {
+				b.log.Warn("Hub listener ping failed", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// MessageTypeAuctionPriceTick tags PriceTick messages on the WebSocket.
+const MessageTypeAuctionPriceTick = "auction.price_tick"
+
+// PriceTick announces a Dutch auction's clock price to the clients following it, so
+// they can follow the price without polling. NextTickAt is absent once the price
+// has stopped dropping.
+type PriceTick struct {
+	Type       string     `json:"type"`
+	AuctionID  string     `json:"auction_id"`
+	Price      float64    `json:"price"`
+	NextTickAt *time.Time `json:"next_tick_at,omitempty"`
+}
+
+// DutchClock is the price schedule of a Dutch auction, as announced in auction.created.
+// The auction service computes the price the same way, so the ticks pushed here match
+// what GetAuctionStatus reports.
+type DutchClock struct {
+	AuctionID  string
+	StartPrice float64
+	Step       float64
+	Interval   time.Duration
+	FloorPrice float64
+	StartTime  time.Time
+	EndTime    time.Time
+}
+
+// Price is the clock price at now: StartPrice less Step for every full Interval since
+// StartTime, never below FloorPrice.
+func (c *DutchClock) Price(now time.Time) float64 {
+	price := c.StartPrice - float64(c.ticks(now))*c.Step
+	return math.Max(math.Round(price*100)/100, c.FloorPrice)
+}
+
+// NextTick returns when the price next drops, and false once it has reached the floor
+// or the next drop would come at or after EndTime.
+func (c *DutchClock) NextTick(now time.Time) (time.Time, bool) {
+	if c.Interval <= 0 || c.Price(now) <= c.FloorPrice {
+		return time.Time{}, false
+	}
+	next := c.StartTime.Add(time.Duration(c.ticks(now)+1) * c.Interval)
+	if !next.Before(c.EndTime) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func (c *DutchClock) ticks(now time.Time) int64 {
+	if c.Interval <= 0 || now.Before(c.StartTime) {
+		return 0
+	}
+	return int64(now.Sub(c.StartTime) / c.Interval)
+}
+
+// PriceTicker pushes the clock price of running Dutch auctions to viewers.
+type PriceTicker interface {
+	// Start pushes the current price and then every drop until the clock stops.
+	Start(clock *DutchClock)
+	// Stop ends the auction's ticks, e.g. once its price has been accepted.
+	Stop(auctionID string)
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// ErrMessageTooLarge is returned by a Broker that can't carry a message of that size.
+var ErrMessageTooLarge = errors.New("hub message too large for the broker")
+
+// HubMessage is a WebSocket push one instance hands to the others, to deliver to the
+// clients connected to them. It reaches the connections of UserIDs and, if AuctionID
+// is set, the auction's followers; or every connection if All is set.
+type HubMessage struct {
+	Origin    string   `json:"origin"` // ID of the publishing instance
+	UserIDs   []string `json:"user_ids,omitempty"`
+	AuctionID string   `json:"auction_id,omitempty"`
+	All       bool     `json:"all,omitempty"`
+	// Resume disconnects the users' connections with a close code asking them to
+	// resume, in place of a Payload the broker couldn't carry.
+	Resume bool `json:"resume,omitempty"`
+	// Seq is the notification's seq, if the payload is a notification.
+	Seq     int64           `json:"seq,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+	SentAt  time.Time       `json:"sent_at"`
+}
+
+// Broker carries HubMessages between the instances of the service.
+type Broker interface {
+	Publish(ctx context.Context, msg *HubMessage) error
+	// Subscribe calls handle with every message published by any instance, this one
+	// included, until ctx is done.
+	Subscribe(ctx context.Context, handle func(*HubMessage)) error
+}
+
+// PresenceRepository tracks which instances hold connections of which users.
+type PresenceRepository interface {
+	// Join records that the instance holds a connection of the user.
+	Join(ctx context.Context, instanceID, userID string, at time.Time) error
+	// Sync replaces the users recorded for the instance with userIDs.
+	Sync(ctx context.Context, instanceID string, userIDs []string, at time.Time) error
+	// Instances returns the instances that held a connection of any of userIDs at
+	// since or later.
+	Instances(ctx context.Context, userIDs []string, since time.Time) ([]string, error)
+	// Sweep forgets whatever was last recorded before before.
+	Sweep(ctx context.Context, before time.Time) error
+}
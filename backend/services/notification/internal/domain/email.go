@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrNoEmailAddress = errors.New("no email address on file")
+
+// EmailStatus is where a queued email is in its delivery.
+type EmailStatus string
+
+const (
+	EmailStatusPending EmailStatus = "PENDING"
+	EmailStatusSent    EmailStatus = "SENT"
+	EmailStatusFailed  EmailStatus = "FAILED" // gave up after too many attempts
+)
+
+// Email is a notification queued for delivery by email. Emails of users on a digest
+// are sent together, in one message per user, once they fall due.
+type Email struct {
+	ID           int64
+	Recipient    string
+	Notification Notification
+	Digest       DigestFrequency
+	Status       EmailStatus
+	Attempts     int
+	LastError    string
+	SendAfter    time.Time
+}
+
+// EmailMessage is a rendered email.
+type EmailMessage struct {
+	To      string
+	Subject string
+	HTML    string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, msg *EmailMessage) error
+}
+
+// ContactRepository holds users' email addresses, learned from user.registered.
+type ContactRepository interface {
+	SaveEmail(ctx context.Context, userID, email string) error
+	// GetEmail returns "" if there is no address for the user.
+	GetEmail(ctx context.Context, userID string) (string, error)
+}
+
+type EmailQueue interface {
+	Enqueue(ctx context.Context, email *Email) error
+	// Claim returns up to limit pending emails due at now, and keeps other workers
+	// from claiming them until lease has passed.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Email, error)
+	MarkSent(ctx context.Context, ids []int64, at time.Time) error
+	// Retry records a failed attempt and schedules the next one.
+	Retry(ctx context.Context, ids []int64, lastError string, at time.Time) error
+	// MarkFailed records a final failed attempt.
+	MarkFailed(ctx context.Context, ids []int64, lastError string) error
+}
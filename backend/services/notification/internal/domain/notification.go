@@ -2,18 +2,56 @@ package domain
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
 	"time"
 )
 
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrInvalidCursor        = errors.New("invalid cursor")
+)
+
 type NotificationType string
 
 const (
-	NotificationTypeAuctionCreated NotificationType = "AUCTION_CREATED"
-	NotificationTypeBidPlaced      NotificationType = "BID_PLACED"
-	NotificationTypeAuctionClosed  NotificationType = "AUCTION_CLOSED"
-	NotificationTypeOutbid         NotificationType = "OUTBID"
+	NotificationTypeAuctionCreated  NotificationType = "AUCTION_CREATED"
+	NotificationTypeBidPlaced       NotificationType = "BID_PLACED"
+	NotificationTypeAuctionClosed   NotificationType = "AUCTION_CLOSED" // to the seller
+	NotificationTypeAuctionWon      NotificationType = "AUCTION_WON"
+	NotificationTypeAuctionLost     NotificationType = "AUCTION_LOST"
+	NotificationTypeOutbid          NotificationType = "OUTBID"
+	NotificationTypeNewBid          NotificationType = "NEW_BID" // to the seller
+	NotificationTypeCompanyVerified NotificationType = "COMPANY_VERIFIED"
+	NotificationTypeBidRetracted    NotificationType = "BID_RETRACTED"
+	NotificationTypeWelcome         NotificationType = "WELCOME"
 )
 
+// NotificationTypes lists every type of notification, for validating preferences.
+var NotificationTypes = []NotificationType{
+	NotificationTypeAuctionCreated,
+	NotificationTypeBidPlaced,
+	NotificationTypeAuctionClosed,
+	NotificationTypeAuctionWon,
+	NotificationTypeAuctionLost,
+	NotificationTypeOutbid,
+	NotificationTypeNewBid,
+	NotificationTypeCompanyVerified,
+	NotificationTypeBidRetracted,
+	NotificationTypeWelcome,
+}
+
+// Valid reports whether t is one of NotificationTypes.
+func (t NotificationType) Valid() bool {
+	for _, known := range NotificationTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
 type Notification struct {
 	ID         string           `json:"id"`
 	UserID     string           `json:"user_id"`
@@ -23,27 +61,228 @@ type Notification struct {
 	ResourceID string           `json:"resource_id"` // e.g., AuctionID
 	IsRead     bool             `json:"is_read"`
 	CreatedAt  time.Time        `json:"created_at"`
+	// Seq orders the notifications pushed to a user. It increases with every one, so a
+	// client that reconnects can ask for those after the last it saw.
+	Seq int64 `json:"seq,omitempty"`
+}
+
+// Cursor is a position in a user's notifications, which are listed newest first. The
+// page after it holds the notifications created before CreatedAt, or at CreatedAt with
+// a smaller ID.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// String encodes the cursor for clients, which should treat it as opaque.
+func (c Cursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(c.CreatedAt.UTC().Format(time.RFC3339Nano) + "|" + c.ID))
+}
+
+// ParseCursor decodes a cursor returned by Cursor.String.
+func ParseCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return nil, ErrInvalidCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	return &Cursor{CreatedAt: t, ID: id}, nil
+}
+
+// NotificationPage is one page of a user's notifications. NextCursor is empty on the
+// last page.
+type NotificationPage struct {
+	Notifications []Notification `json:"notifications"`
+	NextCursor    string         `json:"next_cursor,omitempty"`
+}
+
+// MessageTypeUnreadCount tags UnreadCount messages on the WebSocket.
+const MessageTypeUnreadCount = "notification.unread_count"
+
+// UnreadCount is pushed to every connection of a user whenever their number of unread
+// notifications changes.
+type UnreadCount struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+// MessageTypeAuctionExtended tags AuctionExtended messages on the WebSocket.
+const MessageTypeAuctionExtended = "auction.extended"
+
+// AuctionExtended tells an auction's watchers that a late bid pushed its end time back.
+// It is pushed live over the hub and not stored as a notification.
+type AuctionExtended struct {
+	Type           string    `json:"type"`
+	AuctionID      string    `json:"auction_id"`
+	EndTime        time.Time `json:"end_time"`
+	ExtensionCount int       `json:"extension_count"`
+	MaxExtensions  int       `json:"max_extensions"`
+}
+
+// MessageTypeAuctionUpdated tags AuctionUpdated messages on the WebSocket.
+const MessageTypeAuctionUpdated = "auction.updated"
+
+// AuctionUpdated pushes a seller's edits to an auction's watchers. Like AuctionExtended
+// it is not stored as a notification.
+type AuctionUpdated struct {
+	Type        string `json:"type"`
+	AuctionID   string `json:"auction_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	ImageURL    string `json:"image_url"`
+}
+
+// MessageTypeAuctionBidPlaced tags AuctionBid messages on the WebSocket.
+const MessageTypeAuctionBidPlaced = "auction.bid_placed"
+
+// AuctionBid tells an auction's room that a bid was placed. It names no bidder, and
+// sealed bids carry no amount.
+type AuctionBid struct {
+	Type      string    `json:"type"`
+	AuctionID string    `json:"auction_id"`
+	BidID     string    `json:"bid_id"`
+	Amount    float64   `json:"amount,omitempty"`
+	Sealed    bool      `json:"sealed,omitempty"`
+	PlacedAt  time.Time `json:"placed_at"`
+}
+
+// MessageTypeAuctionPriceChanged tags AuctionPriceChanged messages on the WebSocket.
+const MessageTypeAuctionPriceChanged = "auction.price_changed"
+
+// AuctionPriceChanged tells an auction's room that its price moved other than by a new
+// bid, e.g. back to the next bid when the leading one was withdrawn.
+type AuctionPriceChanged struct {
+	Type      string  `json:"type"`
+	AuctionID string  `json:"auction_id"`
+	Price     float64 `json:"price"`
+}
+
+// MessageTypeAuctionClosed tags AuctionClosed messages on the WebSocket.
+const MessageTypeAuctionClosed = "auction.closed"
+
+// AuctionClosed tells an auction's room that bidding has ended. Participants also get
+// a notification with their own outcome.
+type AuctionClosed struct {
+	Type       string  `json:"type"`
+	AuctionID  string  `json:"auction_id"`
+	Status     string  `json:"status"`
+	FinalPrice float64 `json:"final_price"`
+}
+
+// Auction close statuses, as announced in auction.closed.
+const (
+	AuctionStatusClosed        = "CLOSED"
+	AuctionStatusReserveNotMet = "RESERVE_NOT_MET"
+)
+
+// AuctionOutcome is how an auction closed.
+type AuctionOutcome struct {
+	AuctionID  string
+	SellerID   string
+	Title      string
+	Status     string
+	FinalPrice float64
+	Winners    []AuctionWinner // best first; empty if nobody won
+}
+
+// AuctionWinner is a bidder who won units of a closed auction at Price each.
+type AuctionWinner struct {
+	BidderID string
+	Units    int
+	Price    float64
 }
 
 type NotificationRepository interface {
+	// Create stores the notification and sets its Seq.
 	Create(ctx context.Context, notification *Notification) error
-	ListByUserID(ctx context.Context, userID string, limit int) ([]Notification, error)
-	MarkAsRead(ctx context.Context, id string) error
+	// NextSeq hands out the user's next sequence number, for notifications pushed
+	// without being stored.
+	NextSeq(ctx context.Context, userID string) (int64, error)
+	// ListSince returns up to limit of the user's notifications after afterSeq, oldest
+	// first.
+	ListSince(ctx context.Context, userID string, afterSeq int64, limit int) ([]Notification, error)
+	// ListByUserID returns up to limit of the user's notifications, newest first,
+	// starting after the cursor if one is given.
+	ListByUserID(ctx context.Context, userID string, after *Cursor, limit int) ([]Notification, error)
+	// MarkAsRead returns ErrNotificationNotFound unless the notification belongs to userID.
+	MarkAsRead(ctx context.Context, userID, id string) error
+	MarkAllAsRead(ctx context.Context, userID string) error
+	CountUnread(ctx context.Context, userID string) (int, error)
+	// ListWatchers returns the users following an auction: everyone who has been
+	// notified about it, i.e. its seller and its bidders.
+	ListWatchers(ctx context.Context, auctionID string) ([]string, error)
+}
+
+// ParticipantRepository is a projection of who has bid on each auction, built from
+// bid.placed.
+type ParticipantRepository interface {
+	Add(ctx context.Context, auctionID, userID string, at time.Time) error
+	List(ctx context.Context, auctionID string) ([]string, error)
 }
 
 type NotificationService interface {
 	SendNotification(ctx context.Context, notification *Notification) error
-	GetUserNotifications(ctx context.Context, userID string) ([]Notification, error)
+	// GetUserNotifications returns a page of up to limit notifications, newest first;
+	// a limit of 0 picks the default page size.
+	GetUserNotifications(ctx context.Context, userID string, after *Cursor, limit int) (*NotificationPage, error)
+	// MarkAsRead, MarkAllAsRead and the arrival of new notifications push the user's new
+	// unread count to all of their connections.
+	MarkAsRead(ctx context.Context, userID, id string) error
+	MarkAllAsRead(ctx context.Context, userID string) error
+	UnreadCount(ctx context.Context, userID string) (int, error)
+	// NotificationsSince returns up to limit of the user's stored notifications after
+	// afterSeq, oldest first, for replaying to a resumed connection.
+	NotificationsSince(ctx context.Context, userID string, afterSeq int64, limit int) ([]Notification, error)
+	// NotifyAuctionExtended and NotifyAuctionUpdated push to the auction's room and its
+	// watchers.
+	NotifyAuctionExtended(ctx context.Context, extended *AuctionExtended) error
+	NotifyAuctionUpdated(ctx context.Context, updated *AuctionUpdated) error
+	// NotifyBidPlaced and NotifyPriceChanged push to the auction's room.
+	NotifyBidPlaced(ctx context.Context, bid *AuctionBid)
+	NotifyPriceChanged(ctx context.Context, changed *AuctionPriceChanged)
+	// RecordParticipant notes that userID bid on the auction, so they hear how it ends.
+	RecordParticipant(ctx context.Context, auctionID, userID string, at time.Time) error
+	// NotifyAuctionClosed tells the seller the outcome, the winners that they won and
+	// every other participant that they lost, and the auction's room that it closed.
+	NotifyAuctionClosed(ctx context.Context, outcome *AuctionOutcome) error
+	// GetPreferences returns the user's preferences, or the defaults if they have none.
+	GetPreferences(ctx context.Context, userID string) (*Preferences, error)
+	UpdatePreferences(ctx context.Context, prefs *Preferences) error
+	// InitPreferences gives a newly registered user the default preferences.
+	InitPreferences(ctx context.Context, userID string) error
+	// SaveContact stores the address the user's emails go to.
+	SaveContact(ctx context.Context, userID, email string) error
 }
 
 type Hub interface {
 	BroadcastToUser(userID string, message interface{})
+	// BroadcastToRoom pushes message to the clients following the auction and to the
+	// connections of userIDs, once each.
+	BroadcastToRoom(auctionID string, message interface{}, userIDs ...string)
+	// Broadcast pushes message to every connected client.
+	Broadcast(message interface{})
 	Run()
 	Register(client Client)
 	Unregister(client Client)
 }
 
+// Client is one connection of a user to the hub, over WebSocket or Server-Sent Events.
 type Client interface {
-	ReadPump()
-	WritePump()
+	UserID() string
+	// Send queues message for the client without blocking. It reports false if the
+	// client's buffer is full.
+	Send(message interface{}) bool
+	// Close tells the client the hub is done with it. The hub calls it once, on
+	// Unregister, and never calls Send after.
+	Close()
+	// Evict ends the connection, asking the peer to reconnect and resume from the
+	// last notification it got. It reports whether this call did so.
+	Evict() bool
 }
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidPreferences = errors.New("invalid notification preferences")
+
+// Channel is a way of delivering notifications to a user.
+type Channel string
+
+const (
+	ChannelInApp     Channel = "IN_APP" // stored and listed by GET /api/v1/notifications
+	ChannelWebSocket Channel = "WEBSOCKET"
+	ChannelEmail     Channel = "EMAIL"
+	ChannelWebhook   Channel = "WEBHOOK"
+)
+
+// ChannelSet says which channels a type of notification goes out on.
+type ChannelSet struct {
+	InApp     bool `json:"in_app"`
+	WebSocket bool `json:"websocket"`
+	Email     bool `json:"email"`
+	Webhook   bool `json:"webhook"`
+}
+
+// Enabled reports whether channel is switched on.
+func (s ChannelSet) Enabled(channel Channel) bool {
+	switch channel {
+	case ChannelInApp:
+		return s.InApp
+	case ChannelWebSocket:
+		return s.WebSocket
+	case ChannelEmail:
+		return s.Email
+	case ChannelWebhook:
+		return s.Webhook
+	}
+	return false
+}
+
+// DefaultChannels applies to every type of notification a user has not configured.
+var DefaultChannels = ChannelSet{InApp: true, WebSocket: true}
+
+// DigestFrequency is how often email notifications are sent.
+type DigestFrequency string
+
+const (
+	DigestImmediate DigestFrequency = "IMMEDIATE" // one email per notification
+	DigestHourly    DigestFrequency = "HOURLY"
+	DigestDaily     DigestFrequency = "DAILY"
+)
+
+// Next returns when an email that arrives at t is sent: right away, or with the next
+// hourly or daily (at midnight UTC) digest.
+func (d DigestFrequency) Next(t time.Time) time.Time {
+	switch d {
+	case DigestHourly:
+		return t.Truncate(time.Hour).Add(time.Hour)
+	case DigestDaily:
+		t = t.UTC()
+		return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+	}
+	return t
+}
+
+// QuietHours hold back WebSocket pushes and emails between Start and End, given as
+// "HH:MM" in TimeZone. A period that ends before it starts runs past midnight.
+type QuietHours struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	TimeZone string `json:"time_zone"`
+}
+
+// Until returns when the quiet hours in effect at now end, and false if now is not
+// within them.
+func (q *QuietHours) Until(now time.Time) (time.Time, bool) {
+	loc, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		return time.Time{}, false
+	}
+	start, errStart := parseClock(q.Start)
+	end, errEnd := parseClock(q.End)
+	if errStart != nil || errEnd != nil || start == end {
+		return time.Time{}, false
+	}
+
+	local := now.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	clock := local.Sub(midnight)
+	switch {
+	case start < end && clock >= start && clock < end:
+		return midnight.Add(end), true
+	case start > end && clock >= start:
+		return midnight.AddDate(0, 0, 1).Add(end), true
+	case start > end && clock < end:
+		return midnight.Add(end), true
+	}
+	return time.Time{}, false
+}
+
+func (q *QuietHours) validate() error {
+	if _, err := parseClock(q.Start); err != nil {
+		return err
+	}
+	if _, err := parseClock(q.End); err != nil {
+		return err
+	}
+	if _, err := time.LoadLocation(q.TimeZone); err != nil {
+		return fmt.Errorf("%w: unknown time zone %q", ErrInvalidPreferences, q.TimeZone)
+	}
+	return nil
+}
+
+// parseClock parses "HH:MM" as the time since midnight.
+func parseClock(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q is not a HH:MM time", ErrInvalidPreferences, s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Preferences are a user's choices about which notifications reach them, and how.
+type Preferences struct {
+	UserID string `json:"user_id"`
+	// Channels per type of notification; types left out use DefaultChannels.
+	Channels   map[NotificationType]ChannelSet `json:"channels"`
+	QuietHours *QuietHours                     `json:"quiet_hours,omitempty"`
+	Digest     DigestFrequency                 `json:"digest"`
+	UpdatedAt  time.Time                       `json:"updated_at"`
+}
+
+// DefaultPreferences are given to every new user: everything in the app, plus an
+// email for the events that matter when they are away.
+func DefaultPreferences(userID string) *Preferences {
+	withEmail := DefaultChannels
+	withEmail.Email = true
+	return &Preferences{
+		UserID: userID,
+		Channels: map[NotificationType]ChannelSet{
+			NotificationTypeOutbid:        withEmail,
+			NotificationTypeAuctionWon:    withEmail,
+			NotificationTypeAuctionClosed: withEmail,
+		},
+		Digest: DigestImmediate,
+	}
+}
+
+// ChannelsFor returns the channels notifications of type t go out on.
+func (p *Preferences) ChannelsFor(t NotificationType) ChannelSet {
+	if channels, ok := p.Channels[t]; ok {
+		return channels
+	}
+	return DefaultChannels
+}
+
+// Validate returns an error wrapping ErrInvalidPreferences if the preferences name an
+// unknown notification type or digest frequency, or malformed quiet hours.
+func (p *Preferences) Validate() error {
+	for t := range p.Channels {
+		if !t.Valid() {
+			return fmt.Errorf("%w: unknown notification type %q", ErrInvalidPreferences, t)
+		}
+	}
+	switch p.Digest {
+	case DigestImmediate, DigestHourly, DigestDaily:
+	default:
+		return fmt.Errorf("%w: unknown digest frequency %q", ErrInvalidPreferences, p.Digest)
+	}
+	if p.QuietHours != nil {
+		return p.QuietHours.validate()
+	}
+	return nil
+}
+
+type PreferenceRepository interface {
+	// Get returns nil if the user has no stored preferences.
+	Get(ctx context.Context, userID string) (*Preferences, error)
+	Save(ctx context.Context, prefs *Preferences) error
+	// Init stores prefs unless the user already has preferences.
+	Init(ctx context.Context, prefs *Preferences) error
+}
+
+// Delivery is a notification handed to a ChannelSender.
+type Delivery struct {
+	Notification *Notification
+	// NotBefore is when the user's quiet hours end, if they are in effect; senders
+	// hold the notification back until then. Zero means now.
+	NotBefore time.Time
+	Digest    DigestFrequency
+}
+
+// ChannelSender delivers notifications over a channel other than the app itself and
+// the WebSocket, which the notification service handles directly.
+type ChannelSender interface {
+	Send(ctx context.Context, delivery *Delivery) error
+}
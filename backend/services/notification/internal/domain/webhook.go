@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	ErrWebhookNotFound  = errors.New("webhook subscription not found")
+	ErrDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhook   = errors.New("invalid webhook subscription")
+	ErrWebhookDisabled  = errors.New("webhook subscription is disabled")
+)
+
+// EventNotificationCreated carries a user's notifications to their webhooks, for the
+// notification types they route to the webhook channel.
+const EventNotificationCreated = "notification.created"
+
+// WebhookEventTypes lists the events webhooks can subscribe to: the Kafka topics the
+// service consumes that name the users they concern, plus EventNotificationCreated.
+var WebhookEventTypes = []string{
+	"auction.created",
+	"auction.closed",
+	"bid.placed",
+	"bid.retracted",
+	"company.verified",
+	EventNotificationCreated,
+}
+
+type WebhookOwnerType string
+
+const (
+	WebhookOwnerUser    WebhookOwnerType = "USER"
+	WebhookOwnerCompany WebhookOwnerType = "COMPANY"
+)
+
+// WebhookSubscription sends the events of EventTypes that concern its owner to URL. A
+// user's subscription gets the events that name the user; a company's gets those that
+// name the company or any of its members.
+type WebhookSubscription struct {
+	ID         string           `json:"id"`
+	OwnerType  WebhookOwnerType `json:"owner_type"`
+	OwnerID    string           `json:"owner_id"`
+	URL        string           `json:"url"`
+	EventTypes []string         `json:"event_types"`
+	// Secret signs the deliveries. It is only shown when the subscription is created.
+	Secret string `json:"secret,omitempty"`
+	Active bool   `json:"active"`
+	// ConsecutiveFailures counts the deliveries in a row that ran out of retries; the
+	// subscription is disabled when it reaches a threshold.
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	CreatedBy           string     `json:"created_by"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// WebhookCaller is who is managing webhooks: a user, and the company they belong to.
+type WebhookCaller struct {
+	UserID    string
+	CompanyID string
+}
+
+// Owns reports whether the caller may manage sub.
+func (c WebhookCaller) Owns(sub *WebhookSubscription) bool {
+	switch sub.OwnerType {
+	case WebhookOwnerUser:
+		return sub.OwnerID == c.UserID
+	case WebhookOwnerCompany:
+		return c.CompanyID != "" && sub.OwnerID == c.CompanyID
+	}
+	return false
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "PENDING"
+	DeliveryStatusSucceeded DeliveryStatus = "SUCCEEDED"
+	DeliveryStatusFailed    DeliveryStatus = "FAILED"
+)
+
+// WebhookDelivery is one event sent, or to be sent, to a subscription. Deliveries are
+// kept as the subscription's delivery log.
+type WebhookDelivery struct {
+	ID             string          `json:"id"`
+	SubscriptionID string          `json:"subscription_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty"` // of the last attempt
+	LastError      string          `json:"last_error,omitempty"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	ReplayOf       string          `json:"replay_of,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+type WebhookRepository interface {
+	Create(ctx context.Context, sub *WebhookSubscription) error
+	// Get returns ErrWebhookNotFound if there is no such subscription.
+	Get(ctx context.Context, id string) (*WebhookSubscription, error)
+	// ListByOwner returns the subscriptions of the user and, if companyID is set, of
+	// their company.
+	ListByOwner(ctx context.Context, userID, companyID string) ([]WebhookSubscription, error)
+	Update(ctx context.Context, sub *WebhookSubscription) error
+	Delete(ctx context.Context, id string) error
+	// ListMatching returns the active subscriptions to eventType of the given users and
+	// companies, and of the companies the users are members of.
+	ListMatching(ctx context.Context, eventType string, userIDs, companyIDs []string) ([]WebhookSubscription, error)
+	// RecordSuccess resets the subscription's failure count.
+	RecordSuccess(ctx context.Context, id string) error
+	// RecordFailure counts a delivery that ran out of retries, disabling the
+	// subscription once disableAfter are failed in a row. It reports whether the
+	// subscription is still active.
+	RecordFailure(ctx context.Context, id string, disableAfter int, at time.Time) (bool, error)
+	// AddCompanyMembers records who belongs to a company, as announced in company.verified.
+	AddCompanyMembers(ctx context.Context, companyID string, userIDs []string) error
+}
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *WebhookDelivery) error
+	// Get returns ErrDeliveryNotFound if there is no such delivery.
+	Get(ctx context.Context, id string) (*WebhookDelivery, error)
+	// ListBySubscription returns the subscription's latest deliveries, newest first.
+	ListBySubscription(ctx context.Context, subscriptionID string, limit int) ([]WebhookDelivery, error)
+	// Claim returns up to limit pending deliveries due at now, and keeps other workers
+	// from claiming them until lease has passed.
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]WebhookDelivery, error)
+	MarkSucceeded(ctx context.Context, id string, responseStatus int, at time.Time) error
+	// Retry records a failed attempt and schedules the next one.
+	Retry(ctx context.Context, id string, responseStatus int, lastError string, at time.Time) error
+	// MarkFailed records a final failed attempt.
+	MarkFailed(ctx context.Context, id string, responseStatus int, lastError string) error
+}
+
+type WebhookService interface {
+	// Create subscribes the caller, or their company if sub.OwnerType is COMPANY. The
+	// returned subscription carries its secret.
+	Create(ctx context.Context, caller WebhookCaller, sub *WebhookSubscription) error
+	List(ctx context.Context, caller WebhookCaller) ([]WebhookSubscription, error)
+	// Get, Update, Delete and the delivery methods return ErrWebhookNotFound for
+	// subscriptions the caller does not own.
+	Get(ctx context.Context, caller WebhookCaller, id string) (*WebhookSubscription, error)
+	// Update replaces the URL and event types, and re-enables the subscription if
+	// Active is set.
+	Update(ctx context.Context, caller WebhookCaller, sub *WebhookSubscription) error
+	Delete(ctx context.Context, caller WebhookCaller, id string) error
+	ListDeliveries(ctx context.Context, caller WebhookCaller, subscriptionID string, limit int) ([]WebhookDelivery, error)
+	// Replay queues a new delivery of the same payload.
+	Replay(ctx context.Context, caller WebhookCaller, subscriptionID, deliveryID string) (*WebhookDelivery, error)
+
+	// Publish queues a delivery of the event to every matching subscription.
+	Publish(ctx context.Context, eventType string, payload []byte, userIDs, companyIDs []string) error
+	AddCompanyMembers(ctx context.Context, companyID string, userIDs []string) error
+}
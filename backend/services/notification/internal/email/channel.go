@@ -0,0 +1,41 @@
+package email
+
+import (
+	"context"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+type channel struct {
+	contacts domain.ContactRepository
+	queue    domain.EmailQueue
+}
+
+// NewChannel returns the email ChannelSender. It only queues notifications; the
+// Worker sends them.
+func NewChannel(contacts domain.ContactRepository, queue domain.EmailQueue) domain.ChannelSender {
+	return &channel{contacts: contacts, queue: queue}
+}
+
+func (c *channel) Send(ctx context.Context, delivery *domain.Delivery) error {
+	n := delivery.Notification
+	recipient, err := c.contacts.GetEmail(ctx, n.UserID)
+	if err != nil {
+		return err
+	}
+	if recipient == "" {
+		return domain.ErrNoEmailAddress
+	}
+
+	sendAfter := delivery.Digest.Next(time.Now())
+	if delivery.NotBefore.After(sendAfter) {
+		sendAfter = delivery.NotBefore
+	}
+	return c.queue.Enqueue(ctx, &domain.Email{
+		Recipient:    recipient,
+		Notification: *n,
+		Digest:       delivery.Digest,
+		SendAfter:    sendAfter,
+	})
+}
@@ -0,0 +1,81 @@
+package email
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+type MockContactRepo struct {
+	mock.Mock
+}
+
+func (m *MockContactRepo) SaveEmail(ctx context.Context, userID, email string) error {
+	args := m.Called(ctx, userID, email)
+	return args.Error(0)
+}
+
+func (m *MockContactRepo) GetEmail(ctx context.Context, userID string) (string, error) {
+	args := m.Called(ctx, userID)
+	return args.String(0), args.Error(1)
+}
+
+func TestChannel_Send(t *testing.T) {
+	quietUntil := time.Now().Add(8 * time.Hour)
+
+	tests := []struct {
+		name      string
+		delivery  domain.Delivery
+		wantAfter func(sendAfter time.Time) bool
+	}{
+		{
+			name:      "Immediate",
+			delivery:  domain.Delivery{Digest: domain.DigestImmediate},
+			wantAfter: func(sendAfter time.Time) bool { return !sendAfter.After(time.Now()) },
+		},
+		{
+			name:     "Hourly Digest",
+			delivery: domain.Delivery{Digest: domain.DigestHourly},
+			wantAfter: func(sendAfter time.Time) bool {
+				return sendAfter.After(time.Now()) && sendAfter.Equal(sendAfter.Truncate(time.Hour))
+			},
+		},
+		{
+			name:      "Quiet Hours",
+			delivery:  domain.Delivery{Digest: domain.DigestHourly, NotBefore: quietUntil},
+			wantAfter: func(sendAfter time.Time) bool { return sendAfter.Equal(quietUntil) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contacts := new(MockContactRepo)
+			queue := new(MockEmailQueue)
+			contacts.On("GetEmail", mock.Anything, "user-1").Return("ada@example.com", nil)
+			queue.On("Enqueue", mock.Anything, mock.MatchedBy(func(e *domain.Email) bool {
+				return e.Recipient == "ada@example.com" && e.Notification.ID == "n-1" && e.Digest == tt.delivery.Digest && tt.wantAfter(e.SendAfter)
+			})).Return(nil)
+
+			tt.delivery.Notification = &domain.Notification{ID: "n-1", UserID: "user-1"}
+			err := NewChannel(contacts, queue).Send(context.Background(), &tt.delivery)
+
+			assert.NoError(t, err)
+			queue.AssertExpectations(t)
+		})
+	}
+}
+
+func TestChannel_NoAddress(t *testing.T) {
+	contacts := new(MockContactRepo)
+	queue := new(MockEmailQueue)
+	contacts.On("GetEmail", mock.Anything, "user-1").Return("", nil)
+
+	err := NewChannel(contacts, queue).Send(context.Background(), &domain.Delivery{Notification: &domain.Notification{UserID: "user-1"}})
+
+	assert.ErrorIs(t, err, domain.ErrNoEmailAddress)
+	queue.AssertNotCalled(t, "Enqueue", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,89 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+const (
+	defaultTemplate = "DEFAULT" // for notification types without a template of their own
+	digestTemplate  = "digest"
+)
+
+// Renderer turns notifications into emails. Each notification type has a template,
+// named after it, that defines a "subject" and a "content" shown inside the shared
+// layout; digest.html does the same for a list of notifications.
+type Renderer struct {
+	templates map[string]*template.Template
+}
+
+func NewRenderer() (*Renderer, error) {
+	layout, err := template.ParseFS(templateFS, "templates/layout.html")
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{defaultTemplate, digestTemplate}
+	for _, t := range domain.NotificationTypes {
+		names = append(names, string(t))
+	}
+
+	r := &Renderer{templates: make(map[string]*template.Template)}
+	for _, name := range names {
+		file := "templates/" + name + ".html"
+		if _, err := templateFS.Open(file); err != nil {
+			continue // falls back to the default template
+		}
+		t, err := layout.Clone()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := t.ParseFS(templateFS, file); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", file, err)
+		}
+		r.templates[name] = t
+	}
+	if r.templates[defaultTemplate] == nil || r.templates[digestTemplate] == nil {
+		return nil, fmt.Errorf("missing %s or %s template", defaultTemplate, digestTemplate)
+	}
+	return r, nil
+}
+
+// Render renders one notification.
+func (r *Renderer) Render(to string, n *domain.Notification) (*domain.EmailMessage, error) {
+	t, ok := r.templates[string(n.Type)]
+	if !ok {
+		t = r.templates[defaultTemplate]
+	}
+	return render(t, to, n)
+}
+
+// RenderDigest renders several notifications as one email.
+func (r *Renderer) RenderDigest(to string, notifications []domain.Notification) (*domain.EmailMessage, error) {
+	return render(r.templates[digestTemplate], to, notifications)
+}
+
+func render(t *template.Template, to string, data interface{}) (*domain.EmailMessage, error) {
+	var subject, body bytes.Buffer
+	if err := t.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := t.ExecuteTemplate(&body, "layout", data); err != nil {
+		return nil, err
+	}
+	return &domain.EmailMessage{
+		To: to,
+		// The subject is a header, not HTML, so undo the template's escaping
+		Subject: strings.TrimSpace(html.UnescapeString(subject.String())),
+		HTML:    body.String(),
+	}, nil
+}
@@ -0,0 +1,166 @@
+package email
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+// fakeSMTPServer speaks just enough SMTP for smtpMailer, and keeps what it receives.
+type fakeSMTPServer struct {
+	host, port string
+
+	mu       sync.Mutex
+	messages []receivedMessage
+	failures int // the next this many messages are refused with a 451
+}
+
+type receivedMessage struct {
+	From string
+	To   []string
+	Data string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	s := &fakeSMTPServer{}
+	s.host, s.port, _ = net.SplitHostPort(ln.Addr().String())
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) config() SMTPConfig {
+	return SMTPConfig{Host: s.host, Port: s.port, From: "BidFlow <no-reply@bidflow.local>"}
+}
+
+func (s *fakeSMTPServer) failNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = n
+}
+
+func (s *fakeSMTPServer) received() []receivedMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]receivedMessage(nil), s.messages...)
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	c := textproto.NewConn(conn)
+	defer c.Close()
+
+	c.PrintfLine("220 fake.smtp ESMTP")
+	var msg receivedMessage
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			c.PrintfLine("250-fake.smtp")
+			c.PrintfLine("250 8BITMIME")
+		case "MAIL":
+			from, _, _ := strings.Cut(strings.TrimPrefix(arg, "FROM:"), " ") // drop parameters like BODY=8BITMIME
+			msg = receivedMessage{From: from}
+			c.PrintfLine("250 OK")
+		case "RCPT":
+			msg.To = append(msg.To, strings.TrimPrefix(arg, "TO:"))
+			c.PrintfLine("250 OK")
+		case "DATA":
+			c.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+			data, err := c.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			if s.failures > 0 {
+				s.failures--
+				s.mu.Unlock()
+				c.PrintfLine("451 Try again later")
+				continue
+			}
+			msg.Data = string(data)
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			c.PrintfLine("250 OK")
+		case "RSET", "NOOP":
+			c.PrintfLine("250 OK")
+		case "QUIT":
+			c.PrintfLine("221 Bye")
+			return
+		default:
+			c.PrintfLine("502 Command not implemented")
+		}
+	}
+}
+
+// parse splits a received message into its decoded subject and HTML body.
+func parse(t *testing.T, m receivedMessage) (string, string) {
+	parsed, err := mail.ReadMessage(strings.NewReader(m.Data))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "text/html; charset=UTF-8", parsed.Header.Get("Content-Type"))
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	require.NoError(t, err)
+	return subject, strings.TrimRight(string(body), "\r\n")
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	mailer := NewSMTPMailer(server.config())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := mailer.Send(ctx, &domain.EmailMessage{
+		To:      "Ada Lovelace <ada@example.com>",
+		Subject: "Enchère gagnée — you won",
+		HTML:    "<p>" + strings.Repeat("A long line that has to be wrapped. ", 5) + "</p>",
+	})
+	require.NoError(t, err)
+
+	received := server.received()
+	require.Len(t, received, 1)
+	assert.Equal(t, "<no-reply@bidflow.local>", received[0].From)
+	assert.Equal(t, []string{"<ada@example.com>"}, received[0].To)
+
+	subject, body := parse(t, received[0])
+	assert.Equal(t, "Enchère gagnée — you won", subject)
+	assert.Equal(t, "<p>"+strings.Repeat("A long line that has to be wrapped. ", 5)+"</p>", body)
+}
+
+func TestSMTPMailer_Refused(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	server.failNext(1)
+	mailer := NewSMTPMailer(server.config())
+
+	err := mailer.Send(context.Background(), &domain.EmailMessage{To: "ada@example.com", Subject: "Hi", HTML: "<p>Hi</p>"})
+
+	assert.ErrorContains(t, err, "451")
+	assert.Empty(t, server.received())
+}
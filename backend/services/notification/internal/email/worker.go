@@ -0,0 +1,151 @@
+package email
+
+import (
+	"context"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+const (
+	batchSize   = 50
+	maxAttempts = 8
+	maxBackoff  = time.Hour
+	// claimLease bounds how long a claimed email stays hidden from other workers, so
+	// one that dies mid-send leaves its emails to be retried.
+	claimLease = 5 * time.Minute
+)
+
+// Worker sends queued emails. A failed send is retried with exponential backoff until
+// maxAttempts, after which the email is marked FAILED.
+type Worker struct {
+	queue    domain.EmailQueue
+	mailer   domain.Mailer
+	renderer *Renderer
+	interval time.Duration
+	log      logger.Logger
+}
+
+func NewWorker(queue domain.EmailQueue, mailer domain.Mailer, renderer *Renderer, interval time.Duration, log logger.Logger) *Worker {
+	return &Worker{
+		queue:    queue,
+		mailer:   mailer,
+		renderer: renderer,
+		interval: interval,
+		log:      log,
+	}
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				w.log.Info("email worker stopped")
+				return
+			case now := <-ticker.C:
+				if _, err := w.Drain(ctx, now); err != nil {
+					w.log.Error("failed to drain email queue", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Drain sends one batch of due emails and returns how many messages went out. Each
+// user's digest emails in the batch go out as one message.
+func (w *Worker) Drain(ctx context.Context, now time.Time) (int, error) {
+	emails, err := w.queue.Claim(ctx, now, claimLease, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var batches [][]domain.Email
+	digests := make(map[string]int) // user ID to index in batches
+	for _, e := range emails {
+		if e.Digest == "" || e.Digest == domain.DigestImmediate {
+			batches = append(batches, []domain.Email{e})
+			continue
+		}
+		i, ok := digests[e.Notification.UserID]
+		if !ok {
+			i = len(batches)
+			digests[e.Notification.UserID] = i
+			batches = append(batches, nil)
+		}
+		batches[i] = append(batches[i], e)
+	}
+
+	sent := 0
+	for _, batch := range batches {
+		ok, err := w.send(ctx, now, batch)
+		if err != nil {
+			return sent, err
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// send reports whether the batch went out. It returns an error only if the outcome
+// could not be recorded.
+func (w *Worker) send(ctx context.Context, now time.Time, batch []domain.Email) (bool, error) {
+	ids := make([]int64, len(batch))
+	attempts := 0
+	for i, e := range batch {
+		ids[i] = e.ID
+		attempts = max(attempts, e.Attempts+1)
+	}
+
+	msg, err := w.render(batch)
+	if err == nil {
+		err = w.mailer.Send(ctx, msg)
+	}
+	if err == nil {
+		return true, w.queue.MarkSent(ctx, ids, now)
+	}
+
+	if attempts >= maxAttempts {
+		w.log.Error("giving up on email",
+			zap.Int64s("ids", ids),
+			zap.String("user_id", batch[0].Notification.UserID),
+			zap.Int("attempts", attempts),
+			zap.Error(err),
+		)
+		return false, w.queue.MarkFailed(ctx, ids, err.Error())
+	}
+	w.log.Warn("email send failed, will retry",
+		zap.Int64s("ids", ids),
+		zap.Int("attempts", attempts),
+		zap.Error(err),
+	)
+	return false, w.queue.Retry(ctx, ids, err.Error(), now.Add(backoff(attempts)))
+}
+
+func (w *Worker) render(batch []domain.Email) (*domain.EmailMessage, error) {
+	if len(batch) == 1 && (batch[0].Digest == "" || batch[0].Digest == domain.DigestImmediate) {
+		return w.renderer.Render(batch[0].Recipient, &batch[0].Notification)
+	}
+	notifications := make([]domain.Notification, len(batch))
+	for i, e := range batch {
+		notifications[i] = e.Notification
+	}
+	// The latest address on file wins if it changed between notifications
+	return w.renderer.RenderDigest(batch[len(batch)-1].Recipient, notifications)
+}
+
+// backoff doubles from 30 seconds per failed attempt, capped at maxBackoff.
+func backoff(attempts int) time.Duration {
+	d := 30 * time.Second
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
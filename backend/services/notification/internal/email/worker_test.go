@@ -0,0 +1,162 @@
+package email
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+// --- Mocks ---
+
+type MockEmailQueue struct {
+	mock.Mock
+}
+
+func (m *MockEmailQueue) Enqueue(ctx context.Context, email *domain.Email) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockEmailQueue) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.Email, error) {
+	args := m.Called(ctx, now, lease, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Email), args.Error(1)
+}
+
+func (m *MockEmailQueue) MarkSent(ctx context.Context, ids []int64, at time.Time) error {
+	args := m.Called(ctx, ids, at)
+	return args.Error(0)
+}
+
+func (m *MockEmailQueue) Retry(ctx context.Context, ids []int64, lastError string, at time.Time) error {
+	args := m.Called(ctx, ids, lastError, at)
+	return args.Error(0)
+}
+
+func (m *MockEmailQueue) MarkFailed(ctx context.Context, ids []int64, lastError string) error {
+	args := m.Called(ctx, ids, lastError)
+	return args.Error(0)
+}
+
+type MockLogger struct{}
+
+func (m *MockLogger) Debug(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) Info(msg string, fields ...zap.Field)   {}
+func (m *MockLogger) Warn(msg string, fields ...zap.Field)   {}
+func (m *MockLogger) Error(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) Fatal(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) With(fields ...zap.Field) logger.Logger { return m }
+func (m *MockLogger) Sync() error                            { return nil }
+
+// --- Tests ---
+
+func newTestWorker(t *testing.T, queue domain.EmailQueue, server *fakeSMTPServer) *Worker {
+	renderer, err := NewRenderer()
+	require.NoError(t, err)
+	return NewWorker(queue, NewSMTPMailer(server.config()), renderer, time.Second, &MockLogger{})
+}
+
+func queued(id int64, userID string, digest domain.DigestFrequency, attempts int) domain.Email {
+	return domain.Email{
+		ID:        id,
+		Recipient: userID + "@example.com",
+		Notification: domain.Notification{
+			ID:        "n-" + userID,
+			UserID:    userID,
+			Type:      domain.NotificationTypeOutbid,
+			Title:     "You've Been Outbid",
+			Message:   "Someone bid 120.00 on auction a-1 and took the lead from you.",
+			CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		},
+		Digest:   digest,
+		Status:   domain.EmailStatusPending,
+		Attempts: attempts,
+	}
+}
+
+func TestWorker_Drain(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	queue := new(MockEmailQueue)
+	now := time.Now()
+
+	queue.On("Claim", mock.Anything, now, claimLease, batchSize).Return([]domain.Email{queued(1, "ada", domain.DigestImmediate, 0)}, nil)
+	queue.On("MarkSent", mock.Anything, []int64{1}, now).Return(nil)
+
+	sent, err := newTestWorker(t, queue, server).Drain(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	queue.AssertExpectations(t)
+
+	received := server.received()
+	require.Len(t, received, 1)
+	assert.Equal(t, []string{"<ada@example.com>"}, received[0].To)
+	subject, body := parse(t, received[0])
+	assert.Equal(t, "You've been outbid", subject)
+	assert.Contains(t, body, "Someone bid 120.00 on auction a-1 and took the lead from you.")
+	assert.Contains(t, body, "Bid again before the auction ends")
+}
+
+func TestWorker_Digest(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	queue := new(MockEmailQueue)
+	now := time.Now()
+
+	won := queued(3, "ada", domain.DigestHourly, 0)
+	won.Notification.Type = domain.NotificationTypeAuctionWon
+	won.Notification.Title = "Auction Won"
+	won.Notification.Message = "Congratulations! You won 'Lamp' for 150.00."
+	emails := []domain.Email{queued(1, "ada", domain.DigestHourly, 0), queued(2, "bob", domain.DigestImmediate, 0), won}
+
+	queue.On("Claim", mock.Anything, now, claimLease, batchSize).Return(emails, nil)
+	queue.On("MarkSent", mock.Anything, []int64{1, 3}, now).Return(nil)
+	queue.On("MarkSent", mock.Anything, []int64{2}, now).Return(nil)
+
+	sent, err := newTestWorker(t, queue, server).Drain(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	queue.AssertExpectations(t)
+
+	received := server.received()
+	require.Len(t, received, 2)
+	subject, body := parse(t, received[0])
+	assert.Equal(t, []string{"<ada@example.com>"}, received[0].To)
+	assert.Equal(t, "Your BidFlow digest: 2 new notifications", subject)
+	assert.Contains(t, body, "took the lead from you")
+	assert.Contains(t, body, "You won &#39;Lamp&#39; for 150.00.")
+}
+
+func TestWorker_Retry(t *testing.T) {
+	server := newFakeSMTPServer(t)
+	server.failNext(2)
+	queue := new(MockEmailQueue)
+	now := time.Now()
+
+	queue.On("Claim", mock.Anything, now, claimLease, batchSize).
+		Return([]domain.Email{queued(1, "ada", domain.DigestImmediate, 0), queued(2, "bob", domain.DigestImmediate, maxAttempts-1)}, nil)
+	queue.On("Retry", mock.Anything, []int64{1}, mock.AnythingOfType("string"), now.Add(30*time.Second)).Return(nil)
+	queue.On("MarkFailed", mock.Anything, []int64{2}, mock.AnythingOfType("string")).Return(nil)
+
+	sent, err := newTestWorker(t, queue, server).Drain(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	queue.AssertExpectations(t)
+	assert.Empty(t, server.received())
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, backoff(1))
+	assert.Equal(t, 2*time.Minute, backoff(3))
+	assert.Equal(t, maxBackoff, backoff(20))
+}
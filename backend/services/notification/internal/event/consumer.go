@@ -3,7 +3,9 @@ package event
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/temesgen-abebayehu/bidflow/backend/common/kafka"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
@@ -14,13 +16,17 @@ import (
 type NotificationConsumer struct {
 	consumer *kafka.Consumer
 	service  domain.NotificationService
+	webhooks domain.WebhookService
+	ticker   domain.PriceTicker
 	log      logger.Logger
 }
 
-func NewNotificationConsumer(consumer *kafka.Consumer, service domain.NotificationService, log logger.Logger) *NotificationConsumer {
+func NewNotificationConsumer(consumer *kafka.Consumer, service domain.NotificationService, webhooks domain.WebhookService, ticker domain.PriceTicker, log logger.Logger) *NotificationConsumer {
 	return &NotificationConsumer{
 		consumer: consumer,
 		service:  service,
+		webhooks: webhooks,
+		ticker:   ticker,
 		log:      log,
 	}
 }
@@ -35,11 +41,28 @@ func (c *NotificationConsumer) Start(ctx context.Context) {
 func (c *NotificationConsumer) handleMessage(ctx context.Context, topic string, key, value []byte) error {
 	c.log.Info("Received message", zap.String("topic", topic), zap.String("key", string(key)))
 
+	// Webhooks get the event even if notifying about it failed, and vice versa
+	return errors.Join(c.notify(ctx, topic, value), c.publishWebhooks(ctx, topic, value))
+}
+
+func (c *NotificationConsumer) notify(ctx context.Context, topic string, value []byte) error {
 	switch topic {
 	case TopicAuctionCreated:
 		return c.handleAuctionCreated(ctx, value)
 	case TopicBidPlaced:
 		return c.handleBidPlaced(ctx, value)
+	case TopicBidRetracted:
+		return c.handleBidRetracted(ctx, value)
+	case TopicAuctionUpdated:
+		return c.handleAuctionUpdated(ctx, value)
+	case TopicAuctionClosed:
+		return c.handleAuctionClosed(ctx, value)
+	case TopicAuctionExtended:
+		return c.handleAuctionExtended(ctx, value)
+	case TopicCompanyVerified:
+		return c.handleCompanyVerified(ctx, value)
+	case TopicUserRegistered:
+		return c.handleUserRegistered(ctx, value)
 	default:
 		c.log.Warn("Unknown topic", zap.String("topic", topic))
 		return nil
@@ -65,6 +88,18 @@ func (c *NotificationConsumer) handleAuctionCreated(ctx context.Context, value [
 		c.log.Error("Failed to send notification for AuctionCreated", zap.Error(err))
 		return err
 	}
+
+	if event.AuctionType == auctionTypeDutch {
+		c.ticker.Start(&domain.DutchClock{
+			AuctionID:  event.AuctionID,
+			StartPrice: event.StartPrice,
+			Step:       event.ClockStep,
+			Interval:   time.Duration(event.ClockInterval) * time.Second,
+			FloorPrice: event.FloorPrice,
+			StartTime:  event.StartTime,
+			EndTime:    event.EndTime,
+		})
+	}
 	return nil
 }
 
@@ -75,18 +110,327 @@ func (c *NotificationConsumer) handleBidPlaced(ctx context.Context, value []byte
 		return nil // Don't retry on unmarshal error
 	}
 
-	// Notify the bidder
-	notification := &domain.Notification{
+	// The only bid a Dutch auction takes is the one accepting its price
+	c.ticker.Stop(event.AuctionID)
+
+	c.service.NotifyBidPlaced(ctx, &domain.AuctionBid{
+		AuctionID: event.AuctionID,
+		BidID:     event.BidID,
+		Amount:    event.Amount,
+		Sealed:    event.Sealed,
+		PlacedAt:  event.Timestamp,
+	})
+
+	if err := c.service.RecordParticipant(ctx, event.AuctionID, event.BidderID, event.Timestamp); err != nil {
+		return err
+	}
+
+	// Notify the bidder. Sealed amounts stay out of notifications until the auction closes.
+	message := fmt.Sprintf("You placed a bid of %.2f on auction %s.", event.Amount, event.AuctionID)
+	if event.Sealed {
+		message = fmt.Sprintf("You placed a sealed bid on auction %s.", event.AuctionID)
+	}
+	notifications := []*domain.Notification{{
 		UserID:     event.BidderID,
 		Type:       domain.NotificationTypeBidPlaced,
 		Title:      "Bid Placed",
-		Message:    fmt.Sprintf("You placed a bid of %.2f on auction %s.", event.Amount, event.AuctionID),
+		Message:    message,
 		ResourceID: event.AuctionID,
+	}}
+
+	// Tell whoever led until now that they have been outbid
+	if event.PreviousLeaderID != "" && event.PreviousLeaderID != event.BidderID {
+		notifications = append(notifications, &domain.Notification{
+			UserID:     event.PreviousLeaderID,
+			Type:       domain.NotificationTypeOutbid,
+			Title:      "You've Been Outbid",
+			Message:    fmt.Sprintf("Someone bid %.2f on auction %s and took the lead from you.", event.Amount, event.AuctionID),
+			ResourceID: event.AuctionID,
+		})
+	}
+
+	// And the seller that their auction got a bid
+	if event.SellerID != "" {
+		message := fmt.Sprintf("A bid of %.2f was placed on your auction %s.", event.Amount, event.AuctionID)
+		if event.Sealed {
+			message = fmt.Sprintf("A sealed bid was placed on your auction %s.", event.AuctionID)
+		}
+		notifications = append(notifications, &domain.Notification{
+			UserID:     event.SellerID,
+			Type:       domain.NotificationTypeNewBid,
+			Title:      "New Bid",
+			Message:    message,
+			ResourceID: event.AuctionID,
+		})
+	}
+
+	for _, notification := range notifications {
+		if err := c.service.SendNotification(ctx, notification); err != nil {
+			c.log.Error("Failed to send notification for BidPlaced", zap.Error(err), zap.String("user_id", notification.UserID))
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *NotificationConsumer) handleBidRetracted(ctx context.Context, value []byte) error {
+	var event BidRetractedEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		c.log.Error("Failed to unmarshal BidRetractedEvent", zap.Error(err))
+		return nil // Don't retry on unmarshal error
+	}
+
+	// Withdrawing the leading bid drops the price to the next one. Sealed amounts stay
+	// hidden.
+	if event.WasLeading && event.LeaderID != "" && event.Amount != 0 {
+		c.service.NotifyPriceChanged(ctx, &domain.AuctionPriceChanged{AuctionID: event.AuctionID, Price: event.LeadingAmount})
+	}
+
+	var notifications []*domain.Notification
+
+	// Tell the bidder their bid is gone, and why if someone else withdrew it
+	bid := fmt.Sprintf("your bid of %.2f", event.Amount)
+	if event.Amount == 0 {
+		bid = "your sealed bid"
+	}
+	if event.Cancelled {
+		notifications = append(notifications, &domain.Notification{
+			UserID:     event.BidderID,
+			Type:       domain.NotificationTypeBidRetracted,
+			Title:      "Bid Cancelled",
+			Message:    fmt.Sprintf("The seller or an administrator cancelled %s on auction %s: %s", bid, event.AuctionID, event.Reason),
+			ResourceID: event.AuctionID,
+		})
+	} else {
+		notifications = append(notifications, &domain.Notification{
+			UserID:     event.BidderID,
+			Type:       domain.NotificationTypeBidRetracted,
+			Title:      "Bid Retracted",
+			Message:    fmt.Sprintf("You retracted %s on auction %s.", bid, event.AuctionID),
+			ResourceID: event.AuctionID,
+		})
+	}
+
+	// Whoever the retraction put back in the lead should know
+	if event.WasLeading && event.LeaderID != "" && event.LeaderID != event.BidderID {
+		notifications = append(notifications, &domain.Notification{
+			UserID:     event.LeaderID,
+			Type:       domain.NotificationTypeBidRetracted,
+			Title:      "You're In The Lead",
+			Message:    fmt.Sprintf("The leading bid on auction %s was withdrawn. Your bid of %.2f now leads.", event.AuctionID, event.LeadingAmount),
+			ResourceID: event.AuctionID,
+		})
+	}
+
+	for _, notification := range notifications {
+		if err := c.service.SendNotification(ctx, notification); err != nil {
+			c.log.Error("Failed to send notification for BidRetracted", zap.Error(err), zap.String("user_id", notification.UserID))
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *NotificationConsumer) handleAuctionUpdated(ctx context.Context, value []byte) error {
+	var event AuctionUpdatedEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		c.log.Error("Failed to unmarshal AuctionUpdatedEvent", zap.Error(err))
+		return nil // Don't retry on unmarshal error
+	}
+
+	updated := &domain.AuctionUpdated{
+		AuctionID:   event.AuctionID,
+		Title:       event.Title,
+		Description: event.Description,
+		ImageURL:    event.ImageURL,
+	}
+
+	if err := c.service.NotifyAuctionUpdated(ctx, updated); err != nil {
+		c.log.Error("Failed to notify watchers for AuctionUpdated", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (c *NotificationConsumer) handleAuctionClosed(ctx context.Context, value []byte) error {
+	var event AuctionClosedEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		c.log.Error("Failed to unmarshal AuctionClosedEvent", zap.Error(err))
+		return nil // Don't retry on unmarshal error
+	}
+
+	// A Dutch auction can close at its end time without anyone accepting the price
+	c.ticker.Stop(event.AuctionID)
+
+	outcome := &domain.AuctionOutcome{
+		AuctionID:  event.AuctionID,
+		SellerID:   event.SellerID,
+		Title:      event.Title,
+		Status:     event.Status,
+		FinalPrice: event.FinalPrice,
+	}
+	for _, w := range event.Winners {
+		outcome.Winners = append(outcome.Winners, domain.AuctionWinner{BidderID: w.BidderID, Units: w.Units, Price: w.Price})
+	}
+
+	if err := c.service.NotifyAuctionClosed(ctx, outcome); err != nil {
+		c.log.Error("Failed to send notifications for AuctionClosed", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (c *NotificationConsumer) handleAuctionExtended(ctx context.Context, value []byte) error {
+	var event AuctionExtendedEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		c.log.Error("Failed to unmarshal AuctionExtendedEvent", zap.Error(err))
+		return nil // Don't retry on unmarshal error
+	}
+
+	extended := &domain.AuctionExtended{
+		AuctionID:      event.AuctionID,
+		EndTime:        event.EndTime,
+		ExtensionCount: event.ExtensionCount,
+		MaxExtensions:  event.MaxExtensions,
+	}
+
+	if err := c.service.NotifyAuctionExtended(ctx, extended); err != nil {
+		c.log.Error("Failed to notify watchers for AuctionExtended", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (c *NotificationConsumer) handleCompanyVerified(ctx context.Context, value []byte) error {
+	var event CompanyVerifiedEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		c.log.Error("Failed to unmarshal CompanyVerifiedEvent", zap.Error(err))
+		return nil // Don't retry on unmarshal error
+	}
+
+	// Record the members first, so the company's webhooks get their events
+	if err := c.webhooks.AddCompanyMembers(ctx, event.CompanyID, event.MemberIDs); err != nil {
+		c.log.Error("Failed to record company members", zap.Error(err), zap.String("company_id", event.CompanyID))
+		return err
+	}
+
+	// Notify every member of the company
+	for _, memberID := range event.MemberIDs {
+		notification := &domain.Notification{
+			UserID:     memberID,
+			Type:       domain.NotificationTypeCompanyVerified,
+			Title:      "Company Verified",
+			Message:    fmt.Sprintf("Your company '%s' has been verified. You can now bid on reverse auctions.", event.Name),
+			ResourceID: event.CompanyID,
+		}
+
+		if err := c.service.SendNotification(ctx, notification); err != nil {
+			c.log.Error("Failed to send notification for CompanyVerified", zap.Error(err), zap.String("user_id", memberID))
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *NotificationConsumer) handleUserRegistered(ctx context.Context, value []byte) error {
+	var event UserRegisteredEvent
+	if err := json.Unmarshal(value, &event); err != nil {
+		c.log.Error("Failed to unmarshal UserRegisteredEvent", zap.Error(err))
+		return nil // Don't retry on unmarshal error
+	}
+
+	if event.Email != "" {
+		if err := c.service.SaveContact(ctx, event.UserID, event.Email); err != nil {
+			return err
+		}
+	}
+	// Preferences first, so the welcome goes out over the default channels
+	if err := c.service.InitPreferences(ctx, event.UserID); err != nil {
+		return err
+	}
+
+	name := event.FullName
+	if name == "" {
+		name = event.Username
+	}
+	notification := &domain.Notification{
+		UserID:  event.UserID,
+		Type:    domain.NotificationTypeWelcome,
+		Title:   "Welcome to BidFlow",
+		Message: fmt.Sprintf("Welcome, %s! You can now list items for auction and place bids.", name),
 	}
 
 	if err := c.service.SendNotification(ctx, notification); err != nil {
-		c.log.Error("Failed to send notification for BidPlaced", zap.Error(err))
+		c.log.Error("Failed to send notification for UserRegistered", zap.Error(err))
 		return err
 	}
 	return nil
 }
+
+// publishWebhooks forwards the event as is to the webhooks subscribed to it by the
+// users and companies it concerns.
+func (c *NotificationConsumer) publishWebhooks(ctx context.Context, topic string, value []byte) error {
+	userIDs, companyIDs, ok := webhookParties(topic, value)
+	if !ok {
+		return nil
+	}
+	if err := c.webhooks.Publish(ctx, topic, value, userIDs, companyIDs); err != nil {
+		c.log.Error("Failed to publish webhooks", zap.Error(err), zap.String("topic", topic))
+		return err
+	}
+	return nil
+}
+
+// webhookParties returns who an event concerns, and false for events that webhooks
+// cannot subscribe to or that do not parse.
+func webhookParties(topic string, value []byte) (userIDs, companyIDs []string, ok bool) {
+	switch topic {
+	case TopicAuctionCreated:
+		var event AuctionCreatedEvent
+		if json.Unmarshal(value, &event) != nil {
+			return nil, nil, false
+		}
+		return nonEmpty(event.SellerID), nil, true
+	case TopicBidPlaced:
+		// Not the previous leader: the event names the bidder who overtook them.
+		// They hear about it through their OUTBID notification instead.
+		var event BidPlacedEvent
+		if json.Unmarshal(value, &event) != nil {
+			return nil, nil, false
+		}
+		return nonEmpty(event.BidderID, event.SellerID), nil, true
+	case TopicBidRetracted:
+		var event BidRetractedEvent
+		if json.Unmarshal(value, &event) != nil {
+			return nil, nil, false
+		}
+		return nonEmpty(event.BidderID), nil, true
+	case TopicAuctionClosed:
+		var event AuctionClosedEvent
+		if json.Unmarshal(value, &event) != nil {
+			return nil, nil, false
+		}
+		userIDs = nonEmpty(event.SellerID)
+		for _, w := range event.Winners {
+			userIDs = append(userIDs, w.BidderID)
+		}
+		return userIDs, nil, true
+	case TopicCompanyVerified:
+		var event CompanyVerifiedEvent
+		if json.Unmarshal(value, &event) != nil {
+			return nil, nil, false
+		}
+		return event.MemberIDs, nonEmpty(event.CompanyID), true
+	}
+	return nil, nil, false
+}
+
+func nonEmpty(ids ...string) []string {
+	var out []string
+	for _, id := range ids {
+		if id != "" {
+			out = append(out, id)
+		}
+	}
+	return out
+}
@@ -3,8 +3,14 @@ package event
 import "time"
 
 const (
-	TopicAuctionCreated = "auction.created"
-	TopicBidPlaced      = "bid.placed"
+	TopicAuctionCreated  = "auction.created"
+	TopicAuctionUpdated  = "auction.updated"
+	TopicAuctionClosed   = "auction.closed"
+	TopicAuctionExtended = "auction.extended"
+	TopicBidPlaced       = "bid.placed"
+	TopicBidRetracted    = "bid.retracted"
+	TopicCompanyVerified = "company.verified"
+	TopicUserRegistered  = "user.registered"
 )
 
 type AuctionCreatedEvent struct {
@@ -16,12 +22,92 @@ type AuctionCreatedEvent struct {
 	EndTime    time.Time `json:"end_time"`
 	Category   string    `json:"category"`
 	Timestamp  time.Time `json:"timestamp"`
+
+	AuctionType   string  `json:"auction_type"`
+	ClockStep     float64 `json:"clock_step,omitempty"`
+	ClockInterval int64   `json:"clock_interval,omitempty"` // seconds
+	FloorPrice    float64 `json:"floor_price,omitempty"`
 }
 
+// auctionTypeDutch marks clock auctions, whose price ticks are pushed to viewers.
+const auctionTypeDutch = "DUTCH"
+
 type BidPlacedEvent struct {
 	BidID     string    `json:"bid_id"`
 	AuctionID string    `json:"auction_id"`
 	BidderID  string    `json:"bidder_id"`
-	Amount    float64   `json:"amount"`
+	Amount    float64   `json:"amount,omitempty"` // absent for sealed bids
+	Timestamp time.Time `json:"timestamp"`
+	Sealed    bool      `json:"sealed,omitempty"`
+	SellerID  string    `json:"seller_id,omitempty"`
+	// PreviousLeaderID is the bidder this bid took the lead from, if any.
+	PreviousLeaderID string `json:"previous_leader_id,omitempty"`
+}
+
+// BidRetractedEvent reports a bid withdrawn by its bidder, or cancelled by the seller
+// or an admin. LeaderID is set when the withdrawn bid was leading and another bid
+// has taken its place.
+type BidRetractedEvent struct {
+	BidID         string    `json:"bid_id"`
+	AuctionID     string    `json:"auction_id"`
+	BidderID      string    `json:"bidder_id"`
+	Amount        float64   `json:"amount,omitempty"` // absent for sealed bids
+	RetractedBy   string    `json:"retracted_by"`
+	Cancelled     bool      `json:"cancelled"`
+	Reason        string    `json:"reason,omitempty"`
+	WasLeading    bool      `json:"was_leading"`
+	LeaderID      string    `json:"leader_id,omitempty"`
+	LeadingAmount float64   `json:"leading_amount,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+type AuctionUpdatedEvent struct {
+	AuctionID   string    `json:"auction_id"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	ImageURL    string    `json:"image_url"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+type AuctionClosedEvent struct {
+	AuctionID  string    `json:"auction_id"`
+	SellerID   string    `json:"seller_id"`
+	Title      string    `json:"title"`
+	FinalPrice float64   `json:"final_price"`
+	Winners    []Winner  `json:"winners"` // best first
+	Status     string    `json:"status"`  // CLOSED or RESERVE_NOT_MET
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// Winner is a bid that won units of a closed auction.
+type Winner struct {
+	BidID    string  `json:"bid_id"`
+	BidderID string  `json:"bidder_id"`
+	Units    int     `json:"units"`
+	Price    float64 `json:"price"` // per unit
+}
+
+type AuctionExtendedEvent struct {
+	AuctionID       string    `json:"auction_id"`
+	PreviousEndTime time.Time `json:"previous_end_time"`
+	EndTime         time.Time `json:"end_time"`
+	ExtensionCount  int       `json:"extension_count"`
+	MaxExtensions   int       `json:"max_extensions"`
+	Timestamp       time.Time `json:"timestamp"`
+}
+
+type CompanyVerifiedEvent struct {
+	CompanyID string    `json:"company_id"`
+	Name      string    `json:"name"`
+	MemberIDs []string  `json:"member_ids"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type UserRegisteredEvent struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email"`
+	Username  string    `json:"username"`
+	FullName  string    `json:"fullname"`
+	Role      string    `json:"role"`
 	Timestamp time.Time `json:"timestamp"`
 }
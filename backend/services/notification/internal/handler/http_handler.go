@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -43,14 +45,132 @@ func (h *NotificationHandler) GetNotifications(c *gin.Context) {
 		return
 	}
 
-	notifications, err := h.service.GetUserNotifications(c.Request.Context(), userID.(string))
+	var after *domain.Cursor
+	if cursor := c.Query("cursor"); cursor != "" {
+		var err error
+		if after, err = domain.ParseCursor(cursor); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	limit := 0
+	if l := c.Query("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive number"})
+			return
+		}
+	}
+
+	page, err := h.service.GetUserNotifications(c.Request.Context(), userID.(string), after, limit)
 	if err != nil {
 		h.log.Error("Failed to get notifications", zap.Error(err))
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get notifications"})
 		return
 	}
 
-	c.JSON(http.StatusOK, notifications)
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *NotificationHandler) MarkAsRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	err := h.service.MarkAsRead(c.Request.Context(), userID.(string), c.Param("id"))
+	if errors.Is(err, domain.ErrNotificationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to mark notification as read", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark notification as read"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked as read"})
+}
+
+func (h *NotificationHandler) MarkAllAsRead(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	if err := h.service.MarkAllAsRead(c.Request.Context(), userID.(string)); err != nil {
+		h.log.Error("Failed to mark notifications as read", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to mark notifications as read"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "All notifications marked as read"})
+}
+
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	count, err := h.service.UnreadCount(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.log.Error("Failed to count unread notifications", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count unread notifications"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"count": count})
+}
+
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	prefs, err := h.service.GetPreferences(c.Request.Context(), userID.(string))
+	if err != nil {
+		h.log.Error("Failed to get notification preferences", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get notification preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
+}
+
+// UpdatePreferences replaces the user's preferences; types left out of channels go
+// back to the defaults.
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var prefs domain.Preferences
+	if err := c.ShouldBindJSON(&prefs); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	prefs.UserID = userID.(string)
+
+	err := h.service.UpdatePreferences(c.Request.Context(), &prefs)
+	if errors.Is(err, domain.ErrInvalidPreferences) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.log.Error("Failed to update notification preferences", zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update notification preferences"})
+		return
+	}
+
+	c.JSON(http.StatusOK, prefs)
 }
 
 func (h *NotificationHandler) HandleWebSocket(c *gin.Context) {
@@ -66,13 +186,26 @@ func (h *NotificationHandler) HandleWebSocket(c *gin.Context) {
 		return
 	}
 
+	// A reconnecting client passes the seq of the last notification it saw
+	var lastSeq int64 = -1
+	if raw := c.Query("last_seq"); raw != "" {
+		lastSeq, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil || lastSeq < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid last_seq"})
+			return
+		}
+	}
+
 	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		h.log.Error("Failed to upgrade websocket", zap.Error(err))
 		return
 	}
 
-	client := ws.NewClient(h.hub, conn, claims.UserID, h.log)
+	client := ws.NewClient(h.hub, conn, claims.UserID, h.service, h.log)
+	if lastSeq >= 0 {
+		client.Resume(lastSeq)
+	}
 	h.hub.Register(client)
 
 	// Allow collection of memory referenced by the caller by doing all work in
@@ -80,3 +213,47 @@ func (h *NotificationHandler) HandleWebSocket(c *gin.Context) {
 	go client.WritePump()
 	go client.ReadPump()
 }
+
+// StreamNotifications is the Server-Sent Events fallback for clients that can't open
+// a websocket: the same pushes, one way. The auctions to follow are given as
+// auction_id query parameters. A reconnecting client resumes after the seq in its
+// Last-Event-ID header, or in last_seq on its first connection.
+func (h *NotificationHandler) StreamNotifications(c *gin.Context) {
+	userID, exists := c.Get("user_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	lastSeq := int64(-1)
+	raw := c.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = c.Query("last_seq")
+	}
+	if raw != "" {
+		var err error
+		lastSeq, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil || lastSeq < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+			return
+		}
+	}
+
+	client := ws.NewSSEClient(h.hub, c.Writer, userID.(string), h.service, h.log)
+	if lastSeq >= 0 {
+		client.Resume(lastSeq)
+	}
+	h.hub.Register(client)
+	for _, auctionID := range c.QueryArray("auction_id") {
+		if auctionID == "" {
+			continue
+		}
+		if err := h.hub.Subscribe(client, auctionID); err != nil {
+			h.hub.Unregister(client)
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	client.Serve(c.Request.Context())
+}
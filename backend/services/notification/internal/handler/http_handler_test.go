@@ -5,7 +5,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -26,14 +28,88 @@ func (m *MockNotificationService) SendNotification(ctx context.Context, notifica
 	return args.Error(0)
 }
 
-func (m *MockNotificationService) GetUserNotifications(ctx context.Context, userID string) ([]domain.Notification, error) {
+func (m *MockNotificationService) GetUserNotifications(ctx context.Context, userID string, after *domain.Cursor, limit int) (*domain.NotificationPage, error) {
+	args := m.Called(ctx, userID, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NotificationPage), args.Error(1)
+}
+
+func (m *MockNotificationService) MarkAsRead(ctx context.Context, userID, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) MarkAllAsRead(ctx context.Context, userID string) error {
 	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) UnreadCount(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockNotificationService) NotificationsSince(ctx context.Context, userID string, afterSeq int64, limit int) ([]domain.Notification, error) {
+	args := m.Called(ctx, userID, afterSeq, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.Notification), args.Error(1)
 }
 
+func (m *MockNotificationService) NotifyAuctionExtended(ctx context.Context, extended *domain.AuctionExtended) error {
+	args := m.Called(ctx, extended)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) NotifyAuctionUpdated(ctx context.Context, updated *domain.AuctionUpdated) error {
+	args := m.Called(ctx, updated)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) NotifyBidPlaced(ctx context.Context, bid *domain.AuctionBid) {
+	m.Called(ctx, bid)
+}
+
+func (m *MockNotificationService) NotifyPriceChanged(ctx context.Context, changed *domain.AuctionPriceChanged) {
+	m.Called(ctx, changed)
+}
+
+func (m *MockNotificationService) RecordParticipant(ctx context.Context, auctionID, userID string, at time.Time) error {
+	args := m.Called(ctx, auctionID, userID, at)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) NotifyAuctionClosed(ctx context.Context, outcome *domain.AuctionOutcome) error {
+	args := m.Called(ctx, outcome)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Preferences), args.Error(1)
+}
+
+func (m *MockNotificationService) UpdatePreferences(ctx context.Context, prefs *domain.Preferences) error {
+	args := m.Called(ctx, prefs)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) InitPreferences(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationService) SaveContact(ctx context.Context, userID, email string) error {
+	args := m.Called(ctx, userID, email)
+	return args.Error(0)
+}
+
 type MockLogger struct {
 	mock.Mock
 }
@@ -59,11 +135,11 @@ func TestGetNotifications_Success(t *testing.T) {
 	handler := NewNotificationHandler(mockService, nil, nil, mockLogger)
 
 	userID := "user-1"
-	notifications := []domain.Notification{
-		{ID: "1", Title: "Test"},
+	page := &domain.NotificationPage{
+		Notifications: []domain.Notification{{ID: "1", Title: "Test"}},
 	}
 
-	mockService.On("GetUserNotifications", mock.Anything, userID).Return(notifications, nil)
+	mockService.On("GetUserNotifications", mock.Anything, userID, (*domain.Cursor)(nil), 0).Return(page, nil)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -101,7 +177,7 @@ func TestGetNotifications_ServiceError(t *testing.T) {
 	userID := "user-1"
 	expectedErr := errors.New("db error")
 
-	mockService.On("GetUserNotifications", mock.Anything, userID).Return(nil, expectedErr)
+	mockService.On("GetUserNotifications", mock.Anything, userID, (*domain.Cursor)(nil), 0).Return(nil, expectedErr)
 	mockLogger.On("Error", "Failed to get notifications", mock.Anything).Return()
 
 	w := httptest.NewRecorder()
@@ -115,3 +191,186 @@ func TestGetNotifications_ServiceError(t *testing.T) {
 	mockService.AssertExpectations(t)
 	mockLogger.AssertExpectations(t)
 }
+
+func TestGetNotifications_Cursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockNotificationService)
+	handler := NewNotificationHandler(mockService, nil, nil, new(MockLogger))
+
+	cursor := domain.Cursor{CreatedAt: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), ID: "n-20"}
+	mockService.On("GetUserNotifications", mock.Anything, "user-1", &cursor, 20).
+		Return(&domain.NotificationPage{Notifications: []domain.Notification{}}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/notifications?limit=20&cursor="+cursor.String(), nil)
+	c.Set("user_id", "user-1")
+
+	handler.GetNotifications(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+
+	// A cursor that was not handed out is rejected
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/notifications?cursor=bogus", nil)
+	c.Set("user_id", "user-1")
+
+	handler.GetNotifications(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestMarkAsRead(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"Marked", nil, http.StatusOK},
+		{"Not Owner", domain.ErrNotificationNotFound, http.StatusNotFound},
+		{"Service Error", errors.New("db error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			mockLogger := new(MockLogger)
+			mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+			handler := NewNotificationHandler(mockService, nil, nil, mockLogger)
+
+			mockService.On("MarkAsRead", mock.Anything, "user-1", "n-1").Return(tt.err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("PATCH", "/notifications/n-1/read", nil)
+			c.Params = gin.Params{{Key: "id", Value: "n-1"}}
+			c.Set("user_id", "user-1")
+
+			handler.MarkAsRead(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestMarkAllAsRead(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockNotificationService)
+	handler := NewNotificationHandler(mockService, nil, nil, new(MockLogger))
+
+	mockService.On("MarkAllAsRead", mock.Anything, "user-1").Return(nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/notifications/read-all", nil)
+	c.Set("user_id", "user-1")
+
+	handler.MarkAllAsRead(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetUnreadCount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockNotificationService)
+	handler := NewNotificationHandler(mockService, nil, nil, new(MockLogger))
+
+	mockService.On("UnreadCount", mock.Anything, "user-1").Return(7, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/notifications/unread-count", nil)
+	c.Set("user_id", "user-1")
+
+	handler.GetUnreadCount(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"count": 7}`, w.Body.String())
+}
+
+func TestGetPreferences(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	mockService := new(MockNotificationService)
+	handler := NewNotificationHandler(mockService, nil, nil, new(MockLogger))
+
+	mockService.On("GetPreferences", mock.Anything, "user-1").Return(domain.DefaultPreferences("user-1"), nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/notifications/preferences", nil)
+	c.Set("user_id", "user-1")
+
+	handler.GetPreferences(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"OUTBID":{"in_app":true,"websocket":true,"email":true,"webhook":false}`)
+}
+
+func TestUpdatePreferences(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"Updated", `{"channels": {"NEW_BID": {"in_app": true}}, "quiet_hours": {"start": "22:00", "end": "07:00", "time_zone": "UTC"}}`, nil, http.StatusOK},
+		{"Invalid", `{"digest": "MONTHLY"}`, domain.ErrInvalidPreferences, http.StatusBadRequest},
+		{"Service Error", `{}`, errors.New("db error"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockNotificationService)
+			mockLogger := new(MockLogger)
+			mockLogger.On("Error", mock.Anything, mock.Anything).Return()
+			handler := NewNotificationHandler(mockService, nil, nil, mockLogger)
+
+			// The body can't pick whose preferences are updated
+			mockService.On("UpdatePreferences", mock.Anything, mock.MatchedBy(func(p *domain.Preferences) bool {
+				return p.UserID == "user-1"
+			})).Return(tt.err)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest("PUT", "/notifications/preferences", strings.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Set("user_id", "user-1")
+
+			handler.UpdatePreferences(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestStreamNotifications_Rejected(t *testing.T) {
+	handler := NewNotificationHandler(new(MockNotificationService), nil, nil, new(MockLogger))
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/stream", nil)
+	handler.StreamNotifications(c)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/stream", nil)
+	c.Request.Header.Set("Last-Event-ID", "abc")
+	c.Set("user_id", "user-1")
+	handler.StreamNotifications(c)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -6,7 +6,7 @@ import (
 	"github.com/temesgen-abebayehu/bidflow/backend/common/middleware"
 )
 
-func SetupRouter(h *NotificationHandler, tm *auth.TokenManager) *gin.Engine {
+func SetupRouter(h *NotificationHandler, wh *WebhookHandler, tm *auth.TokenManager) *gin.Engine {
 	r := gin.Default()
 
 	// Global Middleware
@@ -22,6 +22,21 @@ func SetupRouter(h *NotificationHandler, tm *auth.TokenManager) *gin.Engine {
 		protected.Use(middleware.AuthMiddleware(tm))
 		{
 			protected.GET("", h.GetNotifications)
+			protected.GET("/unread-count", h.GetUnreadCount)
+			protected.GET("/stream", h.StreamNotifications)
+			protected.POST("/read-all", h.MarkAllAsRead)
+			protected.PATCH("/:id/read", h.MarkAsRead)
+			protected.GET("/preferences", h.GetPreferences)
+			protected.PUT("/preferences", h.UpdatePreferences)
+
+			webhooks := protected.Group("/webhooks")
+			webhooks.POST("", wh.CreateWebhook)
+			webhooks.GET("", wh.ListWebhooks)
+			webhooks.GET("/:id", wh.GetWebhook)
+			webhooks.PUT("/:id", wh.UpdateWebhook)
+			webhooks.DELETE("/:id", wh.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", wh.ListDeliveries)
+			webhooks.POST("/:id/deliveries/:delivery_id/replay", wh.ReplayDelivery)
 		}
 	}
 
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+const defaultDeliveryLimit = 50
+
+type WebhookHandler struct {
+	service domain.WebhookService
+	log     logger.Logger
+}
+
+func NewWebhookHandler(service domain.WebhookService, log logger.Logger) *WebhookHandler {
+	return &WebhookHandler{service: service, log: log}
+}
+
+type createWebhookRequest struct {
+	URL        string                  `json:"url" binding:"required"`
+	EventTypes []string                `json:"event_types" binding:"required"`
+	OwnerType  domain.WebhookOwnerType `json:"owner_type"` // USER by default
+}
+
+type updateWebhookRequest struct {
+	URL        string   `json:"url" binding:"required"`
+	EventTypes []string `json:"event_types" binding:"required"`
+	// Active re-enables a subscription disabled after repeated failures.
+	Active bool `json:"active"`
+}
+
+// CreateWebhook subscribes the caller, or their company, to events. The response is
+// the only one that carries the signing secret.
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	caller, ok := webhookCaller(c)
+	if !ok {
+		return
+	}
+
+	var req createWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sub := &domain.WebhookSubscription{URL: req.URL, EventTypes: req.EventTypes, OwnerType: req.OwnerType}
+
+	if err := h.service.Create(c.Request.Context(), caller, sub); err != nil {
+		h.respondError(c, err, "Failed to create webhook")
+		return
+	}
+
+	c.JSON(http.StatusCreated, sub)
+}
+
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	caller, ok := webhookCaller(c)
+	if !ok {
+		return
+	}
+
+	subs, err := h.service.List(c.Request.Context(), caller)
+	if err != nil {
+		h.respondError(c, err, "Failed to list webhooks")
+		return
+	}
+	if subs == nil {
+		subs = []domain.WebhookSubscription{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"webhooks": subs})
+}
+
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	caller, ok := webhookCaller(c)
+	if !ok {
+		return
+	}
+
+	sub, err := h.service.Get(c.Request.Context(), caller, c.Param("id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to get webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, sub)
+}
+
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	caller, ok := webhookCaller(c)
+	if !ok {
+		return
+	}
+
+	var req updateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	sub := &domain.WebhookSubscription{ID: c.Param("id"), URL: req.URL, EventTypes: req.EventTypes, Active: req.Active}
+
+	if err := h.service.Update(c.Request.Context(), caller, sub); err != nil {
+		h.respondError(c, err, "Failed to update webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, sub)
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	caller, ok := webhookCaller(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), caller, c.Param("id")); err != nil {
+		h.respondError(c, err, "Failed to delete webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted"})
+}
+
+// ListDeliveries returns the webhook's delivery log, newest first.
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	caller, ok := webhookCaller(c)
+	if !ok {
+		return
+	}
+
+	limit := defaultDeliveryLimit
+	if l := c.Query("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit < 1 || limit > 200 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 200"})
+			return
+		}
+	}
+
+	deliveries, err := h.service.ListDeliveries(c.Request.Context(), caller, c.Param("id"), limit)
+	if err != nil {
+		h.respondError(c, err, "Failed to list webhook deliveries")
+		return
+	}
+	if deliveries == nil {
+		deliveries = []domain.WebhookDelivery{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": deliveries})
+}
+
+// ReplayDelivery sends a past delivery's event again, as a new delivery.
+func (h *WebhookHandler) ReplayDelivery(c *gin.Context) {
+	caller, ok := webhookCaller(c)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.Replay(c.Request.Context(), caller, c.Param("id"), c.Param("delivery_id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to replay webhook delivery")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+func (h *WebhookHandler) respondError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidWebhook):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrWebhookNotFound), errors.Is(err, domain.ErrDeliveryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrWebhookDisabled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.log.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
+
+// webhookCaller reads the caller from the auth middleware, and responds 401 if there
+// is none.
+func webhookCaller(c *gin.Context) (domain.WebhookCaller, bool) {
+	userID := c.GetString("user_id")
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return domain.WebhookCaller{}, false
+	}
+	return domain.WebhookCaller{UserID: userID, CompanyID: c.GetString("company_id")}, true
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) Create(ctx context.Context, caller domain.WebhookCaller, sub *domain.WebhookSubscription) error {
+	args := m.Called(ctx, caller, sub)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) List(ctx context.Context, caller domain.WebhookCaller) ([]domain.WebhookSubscription, error) {
+	args := m.Called(ctx, caller)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookService) Get(ctx context.Context, caller domain.WebhookCaller, id string) (*domain.WebhookSubscription, error) {
+	args := m.Called(ctx, caller, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookService) Update(ctx context.Context, caller domain.WebhookCaller, sub *domain.WebhookSubscription) error {
+	args := m.Called(ctx, caller, sub)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) Delete(ctx context.Context, caller domain.WebhookCaller, id string) error {
+	args := m.Called(ctx, caller, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) ListDeliveries(ctx context.Context, caller domain.WebhookCaller, subscriptionID string, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, caller, subscriptionID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookService) Replay(ctx context.Context, caller domain.WebhookCaller, subscriptionID, deliveryID string) (*domain.WebhookDelivery, error) {
+	args := m.Called(ctx, caller, subscriptionID, deliveryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookService) Publish(ctx context.Context, eventType string, payload []byte, userIDs, companyIDs []string) error {
+	args := m.Called(ctx, eventType, payload, userIDs, companyIDs)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) AddCompanyMembers(ctx context.Context, companyID string, userIDs []string) error {
+	args := m.Called(ctx, companyID, userIDs)
+	return args.Error(0)
+}
+
+var webhookCallerAda = domain.WebhookCaller{UserID: "user-1", CompanyID: "co-1"}
+
+func newWebhookContext(method, target, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(method, target, strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set("user_id", "user-1")
+	c.Set("company_id", "co-1")
+	return c, w
+}
+
+func TestCreateWebhook(t *testing.T) {
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService, new(MockLogger))
+
+	mockService.On("Create", mock.Anything, webhookCallerAda, mock.MatchedBy(func(s *domain.WebhookSubscription) bool {
+		return s.URL == "https://example.com/hook" && s.OwnerType == domain.WebhookOwnerCompany
+	})).Run(func(args mock.Arguments) {
+		args.Get(2).(*domain.WebhookSubscription).Secret = "whsec_x"
+	}).Return(nil)
+
+	c, w := newWebhookContext("POST", "/webhooks", `{"url": "https://example.com/hook", "event_types": ["bid.placed"], "owner_type": "COMPANY"}`)
+	handler.CreateWebhook(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var got domain.WebhookSubscription
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Equal(t, "whsec_x", got.Secret)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateWebhook_Invalid(t *testing.T) {
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService, new(MockLogger))
+
+	mockService.On("Create", mock.Anything, webhookCallerAda, mock.Anything).Return(domain.ErrInvalidWebhook)
+
+	c, w := newWebhookContext("POST", "/webhooks", `{"url": "ftp://example.com", "event_types": ["bid.placed"]}`)
+	handler.CreateWebhook(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateWebhook_Unauthorized(t *testing.T) {
+	handler := NewWebhookHandler(new(MockWebhookService), new(MockLogger))
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/webhooks", strings.NewReader(`{}`))
+	handler.CreateWebhook(c)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestGetWebhook_NotFound(t *testing.T) {
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService, new(MockLogger))
+
+	mockService.On("Get", mock.Anything, webhookCallerAda, "wh-9").Return(nil, domain.ErrWebhookNotFound)
+
+	c, w := newWebhookContext("GET", "/webhooks/wh-9", "")
+	c.Params = gin.Params{{Key: "id", Value: "wh-9"}}
+	handler.GetWebhook(c)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestListDeliveries(t *testing.T) {
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService, new(MockLogger))
+
+	mockService.On("ListDeliveries", mock.Anything, webhookCallerAda, "wh-1", 10).
+		Return([]domain.WebhookDelivery{{ID: "d-1", Status: domain.DeliveryStatusFailed}}, nil)
+
+	c, w := newWebhookContext("GET", "/webhooks/wh-1/deliveries?limit=10", "")
+	c.Params = gin.Params{{Key: "id", Value: "wh-1"}}
+	handler.ListDeliveries(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"FAILED"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestReplayDelivery(t *testing.T) {
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService, new(MockLogger))
+
+	mockService.On("Replay", mock.Anything, webhookCallerAda, "wh-1", "d-1").
+		Return(&domain.WebhookDelivery{ID: "d-2", ReplayOf: "d-1", Status: domain.DeliveryStatusPending}, nil)
+	mockService.On("Replay", mock.Anything, webhookCallerAda, "wh-2", "d-1").Return(nil, domain.ErrWebhookDisabled)
+
+	c, w := newWebhookContext("POST", "/webhooks/wh-1/deliveries/d-1/replay", "")
+	c.Params = gin.Params{{Key: "id", Value: "wh-1"}, {Key: "delivery_id", Value: "d-1"}}
+	handler.ReplayDelivery(c)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Contains(t, w.Body.String(), `"replay_of":"d-1"`)
+
+	c, w = newWebhookContext("POST", "/webhooks/wh-2/deliveries/d-1/replay", "")
+	c.Params = gin.Params{{Key: "id", Value: "wh-2"}, {Key: "delivery_id", Value: "d-1"}}
+	handler.ReplayDelivery(c)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
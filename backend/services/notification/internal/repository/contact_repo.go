@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+type contactRepo struct {
+	db *sql.DB
+}
+
+func NewContactRepo(db *sql.DB) domain.ContactRepository {
+	return &contactRepo{db: db}
+}
+
+func (r *contactRepo) SaveEmail(ctx context.Context, userID, email string) error {
+	query := `
+		INSERT INTO user_contacts (user_id, email, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET email = EXCLUDED.email, updated_at = EXCLUDED.updated_at
+	`
+	_, err := r.db.ExecContext(ctx, query, userID, email, time.Now())
+	return err
+}
+
+func (r *contactRepo) GetEmail(ctx context.Context, userID string) (string, error) {
+	var email string
+	err := r.db.QueryRowContext(ctx, `SELECT email FROM user_contacts WHERE user_id = $1`, userID).Scan(&email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return email, err
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSaveEmail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewContactRepo(db)
+
+	mock.ExpectExec("INSERT INTO user_contacts .* ON CONFLICT \\(user_id\\) DO UPDATE").
+		WithArgs("user-1", "ada@example.com", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SaveEmail(context.Background(), "user-1", "ada@example.com")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetEmail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewContactRepo(db)
+
+	mock.ExpectQuery("SELECT email FROM user_contacts WHERE user_id = \\$1").
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("ada@example.com"))
+	mock.ExpectQuery("SELECT email FROM user_contacts WHERE user_id = \\$1").
+		WithArgs("user-2").
+		WillReturnRows(sqlmock.NewRows([]string{"email"}))
+
+	email, err := repo.GetEmail(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ada@example.com", email)
+
+	// No address is not an error
+	email, err = repo.GetEmail(context.Background(), "user-2")
+	assert.NoError(t, err)
+	assert.Empty(t, email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
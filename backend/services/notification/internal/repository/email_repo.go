@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+type emailRepo struct {
+	db *sql.DB
+}
+
+func NewEmailRepo(db *sql.DB) domain.EmailQueue {
+	return &emailRepo{db: db}
+}
+
+func (r *emailRepo) Enqueue(ctx context.Context, e *domain.Email) error {
+	query := `
+		INSERT INTO email_queue (recipient, user_id, notification_id, type, title, message, resource_id, notified_at, digest, status, send_after, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id
+	`
+	n := e.Notification
+	if e.Status == "" {
+		e.Status = domain.EmailStatusPending
+	}
+	return r.db.QueryRowContext(ctx, query,
+		e.Recipient, n.UserID, n.ID, n.Type, n.Title, n.Message, n.ResourceID, n.CreatedAt, e.Digest, e.Status, e.SendAfter, time.Now(),
+	).Scan(&e.ID)
+}
+
+// Claim moves the claimed emails' send_after past the lease, so a worker that dies
+// mid-send leaves them to be retried once it runs out.
+func (r *emailRepo) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.Email, error) {
+	query := `
+		UPDATE email_queue SET send_after = $2
+		WHERE id IN (
+			SELECT id FROM email_queue
+			WHERE status = 'PENDING' AND send_after <= $1
+			ORDER BY send_after, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, recipient, user_id, notification_id, type, title, message, resource_id, notified_at, digest, attempts
+	`
+	rows, err := r.db.QueryContext(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var emails []domain.Email
+	for rows.Next() {
+		e := domain.Email{Status: domain.EmailStatusPending}
+		n := &e.Notification
+		if err := rows.Scan(&e.ID, &e.Recipient, &n.UserID, &n.ID, &n.Type, &n.Title, &n.Message, &n.ResourceID, &n.CreatedAt, &e.Digest, &e.Attempts); err != nil {
+			return nil, err
+		}
+		emails = append(emails, e)
+	}
+	return emails, rows.Err()
+}
+
+func (r *emailRepo) MarkSent(ctx context.Context, ids []int64, at time.Time) error {
+	query := `UPDATE email_queue SET status = 'SENT', attempts = attempts + 1, sent_at = $2 WHERE id = ANY($1)`
+	_, err := r.db.ExecContext(ctx, query, pq.Array(ids), at)
+	return err
+}
+
+func (r *emailRepo) Retry(ctx context.Context, ids []int64, lastError string, at time.Time) error {
+	query := `UPDATE email_queue SET attempts = attempts + 1, last_error = $2, send_after = $3 WHERE id = ANY($1)`
+	_, err := r.db.ExecContext(ctx, query, pq.Array(ids), lastError, at)
+	return err
+}
+
+func (r *emailRepo) MarkFailed(ctx context.Context, ids []int64, lastError string) error {
+	query := `UPDATE email_queue SET status = 'FAILED', attempts = attempts + 1, last_error = $2 WHERE id = ANY($1)`
+	_, err := r.db.ExecContext(ctx, query, pq.Array(ids), lastError)
+	return err
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+func TestEnqueueEmail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewEmailRepo(db)
+	sendAfter := time.Now()
+	email := &domain.Email{
+		Recipient:    "ada@example.com",
+		Notification: domain.Notification{ID: "n-1", UserID: "user-1", Type: domain.NotificationTypeOutbid, Title: "Outbid", Message: "msg", ResourceID: "a-1"},
+		Digest:       domain.DigestImmediate,
+		SendAfter:    sendAfter,
+	}
+
+	mock.ExpectQuery("INSERT INTO email_queue .* RETURNING id").
+		WithArgs("ada@example.com", "user-1", "n-1", domain.NotificationTypeOutbid, "Outbid", "msg", "a-1", sqlmock.AnyArg(), domain.DigestImmediate, domain.EmailStatusPending, sendAfter, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(42))
+
+	err = repo.Enqueue(context.Background(), email)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), email.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimEmails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewEmailRepo(db)
+	now := time.Now()
+	notifiedAt := now.Add(-time.Hour)
+
+	rows := sqlmock.NewRows([]string{"id", "recipient", "user_id", "notification_id", "type", "title", "message", "resource_id", "notified_at", "digest", "attempts"}).
+		AddRow(7, "ada@example.com", "user-1", "n-1", "OUTBID", "Outbid", "msg", "a-1", notifiedAt, "HOURLY", 2)
+	mock.ExpectQuery("UPDATE email_queue SET send_after = \\$2 WHERE id IN \\(.* FOR UPDATE SKIP LOCKED \\) RETURNING").
+		WithArgs(now, now.Add(time.Minute), 10).
+		WillReturnRows(rows)
+
+	emails, err := repo.Claim(context.Background(), now, time.Minute, 10)
+	assert.NoError(t, err)
+	assert.Len(t, emails, 1)
+	assert.Equal(t, int64(7), emails[0].ID)
+	assert.Equal(t, "n-1", emails[0].Notification.ID)
+	assert.Equal(t, domain.DigestHourly, emails[0].Digest)
+	assert.Equal(t, 2, emails[0].Attempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEmailStatusUpdates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewEmailRepo(db)
+	now := time.Now()
+	ids := []int64{1, 2}
+
+	mock.ExpectExec("UPDATE email_queue SET status = 'SENT'").
+		WithArgs(pq.Array(ids), now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("UPDATE email_queue SET attempts = attempts \\+ 1, last_error = \\$2, send_after = \\$3").
+		WithArgs(pq.Array(ids), "451 Try again later", now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("UPDATE email_queue SET status = 'FAILED'").
+		WithArgs(pq.Array(ids), "550 No such user").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	assert.NoError(t, repo.MarkSent(context.Background(), ids, now))
+	assert.NoError(t, repo.Retry(context.Background(), ids, "451 Try again later", now))
+	assert.NoError(t, repo.MarkFailed(context.Background(), ids, "550 No such user"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+type participantRepo struct {
+	db *sql.DB
+}
+
+func NewParticipantRepo(db *sql.DB) domain.ParticipantRepository {
+	return &participantRepo{db: db}
+}
+
+// Add records userID as a bidder on the auction. Later bids, and redelivered events,
+// keep the time of the first one.
+func (r *participantRepo) Add(ctx context.Context, auctionID, userID string, at time.Time) error {
+	query := `
+		INSERT INTO auction_participants (auction_id, user_id, first_bid_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (auction_id, user_id) DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query, auctionID, userID, at)
+	return err
+}
+
+func (r *participantRepo) List(ctx context.Context, auctionID string) ([]string, error) {
+	query := `SELECT user_id FROM auction_participants WHERE auction_id = $1 ORDER BY first_bid_at`
+	rows, err := r.db.QueryContext(ctx, query, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, rows.Err()
+}
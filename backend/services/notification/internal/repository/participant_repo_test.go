@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddParticipant(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewParticipantRepo(db)
+	at := time.Now()
+
+	mock.ExpectExec("INSERT INTO auction_participants .* ON CONFLICT \\(auction_id, user_id\\) DO NOTHING").
+		WithArgs("auction-1", "user-1", at).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.Add(context.Background(), "auction-1", "user-1", at)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListParticipants(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewParticipantRepo(db)
+
+	rows := sqlmock.NewRows([]string{"user_id"}).AddRow("user-1").AddRow("user-2")
+	mock.ExpectQuery("SELECT user_id FROM auction_participants WHERE auction_id = \\$1").
+		WithArgs("auction-1").
+		WillReturnRows(rows)
+
+	participants, err := repo.List(context.Background(), "auction-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user-1", "user-2"}, participants)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -16,30 +16,77 @@ func NewPostgresRepo(db *sql.DB) domain.NotificationRepository {
 	return &postgresRepo{db: db}
 }
 
+// Create stores the notification under the user's next sequence number, which it sets
+// on n.
 func (r *postgresRepo) Create(ctx context.Context, n *domain.Notification) error {
 	query := `
-		INSERT INTO notifications (id, user_id, type, title, message, resource_id, is_read, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		WITH next AS (` + nextSeqQuery("$2") + `)
+		INSERT INTO notifications (id, user_id, type, title, message, resource_id, is_read, created_at, seq)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, next.seq FROM next
+		RETURNING seq
 	`
 	if n.CreatedAt.IsZero() {
 		n.CreatedAt = time.Now()
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
+	return r.db.QueryRowContext(ctx, query,
 		n.ID, n.UserID, n.Type, n.Title, n.Message, n.ResourceID, n.IsRead, n.CreatedAt,
-	)
-	return err
+	).Scan(&n.Seq)
+}
+
+func (r *postgresRepo) NextSeq(ctx context.Context, userID string) (int64, error) {
+	var seq int64
+	err := r.db.QueryRowContext(ctx, nextSeqQuery("$1"), userID).Scan(&seq)
+	return seq, err
 }
 
-func (r *postgresRepo) ListByUserID(ctx context.Context, userID string, limit int) ([]domain.Notification, error) {
+// nextSeqQuery bumps the sequence of the user in the given parameter and returns the
+// new value.
+func nextSeqQuery(userParam string) string {
+	return `
+		INSERT INTO notification_sequences (user_id, seq) VALUES (` + userParam + `, 1)
+		ON CONFLICT (user_id) DO UPDATE SET seq = notification_sequences.seq + 1
+		RETURNING seq
+	`
+}
+
+func (r *postgresRepo) ListByUserID(ctx context.Context, userID string, after *domain.Cursor, limit int) ([]domain.Notification, error) {
 	query := `
-		SELECT id, user_id, type, title, message, resource_id, is_read, created_at
+		SELECT id, user_id, type, title, message, resource_id, is_read, created_at, seq
 		FROM notifications
 		WHERE user_id = $1
-		ORDER BY created_at DESC
+		ORDER BY created_at DESC, id DESC
 		LIMIT $2
 	`
-	rows, err := r.db.QueryContext(ctx, query, userID, limit)
+	args := []interface{}{userID, limit}
+	if after != nil {
+		query = `
+			SELECT id, user_id, type, title, message, resource_id, is_read, created_at, seq
+			FROM notifications
+			WHERE user_id = $1 AND (created_at, id) < ($3, $4)
+			ORDER BY created_at DESC, id DESC
+			LIMIT $2
+		`
+		args = append(args, after.CreatedAt, after.ID)
+	}
+
+	return r.list(ctx, query, args...)
+}
+
+// ListSince returns the user's notifications after afterSeq, oldest first.
+func (r *postgresRepo) ListSince(ctx context.Context, userID string, afterSeq int64, limit int) ([]domain.Notification, error) {
+	query := `
+		SELECT id, user_id, type, title, message, resource_id, is_read, created_at, seq
+		FROM notifications
+		WHERE user_id = $1 AND seq > $2
+		ORDER BY seq
+		LIMIT $3
+	`
+	return r.list(ctx, query, userID, afterSeq, limit)
+}
+
+func (r *postgresRepo) list(ctx context.Context, query string, args ...interface{}) ([]domain.Notification, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -49,17 +96,62 @@ func (r *postgresRepo) ListByUserID(ctx context.Context, userID string, limit in
 	for rows.Next() {
 		var n domain.Notification
 		if err := rows.Scan(
-			&n.ID, &n.UserID, &n.Type, &n.Title, &n.Message, &n.ResourceID, &n.IsRead, &n.CreatedAt,
+			&n.ID, &n.UserID, &n.Type, &n.Title, &n.Message, &n.ResourceID, &n.IsRead, &n.CreatedAt, &n.Seq,
 		); err != nil {
 			return nil, err
 		}
 		notifications = append(notifications, n)
 	}
-	return notifications, nil
+	return notifications, rows.Err()
+}
+
+func (r *postgresRepo) MarkAsRead(ctx context.Context, userID, id string) error {
+	query := `UPDATE notifications SET is_read = TRUE WHERE id = $1 AND user_id = $2`
+	res, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		// Someone else's notifications are as good as missing
+		return domain.ErrNotificationNotFound
+	}
+	return nil
 }
 
-func (r *postgresRepo) MarkAsRead(ctx context.Context, id string) error {
-	query := `UPDATE notifications SET is_read = TRUE WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
+func (r *postgresRepo) MarkAllAsRead(ctx context.Context, userID string) error {
+	query := `UPDATE notifications SET is_read = TRUE WHERE user_id = $1 AND NOT is_read`
+	_, err := r.db.ExecContext(ctx, query, userID)
 	return err
 }
+
+func (r *postgresRepo) CountUnread(ctx context.Context, userID string) (int, error) {
+	query := `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND NOT is_read`
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *postgresRepo) ListWatchers(ctx context.Context, auctionID string) ([]string, error) {
+	query := `SELECT DISTINCT user_id FROM notifications WHERE resource_id = $1`
+	rows, err := r.db.QueryContext(ctx, query, auctionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var userIDs []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		userIDs = append(userIDs, userID)
+	}
+	return userIDs, rows.Err()
+}
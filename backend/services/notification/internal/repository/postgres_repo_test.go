@@ -28,12 +28,53 @@ func TestCreate(t *testing.T) {
 		CreatedAt:  time.Now(),
 	}
 
-	mock.ExpectExec("INSERT INTO notifications").
+	mock.ExpectQuery("WITH next AS \\(.*INSERT INTO notification_sequences .* INSERT INTO notifications .* RETURNING seq").
 		WithArgs(notification.ID, notification.UserID, notification.Type, notification.Title, notification.Message, notification.ResourceID, notification.IsRead, notification.CreatedAt).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+		WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(12))
 
 	err = repo.Create(context.Background(), notification)
 	assert.NoError(t, err)
+	assert.Equal(t, int64(12), notification.Seq)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNextSeq(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+
+	mock.ExpectQuery("INSERT INTO notification_sequences .* ON CONFLICT \\(user_id\\) DO UPDATE SET seq = notification_sequences.seq \\+ 1 RETURNING seq").
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"seq"}).AddRow(13))
+
+	seq, err := repo.NextSeq(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(13), seq)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListSince(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "type", "title", "message", "resource_id", "is_read", "created_at", "seq"}).
+		AddRow("n-4", "user-1", "OUTBID", "Outbid", "msg", "a-1", false, now, 4).
+		AddRow("n-6", "user-1", "NEW_BID", "New Bid", "msg", "a-2", false, now, 6)
+	mock.ExpectQuery("SELECT .* FROM notifications WHERE user_id = \\$1 AND seq > \\$2 ORDER BY seq LIMIT \\$3").
+		WithArgs("user-1", int64(3), 100).
+		WillReturnRows(rows)
+
+	notifications, err := repo.ListSince(context.Background(), "user-1", 3, 100)
+	assert.NoError(t, err)
+	assert.Len(t, notifications, 2)
+	assert.Equal(t, int64(4), notifications[0].Seq)
+	assert.Equal(t, int64(6), notifications[1].Seq)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -48,20 +89,41 @@ func TestListByUserID(t *testing.T) {
 	limit := 10
 	createdAt := time.Now()
 
-	rows := sqlmock.NewRows([]string{"id", "user_id", "type", "title", "message", "resource_id", "is_read", "created_at"}).
-		AddRow("1", userID, "INFO", "Test", "Message", "res-1", false, createdAt)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "type", "title", "message", "resource_id", "is_read", "created_at", "seq"}).
+		AddRow("1", userID, "INFO", "Test", "Message", "res-1", false, createdAt, 1)
 
-	mock.ExpectQuery("SELECT id, user_id, type, title, message, resource_id, is_read, created_at FROM notifications").
+	mock.ExpectQuery("SELECT id, user_id, type, title, message, resource_id, is_read, created_at, seq FROM notifications").
 		WithArgs(userID, limit).
 		WillReturnRows(rows)
 
-	notifications, err := repo.ListByUserID(context.Background(), userID, limit)
+	notifications, err := repo.ListByUserID(context.Background(), userID, nil, limit)
 	assert.NoError(t, err)
 	assert.Len(t, notifications, 1)
 	assert.Equal(t, "1", notifications[0].ID)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestListByUserID_AfterCursor(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+
+	after := &domain.Cursor{CreatedAt: time.Now(), ID: "5"}
+	rows := sqlmock.NewRows([]string{"id", "user_id", "type", "title", "message", "resource_id", "is_read", "created_at", "seq"}).
+		AddRow("4", "user-1", "INFO", "Test", "Message", "res-1", false, after.CreatedAt, 4)
+
+	mock.ExpectQuery("WHERE user_id = \\$1 AND \\(created_at, id\\) < \\(\\$3, \\$4\\) ORDER BY created_at DESC, id DESC").
+		WithArgs("user-1", 10, after.CreatedAt, "5").
+		WillReturnRows(rows)
+
+	notifications, err := repo.ListByUserID(context.Background(), "user-1", after, 10)
+	assert.NoError(t, err)
+	assert.Len(t, notifications, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMarkAsRead(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	assert.NoError(t, err)
@@ -71,11 +133,70 @@ func TestMarkAsRead(t *testing.T) {
 
 	id := "1"
 
-	mock.ExpectExec("UPDATE notifications SET is_read = TRUE").
-		WithArgs(id).
+	mock.ExpectExec("UPDATE notifications SET is_read = TRUE WHERE id = \\$1 AND user_id = \\$2").
+		WithArgs(id, "user-1").
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = repo.MarkAsRead(context.Background(), id)
+	err = repo.MarkAsRead(context.Background(), "user-1", id)
+	assert.NoError(t, err)
+
+	// Another user's notification is not found
+	mock.ExpectExec("UPDATE notifications SET is_read = TRUE").
+		WithArgs(id, "user-2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.MarkAsRead(context.Background(), "user-2", id)
+	assert.ErrorIs(t, err, domain.ErrNotificationNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkAllAsRead(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+
+	mock.ExpectExec("UPDATE notifications SET is_read = TRUE WHERE user_id = \\$1 AND NOT is_read").
+		WithArgs("user-1").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	err = repo.MarkAllAsRead(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountUnread(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM notifications WHERE user_id = \\$1 AND NOT is_read").
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	count, err := repo.CountUnread(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListWatchers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPostgresRepo(db)
+
+	rows := sqlmock.NewRows([]string{"user_id"}).AddRow("seller-1").AddRow("bidder-1")
+	mock.ExpectQuery("SELECT DISTINCT user_id FROM notifications WHERE resource_id = \\$1").
+		WithArgs("auction-1").
+		WillReturnRows(rows)
+
+	watchers, err := repo.ListWatchers(context.Background(), "auction-1")
 	assert.NoError(t, err)
+	assert.Equal(t, []string{"seller-1", "bidder-1"}, watchers)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
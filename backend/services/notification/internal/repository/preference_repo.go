@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+type preferenceRepo struct {
+	db *sql.DB
+}
+
+func NewPreferenceRepo(db *sql.DB) domain.PreferenceRepository {
+	return &preferenceRepo{db: db}
+}
+
+func (r *preferenceRepo) Get(ctx context.Context, userID string) (*domain.Preferences, error) {
+	query := `
+		SELECT channels, quiet_start, quiet_end, time_zone, digest, updated_at
+		FROM notification_preferences
+		WHERE user_id = $1
+	`
+	var (
+		channels                       []byte
+		quietStart, quietEnd, timeZone sql.NullString
+	)
+	prefs := &domain.Preferences{UserID: userID}
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&channels, &quietStart, &quietEnd, &timeZone, &prefs.Digest, &prefs.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(channels, &prefs.Channels); err != nil {
+		return nil, err
+	}
+	if quietStart.Valid {
+		prefs.QuietHours = &domain.QuietHours{Start: quietStart.String, End: quietEnd.String, TimeZone: timeZone.String}
+	}
+	return prefs, nil
+}
+
+func (r *preferenceRepo) Save(ctx context.Context, prefs *domain.Preferences) error {
+	query := `
+		INSERT INTO notification_preferences (user_id, channels, quiet_start, quiet_end, time_zone, digest, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO UPDATE SET
+			channels = EXCLUDED.channels,
+			quiet_start = EXCLUDED.quiet_start,
+			quiet_end = EXCLUDED.quiet_end,
+			time_zone = EXCLUDED.time_zone,
+			digest = EXCLUDED.digest,
+			updated_at = EXCLUDED.updated_at
+	`
+	return r.write(ctx, query, prefs)
+}
+
+// Init keeps any preferences the user already has, so a redelivered user.registered
+// does not undo their changes.
+func (r *preferenceRepo) Init(ctx context.Context, prefs *domain.Preferences) error {
+	query := `
+		INSERT INTO notification_preferences (user_id, channels, quiet_start, quiet_end, time_zone, digest, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO NOTHING
+	`
+	return r.write(ctx, query, prefs)
+}
+
+func (r *preferenceRepo) write(ctx context.Context, query string, prefs *domain.Preferences) error {
+	channels, err := json.Marshal(prefs.Channels)
+	if err != nil {
+		return err
+	}
+	var quietStart, quietEnd, timeZone sql.NullString
+	if q := prefs.QuietHours; q != nil {
+		quietStart = sql.NullString{String: q.Start, Valid: true}
+		quietEnd = sql.NullString{String: q.End, Valid: true}
+		timeZone = sql.NullString{String: q.TimeZone, Valid: true}
+	}
+	if prefs.UpdatedAt.IsZero() {
+		prefs.UpdatedAt = time.Now()
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		prefs.UserID, channels, quietStart, quietEnd, timeZone, prefs.Digest, prefs.UpdatedAt,
+	)
+	return err
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+func TestGetPreferences(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPreferenceRepo(db)
+	updatedAt := time.Now()
+
+	rows := sqlmock.NewRows([]string{"channels", "quiet_start", "quiet_end", "time_zone", "digest", "updated_at"}).
+		AddRow([]byte(`{"OUTBID": {"in_app": true, "websocket": false, "email": true, "webhook": false}}`), "22:00", "07:00", "Europe/Berlin", "DAILY", updatedAt)
+	mock.ExpectQuery("SELECT channels, quiet_start, quiet_end, time_zone, digest, updated_at FROM notification_preferences").
+		WithArgs("user-1").
+		WillReturnRows(rows)
+
+	prefs, err := repo.Get(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ChannelSet{InApp: true, Email: true}, prefs.ChannelsFor(domain.NotificationTypeOutbid))
+	assert.Equal(t, &domain.QuietHours{Start: "22:00", End: "07:00", TimeZone: "Europe/Berlin"}, prefs.QuietHours)
+	assert.Equal(t, domain.DigestDaily, prefs.Digest)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetPreferences_None(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPreferenceRepo(db)
+
+	mock.ExpectQuery("SELECT .* FROM notification_preferences").
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"channels", "quiet_start", "quiet_end", "time_zone", "digest", "updated_at"}))
+
+	prefs, err := repo.Get(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.Nil(t, prefs)
+}
+
+func TestSavePreferences(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPreferenceRepo(db)
+	prefs := &domain.Preferences{
+		UserID:   "user-1",
+		Channels: map[domain.NotificationType]domain.ChannelSet{domain.NotificationTypeNewBid: {InApp: true}},
+		Digest:   domain.DigestImmediate,
+	}
+
+	// No quiet hours are stored as NULLs
+	mock.ExpectExec("INSERT INTO notification_preferences .* ON CONFLICT \\(user_id\\) DO UPDATE").
+		WithArgs("user-1", []byte(`{"NEW_BID":{"in_app":true,"websocket":false,"email":false,"webhook":false}}`), nil, nil, nil, domain.DigestImmediate, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.Save(context.Background(), prefs)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInitPreferences(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPreferenceRepo(db)
+
+	mock.ExpectExec("INSERT INTO notification_preferences .* ON CONFLICT \\(user_id\\) DO NOTHING").
+		WithArgs("user-1", sqlmock.AnyArg(), nil, nil, nil, domain.DigestImmediate, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.Init(context.Background(), domain.DefaultPreferences("user-1"))
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+type presenceRepo struct {
+	db *sql.DB
+}
+
+func NewPresenceRepo(db *sql.DB) domain.PresenceRepository {
+	return &presenceRepo{db: db}
+}
+
+func (r *presenceRepo) Join(ctx context.Context, instanceID, userID string, at time.Time) error {
+	query := `
+		INSERT INTO hub_presence (instance_id, user_id, seen_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, instance_id) DO UPDATE SET seen_at = EXCLUDED.seen_at
+	`
+	_, err := r.db.ExecContext(ctx, query, instanceID, userID, at)
+	return err
+}
+
+// Sync drops the instance's users that are not in userIDs and refreshes the rest, in
+// one statement.
+func (r *presenceRepo) Sync(ctx context.Context, instanceID string, userIDs []string, at time.Time) error {
+	query := `
+		WITH gone AS (
+			DELETE FROM hub_presence WHERE instance_id = $1 AND user_id <> ALL($2)
+		)
+		INSERT INTO hub_presence (instance_id, user_id, seen_at)
+		SELECT $1, unnest($2::varchar[]), $3
+		ON CONFLICT (user_id, instance_id) DO UPDATE SET seen_at = EXCLUDED.seen_at
+	`
+	_, err := r.db.ExecContext(ctx, query, instanceID, pq.Array(userIDs), at)
+	return err
+}
+
+func (r *presenceRepo) Instances(ctx context.Context, userIDs []string, since time.Time) ([]string, error) {
+	query := `SELECT DISTINCT instance_id FROM hub_presence WHERE user_id = ANY($1) AND seen_at >= $2`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(userIDs), since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var instances []string
+	for rows.Next() {
+		var instanceID string
+		if err := rows.Scan(&instanceID); err != nil {
+			return nil, err
+		}
+		instances = append(instances, instanceID)
+	}
+	return instances, rows.Err()
+}
+
+func (r *presenceRepo) Sweep(ctx context.Context, before time.Time) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM hub_presence WHERE seen_at < $1`, before)
+	return err
+}
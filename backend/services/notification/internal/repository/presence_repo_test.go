@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJoinPresence(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPresenceRepo(db)
+	at := time.Now()
+
+	mock.ExpectExec("INSERT INTO hub_presence .* ON CONFLICT \\(user_id, instance_id\\) DO UPDATE").
+		WithArgs("instance-a", "user-1", at).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.Join(context.Background(), "instance-a", "user-1", at)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSyncPresence(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPresenceRepo(db)
+	at := time.Now()
+	users := []string{"user-1", "user-2"}
+
+	mock.ExpectExec("DELETE FROM hub_presence WHERE instance_id = \\$1 AND user_id <> ALL\\(\\$2\\).*INSERT INTO hub_presence").
+		WithArgs("instance-a", pq.Array(users), at).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = repo.Sync(context.Background(), "instance-a", users, at)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPresenceInstances(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewPresenceRepo(db)
+	since := time.Now().Add(-time.Minute)
+	users := []string{"user-1"}
+
+	rows := sqlmock.NewRows([]string{"instance_id"}).AddRow("instance-a").AddRow("instance-b")
+	mock.ExpectQuery("SELECT DISTINCT instance_id FROM hub_presence WHERE user_id = ANY\\(\\$1\\) AND seen_at >= \\$2").
+		WithArgs(pq.Array(users), since).
+		WillReturnRows(rows)
+
+	instances, err := repo.Instances(context.Background(), users, since)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"instance-a", "instance-b"}, instances)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+const subscriptionColumns = `id, owner_type, owner_id, url, event_types, secret, active, consecutive_failures, disabled_at, created_by, created_at, updated_at`
+
+type webhookRepo struct {
+	db *sql.DB
+}
+
+func NewWebhookRepo(db *sql.DB) domain.WebhookRepository {
+	return &webhookRepo{db: db}
+}
+
+func (r *webhookRepo) Create(ctx context.Context, s *domain.WebhookSubscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (` + subscriptionColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		s.ID, s.OwnerType, s.OwnerID, s.URL, pq.Array(s.EventTypes), s.Secret, s.Active, s.ConsecutiveFailures, s.DisabledAt, s.CreatedBy, s.CreatedAt, s.UpdatedAt,
+	)
+	return err
+}
+
+func (r *webhookRepo) Get(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+	s, err := scanSubscription(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return s, err
+}
+
+func (r *webhookRepo) ListByOwner(ctx context.Context, userID, companyID string) ([]domain.WebhookSubscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions
+		WHERE (owner_type = 'USER' AND owner_id = $1) OR (owner_type = 'COMPANY' AND owner_id = $2 AND $2 <> '')
+		ORDER BY created_at
+	`
+	return r.list(ctx, query, userID, companyID)
+}
+
+func (r *webhookRepo) Update(ctx context.Context, s *domain.WebhookSubscription) error {
+	query := `
+		UPDATE webhook_subscriptions
+		SET url = $2, event_types = $3, active = $4, consecutive_failures = $5, disabled_at = $6, updated_at = $7
+		WHERE id = $1
+	`
+	res, err := r.db.ExecContext(ctx, query, s.ID, s.URL, pq.Array(s.EventTypes), s.Active, s.ConsecutiveFailures, s.DisabledAt, s.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	return notFoundIfNone(res, domain.ErrWebhookNotFound)
+}
+
+func (r *webhookRepo) Delete(ctx context.Context, id string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return notFoundIfNone(res, domain.ErrWebhookNotFound)
+}
+
+func (r *webhookRepo) ListMatching(ctx context.Context, eventType string, userIDs, companyIDs []string) ([]domain.WebhookSubscription, error) {
+	query := `
+		SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions
+		WHERE active AND $1 = ANY(event_types) AND (
+			(owner_type = 'USER' AND owner_id = ANY($2))
+			OR (owner_type = 'COMPANY' AND (
+				owner_id = ANY($3)
+				OR owner_id IN (SELECT company_id FROM company_members WHERE user_id = ANY($2))
+			))
+		)
+	`
+	return r.list(ctx, query, eventType, pq.Array(userIDs), pq.Array(companyIDs))
+}
+
+func (r *webhookRepo) RecordSuccess(ctx context.Context, id string) error {
+	query := `UPDATE webhook_subscriptions SET consecutive_failures = 0 WHERE id = $1 AND consecutive_failures > 0`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+func (r *webhookRepo) RecordFailure(ctx context.Context, id string, disableAfter int, at time.Time) (bool, error) {
+	query := `
+		UPDATE webhook_subscriptions
+		SET consecutive_failures = consecutive_failures + 1,
+			active = active AND consecutive_failures + 1 < $2,
+			disabled_at = CASE WHEN active AND consecutive_failures + 1 >= $2 THEN $3 ELSE disabled_at END
+		WHERE id = $1
+		RETURNING active
+	`
+	var active bool
+	err := r.db.QueryRowContext(ctx, query, id, disableAfter, at).Scan(&active)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, domain.ErrWebhookNotFound
+	}
+	return active, err
+}
+
+func (r *webhookRepo) AddCompanyMembers(ctx context.Context, companyID string, userIDs []string) error {
+	query := `
+		INSERT INTO company_members (company_id, user_id)
+		SELECT $1, unnest($2::varchar[])
+		ON CONFLICT (company_id, user_id) DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query, companyID, pq.Array(userIDs))
+	return err
+}
+
+func (r *webhookRepo) list(ctx context.Context, query string, args ...interface{}) ([]domain.WebhookSubscription, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []domain.WebhookSubscription
+	for rows.Next() {
+		s, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, *s)
+	}
+	return subs, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSubscription(row scanner) (*domain.WebhookSubscription, error) {
+	var s domain.WebhookSubscription
+	var disabledAt sql.NullTime
+	err := row.Scan(&s.ID, &s.OwnerType, &s.OwnerID, &s.URL, pq.Array(&s.EventTypes), &s.Secret, &s.Active, &s.ConsecutiveFailures, &disabledAt, &s.CreatedBy, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if disabledAt.Valid {
+		s.DisabledAt = &disabledAt.Time
+	}
+	return &s, nil
+}
+
+func notFoundIfNone(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
+
+const deliveryColumns = `id, subscription_id, event_type, payload, status, attempts, response_status, last_error, next_attempt_at, replay_of, created_at, delivered_at`
+
+type webhookDeliveryRepo struct {
+	db *sql.DB
+}
+
+func NewWebhookDeliveryRepo(db *sql.DB) domain.WebhookDeliveryRepository {
+	return &webhookDeliveryRepo{db: db}
+}
+
+func (r *webhookDeliveryRepo) Create(ctx context.Context, d *domain.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (id, subscription_id, event_type, payload, status, attempts, next_attempt_at, replay_of, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	var replayOf sql.NullString
+	if d.ReplayOf != "" {
+		replayOf = sql.NullString{String: d.ReplayOf, Valid: true}
+	}
+	_, err := r.db.ExecContext(ctx, query,
+		d.ID, d.SubscriptionID, d.EventType, []byte(d.Payload), d.Status, d.Attempts, d.NextAttemptAt, replayOf, d.CreatedAt,
+	)
+	return err
+}
+
+func (r *webhookDeliveryRepo) Get(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+	d, err := scanDelivery(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrDeliveryNotFound
+	}
+	return d, err
+}
+
+func (r *webhookDeliveryRepo) ListBySubscription(ctx context.Context, subscriptionID string, limit int) ([]domain.WebhookDelivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + ` FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`
+	return r.list(ctx, query, subscriptionID, limit)
+}
+
+// Claim moves the claimed deliveries' next attempt past the lease, so a worker that
+// dies mid-send leaves them to be retried once it runs out.
+func (r *webhookDeliveryRepo) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	query := `
+		UPDATE webhook_deliveries SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'PENDING' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, created_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + deliveryColumns
+	return r.list(ctx, query, now, now.Add(lease), limit)
+}
+
+func (r *webhookDeliveryRepo) MarkSucceeded(ctx context.Context, id string, responseStatus int, at time.Time) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'SUCCEEDED', attempts = attempts + 1, response_status = $2, last_error = NULL, delivered_at = $3
+		WHERE id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query, id, responseStatus, at)
+	return err
+}
+
+func (r *webhookDeliveryRepo) Retry(ctx context.Context, id string, responseStatus int, lastError string, at time.Time) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1, response_status = $2, last_error = $3, next_attempt_at = $4
+		WHERE id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query, id, responseStatus, lastError, at)
+	return err
+}
+
+func (r *webhookDeliveryRepo) MarkFailed(ctx context.Context, id string, responseStatus int, lastError string) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = 'FAILED', attempts = attempts + 1, response_status = $2, last_error = $3
+		WHERE id = $1
+	`
+	_, err := r.db.ExecContext(ctx, query, id, responseStatus, lastError)
+	return err
+}
+
+func (r *webhookDeliveryRepo) list(ctx context.Context, query string, args ...interface{}) ([]domain.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []domain.WebhookDelivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *d)
+	}
+	return deliveries, rows.Err()
+}
+
+func scanDelivery(row scanner) (*domain.WebhookDelivery, error) {
+	var d domain.WebhookDelivery
+	var (
+		payload             []byte
+		responseStatus      sql.NullInt64
+		lastError, replayOf sql.NullString
+		deliveredAt         sql.NullTime
+	)
+	err := row.Scan(&d.ID, &d.SubscriptionID, &d.EventType, &payload, &d.Status, &d.Attempts, &responseStatus, &lastError, &d.NextAttemptAt, &replayOf, &d.CreatedAt, &deliveredAt)
+	if err != nil {
+		return nil, err
+	}
+	d.Payload = payload
+	d.ResponseStatus = int(responseStatus.Int64)
+	d.LastError = lastError.String
+	d.ReplayOf = replayOf.String
+	if deliveredAt.Valid {
+		d.DeliveredAt = &deliveredAt.Time
+	}
+	return &d, nil
+}
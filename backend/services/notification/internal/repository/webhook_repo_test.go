@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+var subscriptionRowColumns = []string{"id", "owner_type", "owner_id", "url", "event_types", "secret", "active", "consecutive_failures", "disabled_at", "created_by", "created_at", "updated_at"}
+
+func TestCreateWebhook(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+	now := time.Now()
+	sub := &domain.WebhookSubscription{
+		ID: "wh-1", OwnerType: domain.WebhookOwnerUser, OwnerID: "user-1", URL: "https://example.com/hook",
+		EventTypes: []string{"bid.placed"}, Secret: "whsec_x", Active: true, CreatedBy: "user-1", CreatedAt: now, UpdatedAt: now,
+	}
+
+	mock.ExpectExec("INSERT INTO webhook_subscriptions").
+		WithArgs("wh-1", domain.WebhookOwnerUser, "user-1", "https://example.com/hook", pq.Array([]string{"bid.placed"}), "whsec_x", true, 0, sqlmock.AnyArg(), "user-1", now, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.Create(context.Background(), sub)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetWebhook(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT .* FROM webhook_subscriptions WHERE id = \\$1").
+		WithArgs("wh-1").
+		WillReturnRows(sqlmock.NewRows(subscriptionRowColumns).
+			AddRow("wh-1", "COMPANY", "co-1", "https://example.com/hook", "{bid.placed,auction.closed}", "whsec_x", false, 5, now, "user-1", now, now))
+	mock.ExpectQuery("SELECT .* FROM webhook_subscriptions WHERE id = \\$1").
+		WithArgs("missing").
+		WillReturnError(sql.ErrNoRows)
+
+	sub, err := repo.Get(context.Background(), "wh-1")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.WebhookOwnerCompany, sub.OwnerType)
+	assert.Equal(t, []string{"bid.placed", "auction.closed"}, sub.EventTypes)
+	assert.False(t, sub.Active)
+	assert.Equal(t, 5, sub.ConsecutiveFailures)
+	assert.NotNil(t, sub.DisabledAt)
+
+	_, err = repo.Get(context.Background(), "missing")
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListMatchingWebhooks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT .* FROM webhook_subscriptions WHERE active AND \\$1 = ANY\\(event_types\\) .* company_members").
+		WithArgs("bid.placed", pq.Array([]string{"user-1", "user-2"}), pq.Array([]string(nil))).
+		WillReturnRows(sqlmock.NewRows(subscriptionRowColumns).
+			AddRow("wh-1", "USER", "user-1", "https://example.com/a", "{bid.placed}", "whsec_a", true, 0, nil, "user-1", now, now).
+			AddRow("wh-2", "COMPANY", "co-1", "https://example.com/b", "{bid.placed}", "whsec_b", true, 1, nil, "user-2", now, now))
+
+	subs, err := repo.ListMatching(context.Background(), "bid.placed", []string{"user-1", "user-2"}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, subs, 2)
+	assert.Equal(t, "whsec_b", subs[1].Secret)
+	assert.Nil(t, subs[0].DisabledAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateWebhook_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+
+	mock.ExpectExec("UPDATE webhook_subscriptions SET url = \\$2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM webhook_subscriptions WHERE id = \\$1").
+		WithArgs("wh-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.Update(context.Background(), &domain.WebhookSubscription{ID: "wh-1", URL: "https://example.com"})
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	err = repo.Delete(context.Background(), "wh-1")
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordWebhookFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+	now := time.Now()
+
+	mock.ExpectQuery("UPDATE webhook_subscriptions SET consecutive_failures = consecutive_failures \\+ 1, .* RETURNING active").
+		WithArgs("wh-1", 5, now).
+		WillReturnRows(sqlmock.NewRows([]string{"active"}).AddRow(false))
+
+	active, err := repo.RecordFailure(context.Background(), "wh-1", 5, now)
+	assert.NoError(t, err)
+	assert.False(t, active)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddCompanyMembers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookRepo(db)
+
+	mock.ExpectExec("INSERT INTO company_members .* ON CONFLICT \\(company_id, user_id\\) DO NOTHING").
+		WithArgs("co-1", pq.Array([]string{"user-1", "user-2"})).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = repo.AddCompanyMembers(context.Background(), "co-1", []string{"user-1", "user-2"})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateWebhookDelivery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookDeliveryRepo(db)
+	now := time.Now()
+	delivery := &domain.WebhookDelivery{
+		ID: "d-2", SubscriptionID: "wh-1", EventType: "bid.placed", Payload: []byte(`{"bid_id":"b-1"}`),
+		Status: domain.DeliveryStatusPending, NextAttemptAt: now, ReplayOf: "d-1", CreatedAt: now,
+	}
+
+	mock.ExpectExec("INSERT INTO webhook_deliveries").
+		WithArgs("d-2", "wh-1", "bid.placed", []byte(`{"bid_id":"b-1"}`), domain.DeliveryStatusPending, 0, now, "d-1", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.Create(context.Background(), delivery)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestClaimWebhookDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookDeliveryRepo(db)
+	now := time.Now()
+
+	rows := sqlmock.NewRows([]string{"id", "subscription_id", "event_type", "payload", "status", "attempts", "response_status", "last_error", "next_attempt_at", "replay_of", "created_at", "delivered_at"}).
+		AddRow("d-1", "wh-1", "bid.placed", []byte(`{"bid_id":"b-1"}`), "PENDING", 2, 503, "receiver responded 503", now.Add(time.Minute), nil, now, nil)
+	mock.ExpectQuery("UPDATE webhook_deliveries SET next_attempt_at = \\$2 WHERE id IN \\(.* FOR UPDATE SKIP LOCKED \\) RETURNING").
+		WithArgs(now, now.Add(time.Minute), 10).
+		WillReturnRows(rows)
+
+	deliveries, err := repo.Claim(context.Background(), now, time.Minute, 10)
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, "d-1", deliveries[0].ID)
+	assert.JSONEq(t, `{"bid_id":"b-1"}`, string(deliveries[0].Payload))
+	assert.Equal(t, 2, deliveries[0].Attempts)
+	assert.Equal(t, 503, deliveries[0].ResponseStatus)
+	assert.Equal(t, "", deliveries[0].ReplayOf)
+	assert.Nil(t, deliveries[0].DeliveredAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookDeliveryStatusUpdates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewWebhookDeliveryRepo(db)
+	now := time.Now()
+
+	mock.ExpectExec("UPDATE webhook_deliveries SET status = 'SUCCEEDED'").
+		WithArgs("d-1", 200, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE webhook_deliveries SET attempts = attempts \\+ 1, response_status = \\$2, last_error = \\$3, next_attempt_at = \\$4").
+		WithArgs("d-2", 503, "receiver responded 503", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE webhook_deliveries SET status = 'FAILED'").
+		WithArgs("d-3", 0, "connection refused").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, repo.MarkSucceeded(context.Background(), "d-1", 200, now))
+	assert.NoError(t, repo.Retry(context.Background(), "d-2", 503, "receiver responded 503", now))
+	assert.NoError(t, repo.MarkFailed(context.Background(), "d-3", 0, "connection refused"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
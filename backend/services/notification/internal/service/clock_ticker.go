@@ -0,0 +1,113 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+// clockTicker runs one goroutine per Dutch auction, pushing a PriceTick to the
+// auction's room when the clock starts and on every drop after that.
+type clockTicker struct {
+	hub domain.Hub
+	log logger.Logger
+
+	mu      sync.Mutex
+	running map[string]chan struct{} // auction ID -> closed to stop its clock
+}
+
+func NewClockTicker(hub domain.Hub, log logger.Logger) domain.PriceTicker {
+	return &clockTicker{
+		hub:     hub,
+		log:     log,
+		running: make(map[string]chan struct{}),
+	}
+}
+
+// Start begins pushing ticks for clock, replacing any clock already running for the
+// auction (auction.created may be redelivered).
+func (t *clockTicker) Start(clock *domain.DutchClock) {
+	stop := make(chan struct{})
+
+	t.mu.Lock()
+	if prev, ok := t.running[clock.AuctionID]; ok {
+		close(prev)
+	}
+	t.running[clock.AuctionID] = stop
+	t.mu.Unlock()
+
+	go t.run(clock, stop)
+}
+
+func (t *clockTicker) Stop(auctionID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if stop, ok := t.running[auctionID]; ok {
+		close(stop)
+		delete(t.running, auctionID)
+	}
+}
+
+func (t *clockTicker) run(clock *domain.DutchClock, stop chan struct{}) {
+	defer t.finish(clock.AuctionID, stop)
+
+	// Wait for the auction to open
+	if wait := time.Until(clock.StartTime); wait > 0 {
+		if !t.sleep(wait, stop) {
+			return
+		}
+	}
+
+	for {
+		now := time.Now()
+		if !now.Before(clock.EndTime) {
+			return
+		}
+
+		tick := &domain.PriceTick{
+			Type:      domain.MessageTypeAuctionPriceTick,
+			AuctionID: clock.AuctionID,
+			Price:     clock.Price(now),
+		}
+		next, ok := clock.NextTick(now)
+		if ok {
+			tick.NextTickAt = &next
+		}
+		t.hub.BroadcastToRoom(clock.AuctionID, tick)
+
+		if !ok {
+			t.log.Info("Dutch clock stopped", zap.String("auction_id", clock.AuctionID), zap.Float64("price", tick.Price))
+			return
+		}
+		if !t.sleep(time.Until(next), stop) {
+			return
+		}
+	}
+}
+
+// sleep waits for d and returns false if the clock was stopped first.
+func (t *clockTicker) sleep(d time.Duration, stop chan struct{}) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+// finish forgets the clock unless Start has already replaced it.
+func (t *clockTicker) finish(auctionID string, stop chan struct{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.running[auctionID] == stop {
+		delete(t.running, auctionID)
+	}
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+// tickHub collects broadcast price ticks on a channel.
+type tickHub struct {
+	MockHub
+	ticks chan *domain.PriceTick
+}
+
+func (h *tickHub) BroadcastToRoom(auctionID string, message interface{}, userIDs ...string) {
+	h.ticks <- message.(*domain.PriceTick)
+}
+
+func newQuietLogger() *MockLogger {
+	log := new(MockLogger)
+	log.On("Info", mock.Anything, mock.Anything).Maybe()
+	return log
+}
+
+func TestClockTicker_TicksDownToFloor(t *testing.T) {
+	hub := &tickHub{ticks: make(chan *domain.PriceTick, 10)}
+	ticker := NewClockTicker(hub, newQuietLogger())
+
+	ticker.Start(&domain.DutchClock{
+		AuctionID:  "auction-1",
+		StartPrice: 100,
+		Step:       10,
+		Interval:   20 * time.Millisecond,
+		FloorPrice: 75,
+		StartTime:  time.Now(),
+		EndTime:    time.Now().Add(time.Hour),
+	})
+
+	var prices []float64
+	for i := 0; i < 4; i++ {
+		select {
+		case tick := <-hub.ticks:
+			assert.Equal(t, domain.MessageTypeAuctionPriceTick, tick.Type)
+			assert.Equal(t, "auction-1", tick.AuctionID)
+			prices = append(prices, tick.Price)
+			if i < 3 {
+				require.NotNil(t, tick.NextTickAt)
+			} else {
+				assert.Nil(t, tick.NextTickAt, "the floor price is the last tick")
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out after ticks %v", prices)
+		}
+	}
+	assert.Equal(t, []float64{100, 90, 80, 75}, prices)
+
+	select {
+	case tick := <-hub.ticks:
+		t.Errorf("unexpected tick after the floor: %+v", tick)
+	case <-time.After(60 * time.Millisecond):
+	}
+}
+
+func TestClockTicker_Stop(t *testing.T) {
+	hub := &tickHub{ticks: make(chan *domain.PriceTick, 10)}
+	ticker := NewClockTicker(hub, newQuietLogger())
+
+	ticker.Start(&domain.DutchClock{
+		AuctionID:  "auction-1",
+		StartPrice: 100,
+		Step:       1,
+		Interval:   20 * time.Millisecond,
+		StartTime:  time.Now(),
+		EndTime:    time.Now().Add(time.Hour),
+	})
+	<-hub.ticks
+	ticker.Stop("auction-1")
+
+	// A tick racing Stop may still arrive; after that the clock must be silent
+	extra := 0
+	deadline := time.After(100 * time.Millisecond)
+	for {
+		select {
+		case <-hub.ticks:
+			extra++
+			if extra > 1 {
+				t.Fatal("clock kept ticking after Stop")
+			}
+		case <-deadline:
+			return
+		}
+	}
+}
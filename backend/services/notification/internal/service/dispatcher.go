@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+// dispatch delivers a notification over the channels its recipient chose for its type.
+// Only failing to store it in the app is an error: the other channels are best effort,
+// and their failures are logged.
+func (s *notificationService) dispatch(ctx context.Context, notification *domain.Notification) error {
+	prefs := s.preferences(ctx, notification.UserID)
+	channels := prefs.ChannelsFor(notification.Type)
+
+	var quietUntil time.Time
+	if prefs.QuietHours != nil {
+		quietUntil, _ = prefs.QuietHours.Until(notification.CreatedAt)
+	}
+
+	if channels.InApp {
+		if err := s.repo.Create(ctx, notification); err != nil {
+			s.log.Error("Failed to save notification", zap.Error(err))
+			return err
+		}
+	}
+	// Quiet hours mute the live push; the notification still waits in the app
+	if channels.WebSocket && quietUntil.IsZero() {
+		if !channels.InApp {
+			// Stored notifications got their number from Create. This one can't be
+			// replayed, but still moves the user's sequence on.
+			seq, err := s.repo.NextSeq(ctx, notification.UserID)
+			if err != nil {
+				s.log.Error("Failed to number notification", zap.Error(err), zap.String("user_id", notification.UserID))
+			}
+			notification.Seq = seq
+		}
+		s.hub.BroadcastToUser(notification.UserID, notification)
+	}
+	if channels.InApp {
+		s.pushUnreadCount(ctx, notification.UserID)
+	}
+
+	for _, channel := range []domain.Channel{domain.ChannelEmail, domain.ChannelWebhook} {
+		sender, ok := s.senders[channel]
+		if !ok || !channels.Enabled(channel) {
+			continue
+		}
+		delivery := &domain.Delivery{Notification: notification, Digest: prefs.Digest}
+		// Webhooks feed other systems, which have no quiet hours
+		if channel == domain.ChannelEmail {
+			delivery.NotBefore = quietUntil
+		}
+		if err := sender.Send(ctx, delivery); err != nil {
+			s.log.Error("Failed to deliver notification", zap.Error(err), zap.String("channel", string(channel)), zap.String("user_id", notification.UserID))
+		}
+	}
+	return nil
+}
+
+// preferences falls back to the defaults when the user's preferences can't be read,
+// rather than hold up the notification.
+func (s *notificationService) preferences(ctx context.Context, userID string) *domain.Preferences {
+	prefs, err := s.prefs.Get(ctx, userID)
+	if err != nil {
+		s.log.Error("Failed to get notification preferences", zap.Error(err), zap.String("user_id", userID))
+	}
+	if prefs == nil {
+		return domain.DefaultPreferences(userID)
+	}
+	return prefs
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,16 +12,26 @@ import (
 )
 
 type notificationService struct {
-	repo domain.NotificationRepository
-	hub  domain.Hub
-	log  logger.Logger
+	repo         domain.NotificationRepository
+	participants domain.ParticipantRepository
+	prefs        domain.PreferenceRepository
+	contacts     domain.ContactRepository
+	hub          domain.Hub
+	senders      map[domain.Channel]domain.ChannelSender
+	log          logger.Logger
 }
 
-func NewNotificationService(repo domain.NotificationRepository, hub domain.Hub, log logger.Logger) domain.NotificationService {
+// NewNotificationService stores notifications and pushes them over the hub itself;
+// senders deliver over the remaining channels, and channels without one are skipped.
+func NewNotificationService(repo domain.NotificationRepository, participants domain.ParticipantRepository, prefs domain.PreferenceRepository, contacts domain.ContactRepository, hub domain.Hub, senders map[domain.Channel]domain.ChannelSender, log logger.Logger) domain.NotificationService {
 	return &notificationService{
-		repo: repo,
-		hub:  hub,
-		log:  log,
+		repo:         repo,
+		participants: participants,
+		prefs:        prefs,
+		contacts:     contacts,
+		hub:          hub,
+		senders:      senders,
+		log:          log,
 	}
 }
 
@@ -32,18 +43,237 @@ func (s *notificationService) SendNotification(ctx context.Context, notification
 		notification.CreatedAt = time.Now()
 	}
 
-	// 1. Save to database
-	if err := s.repo.Create(ctx, notification); err != nil {
-		s.log.Error("Failed to save notification", zap.Error(err))
+	return s.dispatch(ctx, notification)
+}
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+func (s *notificationService) GetUserNotifications(ctx context.Context, userID string, after *domain.Cursor, limit int) (*domain.NotificationPage, error) {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+	limit = min(limit, maxPageSize)
+
+	// One more than asked for tells whether there is a next page
+	notifications, err := s.repo.ListByUserID(ctx, userID, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.NotificationPage{Notifications: notifications}
+	if len(notifications) > limit {
+		page.Notifications = notifications[:limit]
+		last := page.Notifications[limit-1]
+		page.NextCursor = domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.String()
+	}
+	if page.Notifications == nil {
+		page.Notifications = []domain.Notification{}
+	}
+	return page, nil
+}
+
+func (s *notificationService) MarkAsRead(ctx context.Context, userID, id string) error {
+	if err := s.repo.MarkAsRead(ctx, userID, id); err != nil {
+		return err
+	}
+	s.pushUnreadCount(ctx, userID)
+	return nil
+}
+
+func (s *notificationService) MarkAllAsRead(ctx context.Context, userID string) error {
+	if err := s.repo.MarkAllAsRead(ctx, userID); err != nil {
+		return err
+	}
+	s.pushUnreadCount(ctx, userID)
+	return nil
+}
+
+func (s *notificationService) UnreadCount(ctx context.Context, userID string) (int, error) {
+	return s.repo.CountUnread(ctx, userID)
+}
+
+func (s *notificationService) NotificationsSince(ctx context.Context, userID string, afterSeq int64, limit int) ([]domain.Notification, error) {
+	return s.repo.ListSince(ctx, userID, afterSeq, limit)
+}
+
+func (s *notificationService) GetPreferences(ctx context.Context, userID string) (*domain.Preferences, error) {
+	prefs, err := s.prefs.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if prefs == nil {
+		return domain.DefaultPreferences(userID), nil
+	}
+	return prefs, nil
+}
+
+func (s *notificationService) UpdatePreferences(ctx context.Context, prefs *domain.Preferences) error {
+	if prefs.Digest == "" {
+		prefs.Digest = domain.DigestImmediate
+	}
+	if err := prefs.Validate(); err != nil {
+		return err
+	}
+	prefs.UpdatedAt = time.Now()
+	return s.prefs.Save(ctx, prefs)
+}
+
+func (s *notificationService) InitPreferences(ctx context.Context, userID string) error {
+	if err := s.prefs.Init(ctx, domain.DefaultPreferences(userID)); err != nil {
+		s.log.Error("Failed to store default notification preferences", zap.Error(err), zap.String("user_id", userID))
+		return err
+	}
+	return nil
+}
+
+func (s *notificationService) SaveContact(ctx context.Context, userID, email string) error {
+	if err := s.contacts.SaveEmail(ctx, userID, email); err != nil {
+		s.log.Error("Failed to save email address", zap.Error(err), zap.String("user_id", userID))
 		return err
 	}
+	return nil
+}
+
+// pushUnreadCount sends the user's unread count to all of their connections. The
+// change that prompted it already stands, so a failure is only logged.
+func (s *notificationService) pushUnreadCount(ctx context.Context, userID string) {
+	count, err := s.repo.CountUnread(ctx, userID)
+	if err != nil {
+		s.log.Error("Failed to count unread notifications", zap.Error(err), zap.String("user_id", userID))
+		return
+	}
+	s.hub.BroadcastToUser(userID, &domain.UnreadCount{Type: domain.MessageTypeUnreadCount, Count: count})
+}
+
+// NotifyAuctionExtended pushes the new end time to the auction's room and every
+// watcher that is connected.
+func (s *notificationService) NotifyAuctionExtended(ctx context.Context, extended *domain.AuctionExtended) error {
+	watchers, err := s.repo.ListWatchers(ctx, extended.AuctionID)
+	if err != nil {
+		s.log.Error("Failed to list auction watchers", zap.Error(err))
+		return err
+	}
+
+	extended.Type = domain.MessageTypeAuctionExtended
+	s.hub.BroadcastToRoom(extended.AuctionID, extended, watchers...)
+	return nil
+}
+
+// NotifyAuctionUpdated pushes the seller's edits to the auction's room and every
+// watcher that is connected.
+func (s *notificationService) NotifyAuctionUpdated(ctx context.Context, updated *domain.AuctionUpdated) error {
+	watchers, err := s.repo.ListWatchers(ctx, updated.AuctionID)
+	if err != nil {
+		s.log.Error("Failed to list auction watchers", zap.Error(err))
+		return err
+	}
+
+	updated.Type = domain.MessageTypeAuctionUpdated
+	s.hub.BroadcastToRoom(updated.AuctionID, updated, watchers...)
+	return nil
+}
+
+func (s *notificationService) NotifyBidPlaced(ctx context.Context, bid *domain.AuctionBid) {
+	bid.Type = domain.MessageTypeAuctionBidPlaced
+	if bid.Sealed {
+		bid.Amount = 0
+	}
+	s.hub.BroadcastToRoom(bid.AuctionID, bid)
+}
 
-	// 2. Push to WebSocket
-	s.hub.BroadcastToUser(notification.UserID, notification)
+func (s *notificationService) NotifyPriceChanged(ctx context.Context, changed *domain.AuctionPriceChanged) {
+	changed.Type = domain.MessageTypeAuctionPriceChanged
+	s.hub.BroadcastToRoom(changed.AuctionID, changed)
+}
+
+func (s *notificationService) RecordParticipant(ctx context.Context, auctionID, userID string, at time.Time) error {
+	if err := s.participants.Add(ctx, auctionID, userID, at); err != nil {
+		s.log.Error("Failed to record auction participant", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+func (s *notificationService) NotifyAuctionClosed(ctx context.Context, outcome *domain.AuctionOutcome) error {
+	s.hub.BroadcastToRoom(outcome.AuctionID, &domain.AuctionClosed{
+		Type:       domain.MessageTypeAuctionClosed,
+		AuctionID:  outcome.AuctionID,
+		Status:     outcome.Status,
+		FinalPrice: outcome.FinalPrice,
+	})
+
+	participants, err := s.participants.List(ctx, outcome.AuctionID)
+	if err != nil {
+		s.log.Error("Failed to list auction participants", zap.Error(err))
+		return err
+	}
 
+	notifications := []*domain.Notification{{
+		UserID:     outcome.SellerID,
+		Type:       domain.NotificationTypeAuctionClosed,
+		Title:      "Auction Closed",
+		Message:    sellerOutcome(outcome),
+		ResourceID: outcome.AuctionID,
+	}}
+
+	won := make(map[string]bool)
+	for _, w := range outcome.Winners {
+		won[w.BidderID] = true
+		message := fmt.Sprintf("Congratulations! You won '%s' for %.2f.", outcome.Title, w.Price)
+		if w.Units > 1 {
+			message = fmt.Sprintf("Congratulations! You won %d units of '%s' at %.2f each.", w.Units, outcome.Title, w.Price)
+		}
+		notifications = append(notifications, &domain.Notification{
+			UserID:     w.BidderID,
+			Type:       domain.NotificationTypeAuctionWon,
+			Title:      "Auction Won",
+			Message:    message,
+			ResourceID: outcome.AuctionID,
+		})
+	}
+
+	lost := fmt.Sprintf("The auction '%s' has closed and your bid did not win.", outcome.Title)
+	if outcome.Status == domain.AuctionStatusReserveNotMet {
+		lost = fmt.Sprintf("The auction '%s' closed without reaching its reserve price, so no one won.", outcome.Title)
+	}
+	for _, userID := range participants {
+		if won[userID] || userID == outcome.SellerID {
+			continue
+		}
+		notifications = append(notifications, &domain.Notification{
+			UserID:     userID,
+			Type:       domain.NotificationTypeAuctionLost,
+			Title:      "Auction Lost",
+			Message:    lost,
+			ResourceID: outcome.AuctionID,
+		})
+	}
+
+	for _, notification := range notifications {
+		if err := s.SendNotification(ctx, notification); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (s *notificationService) GetUserNotifications(ctx context.Context, userID string) ([]domain.Notification, error) {
-	return s.repo.ListByUserID(ctx, userID, 50) // Default limit 50
+func sellerOutcome(outcome *domain.AuctionOutcome) string {
+	units := 0
+	for _, w := range outcome.Winners {
+		units += max(w.Units, 1)
+	}
+
+	switch {
+	case len(outcome.Winners) > 1 || units > 1:
+		return fmt.Sprintf("Your auction '%s' has closed: %d units sold to %d bidders.", outcome.Title, units, len(outcome.Winners))
+	case len(outcome.Winners) == 1:
+		return fmt.Sprintf("Your auction '%s' has closed and sold for %.2f.", outcome.Title, outcome.Winners[0].Price)
+	case outcome.Status == domain.AuctionStatusReserveNotMet:
+		return fmt.Sprintf("Your auction '%s' closed below your reserve price and was not sold.", outcome.Title)
+	default:
+		return fmt.Sprintf("Your auction '%s' closed without a winning bid.", outcome.Title)
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -23,16 +24,109 @@ func (m *MockNotificationRepo) Create(ctx context.Context, notification *domain.
 	return args.Error(0)
 }
 
-func (m *MockNotificationRepo) ListByUserID(ctx context.Context, userID string, limit int) ([]domain.Notification, error) {
-	args := m.Called(ctx, userID, limit)
+func (m *MockNotificationRepo) NextSeq(ctx context.Context, userID string) (int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockNotificationRepo) ListSince(ctx context.Context, userID string, afterSeq int64, limit int) ([]domain.Notification, error) {
+	args := m.Called(ctx, userID, afterSeq, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]domain.Notification), args.Error(1)
 }
 
-func (m *MockNotificationRepo) MarkAsRead(ctx context.Context, id string) error {
-	args := m.Called(ctx, id)
+func (m *MockNotificationRepo) ListByUserID(ctx context.Context, userID string, after *domain.Cursor, limit int) ([]domain.Notification, error) {
+	args := m.Called(ctx, userID, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepo) MarkAsRead(ctx context.Context, userID, id string) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepo) MarkAllAsRead(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRepo) CountUnread(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockNotificationRepo) ListWatchers(ctx context.Context, auctionID string) ([]string, error) {
+	args := m.Called(ctx, auctionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type MockParticipantRepo struct {
+	mock.Mock
+}
+
+func (m *MockParticipantRepo) Add(ctx context.Context, auctionID, userID string, at time.Time) error {
+	args := m.Called(ctx, auctionID, userID, at)
+	return args.Error(0)
+}
+
+func (m *MockParticipantRepo) List(ctx context.Context, auctionID string) ([]string, error) {
+	args := m.Called(ctx, auctionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+type MockPreferenceRepo struct {
+	mock.Mock
+}
+
+func (m *MockPreferenceRepo) Get(ctx context.Context, userID string) (*domain.Preferences, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Preferences), args.Error(1)
+}
+
+func (m *MockPreferenceRepo) Save(ctx context.Context, prefs *domain.Preferences) error {
+	args := m.Called(ctx, prefs)
+	return args.Error(0)
+}
+
+func (m *MockPreferenceRepo) Init(ctx context.Context, prefs *domain.Preferences) error {
+	args := m.Called(ctx, prefs)
+	return args.Error(0)
+}
+
+type MockContactRepo struct {
+	mock.Mock
+}
+
+func (m *MockContactRepo) SaveEmail(ctx context.Context, userID, email string) error {
+	args := m.Called(ctx, userID, email)
+	return args.Error(0)
+}
+
+func (m *MockContactRepo) GetEmail(ctx context.Context, userID string) (string, error) {
+	args := m.Called(ctx, userID)
+	return args.String(0), args.Error(1)
+}
+
+type MockChannelSender struct {
+	mock.Mock
+}
+
+func (m *MockChannelSender) Send(ctx context.Context, delivery *domain.Delivery) error {
+	args := m.Called(ctx, delivery)
 	return args.Error(0)
 }
 
@@ -44,6 +138,14 @@ func (m *MockHub) BroadcastToUser(userID string, message interface{}) {
 	m.Called(userID, message)
 }
 
+func (m *MockHub) BroadcastToRoom(auctionID string, message interface{}, userIDs ...string) {
+	m.Called(auctionID, message, userIDs)
+}
+
+func (m *MockHub) Broadcast(message interface{}) {
+	m.Called(message)
+}
+
 func (m *MockHub) Run() {
 	m.Called()
 }
@@ -88,17 +190,26 @@ func (m *MockLogger) Sync() error {
 
 type NotificationServiceTestSuite struct {
 	suite.Suite
-	repo    *MockNotificationRepo
-	hub     *MockHub
-	logger  *MockLogger
-	service domain.NotificationService
+	repo         *MockNotificationRepo
+	participants *MockParticipantRepo
+	prefs        *MockPreferenceRepo
+	contacts     *MockContactRepo
+	email        *MockChannelSender
+	hub          *MockHub
+	logger       *MockLogger
+	service      domain.NotificationService
 }
 
 func (s *NotificationServiceTestSuite) SetupTest() {
 	s.repo = new(MockNotificationRepo)
+	s.participants = new(MockParticipantRepo)
+	s.prefs = new(MockPreferenceRepo)
+	s.contacts = new(MockContactRepo)
+	s.email = new(MockChannelSender)
 	s.hub = new(MockHub)
 	s.logger = new(MockLogger)
-	s.service = NewNotificationService(s.repo, s.hub, s.logger)
+	senders := map[domain.Channel]domain.ChannelSender{domain.ChannelEmail: s.email}
+	s.service = NewNotificationService(s.repo, s.participants, s.prefs, s.contacts, s.hub, senders, s.logger)
 }
 
 func (s *NotificationServiceTestSuite) TestSendNotification_Success() {
@@ -109,13 +220,17 @@ func (s *NotificationServiceTestSuite) TestSendNotification_Success() {
 		Message: "An auction has started",
 	}
 
+	s.prefs.On("Get", mock.Anything, notification.UserID).Return(nil, nil)
+
 	// Expect Create to be called
 	s.repo.On("Create", mock.Anything, mock.MatchedBy(func(n *domain.Notification) bool {
 		return n.UserID == notification.UserID && n.Title == notification.Title
 	})).Return(nil)
 
-	// Expect BroadcastToUser to be called
-	s.hub.On("BroadcastToUser", notification.UserID, mock.Anything).Return()
+	// Expect the notification and the new unread count to be pushed
+	s.hub.On("BroadcastToUser", notification.UserID, notification).Return()
+	s.repo.On("CountUnread", mock.Anything, notification.UserID).Return(3, nil)
+	s.hub.On("BroadcastToUser", notification.UserID, &domain.UnreadCount{Type: domain.MessageTypeUnreadCount, Count: 3}).Return()
 
 	err := s.service.SendNotification(context.Background(), notification)
 
@@ -133,6 +248,8 @@ func (s *NotificationServiceTestSuite) TestSendNotification_RepoError() {
 
 	expectedErr := errors.New("db error")
 
+	s.prefs.On("Get", mock.Anything, notification.UserID).Return(nil, nil)
+
 	// Expect Create to fail
 	s.repo.On("Create", mock.Anything, mock.Anything).Return(expectedErr)
 
@@ -154,13 +271,357 @@ func (s *NotificationServiceTestSuite) TestGetUserNotifications() {
 		{ID: "2", UserID: userID, Title: "Notif 2"},
 	}
 
-	s.repo.On("ListByUserID", mock.Anything, userID, 50).Return(notifications, nil)
+	s.repo.On("ListByUserID", mock.Anything, userID, (*domain.Cursor)(nil), 51).Return(notifications, nil)
+
+	result, err := s.service.GetUserNotifications(context.Background(), userID, nil, 0)
+
+	s.NoError(err)
+	s.Equal(notifications, result.Notifications)
+	s.Empty(result.NextCursor) // Last page
+	s.repo.AssertExpectations(s.T())
+}
+
+func (s *NotificationServiceTestSuite) TestGetUserNotifications_NextPage() {
+	userID := "user-1"
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	notifications := []domain.Notification{
+		{ID: "3", UserID: userID, CreatedAt: createdAt.Add(time.Minute)},
+		{ID: "2", UserID: userID, CreatedAt: createdAt},
+		{ID: "1", UserID: userID, CreatedAt: createdAt},
+	}
+	after := &domain.Cursor{CreatedAt: createdAt.Add(time.Hour), ID: "9"}
+
+	s.repo.On("ListByUserID", mock.Anything, userID, after, 3).Return(notifications, nil)
 
-	result, err := s.service.GetUserNotifications(context.Background(), userID)
+	result, err := s.service.GetUserNotifications(context.Background(), userID, after, 2)
+
+	s.NoError(err)
+	s.Len(result.Notifications, 2)
+	next, err := domain.ParseCursor(result.NextCursor)
+	s.NoError(err)
+	s.Equal("2", next.ID)
+	s.True(next.CreatedAt.Equal(createdAt))
+}
+
+func (s *NotificationServiceTestSuite) TestMarkAsRead() {
+	s.repo.On("MarkAsRead", mock.Anything, "user-1", "n-1").Return(nil)
+	s.repo.On("CountUnread", mock.Anything, "user-1").Return(2, nil)
+	s.hub.On("BroadcastToUser", "user-1", &domain.UnreadCount{Type: domain.MessageTypeUnreadCount, Count: 2}).Return()
+
+	err := s.service.MarkAsRead(context.Background(), "user-1", "n-1")
+
+	s.NoError(err)
+	s.hub.AssertExpectations(s.T())
+}
+
+func (s *NotificationServiceTestSuite) TestMarkAsRead_NotOwner() {
+	s.repo.On("MarkAsRead", mock.Anything, "user-2", "n-1").Return(domain.ErrNotificationNotFound)
+
+	err := s.service.MarkAsRead(context.Background(), "user-2", "n-1")
+
+	s.ErrorIs(err, domain.ErrNotificationNotFound)
+	s.hub.AssertNotCalled(s.T(), "BroadcastToUser")
+}
+
+func (s *NotificationServiceTestSuite) TestMarkAllAsRead() {
+	s.repo.On("MarkAllAsRead", mock.Anything, "user-1").Return(nil)
+	s.repo.On("CountUnread", mock.Anything, "user-1").Return(0, nil)
+	s.hub.On("BroadcastToUser", "user-1", &domain.UnreadCount{Type: domain.MessageTypeUnreadCount, Count: 0}).Return()
+
+	err := s.service.MarkAllAsRead(context.Background(), "user-1")
+
+	s.NoError(err)
+	s.hub.AssertExpectations(s.T())
+}
+
+func (s *NotificationServiceTestSuite) TestNotifyAuctionExtended() {
+	endTime := time.Now().Add(time.Minute)
+	extended := &domain.AuctionExtended{AuctionID: "auction-1", EndTime: endTime, ExtensionCount: 1, MaxExtensions: 3}
+
+	s.repo.On("ListWatchers", mock.Anything, "auction-1").Return([]string{"seller-1", "bidder-1"}, nil)
+	isExtension := mock.MatchedBy(func(m *domain.AuctionExtended) bool {
+		return m.Type == domain.MessageTypeAuctionExtended && m.EndTime.Equal(endTime)
+	})
+	s.hub.On("BroadcastToRoom", "auction-1", isExtension, []string{"seller-1", "bidder-1"}).Return()
+
+	err := s.service.NotifyAuctionExtended(context.Background(), extended)
 
 	s.NoError(err)
-	s.Equal(notifications, result)
 	s.repo.AssertExpectations(s.T())
+	s.hub.AssertExpectations(s.T())
+	s.repo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything) // Pushed live, not stored
+}
+
+func (s *NotificationServiceTestSuite) TestNotifyAuctionExtended_RepoError() {
+	s.repo.On("ListWatchers", mock.Anything, "auction-1").Return(nil, errors.New("db error"))
+	s.logger.On("Error", "Failed to list auction watchers", mock.Anything).Return()
+
+	err := s.service.NotifyAuctionExtended(context.Background(), &domain.AuctionExtended{AuctionID: "auction-1"})
+
+	s.Error(err)
+	s.hub.AssertNotCalled(s.T(), "BroadcastToRoom")
+}
+
+func (s *NotificationServiceTestSuite) TestNotifyAuctionUpdated() {
+	s.repo.On("ListWatchers", mock.Anything, "auction-1").Return([]string{"seller-1", "bidder-1"}, nil)
+	isUpdate := mock.MatchedBy(func(m *domain.AuctionUpdated) bool {
+		return m.Type == domain.MessageTypeAuctionUpdated && m.Title == "Vintage Lamp"
+	})
+	s.hub.On("BroadcastToRoom", "auction-1", isUpdate, []string{"seller-1", "bidder-1"}).Return()
+
+	err := s.service.NotifyAuctionUpdated(context.Background(), &domain.AuctionUpdated{AuctionID: "auction-1", Title: "Vintage Lamp"})
+
+	s.NoError(err)
+	s.hub.AssertExpectations(s.T())
+	s.repo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *NotificationServiceTestSuite) TestNotifyAuctionClosed() {
+	outcome := &domain.AuctionOutcome{
+		AuctionID:  "auction-1",
+		SellerID:   "seller-1",
+		Title:      "Vintage Lamp",
+		Status:     domain.AuctionStatusClosed,
+		FinalPrice: 150,
+		Winners:    []domain.AuctionWinner{{BidderID: "bidder-1", Units: 1, Price: 150}},
+	}
+	s.participants.On("List", mock.Anything, "auction-1").Return([]string{"bidder-2", "bidder-1", "bidder-3"}, nil)
+	s.hub.On("BroadcastToRoom", "auction-1", &domain.AuctionClosed{
+		Type: domain.MessageTypeAuctionClosed, AuctionID: "auction-1", Status: domain.AuctionStatusClosed, FinalPrice: 150,
+	}, []string(nil)).Return()
+
+	s.prefs.On("Get", mock.Anything, mock.Anything).Return(nil, nil)
+	s.email.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+	sent := make(map[string]*domain.Notification)
+	s.repo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		n := args.Get(1).(*domain.Notification)
+		sent[n.UserID] = n
+	}).Return(nil)
+	s.hub.On("BroadcastToUser", mock.Anything, mock.Anything).Return()
+	s.repo.On("CountUnread", mock.Anything, mock.Anything).Return(1, nil)
+
+	err := s.service.NotifyAuctionClosed(context.Background(), outcome)
+
+	s.NoError(err)
+	s.Len(sent, 4)
+	s.Equal(domain.NotificationTypeAuctionClosed, sent["seller-1"].Type)
+	s.Contains(sent["seller-1"].Message, "sold for 150.00")
+	s.Equal(domain.NotificationTypeAuctionWon, sent["bidder-1"].Type)
+	s.Equal(domain.NotificationTypeAuctionLost, sent["bidder-2"].Type)
+	s.Equal(domain.NotificationTypeAuctionLost, sent["bidder-3"].Type)
+	s.hub.AssertExpectations(s.T())
+}
+
+func (s *NotificationServiceTestSuite) TestNotifyBidPlaced() {
+	placedAt := time.Now()
+	s.hub.On("BroadcastToRoom", "auction-1", &domain.AuctionBid{
+		Type: domain.MessageTypeAuctionBidPlaced, AuctionID: "auction-1", BidID: "bid-1", Sealed: true, PlacedAt: placedAt,
+	}, []string(nil)).Return()
+
+	// A sealed amount never reaches the room
+	s.service.NotifyBidPlaced(context.Background(), &domain.AuctionBid{AuctionID: "auction-1", BidID: "bid-1", Amount: 120, Sealed: true, PlacedAt: placedAt})
+
+	s.hub.AssertExpectations(s.T())
+}
+
+func (s *NotificationServiceTestSuite) TestNotifyAuctionClosed_ReserveNotMet() {
+	outcome := &domain.AuctionOutcome{
+		AuctionID: "auction-1",
+		SellerID:  "seller-1",
+		Title:     "Vintage Lamp",
+		Status:    domain.AuctionStatusReserveNotMet,
+	}
+	s.participants.On("List", mock.Anything, "auction-1").Return([]string{"bidder-1"}, nil)
+	s.hub.On("BroadcastToRoom", "auction-1", mock.Anything, mock.Anything).Return()
+
+	s.prefs.On("Get", mock.Anything, mock.Anything).Return(nil, nil)
+	s.email.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+	sent := make(map[string]*domain.Notification)
+	s.repo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		n := args.Get(1).(*domain.Notification)
+		sent[n.UserID] = n
+	}).Return(nil)
+	s.hub.On("BroadcastToUser", mock.Anything, mock.Anything).Return()
+	s.repo.On("CountUnread", mock.Anything, mock.Anything).Return(1, nil)
+
+	err := s.service.NotifyAuctionClosed(context.Background(), outcome)
+
+	s.NoError(err)
+	s.Len(sent, 2)
+	s.Contains(sent["seller-1"].Message, "below your reserve price")
+	s.Contains(sent["bidder-1"].Message, "without reaching its reserve price")
+}
+
+func (s *NotificationServiceTestSuite) TestNotifyAuctionClosed_MultiLot() {
+	outcome := &domain.AuctionOutcome{
+		AuctionID: "auction-1",
+		SellerID:  "seller-1",
+		Title:     "Chairs",
+		Status:    domain.AuctionStatusClosed,
+		Winners: []domain.AuctionWinner{
+			{BidderID: "bidder-1", Units: 3, Price: 20},
+			{BidderID: "bidder-2", Units: 2, Price: 20},
+		},
+	}
+	s.participants.On("List", mock.Anything, "auction-1").Return([]string{"bidder-1", "bidder-2"}, nil)
+	s.hub.On("BroadcastToRoom", "auction-1", mock.Anything, mock.Anything).Return()
+
+	s.prefs.On("Get", mock.Anything, mock.Anything).Return(nil, nil)
+	s.email.On("Send", mock.Anything, mock.Anything).Return(nil)
+
+	sent := make(map[string]*domain.Notification)
+	s.repo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		n := args.Get(1).(*domain.Notification)
+		sent[n.UserID] = n
+	}).Return(nil)
+	s.hub.On("BroadcastToUser", mock.Anything, mock.Anything).Return()
+	s.repo.On("CountUnread", mock.Anything, mock.Anything).Return(1, nil)
+
+	err := s.service.NotifyAuctionClosed(context.Background(), outcome)
+
+	s.NoError(err)
+	s.Len(sent, 3)
+	s.Contains(sent["seller-1"].Message, "5 units sold to 2 bidders")
+	s.Contains(sent["bidder-1"].Message, "3 units")
+}
+
+func (s *NotificationServiceTestSuite) TestSendNotification_ChannelsFromPreferences() {
+	prefs := &domain.Preferences{
+		UserID: "user-1",
+		Channels: map[domain.NotificationType]domain.ChannelSet{
+			domain.NotificationTypeOutbid: {Email: true},
+		},
+		Digest: domain.DigestDaily,
+	}
+	s.prefs.On("Get", mock.Anything, "user-1").Return(prefs, nil)
+	s.email.On("Send", mock.Anything, mock.MatchedBy(func(d *domain.Delivery) bool {
+		return d.Notification.Type == domain.NotificationTypeOutbid && d.Digest == domain.DigestDaily && d.NotBefore.IsZero()
+	})).Return(nil)
+
+	err := s.service.SendNotification(context.Background(), &domain.Notification{UserID: "user-1", Type: domain.NotificationTypeOutbid})
+
+	s.NoError(err)
+	s.email.AssertExpectations(s.T())
+	s.repo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything) // In-app switched off
+	s.hub.AssertNotCalled(s.T(), "BroadcastToUser")
+}
+
+func (s *NotificationServiceTestSuite) TestSendNotification_PushedWithoutStoring() {
+	prefs := &domain.Preferences{
+		UserID:   "user-1",
+		Channels: map[domain.NotificationType]domain.ChannelSet{domain.NotificationTypeOutbid: {WebSocket: true}},
+	}
+	s.prefs.On("Get", mock.Anything, "user-1").Return(prefs, nil)
+	s.repo.On("NextSeq", mock.Anything, "user-1").Return(int64(7), nil)
+	s.hub.On("BroadcastToUser", "user-1", mock.MatchedBy(func(n *domain.Notification) bool { return n.Seq == 7 })).Return()
+
+	err := s.service.SendNotification(context.Background(), &domain.Notification{UserID: "user-1", Type: domain.NotificationTypeOutbid})
+
+	s.NoError(err)
+	s.hub.AssertExpectations(s.T())
+	s.repo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (s *NotificationServiceTestSuite) TestSendNotification_QuietHours() {
+	prefs := domain.DefaultPreferences("user-1")
+	prefs.QuietHours = &domain.QuietHours{Start: "22:00", End: "07:00", TimeZone: "Europe/Berlin"}
+	s.prefs.On("Get", mock.Anything, "user-1").Return(prefs, nil)
+
+	// 23:30 in Berlin; quiet until 07:00 the next morning
+	createdAt := time.Date(2024, 5, 1, 21, 30, 0, 0, time.UTC)
+	wantNotBefore := time.Date(2024, 5, 2, 5, 0, 0, 0, time.UTC)
+
+	s.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	s.repo.On("CountUnread", mock.Anything, "user-1").Return(1, nil)
+	s.hub.On("BroadcastToUser", "user-1", &domain.UnreadCount{Type: domain.MessageTypeUnreadCount, Count: 1}).Return()
+	s.email.On("Send", mock.Anything, mock.MatchedBy(func(d *domain.Delivery) bool {
+		return d.NotBefore.Equal(wantNotBefore)
+	})).Return(nil)
+
+	notification := &domain.Notification{UserID: "user-1", Type: domain.NotificationTypeOutbid, CreatedAt: createdAt}
+	err := s.service.SendNotification(context.Background(), notification)
+
+	s.NoError(err)
+	s.repo.AssertExpectations(s.T())
+	s.email.AssertExpectations(s.T())
+	s.hub.AssertNotCalled(s.T(), "BroadcastToUser", "user-1", notification) // Live push muted
+}
+
+func (s *NotificationServiceTestSuite) TestSendNotification_SenderErrorIsLogged() {
+	s.prefs.On("Get", mock.Anything, "user-1").Return(nil, nil)
+	s.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	s.repo.On("CountUnread", mock.Anything, "user-1").Return(1, nil)
+	s.hub.On("BroadcastToUser", "user-1", mock.Anything).Return()
+	s.email.On("Send", mock.Anything, mock.Anything).Return(errors.New("smtp down"))
+	s.logger.On("Error", "Failed to deliver notification", mock.Anything).Return()
+
+	err := s.service.SendNotification(context.Background(), &domain.Notification{UserID: "user-1", Type: domain.NotificationTypeAuctionWon})
+
+	s.NoError(err) // Stored in the app, so not retried
+	s.logger.AssertExpectations(s.T())
+}
+
+func (s *NotificationServiceTestSuite) TestGetPreferences_Defaults() {
+	s.prefs.On("Get", mock.Anything, "user-1").Return(nil, nil)
+
+	prefs, err := s.service.GetPreferences(context.Background(), "user-1")
+
+	s.NoError(err)
+	s.Equal(domain.DefaultPreferences("user-1"), prefs)
+}
+
+func (s *NotificationServiceTestSuite) TestUpdatePreferences() {
+	s.prefs.On("Save", mock.Anything, mock.MatchedBy(func(p *domain.Preferences) bool {
+		return p.Digest == domain.DigestImmediate && !p.UpdatedAt.IsZero()
+	})).Return(nil)
+
+	err := s.service.UpdatePreferences(context.Background(), &domain.Preferences{
+		UserID:   "user-1",
+		Channels: map[domain.NotificationType]domain.ChannelSet{domain.NotificationTypeNewBid: {InApp: true}},
+	})
+
+	s.NoError(err)
+	s.prefs.AssertExpectations(s.T())
+}
+
+func (s *NotificationServiceTestSuite) TestUpdatePreferences_Invalid() {
+	tests := []struct {
+		name  string
+		prefs *domain.Preferences
+	}{
+		{"Unknown Type", &domain.Preferences{Channels: map[domain.NotificationType]domain.ChannelSet{"PARTY": {}}}},
+		{"Unknown Digest", &domain.Preferences{Digest: "MONTHLY"}},
+		{"Bad Quiet Hours", &domain.Preferences{QuietHours: &domain.QuietHours{Start: "10pm", End: "07:00", TimeZone: "UTC"}}},
+		{"Unknown Time Zone", &domain.Preferences{QuietHours: &domain.QuietHours{Start: "22:00", End: "07:00", TimeZone: "Mars/Olympus"}}},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			err := s.service.UpdatePreferences(context.Background(), tt.prefs)
+			s.ErrorIs(err, domain.ErrInvalidPreferences)
+		})
+	}
+	s.prefs.AssertNotCalled(s.T(), "Save", mock.Anything, mock.Anything)
+}
+
+func (s *NotificationServiceTestSuite) TestInitPreferences() {
+	s.prefs.On("Init", mock.Anything, domain.DefaultPreferences("user-1")).Return(nil)
+
+	err := s.service.InitPreferences(context.Background(), "user-1")
+
+	s.NoError(err)
+	s.prefs.AssertExpectations(s.T())
+}
+
+func (s *NotificationServiceTestSuite) TestSaveContact() {
+	s.contacts.On("SaveEmail", mock.Anything, "user-1", "ada@example.com").Return(nil)
+
+	err := s.service.SaveContact(context.Background(), "user-1", "ada@example.com")
+
+	s.NoError(err)
+	s.contacts.AssertExpectations(s.T())
 }
 
 func TestNotificationServiceTestSuite(t *testing.T) {
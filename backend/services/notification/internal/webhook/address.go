@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+// errBlockedAddress is returned when a webhook URL leads to an address that is not
+// public.
+var errBlockedAddress = errors.New("webhook address is not public")
+
+// publicAddress reports whether webhooks may be sent to ip. Loopback, private,
+// link-local (which includes the 169.254.169.254 cloud metadata address), multicast
+// and unspecified addresses would let a subscriber reach inside the network.
+func publicAddress(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// checkHost rejects a webhook host that is, or resolves to, an address that is not
+// public. A name can resolve differently later, so the Worker checks again every
+// time it connects.
+func (s *service) checkHost(ctx context.Context, host string) error {
+	addrs, err := s.lookup(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("%w: url host %q does not resolve", domain.ErrInvalidWebhook, host)
+	}
+	for _, addr := range addrs {
+		if !publicAddress(addr.IP) {
+			return fmt.Errorf("%w: url host %q is not a public address", domain.ErrInvalidWebhook, host)
+		}
+	}
+	return nil
+}
+
+// newClient returns the client deliveries are sent with. It only connects to
+// addresses allow accepts, checked on the address actually dialled, so a name
+// that resolved to a public address at registration can't be pointed inside the
+// network later. Proxies are not used, since the proxy would be the address
+// checked.
+func newClient(allow func(net.IP) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   requestTimeout,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !allow(ip) {
+				return fmt.Errorf("%w: %s", errBlockedAddress, host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   requestTimeout,
+		Transport: transport,
+		// A redirect is reported as a failure rather than followed, so a
+		// delivery never lands somewhere the owner did not register
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+}
@@ -0,0 +1,28 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+type channel struct {
+	webhooks domain.WebhookService
+}
+
+// NewChannel returns the webhook ChannelSender. It publishes each notification as a
+// notification.created event to the user's own subscriptions, right away: webhooks are
+// read by machines, so quiet hours and digests do not apply.
+func NewChannel(webhooks domain.WebhookService) domain.ChannelSender {
+	return &channel{webhooks: webhooks}
+}
+
+func (c *channel) Send(ctx context.Context, delivery *domain.Delivery) error {
+	n := delivery.Notification
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	return c.webhooks.Publish(ctx, domain.EventNotificationCreated, payload, []string{n.UserID}, nil)
+}
@@ -0,0 +1,227 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+type service struct {
+	subs       domain.WebhookRepository
+	deliveries domain.WebhookDeliveryRepository
+	log        logger.Logger
+	lookup     func(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// NewService returns the WebhookService. It only queues deliveries; the Worker sends
+// them.
+func NewService(subs domain.WebhookRepository, deliveries domain.WebhookDeliveryRepository, log logger.Logger) domain.WebhookService {
+	return &service{subs: subs, deliveries: deliveries, log: log, lookup: net.DefaultResolver.LookupIPAddr}
+}
+
+func (s *service) Create(ctx context.Context, caller domain.WebhookCaller, sub *domain.WebhookSubscription) error {
+	if sub.OwnerType == "" {
+		sub.OwnerType = domain.WebhookOwnerUser
+	}
+	switch sub.OwnerType {
+	case domain.WebhookOwnerUser:
+		sub.OwnerID = caller.UserID
+	case domain.WebhookOwnerCompany:
+		if caller.CompanyID == "" {
+			return fmt.Errorf("%w: you do not belong to a company", domain.ErrInvalidWebhook)
+		}
+		sub.OwnerID = caller.CompanyID
+	default:
+		return fmt.Errorf("%w: unknown owner type %q", domain.ErrInvalidWebhook, sub.OwnerType)
+	}
+	if err := s.validate(ctx, sub); err != nil {
+		return err
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	sub.ID = uuid.New().String()
+	sub.Secret = secret
+	sub.Active = true
+	sub.ConsecutiveFailures = 0
+	sub.DisabledAt = nil
+	sub.CreatedBy = caller.UserID
+	sub.CreatedAt = now
+	sub.UpdatedAt = now
+	return s.subs.Create(ctx, sub)
+}
+
+func (s *service) List(ctx context.Context, caller domain.WebhookCaller) ([]domain.WebhookSubscription, error) {
+	subs, err := s.subs.ListByOwner(ctx, caller.UserID, caller.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range subs {
+		subs[i].Secret = ""
+	}
+	return subs, nil
+}
+
+func (s *service) Get(ctx context.Context, caller domain.WebhookCaller, id string) (*domain.WebhookSubscription, error) {
+	sub, err := s.owned(ctx, caller, id)
+	if err != nil {
+		return nil, err
+	}
+	sub.Secret = ""
+	return sub, nil
+}
+
+func (s *service) Update(ctx context.Context, caller domain.WebhookCaller, update *domain.WebhookSubscription) error {
+	sub, err := s.owned(ctx, caller, update.ID)
+	if err != nil {
+		return err
+	}
+	sub.URL = update.URL
+	sub.EventTypes = update.EventTypes
+	if err := s.validate(ctx, sub); err != nil {
+		return err
+	}
+	if update.Active && !sub.Active {
+		sub.Active = true
+		sub.ConsecutiveFailures = 0
+		sub.DisabledAt = nil
+	}
+	sub.UpdatedAt = time.Now()
+	if err := s.subs.Update(ctx, sub); err != nil {
+		return err
+	}
+	sub.Secret = ""
+	*update = *sub
+	return nil
+}
+
+func (s *service) Delete(ctx context.Context, caller domain.WebhookCaller, id string) error {
+	if _, err := s.owned(ctx, caller, id); err != nil {
+		return err
+	}
+	return s.subs.Delete(ctx, id)
+}
+
+func (s *service) ListDeliveries(ctx context.Context, caller domain.WebhookCaller, subscriptionID string, limit int) ([]domain.WebhookDelivery, error) {
+	if _, err := s.owned(ctx, caller, subscriptionID); err != nil {
+		return nil, err
+	}
+	return s.deliveries.ListBySubscription(ctx, subscriptionID, limit)
+}
+
+func (s *service) Replay(ctx context.Context, caller domain.WebhookCaller, subscriptionID, deliveryID string) (*domain.WebhookDelivery, error) {
+	sub, err := s.owned(ctx, caller, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if !sub.Active {
+		return nil, domain.ErrWebhookDisabled
+	}
+	original, err := s.deliveries.Get(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.SubscriptionID != sub.ID {
+		return nil, domain.ErrDeliveryNotFound
+	}
+
+	delivery := newDelivery(sub.ID, original.EventType, original.Payload, time.Now())
+	delivery.ReplayOf = original.ID
+	if err := s.deliveries.Create(ctx, delivery); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+func (s *service) Publish(ctx context.Context, eventType string, payload []byte, userIDs, companyIDs []string) error {
+	subs, err := s.subs.ListMatching(ctx, eventType, userIDs, companyIDs)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, sub := range subs {
+		if err := s.deliveries.Create(ctx, newDelivery(sub.ID, eventType, payload, now)); err != nil {
+			return err
+		}
+		s.log.Debug("Webhook delivery queued", zap.String("subscription_id", sub.ID), zap.String("event", eventType))
+	}
+	return nil
+}
+
+func (s *service) AddCompanyMembers(ctx context.Context, companyID string, userIDs []string) error {
+	if companyID == "" || len(userIDs) == 0 {
+		return nil
+	}
+	return s.subs.AddCompanyMembers(ctx, companyID, userIDs)
+}
+
+// owned returns the subscription if the caller owns it, and ErrWebhookNotFound
+// otherwise so that other owners' subscriptions are not revealed.
+func (s *service) owned(ctx context.Context, caller domain.WebhookCaller, id string) (*domain.WebhookSubscription, error) {
+	sub, err := s.subs.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !caller.Owns(sub) {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return sub, nil
+}
+
+func (s *service) validate(ctx context.Context, sub *domain.WebhookSubscription) error {
+	u, err := url.Parse(sub.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", domain.ErrInvalidWebhook)
+	}
+	if len(sub.EventTypes) == 0 {
+		return fmt.Errorf("%w: at least one event type is required", domain.ErrInvalidWebhook)
+	}
+	for _, t := range sub.EventTypes {
+		if !slices.Contains(domain.WebhookEventTypes, t) {
+			return fmt.Errorf("%w: unknown event type %q", domain.ErrInvalidWebhook, t)
+		}
+		// A member's notifications are theirs alone
+		if t == domain.EventNotificationCreated && sub.OwnerType == domain.WebhookOwnerCompany {
+			return fmt.Errorf("%w: company webhooks cannot subscribe to %s", domain.ErrInvalidWebhook, t)
+		}
+	}
+	if err := s.checkHost(ctx, u.Hostname()); err != nil {
+		return err
+	}
+	slices.Sort(sub.EventTypes)
+	sub.EventTypes = slices.Compact(sub.EventTypes)
+	return nil
+}
+
+func newDelivery(subscriptionID, eventType string, payload []byte, now time.Time) *domain.WebhookDelivery {
+	return &domain.WebhookDelivery{
+		ID:             uuid.New().String(),
+		SubscriptionID: subscriptionID,
+		EventType:      eventType,
+		Payload:        payload,
+		Status:         domain.DeliveryStatusPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(b), nil
+}
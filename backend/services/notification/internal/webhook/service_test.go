@@ -0,0 +1,183 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+var (
+	ada     = domain.WebhookCaller{UserID: "user-1", CompanyID: "co-1"}
+	mallory = domain.WebhookCaller{UserID: "user-9"}
+)
+
+// testHosts stands in for DNS. IP literals resolve to themselves.
+var testHosts = map[string]string{
+	"example.com":          "93.184.216.34",
+	"old.example.com":      "93.184.216.34",
+	"new.example.com":      "93.184.216.34",
+	"intranet.example.com": "10.0.0.5",
+}
+
+func lookupTestHost(ctx context.Context, host string) ([]net.IPAddr, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return []net.IPAddr{{IP: ip}}, nil
+	}
+	if addr, ok := testHosts[host]; ok {
+		return []net.IPAddr{{IP: net.ParseIP(addr)}}, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func newTestService() (domain.WebhookService, *MockWebhookRepo, *MockDeliveryRepo) {
+	subs := new(MockWebhookRepo)
+	deliveries := new(MockDeliveryRepo)
+	svc := NewService(subs, deliveries, &MockLogger{}).(*service)
+	svc.lookup = lookupTestHost
+	return svc, subs, deliveries
+}
+
+func TestService_Create(t *testing.T) {
+	svc, subs, _ := newTestService()
+	subs.On("Create", mock.Anything, mock.AnythingOfType("*domain.WebhookSubscription")).Return(nil)
+
+	sub := &domain.WebhookSubscription{URL: "https://example.com/hook", EventTypes: []string{"bid.placed", "auction.closed", "bid.placed"}}
+	err := svc.Create(context.Background(), ada, sub)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, sub.ID)
+	assert.Equal(t, domain.WebhookOwnerUser, sub.OwnerType)
+	assert.Equal(t, "user-1", sub.OwnerID)
+	assert.Equal(t, "user-1", sub.CreatedBy)
+	assert.True(t, sub.Active)
+	assert.True(t, strings.HasPrefix(sub.Secret, "whsec_"))
+	assert.Equal(t, []string{"auction.closed", "bid.placed"}, sub.EventTypes)
+	subs.AssertExpectations(t)
+}
+
+func TestService_CreateInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		caller domain.WebhookCaller
+		sub    domain.WebhookSubscription
+	}{
+		{"relative url", ada, domain.WebhookSubscription{URL: "/hook", EventTypes: []string{"bid.placed"}}},
+		{"not http", ada, domain.WebhookSubscription{URL: "ftp://example.com/hook", EventTypes: []string{"bid.placed"}}},
+		{"no events", ada, domain.WebhookSubscription{URL: "https://example.com/hook"}},
+		{"unknown event", ada, domain.WebhookSubscription{URL: "https://example.com/hook", EventTypes: []string{"auction.deleted"}}},
+		{"no company", mallory, domain.WebhookSubscription{OwnerType: domain.WebhookOwnerCompany, URL: "https://example.com/hook", EventTypes: []string{"bid.placed"}}},
+		{"company notifications", ada, domain.WebhookSubscription{OwnerType: domain.WebhookOwnerCompany, URL: "https://example.com/hook", EventTypes: []string{domain.EventNotificationCreated}}},
+		{"loopback", ada, domain.WebhookSubscription{URL: "http://127.0.0.1:8080/hook", EventTypes: []string{"bid.placed"}}},
+		{"loopback v6", ada, domain.WebhookSubscription{URL: "http://[::1]/hook", EventTypes: []string{"bid.placed"}}},
+		{"metadata", ada, domain.WebhookSubscription{URL: "http://169.254.169.254/latest/meta-data", EventTypes: []string{"bid.placed"}}},
+		{"unspecified", ada, domain.WebhookSubscription{URL: "http://0.0.0.0/hook", EventTypes: []string{"bid.placed"}}},
+		{"resolves to private", ada, domain.WebhookSubscription{URL: "https://intranet.example.com/hook", EventTypes: []string{"bid.placed"}}},
+		{"does not resolve", ada, domain.WebhookSubscription{URL: "https://nowhere.invalid/hook", EventTypes: []string{"bid.placed"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, subs, _ := newTestService()
+			err := svc.Create(context.Background(), tt.caller, &tt.sub)
+			assert.ErrorIs(t, err, domain.ErrInvalidWebhook)
+			subs.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_GetHidesOthersAndSecret(t *testing.T) {
+	svc, subs, _ := newTestService()
+	subs.On("Get", mock.Anything, "wh-1").Return(&domain.WebhookSubscription{
+		ID: "wh-1", OwnerType: domain.WebhookOwnerCompany, OwnerID: "co-1", Secret: testSecret, Active: true,
+	}, nil)
+
+	sub, err := svc.Get(context.Background(), ada, "wh-1")
+	require.NoError(t, err)
+	assert.Empty(t, sub.Secret)
+
+	_, err = svc.Get(context.Background(), mallory, "wh-1")
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+}
+
+func TestService_UpdateReenables(t *testing.T) {
+	svc, subs, _ := newTestService()
+	disabledAt := time.Now()
+	subs.On("Get", mock.Anything, "wh-1").Return(&domain.WebhookSubscription{
+		ID: "wh-1", OwnerType: domain.WebhookOwnerUser, OwnerID: "user-1", URL: "https://old.example.com", EventTypes: []string{"bid.placed"},
+		ConsecutiveFailures: 5, DisabledAt: &disabledAt,
+	}, nil)
+	subs.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.WebhookSubscription) bool {
+		return s.Active && s.ConsecutiveFailures == 0 && s.DisabledAt == nil && s.URL == "https://new.example.com"
+	})).Return(nil)
+
+	update := &domain.WebhookSubscription{ID: "wh-1", URL: "https://new.example.com", EventTypes: []string{"auction.closed"}, Active: true}
+	err := svc.Update(context.Background(), ada, update)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"auction.closed"}, update.EventTypes)
+	subs.AssertExpectations(t)
+}
+
+func TestService_Publish(t *testing.T) {
+	svc, subs, deliveries := newTestService()
+	payload := []byte(`{"bid_id":"b-1"}`)
+	subs.On("ListMatching", mock.Anything, "bid.placed", []string{"user-1"}, []string(nil)).
+		Return([]domain.WebhookSubscription{{ID: "wh-1"}, {ID: "wh-2"}}, nil)
+	for _, id := range []string{"wh-1", "wh-2"} {
+		deliveries.On("Create", mock.Anything, mock.MatchedBy(func(d *domain.WebhookDelivery) bool {
+			return d.SubscriptionID == id && d.Status == domain.DeliveryStatusPending && string(d.Payload) == string(payload)
+		})).Return(nil).Once()
+	}
+
+	err := svc.Publish(context.Background(), "bid.placed", payload, []string{"user-1"}, nil)
+
+	require.NoError(t, err)
+	deliveries.AssertExpectations(t)
+}
+
+func TestService_Replay(t *testing.T) {
+	svc, subs, deliveries := newTestService()
+	subs.On("Get", mock.Anything, "wh-1").Return(subscription("https://example.com"), nil)
+	original := pending("d-1", 3)
+	original.Status = domain.DeliveryStatusFailed
+	deliveries.On("Get", mock.Anything, "d-1").Return(&original, nil)
+	deliveries.On("Create", mock.Anything, mock.AnythingOfType("*domain.WebhookDelivery")).Return(nil)
+
+	replay, err := svc.Replay(context.Background(), ada, "wh-1", "d-1")
+
+	require.NoError(t, err)
+	assert.NotEqual(t, "d-1", replay.ID)
+	assert.Equal(t, "d-1", replay.ReplayOf)
+	assert.Equal(t, domain.DeliveryStatusPending, replay.Status)
+	assert.Zero(t, replay.Attempts)
+	assert.Equal(t, original.Payload, replay.Payload)
+}
+
+func TestService_ReplayRejected(t *testing.T) {
+	svc, subs, deliveries := newTestService()
+	disabled := subscription("https://example.com")
+	disabled.ID = "wh-2"
+	disabled.Active = false
+	subs.On("Get", mock.Anything, "wh-1").Return(subscription("https://example.com"), nil)
+	subs.On("Get", mock.Anything, "wh-2").Return(disabled, nil)
+	other := pending("d-9", 0)
+	other.SubscriptionID = "wh-3"
+	deliveries.On("Get", mock.Anything, "d-9").Return(&other, nil)
+
+	_, err := svc.Replay(context.Background(), ada, "wh-2", "d-1")
+	assert.ErrorIs(t, err, domain.ErrWebhookDisabled)
+
+	_, err = svc.Replay(context.Background(), ada, "wh-1", "d-9")
+	assert.ErrorIs(t, err, domain.ErrDeliveryNotFound)
+
+	_, err = svc.Replay(context.Background(), mallory, "wh-1", "d-1")
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	deliveries.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader carries the delivery's signature, as "t=<unix time>,v1=<hex>". v1 is
+// the HMAC-SHA256 of "<unix time>.<body>" keyed with the subscription's secret; the
+// timestamp lets receivers reject replayed requests.
+const SignatureHeader = "X-BidFlow-Signature"
+
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Sign returns the SignatureHeader value for body sent at ts.
+func Sign(secret string, ts time.Time, body []byte) string {
+	unix := strconv.FormatInt(ts.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", unix, mac(secret, unix, body))
+}
+
+// Verify checks a SignatureHeader value against body, as a receiver would. Signatures
+// older than tolerance are rejected; a zero tolerance accepts any age.
+func Verify(secret, header string, body []byte, tolerance time.Duration) error {
+	var unix string
+	var sigs []string
+	for _, part := range strings.Split(header, ",") {
+		k, v, _ := strings.Cut(part, "=")
+		switch k {
+		case "t":
+			unix = v
+		case "v1":
+			sigs = append(sigs, v)
+		}
+	}
+	ts, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil || len(sigs) == 0 {
+		return ErrInvalidSignature
+	}
+	if tolerance > 0 && time.Since(time.Unix(ts, 0)) > tolerance {
+		return ErrInvalidSignature
+	}
+
+	want := mac(secret, unix, body)
+	for _, sig := range sigs {
+		if hmac.Equal([]byte(sig), []byte(want)) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func mac(secret, unix string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(unix))
+	h.Write([]byte("."))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
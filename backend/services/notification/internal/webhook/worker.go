@@ -0,0 +1,208 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+const (
+	batchSize   = 50
+	maxAttempts = 8
+	maxBackoff  = 6 * time.Hour
+	// disableAfter is how many deliveries in a row may run out of retries before the
+	// subscription is disabled.
+	disableAfter = 5
+	// claimLease bounds how long a claimed delivery stays hidden from other workers,
+	// so one that dies mid-send leaves its deliveries to be retried.
+	claimLease     = 5 * time.Minute
+	requestTimeout = 10 * time.Second
+)
+
+// Envelope is the body POSTed to a subscription's URL.
+type Envelope struct {
+	ID        string          `json:"id"` // of the delivery; the same across retries, new on replay
+	Event     string          `json:"event"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Worker sends pending webhook deliveries. A failed delivery is retried with
+// exponential backoff until maxAttempts, after which it is marked FAILED and counts
+// towards disabling its subscription.
+type Worker struct {
+	subs       domain.WebhookRepository
+	deliveries domain.WebhookDeliveryRepository
+	client     *http.Client
+	interval   time.Duration
+	log        logger.Logger
+}
+
+func NewWorker(subs domain.WebhookRepository, deliveries domain.WebhookDeliveryRepository, interval time.Duration, log logger.Logger) *Worker {
+	return &Worker{
+		subs:       subs,
+		deliveries: deliveries,
+		client:     newClient(publicAddress),
+		interval:   interval,
+		log:        log,
+	}
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				w.log.Info("webhook worker stopped")
+				return
+			case now := <-ticker.C:
+				if _, err := w.Drain(ctx, now); err != nil {
+					w.log.Error("failed to drain webhook deliveries", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// Drain sends one batch of due deliveries and returns how many succeeded.
+func (w *Worker) Drain(ctx context.Context, now time.Time) (int, error) {
+	deliveries, err := w.deliveries.Claim(ctx, now, claimLease, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	subs := make(map[string]*domain.WebhookSubscription)
+	sent := 0
+	for i := range deliveries {
+		d := &deliveries[i]
+		sub, ok := subs[d.SubscriptionID]
+		if !ok {
+			sub, err = w.subs.Get(ctx, d.SubscriptionID)
+			if err != nil && !errors.Is(err, domain.ErrWebhookNotFound) {
+				return sent, err
+			}
+			subs[d.SubscriptionID] = sub
+		}
+		if sub == nil || !sub.Active {
+			// Deleted subscriptions take their deliveries with them; this one went
+			// between the claim and now
+			if err := w.deliveries.MarkFailed(ctx, d.ID, 0, "subscription disabled"); err != nil {
+				return sent, err
+			}
+			continue
+		}
+
+		ok, err := w.send(ctx, now, sub, d)
+		if err != nil {
+			return sent, err
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// send reports whether the delivery succeeded. It returns an error only if the outcome
+// could not be recorded.
+func (w *Worker) send(ctx context.Context, now time.Time, sub *domain.WebhookSubscription, d *domain.WebhookDelivery) (bool, error) {
+	status, err := w.post(ctx, now, sub, d)
+	if err == nil {
+		if err := w.deliveries.MarkSucceeded(ctx, d.ID, status, now); err != nil {
+			return true, err
+		}
+		if sub.ConsecutiveFailures > 0 {
+			sub.ConsecutiveFailures = 0
+			return true, w.subs.RecordSuccess(ctx, sub.ID)
+		}
+		return true, nil
+	}
+
+	attempts := d.Attempts + 1
+	if attempts < maxAttempts {
+		w.log.Warn("webhook delivery failed, will retry",
+			zap.String("delivery_id", d.ID),
+			zap.String("subscription_id", sub.ID),
+			zap.Int("attempts", attempts),
+			zap.Error(err),
+		)
+		return false, w.deliveries.Retry(ctx, d.ID, status, err.Error(), now.Add(backoff(attempts)))
+	}
+
+	w.log.Error("giving up on webhook delivery",
+		zap.String("delivery_id", d.ID),
+		zap.String("subscription_id", sub.ID),
+		zap.Int("attempts", attempts),
+		zap.Error(err),
+	)
+	if err := w.deliveries.MarkFailed(ctx, d.ID, status, err.Error()); err != nil {
+		return false, err
+	}
+	active, err := w.subs.RecordFailure(ctx, sub.ID, disableAfter, now)
+	if err != nil {
+		return false, err
+	}
+	sub.ConsecutiveFailures++
+	if !active && sub.Active {
+		w.log.Warn("webhook subscription disabled after repeated failures",
+			zap.String("subscription_id", sub.ID),
+			zap.String("url", sub.URL),
+		)
+	}
+	sub.Active = active
+	return false, nil
+}
+
+// post returns the response status, if there was a response, and an error unless it
+// was a 2xx.
+func (w *Worker) post(ctx context.Context, now time.Time, sub *domain.WebhookSubscription, d *domain.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(Envelope{ID: d.ID, Event: d.EventType, CreatedAt: d.CreatedAt, Data: d.Payload})
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "BidFlow-Webhooks/1.0")
+	req.Header.Set("X-BidFlow-Event", d.EventType)
+	req.Header.Set("X-BidFlow-Delivery", d.ID)
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, now, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // so the connection can be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff doubles from 30 seconds per failed attempt, capped at maxBackoff.
+func backoff(attempts int) time.Duration {
+	d := 30 * time.Second
+	for i := 1; i < attempts && d < maxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, maxBackoff)
+}
@@ -0,0 +1,381 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+// --- Mocks ---
+
+type MockWebhookRepo struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepo) Create(ctx context.Context, sub *domain.WebhookSubscription) error {
+	args := m.Called(ctx, sub)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) Get(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepo) ListByOwner(ctx context.Context, userID, companyID string) ([]domain.WebhookSubscription, error) {
+	args := m.Called(ctx, userID, companyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepo) Update(ctx context.Context, sub *domain.WebhookSubscription) error {
+	args := m.Called(ctx, sub)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) ListMatching(ctx context.Context, eventType string, userIDs, companyIDs []string) ([]domain.WebhookSubscription, error) {
+	args := m.Called(ctx, eventType, userIDs, companyIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepo) RecordSuccess(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepo) RecordFailure(ctx context.Context, id string, disableAfter int, at time.Time) (bool, error) {
+	args := m.Called(ctx, id, disableAfter, at)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWebhookRepo) AddCompanyMembers(ctx context.Context, companyID string, userIDs []string) error {
+	args := m.Called(ctx, companyID, userIDs)
+	return args.Error(0)
+}
+
+type MockDeliveryRepo struct {
+	mock.Mock
+}
+
+func (m *MockDeliveryRepo) Create(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockDeliveryRepo) Get(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockDeliveryRepo) ListBySubscription(ctx context.Context, subscriptionID string, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, subscriptionID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockDeliveryRepo) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, now, lease, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockDeliveryRepo) MarkSucceeded(ctx context.Context, id string, responseStatus int, at time.Time) error {
+	args := m.Called(ctx, id, responseStatus, at)
+	return args.Error(0)
+}
+
+func (m *MockDeliveryRepo) Retry(ctx context.Context, id string, responseStatus int, lastError string, at time.Time) error {
+	args := m.Called(ctx, id, responseStatus, lastError, at)
+	return args.Error(0)
+}
+
+func (m *MockDeliveryRepo) MarkFailed(ctx context.Context, id string, responseStatus int, lastError string) error {
+	args := m.Called(ctx, id, responseStatus, lastError)
+	return args.Error(0)
+}
+
+type MockLogger struct{}
+
+func (m *MockLogger) Debug(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) Info(msg string, fields ...zap.Field)   {}
+func (m *MockLogger) Warn(msg string, fields ...zap.Field)   {}
+func (m *MockLogger) Error(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) Fatal(msg string, fields ...zap.Field)  {}
+func (m *MockLogger) With(fields ...zap.Field) logger.Logger { return m }
+func (m *MockLogger) Sync() error                            { return nil }
+
+// receiver is a webhook endpoint that answers with the given statuses in turn, and
+// keeps the requests it gets.
+type receiver struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedRequest
+}
+
+type receivedRequest struct {
+	Header http.Header
+	Body   []byte
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.requests = append(r.requests, receivedRequest{Header: req.Header.Clone(), Body: body})
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		r.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) received() []receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedRequest(nil), r.requests...)
+}
+
+// newTestWorker returns a Worker that may also send to the loopback receivers.
+func newTestWorker(subs domain.WebhookRepository, deliveries domain.WebhookDeliveryRepository) *Worker {
+	w := NewWorker(subs, deliveries, time.Second, &MockLogger{})
+	w.client = newClient(func(net.IP) bool { return true })
+	return w
+}
+
+// --- Tests ---
+
+const testSecret = "whsec_test"
+
+func subscription(url string) *domain.WebhookSubscription {
+	return &domain.WebhookSubscription{
+		ID:         "wh-1",
+		OwnerType:  domain.WebhookOwnerUser,
+		OwnerID:    "user-1",
+		URL:        url,
+		EventTypes: []string{"bid.placed"},
+		Secret:     testSecret,
+		Active:     true,
+	}
+}
+
+func pending(id string, attempts int) domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		ID:             id,
+		SubscriptionID: "wh-1",
+		EventType:      "bid.placed",
+		Payload:        json.RawMessage(`{"bid_id":"b-1","amount":120}`),
+		Status:         domain.DeliveryStatusPending,
+		Attempts:       attempts,
+		CreatedAt:      time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWorker_Drain(t *testing.T) {
+	server := newReceiver(t)
+	subs := new(MockWebhookRepo)
+	deliveries := new(MockDeliveryRepo)
+	now := time.Now()
+
+	deliveries.On("Claim", mock.Anything, now, claimLease, batchSize).Return([]domain.WebhookDelivery{pending("d-1", 0)}, nil)
+	subs.On("Get", mock.Anything, "wh-1").Return(subscription(server.URL), nil)
+	deliveries.On("MarkSucceeded", mock.Anything, "d-1", http.StatusOK, now).Return(nil)
+
+	sent, err := newTestWorker(subs, deliveries).Drain(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	subs.AssertExpectations(t)
+	deliveries.AssertExpectations(t)
+
+	received := server.received()
+	require.Len(t, received, 1)
+	req := received[0]
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "bid.placed", req.Header.Get("X-BidFlow-Event"))
+	assert.Equal(t, "d-1", req.Header.Get("X-BidFlow-Delivery"))
+	assert.NoError(t, Verify(testSecret, req.Header.Get(SignatureHeader), req.Body, time.Minute))
+	assert.ErrorIs(t, Verify("whsec_other", req.Header.Get(SignatureHeader), req.Body, time.Minute), ErrInvalidSignature)
+
+	var envelope Envelope
+	require.NoError(t, json.Unmarshal(req.Body, &envelope))
+	assert.Equal(t, "d-1", envelope.ID)
+	assert.Equal(t, "bid.placed", envelope.Event)
+	assert.JSONEq(t, `{"bid_id":"b-1","amount":120}`, string(envelope.Data))
+}
+
+func TestWorker_Retry(t *testing.T) {
+	server := newReceiver(t, http.StatusServiceUnavailable, http.StatusInternalServerError)
+	subs := new(MockWebhookRepo)
+	deliveries := new(MockDeliveryRepo)
+	now := time.Now()
+
+	sub := subscription(server.URL)
+	sub.ConsecutiveFailures = 2
+	deliveries.On("Claim", mock.Anything, now, claimLease, batchSize).
+		Return([]domain.WebhookDelivery{pending("d-1", 0), pending("d-2", maxAttempts-1)}, nil)
+	subs.On("Get", mock.Anything, "wh-1").Return(sub, nil).Once()
+	deliveries.On("Retry", mock.Anything, "d-1", http.StatusServiceUnavailable, mock.AnythingOfType("string"), now.Add(30*time.Second)).Return(nil)
+	deliveries.On("MarkFailed", mock.Anything, "d-2", http.StatusInternalServerError, mock.AnythingOfType("string")).Return(nil)
+	subs.On("RecordFailure", mock.Anything, "wh-1", disableAfter, now).Return(true, nil)
+
+	sent, err := newTestWorker(subs, deliveries).Drain(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	subs.AssertExpectations(t)
+	deliveries.AssertExpectations(t)
+	assert.Len(t, server.received(), 2)
+}
+
+func TestWorker_DisablesAfterRepeatedFailures(t *testing.T) {
+	server := newReceiver(t, http.StatusGone)
+	subs := new(MockWebhookRepo)
+	deliveries := new(MockDeliveryRepo)
+	now := time.Now()
+
+	sub := subscription(server.URL)
+	sub.ConsecutiveFailures = disableAfter - 1
+	deliveries.On("Claim", mock.Anything, now, claimLease, batchSize).
+		Return([]domain.WebhookDelivery{pending("d-1", maxAttempts-1), pending("d-2", 0)}, nil)
+	subs.On("Get", mock.Anything, "wh-1").Return(sub, nil).Once()
+	deliveries.On("MarkFailed", mock.Anything, "d-1", http.StatusGone, mock.AnythingOfType("string")).Return(nil)
+	subs.On("RecordFailure", mock.Anything, "wh-1", disableAfter, now).Return(false, nil)
+	// Once disabled, the rest of the batch is not sent
+	deliveries.On("MarkFailed", mock.Anything, "d-2", 0, "subscription disabled").Return(nil)
+
+	sent, err := newTestWorker(subs, deliveries).Drain(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	subs.AssertExpectations(t)
+	deliveries.AssertExpectations(t)
+	assert.Len(t, server.received(), 1)
+}
+
+func TestWorker_SuccessResetsFailures(t *testing.T) {
+	server := newReceiver(t, http.StatusNoContent)
+	subs := new(MockWebhookRepo)
+	deliveries := new(MockDeliveryRepo)
+	now := time.Now()
+
+	sub := subscription(server.URL)
+	sub.ConsecutiveFailures = 3
+	deliveries.On("Claim", mock.Anything, now, claimLease, batchSize).Return([]domain.WebhookDelivery{pending("d-1", 4)}, nil)
+	subs.On("Get", mock.Anything, "wh-1").Return(sub, nil)
+	deliveries.On("MarkSucceeded", mock.Anything, "d-1", http.StatusNoContent, now).Return(nil)
+	subs.On("RecordSuccess", mock.Anything, "wh-1").Return(nil)
+
+	sent, err := newTestWorker(subs, deliveries).Drain(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	subs.AssertExpectations(t)
+	deliveries.AssertExpectations(t)
+}
+
+func TestWorker_DoesNotFollowRedirects(t *testing.T) {
+	target := newReceiver(t)
+	server := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+	defer server.Close()
+	subs := new(MockWebhookRepo)
+	deliveries := new(MockDeliveryRepo)
+	now := time.Now()
+
+	deliveries.On("Claim", mock.Anything, now, claimLease, batchSize).Return([]domain.WebhookDelivery{pending("d-1", 0)}, nil)
+	subs.On("Get", mock.Anything, "wh-1").Return(subscription(server.URL), nil)
+	deliveries.On("Retry", mock.Anything, "d-1", http.StatusFound, mock.AnythingOfType("string"), now.Add(30*time.Second)).Return(nil)
+
+	sent, err := newTestWorker(subs, deliveries).Drain(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	deliveries.AssertExpectations(t)
+	assert.Empty(t, target.received())
+}
+
+func TestWorker_RefusesPrivateAddresses(t *testing.T) {
+	server := newReceiver(t)
+	subs := new(MockWebhookRepo)
+	deliveries := new(MockDeliveryRepo)
+	now := time.Now()
+
+	deliveries.On("Claim", mock.Anything, now, claimLease, batchSize).Return([]domain.WebhookDelivery{pending("d-1", 0)}, nil)
+	subs.On("Get", mock.Anything, "wh-1").Return(subscription(server.URL), nil)
+	deliveries.On("Retry", mock.Anything, "d-1", 0, mock.MatchedBy(func(msg string) bool {
+		return strings.Contains(msg, errBlockedAddress.Error())
+	}), now.Add(30*time.Second)).Return(nil)
+
+	// The receiver listens on loopback, which the worker must not dial
+	sent, err := NewWorker(subs, deliveries, time.Second, &MockLogger{}).Drain(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	deliveries.AssertExpectations(t)
+	assert.Empty(t, server.received())
+}
+
+func TestPublicAddress(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "::1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "fe80::1", "fd00::1", "0.0.0.0", "::", "::ffff:127.0.0.1"} {
+		assert.False(t, publicAddress(net.ParseIP(addr)), addr)
+	}
+	for _, addr := range []string{"93.184.216.34", "2606:2800:220:1::1"} {
+		assert.True(t, publicAddress(net.ParseIP(addr)), addr)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, backoff(1))
+	assert.Equal(t, 2*time.Minute, backoff(3))
+	assert.Equal(t, maxBackoff, backoff(20))
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"d-1"}`)
+	header := Sign(testSecret, time.Now().Add(-10*time.Minute), body)
+
+	assert.NoError(t, Verify(testSecret, header, body, 0))
+	assert.ErrorIs(t, Verify(testSecret, header, body, 5*time.Minute), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(testSecret, header, []byte(`{"id":"d-2"}`), 0), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(testSecret, "v1=abc", body, 0), ErrInvalidSignature)
+}
@@ -1,11 +1,14 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
 	"go.uber.org/zap"
 )
 
@@ -21,6 +24,27 @@ const (
 
 	// Maximum message size allowed from peer.
 	maxMessageSize = 512
+
+	// Time allowed to carry out an action requested by the peer.
+	actionTimeout = 10 * time.Second
+
+	// Time allowed to load the notifications a resuming peer missed.
+	replayTimeout = 30 * time.Second
+
+	// Missed notifications are replayed this many at a time, up to maxReplay. A peer
+	// that missed more is told to resync instead.
+	replayPageSize = 100
+	maxReplay      = 1000
+)
+
+// Close codes the server ends a connection with.
+const (
+	// CloseResume asks the peer to reconnect with the last seq it saw, e.g. because it
+	// fell too far behind to keep up.
+	CloseResume = 4000
+	// CloseResync asks the peer to reload its notifications over HTTP and reconnect
+	// without last_seq, as it missed too many to replay.
+	CloseResync = 4001
 )
 
 var (
@@ -30,27 +54,53 @@ var (
 
 // Client is a middleman between the websocket connection and the hub.
 type Client struct {
-	hub *Hub
+	peer
 
 	// The websocket connection.
 	conn *websocket.Conn
+}
+
+func NewClient(hub *Hub, conn *websocket.Conn, userID string, service domain.NotificationService, log logger.Logger) *Client {
+	return &Client{
+		peer: newPeer(hub, userID, service, log),
+		conn: conn,
+	}
+}
 
-	// Buffered channel of outbound messages.
-	send chan interface{}
+// Actions a peer can send, as {"action": "mark_read", "id": "..."} or
+// {"action": "subscribe", "auction_id": "..."}.
+const (
+	actionMarkRead    = "mark_read"
+	actionSubscribe   = "subscribe"
+	actionUnsubscribe = "unsubscribe"
+)
+
+type action struct {
+	Action    string `json:"action"`
+	ID        string `json:"id,omitempty"`
+	AuctionID string `json:"auction_id,omitempty"`
+}
 
-	userID string
+// Message types confirming the peer's subscriptions, and reporting failed actions.
+const (
+	MessageTypeSubscribed   = "subscribed"
+	MessageTypeUnsubscribed = "unsubscribed"
+	MessageTypeError        = "error"
+)
 
-	log logger.Logger
+// subscription confirms that the peer joined or left an auction's room.
+type subscription struct {
+	Type      string `json:"type"`
+	AuctionID string `json:"auction_id"`
 }
 
-func NewClient(hub *Hub, conn *websocket.Conn, userID string, log logger.Logger) *Client {
-	return &Client{
-		hub:    hub,
-		conn:   conn,
-		send:   make(chan interface{}, 256),
-		userID: userID,
-		log:    log,
-	}
+// actionError tells the peer that an action it sent failed.
+type actionError struct {
+	Type      string `json:"type"`
+	Action    string `json:"action"`
+	ID        string `json:"id,omitempty"`
+	AuctionID string `json:"auction_id,omitempty"`
+	Error     string `json:"error"`
 }
 
 // ReadPump pumps messages from the websocket connection to the hub.
@@ -59,24 +109,69 @@ func NewClient(hub *Hub, conn *websocket.Conn, userID string, log logger.Logger)
 // reads from this goroutine.
 func (c *Client) ReadPump() {
 	defer func() {
-		c.hub.unregister <- c
+		c.hub.Unregister(c)
 		c.conn.Close()
 	}()
 	c.conn.SetReadLimit(maxMessageSize)
 	c.conn.SetReadDeadline(time.Now().Add(pongWait))
 	c.conn.SetPongHandler(func(string) error { c.conn.SetReadDeadline(time.Now().Add(pongWait)); return nil })
 	for {
-		_, _, err := c.conn.ReadMessage()
+		_, data, err := c.conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				c.log.Error("websocket error", zap.Error(err))
 			}
 			break
 		}
-		// We can handle incoming messages here if needed (e.g. "mark as read")
+		c.handleAction(data)
+	}
+}
+
+// handleAction carries out an action sent by the peer. Failures are reported back to
+// the peer and never close the connection.
+func (c *Client) handleAction(data []byte) {
+	var a action
+	if err := json.Unmarshal(data, &a); err != nil {
+		c.reject(a, "malformed message")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), actionTimeout)
+	defer cancel()
+
+	switch a.Action {
+	case actionMarkRead:
+		// The new unread count is pushed to all of the user's connections
+		err := c.service.MarkAsRead(ctx, c.userID, a.ID)
+		switch {
+		case errors.Is(err, domain.ErrNotificationNotFound):
+			c.reject(a, err.Error())
+		case err != nil:
+			c.log.Error("Failed to mark notification as read", zap.Error(err), zap.String("user_id", c.userID))
+			c.reject(a, "failed to mark notification as read")
+		}
+	case actionSubscribe:
+		if a.AuctionID == "" {
+			c.reject(a, "auction_id is required")
+			return
+		}
+		if err := c.hub.Subscribe(c, a.AuctionID); err != nil {
+			c.reject(a, err.Error())
+			return
+		}
+		c.hub.send(c, &subscription{Type: MessageTypeSubscribed, AuctionID: a.AuctionID})
+	case actionUnsubscribe:
+		c.hub.Unsubscribe(c, a.AuctionID)
+		c.hub.send(c, &subscription{Type: MessageTypeUnsubscribed, AuctionID: a.AuctionID})
+	default:
+		c.reject(a, "unknown action")
 	}
 }
 
+func (c *Client) reject(a action, reason string) {
+	c.hub.send(c, &actionError{Type: MessageTypeError, Action: a.Action, ID: a.ID, AuctionID: a.AuctionID, Error: reason})
+}
+
 // WritePump pumps messages from the hub to the websocket connection.
 // A goroutine running WritePump is started for each connection. The
 // application ensures that there is at most one writer to a connection by
@@ -87,8 +182,37 @@ func (c *Client) WritePump() {
 		ticker.Stop()
 		c.conn.Close()
 	}()
+
+	// Live messages queue up in c.send while the replay runs
+	replayed, err := c.replay(context.Background(), func(message interface{}) error {
+		c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+		return c.conn.WriteJSON(message)
+	})
+	switch {
+	case errors.Is(err, errTooManyMissed):
+		c.closeWith(CloseResync, "too many missed notifications; reload and reconnect without last_seq")
+		return
+	case errors.Is(err, errReplayFailed):
+		c.closeWith(CloseResume, "replay failed; reconnect with last_seq")
+		return
+	case err != nil:
+		return
+	}
+
 	for {
+		// An evicted client goes before anything else it has queued
+		select {
+		case <-c.evicted:
+			c.closeWith(CloseResume, "reconnect with last_seq")
+			return
+		default:
+		}
+
 		select {
+		case <-c.evicted:
+			c.closeWith(CloseResume, "reconnect with last_seq")
+			return
+
 		case message, ok := <-c.send:
 			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
@@ -96,6 +220,9 @@ func (c *Client) WritePump() {
 				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
 			}
+			if replayedAlready(message, replayed) {
+				continue
+			}
 
 			w, err := c.conn.NextWriter(websocket.TextMessage)
 			if err != nil {
@@ -107,7 +234,9 @@ func (c *Client) WritePump() {
 			// Add queued chat messages to the current websocket message.
 			n := len(c.send)
 			for i := 0; i < n; i++ {
-				json.NewEncoder(w).Encode(<-c.send)
+				if message := <-c.send; !replayedAlready(message, replayed) {
+					json.NewEncoder(w).Encode(message)
+				}
 			}
 
 			if err := w.Close(); err != nil {
@@ -122,3 +251,8 @@ func (c *Client) WritePump() {
 		}
 	}
 }
+
+func (c *Client) closeWith(code int, text string) {
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(code, text))
+}
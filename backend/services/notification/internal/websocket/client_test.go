@@ -0,0 +1,242 @@
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+// readMarker implements only the part of the service the client uses.
+type readMarker struct {
+	domain.NotificationService
+	marked chan string
+}
+
+func (r *readMarker) MarkAsRead(ctx context.Context, userID, id string) error {
+	if id != "n-1" {
+		return domain.ErrNotificationNotFound
+	}
+	r.marked <- userID + "/" + id
+	return nil
+}
+
+// replayer serves the notifications after a seq from a fixed history, waiting for
+// release before it does.
+type replayer struct {
+	domain.NotificationService
+	history []domain.Notification
+	release chan struct{}
+}
+
+func (r *replayer) NotificationsSince(ctx context.Context, userID string, afterSeq int64, limit int) ([]domain.Notification, error) {
+	if r.release != nil {
+		<-r.release
+	}
+	var page []domain.Notification
+	for _, n := range r.history {
+		if n.Seq > afterSeq && len(page) < limit {
+			page = append(page, n)
+		}
+	}
+	return page, nil
+}
+
+func (r *replayer) UnreadCount(ctx context.Context, userID string) (int, error) {
+	return 2, nil
+}
+
+// dial serves a client for user-1 and connects to it.
+func dial(t *testing.T, hub *Hub, service domain.NotificationService) *websocket.Conn {
+	return dialWith(t, hub, service, nil)
+}
+
+// dialWith is dial, with setup run on the client before it is registered.
+func dialWith(t *testing.T, hub *Hub, service domain.NotificationService, setup func(*Client)) *websocket.Conn {
+	return dialUser(t, hub, "user-1", service, setup)
+}
+
+// dialUser is dialWith for a client of userID.
+func dialUser(t *testing.T, hub *Hub, userID string, service domain.NotificationService, setup func(*Client)) *websocket.Conn {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		client := NewClient(hub, conn, userID, service, &MockLogger{})
+		if setup != nil {
+			setup(client)
+		}
+		hub.Register(client)
+		go client.WritePump()
+		go client.ReadPump()
+	}))
+	t.Cleanup(srv.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestClient_MarkRead(t *testing.T) {
+	hub := NewHub(&MockLogger{})
+	go hub.Run()
+
+	service := &readMarker{marked: make(chan string, 1)}
+	conn := dial(t, hub, service)
+
+	require.NoError(t, conn.WriteJSON(map[string]string{"action": "mark_read", "id": "n-1"}))
+
+	select {
+	case marked := <-service.marked:
+		assert.Equal(t, "user-1/n-1", marked)
+	case <-time.After(time.Second):
+		t.Fatal("expected the notification to be marked as read")
+	}
+}
+
+func TestClient_RejectedActions(t *testing.T) {
+	hub := NewHub(&MockLogger{})
+	go hub.Run()
+
+	conn := dial(t, hub, &readMarker{marked: make(chan string, 1)})
+
+	tests := []struct {
+		name      string
+		frame     string
+		wantError string
+	}{
+		{"Someone Else's Notification", `{"action": "mark_read", "id": "n-2"}`, domain.ErrNotificationNotFound.Error()},
+		{"Unknown Action", `{"action": "archive", "id": "n-1"}`, "unknown action"},
+		{"Malformed", `mark_read`, "malformed message"},
+		{"Subscribe Without Auction", `{"action": "subscribe"}`, "auction_id is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(tt.frame)))
+
+			var reply actionError
+			conn.SetReadDeadline(time.Now().Add(time.Second))
+			require.NoError(t, conn.ReadJSON(&reply))
+			assert.Equal(t, MessageTypeError, reply.Type)
+			assert.Equal(t, tt.wantError, reply.Error)
+		})
+	}
+}
+
+func TestClient_Subscribe(t *testing.T) {
+	hub := NewHub(&MockLogger{})
+	go hub.Run()
+
+	conn := dial(t, hub, &readMarker{marked: make(chan string, 1)})
+	read := func() map[string]interface{} {
+		var msg map[string]interface{}
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		require.NoError(t, conn.ReadJSON(&msg))
+		return msg
+	}
+
+	require.NoError(t, conn.WriteJSON(map[string]string{"action": "subscribe", "auction_id": "auction-1"}))
+	assert.Equal(t, map[string]interface{}{"type": MessageTypeSubscribed, "auction_id": "auction-1"}, read())
+
+	hub.BroadcastToRoom("auction-1", &domain.AuctionBid{Type: domain.MessageTypeAuctionBidPlaced, AuctionID: "auction-1", BidID: "bid-1", Amount: 120})
+	bid := read()
+	assert.Equal(t, domain.MessageTypeAuctionBidPlaced, bid["type"])
+	assert.Equal(t, 120.0, bid["amount"])
+
+	require.NoError(t, conn.WriteJSON(map[string]string{"action": "unsubscribe", "auction_id": "auction-1"}))
+	assert.Equal(t, map[string]interface{}{"type": MessageTypeUnsubscribed, "auction_id": "auction-1"}, read())
+
+	hub.BroadcastToRoom("auction-1", &domain.AuctionClosed{Type: domain.MessageTypeAuctionClosed, AuctionID: "auction-1"})
+	hub.BroadcastToUser("user-1", "marker")
+	assert.Equal(t, "marker", func() string {
+		var msg string
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		require.NoError(t, conn.ReadJSON(&msg))
+		return msg
+	}())
+}
+
+func TestClient_Resume(t *testing.T) {
+	hub := NewHub(&MockLogger{})
+	go hub.Run()
+
+	service := &replayer{
+		history: []domain.Notification{{ID: "n-3", Seq: 3}, {ID: "n-4", Seq: 4}, {ID: "n-5", Seq: 5}},
+		release: make(chan struct{}),
+	}
+	conn := dialWith(t, hub, service, func(c *Client) { c.Resume(3) })
+
+	// Live notifications arrive while the replay is still loading, one of them already in it
+	require.Eventually(t, func() bool {
+		hub.mu.RLock()
+		defer hub.mu.RUnlock()
+		return len(hub.userClients["user-1"]) == 1
+	}, time.Second, 10*time.Millisecond)
+	hub.BroadcastToUser("user-1", &domain.Notification{ID: "n-5", Seq: 5})
+	hub.BroadcastToUser("user-1", &domain.Notification{ID: "n-6", Seq: 6})
+	close(service.release)
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		var msg map[string]interface{}
+		conn.SetReadDeadline(time.Now().Add(time.Second))
+		require.NoError(t, conn.ReadJSON(&msg))
+		if msg["type"] == domain.MessageTypeUnreadCount {
+			got = append(got, "unread")
+			continue
+		}
+		got = append(got, msg["id"].(string))
+	}
+	assert.Equal(t, []string{"n-4", "n-5", "unread", "n-6"}, got)
+}
+
+func TestClient_ResumeTooFarBehind(t *testing.T) {
+	hub := NewHub(&MockLogger{})
+	go hub.Run()
+
+	service := &replayer{}
+	for seq := int64(1); seq <= maxReplay+1; seq++ {
+		service.history = append(service.history, domain.Notification{Seq: seq})
+	}
+	conn := dialWith(t, hub, service, func(c *Client) { c.Resume(0) })
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var err error
+	for err == nil {
+		_, _, err = conn.ReadMessage()
+	}
+	assert.True(t, websocket.IsCloseError(err, CloseResync), "got %v", err)
+}
+
+func TestClient_SlowConsumerDisconnected(t *testing.T) {
+	hub := NewHub(&MockLogger{})
+	go hub.Run()
+
+	conn := dialWith(t, hub, &readMarker{}, func(c *Client) {
+		// The buffer is full before the write pump starts
+		c.send = make(chan interface{}, 1)
+		c.send <- "first"
+		hub.mu.RLock()
+		hub.deliver(c, "second")
+		hub.mu.RUnlock()
+	})
+
+	// The close goes ahead of the message still queued
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	var err error
+	for err == nil {
+		_, _, err = conn.ReadMessage()
+	}
+	assert.True(t, websocket.IsCloseError(err, CloseResume), "got %v", err)
+}
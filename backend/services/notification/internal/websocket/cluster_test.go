@@ -0,0 +1,242 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+// memoryBroker hands every message, JSON round-tripped, to all subscribers at once.
+type memoryBroker struct {
+	maxPayload int // 0 for no limit
+
+	mu        sync.Mutex
+	handlers  []func(*domain.HubMessage)
+	published int
+}
+
+func (b *memoryBroker) Publish(ctx context.Context, msg *domain.HubMessage) error {
+	if b.maxPayload > 0 && len(msg.Payload) > b.maxPayload {
+		return domain.ErrMessageTooLarge
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	b.published++
+	handlers := append([]func(*domain.HubMessage){}, b.handlers...)
+	b.mu.Unlock()
+
+	for _, handle := range handlers {
+		var copy domain.HubMessage
+		if err := json.Unmarshal(data, &copy); err != nil {
+			return err
+		}
+		handle(&copy)
+	}
+	return nil
+}
+
+func (b *memoryBroker) Subscribe(ctx context.Context, handle func(*domain.HubMessage)) error {
+	b.mu.Lock()
+	b.handlers = append(b.handlers, handle)
+	b.mu.Unlock()
+	<-ctx.Done()
+	return nil
+}
+
+func (b *memoryBroker) counts() (subscribers, published int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.handlers), b.published
+}
+
+// memoryPresence keeps presence in a map of user ID to instance ID to last seen.
+type memoryPresence struct {
+	mu   sync.Mutex
+	seen map[string]map[string]time.Time
+}
+
+func (p *memoryPresence) Join(ctx context.Context, instanceID, userID string, at time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.seen == nil {
+		p.seen = make(map[string]map[string]time.Time)
+	}
+	if p.seen[userID] == nil {
+		p.seen[userID] = make(map[string]time.Time)
+	}
+	p.seen[userID][instanceID] = at
+	return nil
+}
+
+func (p *memoryPresence) Sync(ctx context.Context, instanceID string, userIDs []string, at time.Time) error {
+	p.mu.Lock()
+	for _, instances := range p.seen {
+		delete(instances, instanceID)
+	}
+	p.mu.Unlock()
+	for _, userID := range userIDs {
+		p.Join(ctx, instanceID, userID, at)
+	}
+	return nil
+}
+
+func (p *memoryPresence) Instances(ctx context.Context, userIDs []string, since time.Time) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var out []string
+	for _, userID := range userIDs {
+		for instanceID, at := range p.seen[userID] {
+			if !at.Before(since) {
+				out = append(out, instanceID)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (p *memoryPresence) Sweep(ctx context.Context, before time.Time) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, instances := range p.seen {
+		for instanceID, at := range instances {
+			if at.Before(before) {
+				delete(instances, instanceID)
+			}
+		}
+	}
+	return nil
+}
+
+// cluster starts two hubs sharing broker and presence.
+func cluster(t *testing.T, broker *memoryBroker, presence *memoryPresence) (*Hub, *Hub) {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	a := NewClusteredHub("instance-a", broker, presence, &MockLogger{})
+	b := NewClusteredHub("instance-b", broker, presence, &MockLogger{})
+	for _, hub := range []*Hub{a, b} {
+		go hub.Run()
+		hub.Listen(ctx)
+	}
+	require.Eventually(t, func() bool {
+		subscribers, _ := broker.counts()
+		return subscribers == 2
+	}, time.Second, 10*time.Millisecond)
+	return a, b
+}
+
+// connected waits for the hub to register a connection of the user.
+func connected(t *testing.T, hub *Hub, userID string) {
+	require.Eventually(t, func() bool {
+		hub.mu.RLock()
+		defer hub.mu.RUnlock()
+		return len(hub.userClients[userID]) > 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	var msg map[string]interface{}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func TestCluster_FansOutAcrossInstances(t *testing.T) {
+	broker := &memoryBroker{}
+	a, b := cluster(t, broker, &memoryPresence{})
+
+	onB := dialUser(t, b, "user-1", &readMarker{}, nil)
+	onA := dialUser(t, a, "user-2", &readMarker{}, nil)
+	connected(t, b, "user-1")
+	connected(t, a, "user-2")
+
+	// A notification handled on one instance reaches the user on the other
+	a.BroadcastToUser("user-1", &domain.Notification{ID: "n-1", UserID: "user-1", Seq: 7})
+	got := readMessage(t, onB)
+	assert.Equal(t, "n-1", got["id"])
+	assert.Equal(t, 7.0, got["seq"])
+
+	// So does a push to an auction followed from the other instance
+	require.NoError(t, onB.WriteJSON(map[string]string{"action": "subscribe", "auction_id": "auction-1"}))
+	assert.Equal(t, MessageTypeSubscribed, readMessage(t, onB)["type"])
+	a.BroadcastToRoom("auction-1", &domain.AuctionBid{Type: domain.MessageTypeAuctionBidPlaced, AuctionID: "auction-1", Amount: 120})
+	assert.Equal(t, domain.MessageTypeAuctionBidPlaced, readMessage(t, onB)["type"])
+
+	b.Broadcast(map[string]string{"type": "system"})
+	assert.Equal(t, "system", readMessage(t, onA)["type"])
+	assert.Equal(t, "system", readMessage(t, onB)["type"])
+
+	// Users connected nowhere else aren't published
+	_, before := broker.counts()
+	a.BroadcastToUser("user-2", map[string]string{"type": "local"})
+	a.BroadcastToUser("user-3", map[string]string{"type": "offline"})
+	_, after := broker.counts()
+	assert.Equal(t, before, after)
+	assert.Equal(t, "local", readMessage(t, onA)["type"])
+}
+
+func TestCluster_TooLargeAsksToResume(t *testing.T) {
+	a, b := cluster(t, &memoryBroker{maxPayload: 100}, &memoryPresence{})
+
+	conn := dialUser(t, b, "user-1", &readMarker{}, nil)
+	connected(t, b, "user-1")
+
+	a.BroadcastToUser("user-1", &domain.Notification{ID: "n-1", Message: strings.Repeat("x", 200), Seq: 8})
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	var err error
+	for err == nil {
+		_, _, err = conn.ReadMessage()
+	}
+	assert.True(t, websocket.IsCloseError(err, CloseResume), "got %v", err)
+}
+
+func TestCluster_ResumeSkipsRelayedDuplicates(t *testing.T) {
+	a, b := cluster(t, &memoryBroker{}, &memoryPresence{})
+
+	service := &replayer{
+		history: []domain.Notification{{ID: "n-4", Seq: 4}},
+		release: make(chan struct{}),
+	}
+	conn := dialUser(t, b, "user-1", service, func(c *Client) { c.Resume(3) })
+	connected(t, b, "user-1")
+
+	a.BroadcastToUser("user-1", &domain.Notification{ID: "n-4", Seq: 4})
+	a.BroadcastToUser("user-1", &domain.Notification{ID: "n-5", Seq: 5})
+	close(service.release)
+
+	assert.Equal(t, "n-4", readMessage(t, conn)["id"])
+	assert.Equal(t, domain.MessageTypeUnreadCount, readMessage(t, conn)["type"])
+	assert.Equal(t, "n-5", readMessage(t, conn)["id"])
+}
+
+func TestHub_SyncPresence(t *testing.T) {
+	presence := &memoryPresence{}
+	hub := NewClusteredHub("instance-a", &memoryBroker{}, presence, &MockLogger{})
+	now := time.Now()
+
+	presence.Join(context.Background(), "instance-a", "gone", now)
+	presence.Join(context.Background(), "instance-b", "user-2", now.Add(-2*presenceTTL))
+	client := &Client{peer: peer{hub: hub, userID: "user-1", send: make(chan interface{}, 1)}}
+	hub.clients[client] = true
+	hub.userClients["user-1"] = map[domain.Client]bool{client: true}
+
+	require.NoError(t, hub.SyncPresence(context.Background(), now))
+
+	for userID, want := range map[string][]string{"user-1": {"instance-a"}, "gone": nil, "user-2": nil} {
+		got, _ := presence.Instances(context.Background(), []string{userID}, time.Time{})
+		assert.Equal(t, want, got, userID)
+	}
+}
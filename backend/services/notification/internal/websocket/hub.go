@@ -1,28 +1,60 @@
 package websocket
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"sync"
+	"time"
 
 	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
 	"go.uber.org/zap"
 )
 
+// maxRoomsPerClient bounds how many auctions a single connection may follow.
+const maxRoomsPerClient = 50
+
+const (
+	// How often a clustered hub records the users connected to it, and how long
+	// until an instance that stopped doing so is presumed gone.
+	presenceInterval = 15 * time.Second
+	presenceTTL      = 3 * presenceInterval
+
+	// Time allowed to publish a message to the other instances, or record a connection.
+	clusterTimeout = 5 * time.Second
+
+	// Messages from other instances older than this are dropped; clients that missed
+	// notifications meanwhile catch up when they resume.
+	maxRelayAge = time.Minute
+)
+
+var ErrTooManyRooms = errors.New("too many auction subscriptions on this connection")
+
 type Hub struct {
 	// Registered clients.
-	clients map[*Client]bool
+	clients map[domain.Client]bool
 
 	// Map userID to clients (one user can have multiple connections)
-	userClients map[string]map[*Client]bool
+	userClients map[string]map[domain.Client]bool
+
+	// Map auctionID to the clients following it, and each client to the auctions
+	rooms       map[string]map[domain.Client]bool
+	clientRooms map[domain.Client]map[string]bool
 
 	// Inbound messages from the clients.
 	broadcast chan []byte
 
 	// Register requests from the clients.
-	register chan *Client
+	register chan domain.Client
 
 	// Unregister requests from clients.
-	unregister chan *Client
+	unregister chan domain.Client
+
+	// Set for a hub sharing its clients with other instances
+	instanceID string
+	broker     domain.Broker
+	presence   domain.PresenceRepository
 
 	logger logger.Logger
 	mu     sync.RWMutex
@@ -31,53 +63,65 @@ type Hub struct {
 func NewHub(log logger.Logger) *Hub {
 	return &Hub{
 		broadcast:   make(chan []byte),
-		register:    make(chan *Client),
-		unregister:  make(chan *Client),
-		clients:     make(map[*Client]bool),
-		userClients: make(map[string]map[*Client]bool),
+		register:    make(chan domain.Client),
+		unregister:  make(chan domain.Client),
+		clients:     make(map[domain.Client]bool),
+		userClients: make(map[string]map[domain.Client]bool),
+		rooms:       make(map[string]map[domain.Client]bool),
+		clientRooms: make(map[domain.Client]map[string]bool),
 		logger:      log,
 	}
 }
 
+// NewClusteredHub returns a hub for one of several instances of the service. Every
+// push is delivered to the clients connected here and published through broker for
+// the other instances to deliver to theirs. Pushes to users are only published when
+// presence puts one of them on another instance; with no presence, all are.
+func NewClusteredHub(instanceID string, broker domain.Broker, presence domain.PresenceRepository, log logger.Logger) *Hub {
+	h := NewHub(log)
+	h.instanceID = instanceID
+	h.broker = broker
+	h.presence = presence
+	return h
+}
+
 func (h *Hub) Run() {
 	for {
 		select {
 		case client := <-h.register:
 			h.mu.Lock()
 			h.clients[client] = true
-			if _, ok := h.userClients[client.userID]; !ok {
-				h.userClients[client.userID] = make(map[*Client]bool)
+			if _, ok := h.userClients[client.UserID()]; !ok {
+				h.userClients[client.UserID()] = make(map[domain.Client]bool)
 			}
-			h.userClients[client.userID][client] = true
+			h.userClients[client.UserID()][client] = true
 			h.mu.Unlock()
-			h.logger.Info("Client registered", zap.String("user_id", client.userID))
+			h.logger.Info("Client registered", zap.String("user_id", client.UserID()))
 
 		case client := <-h.unregister:
 			h.mu.Lock()
 			if _, ok := h.clients[client]; ok {
 				delete(h.clients, client)
-				close(client.send)
+				client.Close()
 
-				if userMap, ok := h.userClients[client.userID]; ok {
+				if userMap, ok := h.userClients[client.UserID()]; ok {
 					delete(userMap, client)
 					if len(userMap) == 0 {
-						delete(h.userClients, client.userID)
+						delete(h.userClients, client.UserID())
 					}
 				}
 			}
+			for auctionID := range h.clientRooms[client] {
+				h.leave(client, auctionID)
+			}
 			h.mu.Unlock()
-			h.logger.Info("Client unregistered", zap.String("user_id", client.userID))
+			h.logger.Info("Client unregistered", zap.String("user_id", client.UserID()))
 
 		case message := <-h.broadcast:
 			// Broadcast to everyone (optional, maybe for system alerts)
 			h.mu.RLock()
 			for client := range h.clients {
-				select {
-				case client.send <- message:
-				default:
-					close(client.send)
-					delete(h.clients, client)
-				}
+				h.deliver(client, message)
 			}
 			h.mu.RUnlock()
 		}
@@ -85,36 +129,283 @@ func (h *Hub) Run() {
 }
 
 func (h *Hub) BroadcastToUser(userID string, message interface{}) {
+	h.toUsers(message, userID)
+	h.publish(&domain.HubMessage{UserIDs: []string{userID}}, message)
+}
+
+// BroadcastToRoom pushes message to every client following the auction, and to all
+// connections of userIDs. Each client gets the message once.
+func (h *Hub) BroadcastToRoom(auctionID string, message interface{}, userIDs ...string) {
+	h.toRoom(auctionID, message, userIDs...)
+	h.publish(&domain.HubMessage{AuctionID: auctionID, UserIDs: userIDs}, message)
+}
+
+func (h *Hub) toUsers(message interface{}, userIDs ...string) {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
-	clients, ok := h.userClients[userID]
-	if !ok {
-		return // User not connected
+	for _, userID := range userIDs {
+		for client := range h.userClients[userID] {
+			h.deliver(client, message)
+		}
 	}
+}
 
-	for client := range clients {
-		select {
-		case client.send <- message:
-		default:
-			// Channel full or closed, we might want to clean up here or let the main loop handle it
-			// For now, just skip
+func (h *Hub) toRoom(auctionID string, message interface{}, userIDs ...string) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	targets := make(map[domain.Client]bool, len(h.rooms[auctionID]))
+	for client := range h.rooms[auctionID] {
+		targets[client] = true
+	}
+	for _, userID := range userIDs {
+		for client := range h.userClients[userID] {
+			targets[client] = true
+		}
+	}
+
+	for client := range targets {
+		h.deliver(client, message)
+	}
+}
+
+// Subscribe adds client to the auction's room. It fails with ErrTooManyRooms once the
+// client follows maxRoomsPerClient auctions.
+func (h *Hub) Subscribe(client domain.Client, auctionID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	rooms := h.clientRooms[client]
+	if rooms[auctionID] {
+		return nil
+	}
+	if len(rooms) >= maxRoomsPerClient {
+		return ErrTooManyRooms
+	}
+	if rooms == nil {
+		rooms = make(map[string]bool)
+		h.clientRooms[client] = rooms
+	}
+	rooms[auctionID] = true
+	if _, ok := h.rooms[auctionID]; !ok {
+		h.rooms[auctionID] = make(map[domain.Client]bool)
+	}
+	h.rooms[auctionID][client] = true
+	return nil
+}
+
+func (h *Hub) Unsubscribe(client domain.Client, auctionID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.leave(client, auctionID)
+}
+
+// leave removes client from the auction's room. The caller must hold h.mu.
+func (h *Hub) leave(client domain.Client, auctionID string) {
+	if rooms, ok := h.clientRooms[client]; ok {
+		delete(rooms, auctionID)
+		if len(rooms) == 0 {
+			delete(h.clientRooms, client)
+		}
+	}
+	if room, ok := h.rooms[auctionID]; ok {
+		delete(room, client)
+		if len(room) == 0 {
+			delete(h.rooms, auctionID)
 		}
 	}
 }
 
+// send queues message for a single client, unless it has gone.
+func (h *Hub) send(client domain.Client, message interface{}) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if !h.clients[client] {
+		return
+	}
+	h.deliver(client, message)
+}
+
+// Broadcast pushes message to every connected client.
+func (h *Hub) Broadcast(message interface{}) {
+	h.toAll(message)
+	h.publish(&domain.HubMessage{All: true}, message)
+}
+
+func (h *Hub) toAll(message interface{}) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for client := range h.clients {
+		h.deliver(client, message)
+	}
+}
+
+// deliver queues message for client without blocking. A client whose send buffer is
+// full is disconnected with CloseResume rather than silently miss the message; it can
+// reconnect with its last seq and have the notifications replayed. The caller must
+// hold h.mu.
+func (h *Hub) deliver(client domain.Client, message interface{}) {
+	if !client.Send(message) && client.Evict() {
+		h.logger.Warn("Disconnecting slow client", zap.String("user_id", client.UserID()))
+	}
+}
+
 func (h *Hub) Register(client domain.Client) {
-	// This method is needed to satisfy the interface but the channel is used internally
-	// We can cast or change the interface.
-	// For simplicity in this implementation, we use the channel directly in the handler.
-	// But to satisfy domain.Hub interface:
-	if c, ok := client.(*Client); ok {
-		h.register <- c
+	// Other instances look for the user from now on; leaving is recorded lazily, by
+	// SyncPresence
+	if h.presence != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), clusterTimeout)
+		if err := h.presence.Join(ctx, h.instanceID, client.UserID(), time.Now()); err != nil {
+			h.logger.Error("Failed to record presence", zap.Error(err), zap.String("user_id", client.UserID()))
+		}
+		cancel()
 	}
+	h.register <- client
 }
 
 func (h *Hub) Unregister(client domain.Client) {
-	if c, ok := client.(*Client); ok {
-		h.unregister <- c
+	h.unregister <- client
+}
+
+// relayed is a message published by another instance, still encoded.
+type relayed struct {
+	json.RawMessage
+	seq int64
+}
+
+// publish hands message to the other instances, if there are any.
+func (h *Hub) publish(msg *domain.HubMessage, message interface{}) {
+	if h.broker == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), clusterTimeout)
+	defer cancel()
+
+	if msg.AuctionID == "" && !msg.All && !h.elsewhere(ctx, msg.UserIDs) {
+		return
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		h.logger.Error("Failed to encode hub message", zap.Error(err))
+		return
+	}
+	msg.Origin = h.instanceID
+	msg.Payload = payload
+	msg.SentAt = time.Now()
+	if n, ok := message.(*domain.Notification); ok {
+		msg.Seq = n.Seq
+	}
+
+	err = h.broker.Publish(ctx, msg)
+	if errors.Is(err, domain.ErrMessageTooLarge) && len(msg.UserIDs) > 0 {
+		// The users' connections elsewhere get the notification when they resume
+		h.logger.Warn("Hub message too large; asking remote clients to resume", zap.Strings("user_ids", msg.UserIDs))
+		err = h.broker.Publish(ctx, &domain.HubMessage{Origin: h.instanceID, UserIDs: msg.UserIDs, Resume: true, SentAt: msg.SentAt})
+	}
+	if err != nil {
+		h.logger.Error("Failed to publish hub message", zap.Error(err))
+	}
+}
+
+// elsewhere reports whether another instance holds a connection of any of userIDs,
+// assuming so when it can't tell.
+func (h *Hub) elsewhere(ctx context.Context, userIDs []string) bool {
+	if h.presence == nil {
+		return true
+	}
+	instances, err := h.presence.Instances(ctx, userIDs, time.Now().Add(-presenceTTL))
+	if err != nil {
+		h.logger.Error("Failed to look up websocket presence", zap.Error(err))
+		return true
+	}
+	for _, instanceID := range instances {
+		if instanceID != h.instanceID {
+			return true
+		}
+	}
+	return false
+}
+
+// receive delivers a message published by another instance to the clients connected
+// here.
+func (h *Hub) receive(msg *domain.HubMessage) {
+	if msg.Origin == h.instanceID || time.Since(msg.SentAt) > maxRelayAge {
+		return
+	}
+
+	if msg.Resume {
+		h.mu.RLock()
+		defer h.mu.RUnlock()
+		for _, userID := range msg.UserIDs {
+			for client := range h.userClients[userID] {
+				client.Evict()
+			}
+		}
+		return
+	}
+
+	message := relayed{RawMessage: msg.Payload, seq: msg.Seq}
+	switch {
+	case msg.All:
+		h.toAll(message)
+	case msg.AuctionID != "":
+		h.toRoom(msg.AuctionID, message, msg.UserIDs...)
+	default:
+		h.toUsers(message, msg.UserIDs...)
+	}
+}
+
+// Listen delivers the messages other instances publish to the clients connected here,
+// and keeps this instance's presence up to date, until ctx is done. It does nothing
+// for a hub made with NewHub.
+func (h *Hub) Listen(ctx context.Context) {
+	if h.broker == nil {
+		return
+	}
+
+	go func() {
+		if err := h.broker.Subscribe(ctx, h.receive); err != nil {
+			h.logger.Error("Failed to subscribe to hub messages", zap.Error(err))
+		}
+	}()
+
+	if h.presence == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(presenceInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if err := h.SyncPresence(ctx, now); err != nil {
+					h.logger.Error("Failed to sync websocket presence", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// SyncPresence records the users connected here, and forgets the instances that
+// haven't recorded theirs within presenceTTL.
+func (h *Hub) SyncPresence(ctx context.Context, now time.Time) error {
+	h.mu.RLock()
+	userIDs := make([]string, 0, len(h.userClients))
+	for userID := range h.userClients {
+		userIDs = append(userIDs, userID)
+	}
+	h.mu.RUnlock()
+
+	if err := h.presence.Sync(ctx, h.instanceID, userIDs, now); err != nil {
+		return err
 	}
+	return h.presence.Sweep(ctx, now.Add(-presenceTTL))
 }
@@ -1,10 +1,12 @@
 package websocket
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
 	"go.uber.org/zap"
 )
 
@@ -38,11 +40,11 @@ func TestHub_Register(t *testing.T) {
 	// Start the hub in a goroutine
 	go hub.Run()
 
-	client := &Client{
+	client := &Client{peer: peer{
 		hub:    hub,
 		userID: "user-1",
 		send:   make(chan interface{}, 1), // Buffered to prevent blocking if logic changes
-	}
+	}}
 
 	// Register
 	hub.register <- client
@@ -74,11 +76,11 @@ func TestHub_Unregister(t *testing.T) {
 	hub := NewHub(log)
 	go hub.Run()
 
-	client := &Client{
+	client := &Client{peer: peer{
 		hub:    hub,
 		userID: "user-1",
 		send:   make(chan interface{}, 1),
-	}
+	}}
 
 	hub.register <- client
 
@@ -102,3 +104,59 @@ func TestHub_Unregister(t *testing.T) {
 	_, ok := <-client.send
 	assert.False(t, ok, "Channel should be closed after unregister")
 }
+
+func TestHub_Rooms(t *testing.T) {
+	hub := NewHub(&MockLogger{})
+
+	viewer := &Client{peer: peer{hub: hub, userID: "viewer", send: make(chan interface{}, 2)}}
+	watcher := &Client{peer: peer{hub: hub, userID: "watcher", send: make(chan interface{}, 2)}}
+	other := &Client{peer: peer{hub: hub, userID: "other", send: make(chan interface{}, 2)}}
+	for _, c := range []*Client{viewer, watcher, other} {
+		hub.clients[c] = true
+		hub.userClients[c.userID] = map[domain.Client]bool{c: true}
+	}
+
+	assert.NoError(t, hub.Subscribe(viewer, "auction-1"))
+	assert.NoError(t, hub.Subscribe(watcher, "auction-1"))
+	assert.NoError(t, hub.Subscribe(other, "auction-2"))
+
+	// The watcher is in the room and named as a user, and still gets it once
+	hub.BroadcastToRoom("auction-1", "bid", "watcher")
+	assert.Equal(t, "bid", <-viewer.send)
+	assert.Equal(t, "bid", <-watcher.send)
+	assert.Empty(t, watcher.send)
+	assert.Empty(t, other.send)
+
+	hub.Unsubscribe(viewer, "auction-1")
+	hub.BroadcastToRoom("auction-1", "close")
+	assert.Empty(t, viewer.send)
+	assert.Equal(t, "close", <-watcher.send)
+}
+
+func TestHub_RoomLimit(t *testing.T) {
+	hub := NewHub(&MockLogger{})
+	client := &Client{peer: peer{hub: hub, userID: "user-1", send: make(chan interface{}, 1)}}
+
+	for i := 0; i < maxRoomsPerClient; i++ {
+		assert.NoError(t, hub.Subscribe(client, fmt.Sprintf("auction-%d", i)))
+	}
+	assert.NoError(t, hub.Subscribe(client, "auction-0"), "rejoining a room is free")
+	assert.ErrorIs(t, hub.Subscribe(client, "auction-x"), ErrTooManyRooms)
+}
+
+func TestHub_UnregisterLeavesRooms(t *testing.T) {
+	hub := NewHub(&MockLogger{})
+	go hub.Run()
+
+	client := &Client{peer: peer{hub: hub, userID: "user-1", send: make(chan interface{}, 1)}}
+	hub.register <- client
+	assert.NoError(t, hub.Subscribe(client, "auction-1"))
+
+	hub.unregister <- client
+	_, ok := <-client.send
+	assert.False(t, ok)
+
+	hub.mu.RLock()
+	defer hub.mu.RUnlock()
+	assert.Empty(t, hub.rooms)
+}
@@ -0,0 +1,154 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+var (
+	// errTooManyMissed ends a replay once maxReplay notifications have been sent and
+	// there are more.
+	errTooManyMissed = errors.New("too many missed notifications to replay")
+	// errReplayFailed ends a replay whose notifications couldn't be loaded.
+	errReplayFailed = errors.New("failed to load missed notifications")
+)
+
+// peer is the part of a client that doesn't depend on the transport: the queue of
+// messages the hub pushes to it, and the replay of the notifications it missed.
+type peer struct {
+	hub *Hub
+
+	// Buffered channel of outbound messages.
+	send chan interface{}
+
+	userID string
+
+	// Set by Resume: replay the notifications after lastSeq before live traffic.
+	resume  bool
+	lastSeq int64
+
+	// Closed when the hub evicts the client.
+	evicted   chan struct{}
+	evictOnce sync.Once
+
+	service domain.NotificationService
+
+	log logger.Logger
+}
+
+func newPeer(hub *Hub, userID string, service domain.NotificationService, log logger.Logger) peer {
+	return peer{
+		hub:     hub,
+		send:    make(chan interface{}, 256),
+		evicted: make(chan struct{}),
+		userID:  userID,
+		service: service,
+		log:     log,
+	}
+}
+
+func (p *peer) UserID() string {
+	return p.userID
+}
+
+func (p *peer) Send(message interface{}) bool {
+	select {
+	case p.send <- message:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *peer) Close() {
+	close(p.send)
+}
+
+func (p *peer) Evict() bool {
+	if p.evicted == nil {
+		return false
+	}
+	first := false
+	p.evictOnce.Do(func() {
+		close(p.evicted)
+		first = true
+	})
+	return first
+}
+
+// Resume makes the client replay the user's notifications after lastSeq, and their
+// unread count, before any live message. It must be called before the client starts
+// writing.
+func (p *peer) Resume(lastSeq int64) {
+	p.resume = true
+	p.lastSeq = lastSeq
+}
+
+// replay hands write the notifications the peer missed, oldest first, then its unread
+// count, if it is resuming. It returns the seq of the last notification replayed. It
+// fails with errTooManyMissed after maxReplay notifications, with errReplayFailed if
+// they can't be loaded, and with whatever write returns.
+func (p *peer) replay(ctx context.Context, write func(message interface{}) error) (int64, error) {
+	if !p.resume {
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, replayTimeout)
+	defer cancel()
+
+	after := p.lastSeq
+	for total := 0; ; {
+		page, err := p.service.NotificationsSince(ctx, p.userID, after, replayPageSize)
+		if err != nil {
+			p.log.Error("Failed to load missed notifications", zap.Error(err), zap.String("user_id", p.userID))
+			return 0, errReplayFailed
+		}
+		for i := range page {
+			if err := write(&page[i]); err != nil {
+				return 0, err
+			}
+			after = page[i].Seq
+		}
+
+		total += len(page)
+		if len(page) < replayPageSize {
+			break
+		}
+		if total >= maxReplay {
+			return 0, errTooManyMissed
+		}
+	}
+
+	// Whatever was read while the peer was away
+	count, err := p.service.UnreadCount(ctx, p.userID)
+	if err != nil {
+		p.log.Error("Failed to count unread notifications", zap.Error(err), zap.String("user_id", p.userID))
+		return after, nil
+	}
+	if err := write(&domain.UnreadCount{Type: domain.MessageTypeUnreadCount, Count: count}); err != nil {
+		return 0, err
+	}
+	return after, nil
+}
+
+// seqOf returns the seq of message if it is a notification, or 0.
+func seqOf(message interface{}) int64 {
+	switch m := message.(type) {
+	case *domain.Notification:
+		return m.Seq
+	case relayed:
+		return m.seq
+	}
+	return 0
+}
+
+// replayedAlready reports whether message is a notification the replay has sent.
+func replayedAlready(message interface{}, replayed int64) bool {
+	seq := seqOf(message)
+	return seq != 0 && seq <= replayed
+}
@@ -0,0 +1,139 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"go.uber.org/zap"
+)
+
+const (
+	// Send a comment to the SSE peer with this period, so that proxies keep the
+	// stream open and a gone peer is noticed.
+	heartbeatPeriod = 15 * time.Second
+
+	// How long the SSE peer waits before reconnecting.
+	sseRetry = 3 * time.Second
+)
+
+// MessageTypeResync ends an SSE stream whose peer missed too many notifications to
+// replay. The peer should reload them over HTTP; its reconnect starts afresh.
+const MessageTypeResync = "resync"
+
+// SSEClient is a middleman between a Server-Sent Events response and the hub, for
+// peers that can't open a websocket. It only carries messages to the peer: the
+// auctions it follows are subscribed when it connects.
+type SSEClient struct {
+	peer
+
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+func NewSSEClient(hub *Hub, w http.ResponseWriter, userID string, service domain.NotificationService, log logger.Logger) *SSEClient {
+	return &SSEClient{
+		peer: newPeer(hub, userID, service, log),
+		w:    w,
+		rc:   http.NewResponseController(w),
+	}
+}
+
+// Serve streams messages from the hub to the peer until ctx is done, the peer goes
+// away or the hub evicts the client, and then unregisters the client. Each
+// notification is an event whose id is its seq, so a reconnecting peer's
+// Last-Event-ID says where to resume.
+func (c *SSEClient) Serve(ctx context.Context) {
+	defer c.hub.Unregister(c)
+
+	header := c.w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no") // Keep nginx from buffering the stream
+	c.w.WriteHeader(http.StatusOK)
+	if err := c.write(fmt.Sprintf("retry: %d\n\n", sseRetry.Milliseconds())); err != nil {
+		return
+	}
+
+	// Live messages queue up in c.send while the replay runs
+	replayed, err := c.replay(ctx, c.event)
+	if errors.Is(err, errTooManyMissed) {
+		// The empty id clears the peer's Last-Event-ID
+		c.write(fmt.Sprintf("id:\ndata: {\"type\":%q}\n\n", MessageTypeResync))
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(heartbeatPeriod)
+	defer heartbeat.Stop()
+
+	for {
+		// An evicted client goes before anything else it has queued
+		select {
+		case <-c.evicted:
+			return
+		default:
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-c.evicted:
+			return
+
+		case message, ok := <-c.send:
+			if !ok {
+				// The hub closed the channel.
+				return
+			}
+			if replayedAlready(message, replayed) {
+				continue
+			}
+			if err := c.event(message); err != nil {
+				return
+			}
+
+		case <-heartbeat.C:
+			if err := c.write(": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// event writes message as an event, with its seq as the id if it is a notification.
+func (c *SSEClient) event(message interface{}) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		c.log.Error("Failed to encode event", zap.Error(err), zap.String("user_id", c.userID))
+		return nil
+	}
+
+	var b strings.Builder
+	if seq := seqOf(message); seq != 0 {
+		fmt.Fprintf(&b, "id: %d\n", seq)
+	}
+	b.WriteString("data: ")
+	b.Write(data)
+	b.WriteString("\n\n")
+	return c.write(b.String())
+}
+
+func (c *SSEClient) write(s string) error {
+	c.rc.SetWriteDeadline(time.Now().Add(writeWait))
+	if _, err := io.WriteString(c.w, s); err != nil {
+		return err
+	}
+	return c.rc.Flush()
+}
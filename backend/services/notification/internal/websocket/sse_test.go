@@ -0,0 +1,124 @@
+package websocket
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+)
+
+type sseEvent struct {
+	id    string
+	hasID bool
+	data  string
+}
+
+// stream serves an SSE client for user-1 and opens the stream.
+func stream(t *testing.T, hub *Hub, service domain.NotificationService, setup func(*SSEClient)) *bufio.Reader {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := NewSSEClient(hub, w, "user-1", service, &MockLogger{})
+		if setup != nil {
+			setup(client)
+		}
+		hub.Register(client)
+		client.Serve(r.Context())
+	}))
+	t.Cleanup(srv.Close)
+
+	resp, err := (&http.Client{Timeout: 5 * time.Second}).Get(srv.URL)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return bufio.NewReader(resp.Body)
+}
+
+// nextEvent reads up to the next event that carries data, skipping comments.
+func nextEvent(t *testing.T, r *bufio.Reader) sseEvent {
+	var e sseEvent
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+
+		switch {
+		case line == "":
+			if e.data != "" {
+				return e
+			}
+			e = sseEvent{}
+		case strings.HasPrefix(line, "id:"):
+			e.id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+			e.hasID = true
+		case strings.HasPrefix(line, "data: "):
+			e.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestSSEClient_Stream(t *testing.T) {
+	hub := NewHub(&MockLogger{})
+	go hub.Run()
+
+	r := stream(t, hub, &readMarker{}, func(c *SSEClient) {
+		require.NoError(t, hub.Subscribe(c, "auction-1"))
+	})
+	connected(t, hub, "user-1")
+
+	hub.BroadcastToUser("user-1", &domain.Notification{ID: "n-1", Seq: 7})
+	e := nextEvent(t, r)
+	assert.Equal(t, "7", e.id)
+	assert.Contains(t, e.data, `"id":"n-1"`)
+
+	hub.BroadcastToRoom("auction-1", &domain.AuctionClosed{Type: domain.MessageTypeAuctionClosed, AuctionID: "auction-1"})
+	e = nextEvent(t, r)
+	assert.False(t, e.hasID)
+	assert.Contains(t, e.data, domain.MessageTypeAuctionClosed)
+}
+
+func TestSSEClient_Resume(t *testing.T) {
+	hub := NewHub(&MockLogger{})
+	go hub.Run()
+
+	service := &replayer{
+		history: []domain.Notification{{ID: "n-4", Seq: 4}, {ID: "n-5", Seq: 5}},
+		release: make(chan struct{}),
+	}
+	r := stream(t, hub, service, func(c *SSEClient) { c.Resume(3) })
+	connected(t, hub, "user-1")
+
+	hub.BroadcastToUser("user-1", &domain.Notification{ID: "n-5", Seq: 5})
+	hub.BroadcastToUser("user-1", &domain.Notification{ID: "n-6", Seq: 6})
+	close(service.release)
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		got = append(got, nextEvent(t, r).id)
+	}
+	assert.Equal(t, []string{"4", "5", "", "6"}, got)
+}
+
+func TestSSEClient_ResumeTooFarBehind(t *testing.T) {
+	hub := NewHub(&MockLogger{})
+	go hub.Run()
+
+	service := &replayer{}
+	for seq := int64(1); seq <= maxReplay+1; seq++ {
+		service.history = append(service.history, domain.Notification{Seq: seq})
+	}
+	r := stream(t, hub, service, func(c *SSEClient) { c.Resume(0) })
+
+	for {
+		e := nextEvent(t, r)
+		if strings.Contains(e.data, MessageTypeResync) {
+			assert.True(t, e.hasID)
+			assert.Empty(t, e.id, "the empty id resets Last-Event-ID")
+			return
+		}
+	}
+}
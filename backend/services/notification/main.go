@@ -9,16 +9,22 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // quiet hours are kept in the user's time zone
 
+	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/auth"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/config"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/kafka"
 	"github.com/temesgen-abebayehu/bidflow/backend/common/logger"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/broker"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/domain"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/email"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/event"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/handler"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/repository"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/service"
+	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/webhook"
 	"github.com/temesgen-abebayehu/bidflow/backend/services/notification/internal/websocket"
 	"go.uber.org/zap"
 )
@@ -49,9 +55,19 @@ func main() {
 
 	// 3. Initialize Components
 	repo := repository.NewPostgresRepo(db)
-	hub := websocket.NewHub(log)
-	svc := service.NewNotificationService(repo, hub, log)
-	tokenManager := auth.NewTokenManager(cfg.JWTSecret)
+	participantRepo := repository.NewParticipantRepo(db)
+	preferenceRepo := repository.NewPreferenceRepo(db)
+	contactRepo := repository.NewContactRepo(db)
+	emailQueue := repository.NewEmailRepo(db)
+	webhookRepo := repository.NewWebhookRepo(db)
+	webhookDeliveryRepo := repository.NewWebhookDeliveryRepo(db)
+	webhooks := webhook.NewService(webhookRepo, webhookDeliveryRepo, log)
+	hub := newHub(cfg, db, dsn, log)
+	senders := map[domain.Channel]domain.ChannelSender{
+		domain.ChannelEmail:   email.NewChannel(contactRepo, emailQueue),
+		domain.ChannelWebhook: webhook.NewChannel(webhooks),
+	}
+	svc := service.NewNotificationService(repo, participantRepo, preferenceRepo, contactRepo, hub, senders, log)
 
 	// 4. Start WebSocket Hub
 	go hub.Run()
@@ -59,18 +75,41 @@ func main() {
 	// 5. Initialize and Start Kafka Consumer
 	kafkaConsumer := kafka.NewConsumer(
 		cfg.KafkaBrokers,
-		[]string{event.TopicAuctionCreated, event.TopicBidPlaced},
+		[]string{event.TopicAuctionCreated, event.TopicAuctionUpdated, event.TopicAuctionClosed, event.TopicBidPlaced, event.TopicBidRetracted, event.TopicAuctionExtended, event.TopicCompanyVerified, event.TopicUserRegistered},
 		"notification-service-group",
 		log,
 	)
-	consumer := event.NewNotificationConsumer(kafkaConsumer, svc, log)
+	ticker := service.NewClockTicker(hub, log)
+	consumer := event.NewNotificationConsumer(kafkaConsumer, svc, webhooks, ticker, log)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	consumer.Start(ctx)
+	hub.Listen(ctx)
+
+	// Verify tokens with the auth service's public keys, and reject those it revokes
+	tokenManager := auth.NewVerifier(ctx, cfg.JWKSURL, cfg.JWKSRefreshInterval, cfg.JWTSecret, log)
+	denylist := auth.NewDenylist()
+	tokenManager.UseDenylist(denylist)
+	revocations := auth.WatchRevocations(ctx, cfg.KafkaBrokers, denylist, log)
+
+	renderer, err := email.NewRenderer()
+	if err != nil {
+		log.Fatal("failed to load email templates", zap.Error(err))
+	}
+	mailer := email.NewSMTPMailer(email.SMTPConfig{
+		Host:     cfg.SMTPHost,
+		Port:     cfg.SMTPPort,
+		Username: cfg.SMTPUsername,
+		Password: cfg.SMTPPassword,
+		From:     cfg.SMTPFrom,
+	})
+	email.NewWorker(emailQueue, mailer, renderer, cfg.EmailQueueInterval, log).Start(ctx)
+	webhook.NewWorker(webhookRepo, webhookDeliveryRepo, cfg.WebhookDeliveryInterval, log).Start(ctx)
 
 	// 6. Setup HTTP Server
 	h := handler.NewNotificationHandler(svc, hub, tokenManager, log)
-	r := handler.SetupRouter(h, tokenManager)
+	wh := handler.NewWebhookHandler(webhooks, log)
+	r := handler.SetupRouter(h, wh, tokenManager)
 
 	// 7. Start Server
 	srv := &http.Server{
@@ -101,6 +140,32 @@ func main() {
 	if err := kafkaConsumer.Close(); err != nil {
 		log.Error("Failed to close kafka consumer", zap.Error(err))
 	}
+	if err := revocations.Close(); err != nil {
+		log.Error("Failed to close revocations consumer", zap.Error(err))
+	}
 
 	log.Info("Server exiting")
 }
+
+// newHub returns a hub for this instance alone, or one sharing pushes with the other
+// instances through the broker HUB_BROKER names.
+func newHub(cfg *config.Config, db *sql.DB, dsn string, log logger.Logger) *websocket.Hub {
+	instanceID := cfg.InstanceID
+	if instanceID == "" {
+		host, _ := os.Hostname()
+		instanceID = host + "-" + uuid.NewString()[:8]
+	}
+
+	switch cfg.HubBroker {
+	case "":
+		return websocket.NewHub(log)
+	case "postgres":
+		return websocket.NewClusteredHub(instanceID, broker.NewPostgres(db, dsn, log), repository.NewPresenceRepo(db), log)
+	case "kafka":
+		publisher := kafka.NewProducer(cfg.KafkaBrokers, log)
+		return websocket.NewClusteredHub(instanceID, broker.NewKafka(publisher, cfg.KafkaBrokers, instanceID, log), repository.NewPresenceRepo(db), log)
+	default:
+		log.Fatal("unknown hub broker", zap.String("hub_broker", cfg.HubBroker))
+		return nil
+	}
+}